@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/baseline"
+)
+
+func newBaselineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Manage the baseline of accepted findings",
+		Long: "A baseline records the findings a project has accepted. " +
+			"`fsct check --baseline` then reports only findings that are not in it,\n" +
+			"so CI fails on newly introduced findings only.",
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(newBaselineCreateCmd())
+	return cmd
+}
+
+func newBaselineCreateCmd() *cobra.Command {
+	opts := &checkOptions{format: "console", severity: "info"}
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "create [path]",
+		Short: "Write a baseline of the project's current findings",
+		Long: "Run the checks and write every finding that is not suppressed to a baseline\n" +
+			"file (default " + baseline.DefaultFile + " in the project directory).\n\n" +
+			"Exit codes:\n" +
+			"  0  success\n" +
+			"  2  usage or runtime error, or the run was interrupted",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			run, err := scan(cmd, path, opts)
+			if err != nil {
+				return err
+			}
+
+			base := baseline.New(run.findings)
+			file := baselinePath(path, output)
+			if err := base.Save(file); err != nil {
+				return &exitCodeError{code: exitError, err: fmt.Errorf("write baseline: %w", err)}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Baseline of %d finding(s) written to %s\n", len(base.Entries), file)
+			return nil
+		},
+	}
+
+	addScanFlags(cmd, opts)
+	cmd.Flags().StringVar(&output, "output", "", "Baseline file to write (default "+baseline.DefaultFile+" in the project directory)")
+
+	return cmd
+}
@@ -0,0 +1,782 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	aipkg "github.com/ricky-irfandi/fsct/internal/ai"
+	"github.com/ricky-irfandi/fsct/internal/baseline"
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/filter"
+	"github.com/ricky-irfandi/fsct/internal/fingerprint"
+	"github.com/ricky-irfandi/fsct/internal/formatter"
+	"github.com/ricky-irfandi/fsct/internal/kb"
+	"github.com/ricky-irfandi/fsct/internal/loader"
+	"github.com/ricky-irfandi/fsct/internal/profile"
+	"github.com/ricky-irfandi/fsct/internal/registry"
+	"github.com/ricky-irfandi/fsct/internal/report"
+	"github.com/ricky-irfandi/fsct/internal/runner"
+	"github.com/ricky-irfandi/fsct/internal/suppress"
+)
+
+type checkOptions struct {
+	platform string
+	profile  string
+	format   string
+	output   string
+	severity string
+	failOn   string
+	skip     []string
+	checks   []string
+	ci       bool
+	verbose  bool
+	jobs     int
+	timeout  time.Duration
+
+	offline    bool
+	aiKey      string
+	aiProvider string
+	aiURL      string
+	aiModel    string
+
+	reportUnusedSuppressions bool
+	includeGenerated         bool
+	baseline                 string
+	updateBaseline           bool
+}
+
+var validFormats = []string{"console", "json", "yaml", "html", "sarif", "prompt"}
+
+func newCheckCmd() *cobra.Command {
+	opts := &checkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check [path]",
+		Short: "Run compliance checks against a Flutter project",
+		Long: "Run compliance checks against a Flutter project.\n\n" +
+			"Exit codes:\n" +
+			"  0  success (no findings at the --fail-on severity or above)\n" +
+			"  1  findings at the --fail-on severity or above were reported\n" +
+			"  2  usage or runtime error, or the run was interrupted\n\n" +
+			"--fail-on is checked against every finding that is not suppressed or\n" +
+			"baselined, whatever --severity hides from the report. --ci alone fails on\n" +
+			"high and critical findings.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return runCheck(cmd, path, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	addScanFlags(cmd, opts)
+	flags.StringVar(&opts.format, "format", "console", "Output format: "+strings.Join(validFormats, ", "))
+	flags.StringVar(&opts.output, "output", "", "Output file path (default: stdout)")
+	flags.StringVar(&opts.severity, "severity", "info", "Minimum severity to report: "+report.SeverityNames())
+	flags.StringVar(&opts.failOn, "fail-on", "", "Exit with code 1 when findings of this severity or above are found: "+report.SeverityNames())
+	flags.BoolVar(&opts.ci, "ci", false, "CI mode: exit with code 1 when HIGH or CRITICAL issues are found (same as --fail-on high)")
+	flags.StringVar(&opts.baseline, "baseline", "", "Report only findings not in this baseline file (default "+baseline.DefaultFile+" in the project when given without a value)")
+	flags.Lookup("baseline").NoOptDefVal = baseline.DefaultFile
+	flags.BoolVar(&opts.updateBaseline, "update-baseline", false, "Remove fixed findings from the baseline file")
+
+	return cmd
+}
+
+// addScanFlags adds the flags that select and run checks, shared by check
+// and baseline create.
+func addScanFlags(cmd *cobra.Command, opts *checkOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.platform, "platform", "both", "Platform to check: android, ios, or both")
+	flags.StringVar(&opts.profile, "profile", "", "Check profile: store, quality, full, or one defined in .fsct.yaml (default: store)")
+	flags.StringSliceVar(&opts.skip, "skip", nil, "Comma-separated list of check IDs to skip")
+	flags.StringSliceVar(&opts.checks, "checks", nil, "Comma-separated list of check IDs to run")
+	flags.BoolVar(&opts.includeGenerated, "include-generated", false, "Check generated Dart files such as *.g.dart and *.freezed.dart")
+	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "Verbose output")
+	flags.BoolVar(&opts.reportUnusedSuppressions, "report-unused-suppressions", false, "Report fsct-ignore directives that no longer match a finding")
+	flags.IntVarP(&opts.jobs, "jobs", "j", 0, "Number of checks to run in parallel (default: number of CPUs)")
+	flags.DurationVar(&opts.timeout, "timeout", 2*time.Minute, "Per-check time limit, e.g. 30s or 2m (0 disables)")
+
+	flags.BoolVar(&opts.offline, "offline", false, "Skip AI-powered checks")
+	flags.StringVar(&opts.aiKey, "ai-key", "", "AI provider API key (prefer the AI_API_KEY env var)")
+	flags.StringVar(&opts.aiProvider, "ai-provider", "", "AI provider: minimax, openai, or custom")
+	flags.StringVar(&opts.aiURL, "ai-url", "", "Base URL for the AI provider")
+	flags.StringVar(&opts.aiModel, "ai-model", "", "Model to use for AI analysis")
+}
+
+// scanResult is the outcome of running the selected checks on a project.
+type scanResult struct {
+	project  *checker.Project
+	profile  *profile.Profile
+	static   []checker.Check
+	ai       []checker.Check
+	results  []runner.Result
+	findings []report.Finding
+	// skipped are the findings of the project diagnostics --platform left
+	// out.
+	skipped []report.Finding
+	// excluded are the findings in files the paths section of the
+	// configuration excludes.
+	excluded []report.Finding
+}
+
+// scan loads the project at path and runs the checks selected by opts.
+// Every finding is fingerprinted, and findings covered by an fsct-ignore
+// directive are marked as suppressed.
+// Errors are returned as exitCodeErrors.
+func scan(cmd *cobra.Command, path string, opts *checkOptions) (*scanResult, error) {
+	if err := validateCheckOptions(opts); err != nil {
+		return nil, &exitCodeError{code: exitError, err: err}
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return nil, &exitCodeError{code: exitError, err: err}
+	}
+
+	base, err := loadKnowledgeBase(cfg)
+	if err != nil {
+		return nil, &exitCodeError{code: exitError, err: fmt.Errorf("load knowledge base: %w", err)}
+	}
+
+	project, err := loader.LoadWithOptions(path, loader.Options{IncludeGenerated: opts.includeGenerated, KnowledgeBase: base})
+	if err != nil {
+		return nil, &exitCodeError{code: exitError, err: err}
+	}
+	applyConfig(cmd, cfg, opts)
+	project.Thresholds = thresholds(cfg)
+
+	prof, err := profile.Lookup(opts.profile, cfg.Profiles)
+	if err != nil {
+		return nil, &exitCodeError{code: exitError, err: err}
+	}
+
+	reg := registry.NewRegistry()
+	reg.RegisterAll()
+	if cfg.Reviewer != nil {
+		reg.RegisterReviewerChecks(cfg.Reviewer)
+	}
+	if client := newAIClient(cfg, opts); client != nil {
+		reg.RegisterAIChecks(client)
+	}
+
+	static, aiChecks := selectChecks(reg, prof, opts)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	runOpts := runner.Options{Workers: opts.jobs, Timeout: opts.timeout}
+	stderr := cmd.ErrOrStderr()
+
+	sup := loadSuppressions(project)
+
+	diagnostics, excluded := platformDiagnostics(project.Diagnostics, opts.platform)
+	findings := loader.Findings(diagnostics)
+	results := runner.Run(ctx, static, project, runOpts)
+	findings = append(findings, runner.Findings(results)...)
+	overrideSeverities(cfg, findings)
+	sup.Apply(findings)
+	if len(aiChecks) > 0 && ctx.Err() == nil {
+		// AI checks only see findings that have not been suppressed.
+		staticFindings := unsuppressed(findings)
+		for _, c := range aiChecks {
+			if setter, ok := c.(interface {
+				SetContext([]report.Finding, int)
+			}); ok {
+				setter.SetContext(staticFindings, len(static))
+			}
+		}
+		aiResults := runner.Run(ctx, aiChecks, project, runOpts)
+		aiFindings := runner.Findings(aiResults)
+		overrideSeverities(cfg, aiFindings)
+		sup.Apply(aiFindings)
+		results = append(results, aiResults...)
+		findings = append(findings, aiFindings...)
+	}
+	if opts.verbose {
+		logResults(stderr, results)
+	}
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.Canceled) {
+			err = errors.New("interrupted")
+		}
+		return nil, &exitCodeError{code: exitError, err: err}
+	}
+	findings = append(findings, sup.Problems()...)
+	if opts.reportUnusedSuppressions {
+		ran := make(map[string]bool, len(results))
+		for _, r := range results {
+			ran[r.ID] = true
+		}
+		findings = append(findings, sup.Unused(func(id string) bool { return ran[id] })...)
+	}
+	findings, outside := filterPaths(project.Path, cfg.Paths, findings)
+	fp := fingerprint.New(project.Path)
+	fp.Assign(findings)
+	fp.Assign(outside)
+	skipped := loader.Findings(excluded)
+	fp.Assign(skipped)
+
+	return &scanResult{
+		project:  project,
+		profile:  prof,
+		static:   static,
+		ai:       aiChecks,
+		results:  results,
+		findings: findings,
+		skipped:  skipped,
+		excluded: outside,
+	}, nil
+}
+
+func runCheck(cmd *cobra.Command, path string, opts *checkOptions) error {
+	run, err := scan(cmd, path, opts)
+	if err != nil {
+		return err
+	}
+
+	findings := run.findings
+	baselined := 0
+	if opts.baseline != "" || opts.updateBaseline {
+		file := baselinePath(path, opts.baseline)
+		base, err := baseline.Load(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				err = fmt.Errorf("baseline %s not found (create one with 'fsct baseline create')", file)
+			}
+			return &exitCodeError{code: exitError, err: err}
+		}
+
+		var matched []baseline.Entry
+		findings, matched = base.Filter(findings)
+		baselined = len(matched)
+
+		if opts.updateBaseline {
+			// Findings in excluded paths are not reported, but those still
+			// found have not been fixed.
+			_, outside := base.Filter(run.excluded)
+			keep := append(matched, outside...)
+			removed := base.Prune(append(keep, notRun(base.Entries, run.results, run.skipped)...))
+			if err := base.Save(file); err != nil {
+				return &exitCodeError{code: exitError, err: fmt.Errorf("write baseline: %w", err)}
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Baseline %s updated: %d fixed finding(s) removed, %d remaining\n", file, removed, len(base.Entries))
+		}
+	}
+	passed := runner.Passed(run.results)
+
+	f := filter.NewFilter()
+	f.SetMinSeverity(opts.severity)
+	visible := make([]report.Finding, 0, len(findings))
+	for _, finding := range findings {
+		if f.ShouldInclude(finding.Severity, finding.ID) {
+			visible = append(visible, finding)
+		}
+	}
+
+	summary := summarize(visible, passed)
+	summary.Profile = run.profile.Name
+	summary.Checks = len(run.results)
+	summary.Baselined = baselined
+
+	out := formatter.NewFormatter(opts.format)
+	if opts.format == "sarif" {
+		var rules []checker.Metadata
+		for _, c := range run.static {
+			rules = append(rules, checker.Describe(c))
+		}
+		for _, c := range run.ai {
+			rules = append(rules, checker.Describe(c))
+		}
+		out = formatter.NewSARIFFormatter(rules)
+	}
+	data, err := out.Format(visible, summary)
+	if err != nil {
+		return &exitCodeError{code: exitError, err: fmt.Errorf("format report: %w", err)}
+	}
+
+	if err := writeReport(cmd.OutOrStdout(), data, opts.output, out.GetExtension()); err != nil {
+		return &exitCodeError{code: exitError, err: err}
+	}
+
+	if failOn := failOnSeverity(opts); failOn != "" && countAtLeast(findings, failOn) > 0 {
+		return &exitCodeError{code: exitFindings}
+	}
+	return nil
+}
+
+// failOnSeverity returns the severity at which the run fails, or "" if it
+// never does.
+func failOnSeverity(opts *checkOptions) report.Severity {
+	if severity, ok := report.ParseSeverity(opts.failOn); ok {
+		return severity
+	}
+	if opts.ci {
+		return report.SeverityHigh
+	}
+	return ""
+}
+
+// countAtLeast returns the number of findings, not counting suppressed
+// ones, that are at least min.
+func countAtLeast(findings []report.Finding, min report.Severity) int {
+	n := 0
+	for _, f := range findings {
+		if f.Suppression == nil && f.Severity.AtLeast(min) {
+			n++
+		}
+	}
+	return n
+}
+
+func validateCheckOptions(opts *checkOptions) error {
+	switch opts.platform {
+	case "android", "ios", "both":
+	default:
+		return fmt.Errorf("invalid --platform %q (must be android, ios, or both)", opts.platform)
+	}
+
+	valid := false
+	for _, format := range validFormats {
+		if opts.format == format {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid --format %q (must be one of: %s)", opts.format, strings.Join(validFormats, ", "))
+	}
+
+	if opts.jobs < 0 {
+		return fmt.Errorf("invalid --jobs %d (must be 0 or more)", opts.jobs)
+	}
+	if opts.timeout < 0 {
+		return fmt.Errorf("invalid --timeout %s (must be 0 or more)", opts.timeout)
+	}
+
+	if _, ok := report.ParseSeverity(opts.severity); !ok {
+		return fmt.Errorf("invalid --severity %q (must be %s)", opts.severity, report.SeverityNames())
+	}
+	if _, ok := report.ParseSeverity(opts.failOn); opts.failOn != "" && !ok {
+		return fmt.Errorf("invalid --fail-on %q (must be %s)", opts.failOn, report.SeverityNames())
+	}
+
+	known := make(map[string]bool)
+	for _, meta := range registry.Catalog() {
+		known[meta.ID] = true
+	}
+	for _, flag := range []struct {
+		name string
+		ids  []string
+	}{{"checks", opts.checks}, {"skip", opts.skip}} {
+		for _, id := range flag.ids {
+			if name := strings.ToUpper(strings.TrimSpace(id)); !known[name] {
+				return fmt.Errorf("unknown check %q in --%s (see fsct checks list)", name, flag.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyConfig merges .fsct.yaml settings into options that were not set on
+// the command line.
+func applyConfig(cmd *cobra.Command, cfg *config.Config, opts *checkOptions) {
+	if cfg.Checks != nil {
+		// Copy so that appending never writes into the flag's or the
+		// configuration's slice.
+		skip := append([]string(nil), opts.skip...)
+		skip = append(skip, cfg.Checks.Skip...)
+		if !cmd.Flags().Changed("checks") && len(cfg.Checks.Include) > 0 {
+			opts.checks = cfg.Checks.Include
+		}
+		for _, id := range sortedIDs(cfg.Checks.Overrides) {
+			if override := cfg.Checks.Overrides[id]; override != nil && override.Enabled != nil && !*override.Enabled {
+				skip = append(skip, id)
+			}
+		}
+		opts.skip = skip
+	}
+
+	if cfg.Profile != "" && !cmd.Flags().Changed("profile") {
+		opts.profile = cfg.Profile
+	}
+	if cfg.FailOn != "" && !cmd.Flags().Changed("fail-on") {
+		opts.failOn = cfg.FailOn
+	}
+
+	if cfg.Platforms != nil && !cmd.Flags().Changed("platform") {
+		switch {
+		case cfg.Platforms.Android && !cfg.Platforms.IOS:
+			opts.platform = "android"
+		case cfg.Platforms.IOS && !cfg.Platforms.Android:
+			opts.platform = "ios"
+		}
+	}
+
+	if cfg.AI != nil && cfg.AI.Offline {
+		opts.offline = true
+	}
+}
+
+// newAIClient returns a configured AI client, or nil when AI analysis is
+// disabled or not configured.
+func newAIClient(cfg *config.Config, opts *checkOptions) *aipkg.Client {
+	if opts.offline || opts.format == "prompt" {
+		return nil
+	}
+
+	aiCfg, _ := resolveAIConfig(cfg, opts)
+	if !aiCfg.IsConfigured() {
+		return nil
+	}
+
+	client, err := aiCfg.NewClient()
+	if err != nil {
+		return nil
+	}
+	return client
+}
+
+// resolveAIConfig merges the AI settings of the defaults, .fsct.yaml, the
+// environment and flags, later sources taking precedence. It also returns
+// where each of api_key, provider, url and model came from.
+func resolveAIConfig(cfg *config.Config, opts *checkOptions) (*aipkg.Config, map[string]string) {
+	aiCfg := aipkg.NewConfig()
+	sources := map[string]string{"api_key": "default", "provider": "default", "url": "default", "model": "default"}
+
+	if cfg.AI != nil {
+		if cfg.AI.APIKeyEnv != "" {
+			aiCfg.APIKeyEnv = cfg.AI.APIKeyEnv
+		}
+		if cfg.AI.APIKey != "" {
+			aiCfg.APIKey, sources["api_key"] = cfg.AI.APIKey, cfg.Source("ai", "api_key")
+		}
+		if cfg.AI.URL != "" {
+			aiCfg.BaseURL, sources["url"] = cfg.AI.URL, cfg.Source("ai", "url")
+		}
+		if cfg.AI.Model != "" {
+			aiCfg.Model, sources["model"] = cfg.AI.Model, cfg.Source("ai", "model")
+		}
+	}
+
+	before := *aiCfg
+	aiCfg.LoadFromEnv()
+	if aiCfg.APIKey != before.APIKey {
+		sources["api_key"] = "env"
+	}
+	if aiCfg.Provider != before.Provider {
+		sources["provider"] = "env AI_PROVIDER"
+	}
+	if aiCfg.BaseURL != before.BaseURL {
+		sources["url"] = "env AI_BASE_URL"
+	}
+	if aiCfg.Model != before.Model {
+		sources["model"] = "env AI_MODEL"
+	}
+
+	if opts.aiKey != "" {
+		aiCfg.APIKey, sources["api_key"] = opts.aiKey, "flag --ai-key"
+	}
+	if opts.aiProvider != "" {
+		aiCfg.Provider, sources["provider"] = opts.aiProvider, "flag --ai-provider"
+	}
+	if opts.aiURL != "" {
+		aiCfg.BaseURL, sources["url"] = opts.aiURL, "flag --ai-url"
+	}
+	if opts.aiModel != "" {
+		aiCfg.Model, sources["model"] = opts.aiModel, "flag --ai-model"
+	}
+	aiCfg.Offline = opts.offline
+
+	return aiCfg, sources
+}
+
+// loadConfig loads .fsct.yaml from the project at path and checks that the
+// check IDs and profiles it names exist.
+func loadConfig(path string) (*config.Config, error) {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if errs := checkConfigReferences(cfg); len(errs) > 0 {
+		return nil, errs
+	}
+	return cfg, nil
+}
+
+// loadKnowledgeBase returns the built-in knowledge base merged with the
+// user one and the file named by the knowledge_base key of cfg.
+func loadKnowledgeBase(cfg *config.Config) (*kb.KnowledgeBase, error) {
+	if file := cfg.KnowledgeBaseFile(); file != "" {
+		return kb.Load(file)
+	}
+	return kb.Load()
+}
+
+// checkConfigReferences reports check IDs and profiles named in cfg that do
+// not exist.
+func checkConfigReferences(cfg *config.Config) config.Errors {
+	known := make(map[string]bool)
+	for _, meta := range registry.Catalog() {
+		known[meta.ID] = true
+	}
+
+	var errs config.Errors
+	unknown := func(keys []string, ids ...string) {
+		for _, id := range ids {
+			if name := strings.ToUpper(strings.TrimSpace(id)); !known[name] {
+				errs = append(errs, cfg.Errorf(append(keys, id), "unknown check %q", name))
+			}
+		}
+	}
+
+	if cfg.Checks != nil {
+		unknown([]string{"checks", "skip"}, cfg.Checks.Skip...)
+		unknown([]string{"checks", "include"}, cfg.Checks.Include...)
+		for _, id := range sortedIDs(cfg.Checks.Overrides) {
+			unknown([]string{"checks", "overrides"}, id)
+		}
+	}
+
+	for _, name := range sortedIDs(cfg.Profiles) {
+		if p := cfg.Profiles[name]; p != nil {
+			unknown([]string{"profiles", name, "checks"}, p.Checks...)
+			unknown([]string{"profiles", name, "exclude"}, p.Exclude...)
+		}
+		if _, err := profile.Lookup(name, cfg.Profiles); err != nil {
+			errs = append(errs, cfg.Errorf([]string{"profiles", name}, "%v", err))
+		}
+	}
+	if cfg.Profile != "" {
+		if _, err := profile.Lookup(cfg.Profile, cfg.Profiles); err != nil {
+			errs = append(errs, cfg.Errorf([]string{"profile"}, "%v", err))
+		}
+	}
+
+	return errs
+}
+
+func sortedIDs[V any](m map[string]V) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// thresholds returns the check limits configured in cfg.
+func thresholds(cfg *config.Config) checker.Thresholds {
+	if cfg.Thresholds == nil {
+		return checker.Thresholds{}
+	}
+	return checker.Thresholds{
+		MinTargetSDK:           cfg.Thresholds.MinTargetSDK,
+		MinIOSDeploymentTarget: cfg.Thresholds.MinIOSDeploymentTarget,
+		MaxFileLines:           cfg.Thresholds.MaxFileLines,
+		MinTestRatio:           cfg.Thresholds.MinTestRatio,
+	}
+}
+
+// overrideSeverities applies the severity overrides in cfg to findings.
+func overrideSeverities(cfg *config.Config, findings []report.Finding) {
+	if cfg.Checks == nil {
+		return
+	}
+	for i := range findings {
+		override := cfg.Checks.Overrides[findings[i].ID]
+		if override == nil || override.Severity == "" {
+			continue
+		}
+		if severity, ok := report.ParseSeverity(override.Severity); ok {
+			findings[i].Severity = severity
+		}
+	}
+}
+
+// filterPaths splits findings into those kept and those in files excluded
+// by the paths section of the configuration. Findings that do not point at
+// a project file, such as a missing LICENSE, are kept.
+func filterPaths(root string, paths *config.PathsConfig, findings []report.Finding) (kept, excluded []report.Finding) {
+	if paths == nil || (len(paths.Include) == 0 && len(paths.Exclude) == 0) {
+		return findings, nil
+	}
+	f := filter.NewFilter()
+	f.SetPaths(paths.Include, paths.Exclude)
+
+	kept = make([]report.Finding, 0, len(findings))
+	for _, finding := range findings {
+		if finding.File != "" && isProjectFile(root, finding.File) && !f.ShouldIncludePath(finding.File) {
+			excluded = append(excluded, finding)
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept, excluded
+}
+
+func isProjectFile(root, file string) bool {
+	_, err := os.Stat(filepath.Join(root, filepath.FromSlash(file)))
+	return err == nil
+}
+
+// selectChecks returns the static and AI checks to run, sorted by ID. Checks
+// named with --checks run regardless of the profile.
+func selectChecks(reg *registry.CheckerRegistry, prof *profile.Profile, opts *checkOptions) (static, ai []checker.Check) {
+	skip := make(map[string]bool)
+	for _, id := range opts.skip {
+		skip[strings.ToUpper(strings.TrimSpace(id))] = true
+	}
+	only := make(map[string]bool)
+	for _, id := range opts.checks {
+		only[strings.ToUpper(strings.TrimSpace(id))] = true
+	}
+
+	for _, c := range reg.GetByPlatform(opts.platform) {
+		id := c.ID()
+		if skip[id] || (len(only) > 0 && !only[id]) {
+			continue
+		}
+		meta := checker.Describe(c)
+		if len(only) == 0 && !prof.Includes(meta) {
+			continue
+		}
+		if meta.Category == checker.CategoryAI {
+			ai = append(ai, c)
+		} else {
+			static = append(static, c)
+		}
+	}
+
+	return static, ai
+}
+
+// loadSuppressions collects the fsct-ignore directives in the project's
+// sources and configuration files. Other files are scanned when a finding
+// points at them.
+func loadSuppressions(project *checker.Project) *suppress.Set {
+	sup := suppress.New(project.Path)
+	for _, f := range project.Sources.Files() {
+		sup.Add(f.Path, f.Content)
+	}
+	sup.Load(project.Files...)
+	return sup
+}
+
+func unsuppressed(findings []report.Finding) []report.Finding {
+	active := make([]report.Finding, 0, len(findings))
+	for _, f := range findings {
+		if f.Suppression == nil {
+			active = append(active, f)
+		}
+	}
+	return active
+}
+
+// notRun returns the baseline entries of catalog checks that were not run,
+// for example because of --checks or --platform, and those of the skipped
+// diagnostics of a platform --platform left out. Updating the baseline
+// keeps them: nothing is known about whether they were fixed.
+func notRun(entries []baseline.Entry, results []runner.Result, skipped []report.Finding) []baseline.Entry {
+	ran := make(map[string]bool, len(results))
+	for _, r := range results {
+		ran[r.ID] = true
+	}
+	known := make(map[string]bool)
+	for _, meta := range registry.Catalog() {
+		known[meta.ID] = true
+	}
+	diagnostics := make(map[string]bool, len(skipped))
+	for _, f := range skipped {
+		diagnostics[f.Fingerprint] = true
+	}
+
+	var kept []baseline.Entry
+	for _, e := range entries {
+		if known[e.ID] && !ran[e.ID] || diagnostics[e.Fingerprint] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// baselinePath resolves the --baseline value. The default file name is
+// looked up in the project directory; other paths are used as given.
+func baselinePath(projectPath, file string) string {
+	if file == "" || file == baseline.DefaultFile {
+		return filepath.Join(projectPath, baseline.DefaultFile)
+	}
+	return file
+}
+
+// platformDiagnostics splits diagnostics into those of the platforms
+// selected by platform and the rest.
+func platformDiagnostics(diagnostics []checker.Diagnostic, platform string) (selected, excluded []checker.Diagnostic) {
+	for _, d := range diagnostics {
+		if d.Platform == "" || platform == "both" || d.Platform == platform {
+			selected = append(selected, d)
+		} else {
+			excluded = append(excluded, d)
+		}
+	}
+	return selected, excluded
+}
+
+// logResults prints one line per check with its status and duration.
+func logResults(log io.Writer, results []runner.Result) {
+	for _, r := range results {
+		fmt.Fprintf(log, "  %-8s %-40s %-8s %3d finding(s) %s\n",
+			r.ID, r.Name, r.Status, len(r.Findings), r.Duration.Round(time.Microsecond))
+	}
+}
+
+func summarize(findings []report.Finding, passed int) report.Summary {
+	summary := report.Summary{Passed: passed}
+	for _, f := range findings {
+		if f.Suppression != nil {
+			summary.Suppressed++
+			continue
+		}
+		summary.Count(f.Severity)
+	}
+	return summary
+}
+
+// writeReport writes the report to stdout, or to output when set. The
+// formatter's extension is appended unless output already ends with it.
+func writeReport(stdout io.Writer, data []byte, output, extension string) error {
+	if output == "" {
+		_, err := stdout.Write(data)
+		if err == nil && len(data) > 0 && data[len(data)-1] != '\n' {
+			_, err = fmt.Fprintln(stdout)
+		}
+		return err
+	}
+
+	if extension != "" && strings.EqualFold(filepath.Ext(output), "."+extension) {
+		extension = ""
+	}
+	if err := formatter.WriteToFile(data, output, extension); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	written := output
+	if extension != "" {
+		written = output + "." + extension
+	}
+	fmt.Fprintf(stdout, "Report written to %s\n", written)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/baseline"
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/report"
+	"github.com/ricky-irfandi/fsct/internal/runner"
+)
+
+func TestApplyConfigSkip(t *testing.T) {
+	disabled := false
+	skip := make([]string, 1, 8)
+	skip[0] = "AND-012"
+	cfg := &config.Config{Checks: &config.ChecksConfig{
+		Skip: skip,
+		Overrides: map[string]*config.CheckOverride{
+			"SEC-005": {Enabled: &disabled},
+			"AND-001": {Enabled: &disabled},
+			"IOS-007": {Enabled: &disabled},
+			"POL-001": {Severity: "info"},
+		},
+	}}
+
+	opts := &checkOptions{}
+	applyConfig(newCheckCmd(), cfg, opts)
+
+	if want := []string{"AND-012", "AND-001", "IOS-007", "SEC-005"}; !reflect.DeepEqual(opts.skip, want) {
+		t.Errorf("skip = %v, want %v", opts.skip, want)
+	}
+	if got := skip[:cap(skip)][1]; got != "" {
+		t.Errorf("expected the configuration's skip list to be left alone, got %q after it", got)
+	}
+}
+
+func TestNotRun(t *testing.T) {
+	entries := []baseline.Entry{
+		{Fingerprint: "a", ID: "AND-001"},
+		{Fingerprint: "b", ID: "SEC-001"},
+		{Fingerprint: "c", ID: "LOAD-001", File: "ios/Runner/Info.plist"},
+		{Fingerprint: "d", ID: "LOAD-002", File: "android/app/src/main/AndroidManifest.xml"},
+	}
+	results := []runner.Result{{ID: "AND-001"}}
+	skipped := []report.Finding{{ID: "LOAD-001", File: "ios/Runner/Info.plist", Fingerprint: "c"}}
+
+	var ids []string
+	for _, e := range notRun(entries, results, skipped) {
+		ids = append(ids, e.ID)
+	}
+	if want := []string{"SEC-001", "LOAD-001"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("notRun = %v, want %v", ids, want)
+	}
+}
+
+func TestFilterPathsReinclude(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".fsct.yaml":           "paths:\n  exclude: [\"lib/legacy/*\", \"!lib/legacy/keep.dart\"]\n",
+		"lib/legacy/old.dart":  "",
+		"lib/legacy/keep.dart": "",
+		"lib/main.dart":        "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := []report.Finding{
+		{ID: "COD-001", File: "lib/legacy/old.dart"},
+		{ID: "COD-001", File: "lib/legacy/keep.dart"},
+		{ID: "COD-001", File: "lib/main.dart"},
+		{ID: "PUB-001", File: ""},
+	}
+	kept, excluded := filterPaths(dir, cfg.Paths, findings)
+	var got []string
+	for _, f := range kept {
+		got = append(got, f.File)
+	}
+	want := []string{"lib/legacy/keep.dart", "lib/main.dart", ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterPaths kept %q, want %q", got, want)
+	}
+	if len(excluded) != 1 || excluded[0].File != "lib/legacy/old.dart" {
+		t.Errorf("filterPaths excluded %v, want lib/legacy/old.dart", excluded)
+	}
+}
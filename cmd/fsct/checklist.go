@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/checker/reviewer"
+	"github.com/ricky-irfandi/fsct/internal/config"
+)
+
+func newChecklistCmd() *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "checklist [path]",
+		Short: "Generate the reviewer pre-submission checklist",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			fileCfg, err := config.LoadConfig(path)
+			if err != nil {
+				return &exitCodeError{code: exitError, err: err}
+			}
+			cfg := fileCfg.Reviewer
+			if cfg == nil {
+				cfg = reviewer.GetConfigFromEnv()
+			}
+			checklist := reviewer.GenerateChecklist(cfg)
+
+			var content string
+			switch format {
+			case "console":
+				content = checklist.ToConsole()
+			case "markdown":
+				content = checklist.ToMarkdown()
+			case "html":
+				content = checklist.ToHTML()
+			default:
+				return &exitCodeError{code: exitError, err: fmt.Errorf("invalid --format %q (must be console, markdown, or html)", format)}
+			}
+
+			if output == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), content)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+				return &exitCodeError{code: exitError, err: fmt.Errorf("write checklist: %w", err)}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Checklist written to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "console", "Output format: console, markdown, or html")
+	cmd.Flags().StringVar(&output, "output", "", "Output file path (default: stdout)")
+
+	return cmd
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/profile"
+	"github.com/ricky-irfandi/fsct/internal/registry"
+)
+
+type checksListOptions struct {
+	category string
+	platform string
+	profile  string
+	format   string
+}
+
+func newChecksCmd() *cobra.Command {
+	opts := &checksListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "checks",
+		Short: "List and explain available compliance checks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecksList(cmd.OutOrStdout(), opts)
+		},
+	}
+	addChecksListFlags(cmd, opts)
+
+	cmd.AddCommand(newChecksListCmd(), newChecksExplainCmd())
+	return cmd
+}
+
+func newChecksListCmd() *cobra.Command {
+	opts := &checksListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available compliance checks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChecksList(cmd.OutOrStdout(), opts)
+		},
+	}
+	addChecksListFlags(cmd, opts)
+
+	return cmd
+}
+
+func addChecksListFlags(cmd *cobra.Command, opts *checksListOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.category, "category", "", "Only list checks in this category (e.g. Android, Security) or with this ID prefix (e.g. AND)")
+	flags.StringVar(&opts.platform, "platform", "", "Only list checks that apply to this platform: android or ios")
+	flags.StringVar(&opts.profile, "profile", "", "Only list checks in this profile: store, quality, full, or one defined in .fsct.yaml")
+	flags.StringVar(&opts.format, "format", "table", "Output format: table or json")
+}
+
+func newChecksExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain <check-id>",
+		Short: "Show details and remediation for a check",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := strings.ToUpper(strings.TrimSpace(args[0]))
+			for _, meta := range registry.Catalog() {
+				if meta.ID == id {
+					writeExplanation(cmd.OutOrStdout(), meta)
+					return nil
+				}
+			}
+			return &exitCodeError{code: exitError, err: fmt.Errorf("unknown check %q (run 'fsct checks list' to see all checks)", args[0])}
+		},
+	}
+}
+
+func runChecksList(out io.Writer, opts *checksListOptions) error {
+	switch opts.platform {
+	case "", checker.PlatformAndroid, checker.PlatformIOS:
+	default:
+		return &exitCodeError{code: exitError, err: fmt.Errorf("invalid --platform %q (must be android or ios)", opts.platform)}
+	}
+
+	var prof *profile.Profile
+	if opts.profile != "" {
+		cfg, err := config.LoadConfig(".")
+		if err != nil {
+			return &exitCodeError{code: exitError, err: err}
+		}
+		prof, err = profile.Lookup(opts.profile, cfg.Profiles)
+		if err != nil {
+			return &exitCodeError{code: exitError, err: err}
+		}
+	}
+
+	var checks []checker.Metadata
+	for _, meta := range registry.Catalog() {
+		if opts.category != "" && !matchesCategory(meta, opts.category) {
+			continue
+		}
+		if prof != nil && !prof.Includes(meta) {
+			continue
+		}
+		if opts.platform != "" && !meta.AppliesTo(opts.platform) {
+			continue
+		}
+		checks = append(checks, meta)
+	}
+
+	switch opts.format {
+	case "json":
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case "table":
+	default:
+		return &exitCodeError{code: exitError, err: fmt.Errorf("invalid --format %q (must be table or json)", opts.format)}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCATEGORY\tSEVERITY\tPLATFORMS\tNAME")
+	for _, meta := range checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", meta.ID, meta.Category, meta.DefaultSeverity, platformsLabel(meta), meta.Name)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d checks available\n", len(checks))
+	return nil
+}
+
+// matchesCategory accepts either a category name or an ID prefix.
+func matchesCategory(meta checker.Metadata, category string) bool {
+	c, ok := checker.ParseCategory(category)
+	return ok && meta.Category == c
+}
+
+func platformsLabel(meta checker.Metadata) string {
+	if len(meta.Platforms) == 0 {
+		return "all"
+	}
+	return strings.Join(meta.Platforms, ",")
+}
+
+func writeExplanation(out io.Writer, meta checker.Metadata) {
+	fmt.Fprintf(out, "%s  %s\n\n", meta.ID, meta.Name)
+	fmt.Fprintf(out, "Category:   %s\n", meta.Category)
+	fmt.Fprintf(out, "Severity:   %s\n", meta.DefaultSeverity)
+	fmt.Fprintf(out, "Platforms:  %s\n", platformsLabel(meta))
+	if meta.Guideline != "" {
+		fmt.Fprintf(out, "Guideline:  %s\n", meta.Guideline)
+	}
+
+	fmt.Fprintf(out, "\n%s\n", meta.Description)
+	if meta.Rationale != "" {
+		fmt.Fprintf(out, "\nWhy it matters:\n  %s\n", meta.Rationale)
+	}
+	if meta.Remediation != "" {
+		fmt.Fprintf(out, "\nHow to fix:\n  %s\n", meta.Remediation)
+	}
+}
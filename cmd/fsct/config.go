@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/kb"
+	"github.com/ricky-irfandi/fsct/internal/profile"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate and inspect .fsct.yaml",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newConfigValidateCmd(), newConfigPrintCmd())
+	return cmd
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check .fsct.yaml for unknown keys, type errors and invalid values",
+		Long: "Check the project's .fsct.yaml for unknown keys, type errors, invalid values\n" +
+			"and references to checks or profiles that do not exist.\n\n" +
+			"Exit codes:\n" +
+			"  0  the configuration is valid, or there is none\n" +
+			"  1  the configuration has errors\n" +
+			"  2  usage or runtime error",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			cfg, err := loadConfig(path)
+			if err != nil {
+				var errs config.Errors
+				if !errors.As(err, &errs) {
+					return &exitCodeError{code: exitError, err: err}
+				}
+				for _, e := range errs {
+					fmt.Fprintln(cmd.OutOrStdout(), e)
+				}
+				return &exitCodeError{code: exitFindings}
+			}
+
+			if cfg.File == "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "No %s found; defaults apply\n", config.FileName)
+				return nil
+			}
+			if bases := cfg.Bases(); len(bases) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s is valid (extends %s)\n", cfg.File, strings.Join(bases, ", "))
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", cfg.File)
+			return nil
+		},
+	}
+}
+
+func newConfigPrintCmd() *cobra.Command {
+	opts := &checkOptions{format: "console", severity: "info"}
+	var effective bool
+
+	cmd := &cobra.Command{
+		Use:   "print [path]",
+		Short: "Print the configuration",
+		Long: "Print the project's .fsct.yaml as fsct reads it, merged with the files it\n" +
+			"extends, each value annotated with the file and line it came from.\n\n" +
+			"With --effective, print the settings a check run would use instead: the\n" +
+			"defaults, merged with .fsct.yaml, the environment and any check flags given,\n" +
+			"each annotated with where it came from.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			cfg, err := loadConfig(path)
+			if err != nil {
+				return &exitCodeError{code: exitError, err: err}
+			}
+
+			var doc *yaml.Node
+			switch {
+			case effective:
+				doc = effectiveConfig(cmd, cfg, opts)
+			case cfg.File == "":
+				fmt.Fprintf(cmd.OutOrStdout(), "# No %s found\n", config.FileName)
+				return nil
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "# %s\n", cfg.File)
+				if bases := cfg.Bases(); len(bases) > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "# merged over %s\n", strings.Join(bases, ", "))
+				}
+				doc = cfg.Document()
+			}
+
+			enc := yaml.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent(2)
+			if err := enc.Encode(doc); err != nil {
+				return &exitCodeError{code: exitError, err: err}
+			}
+			return enc.Close()
+		},
+	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "Print the merged settings of defaults, .fsct.yaml, environment and flags")
+	addScanFlags(cmd, opts)
+
+	return cmd
+}
+
+// effectiveConfig returns the settings a check run with opts would use as
+// a YAML document, each value commented with its source.
+func effectiveConfig(cmd *cobra.Command, cfg *config.Config, opts *checkOptions) *yaml.Node {
+	flags := cmd.Flags()
+	applyConfig(cmd, cfg, opts)
+
+	source := func(flag string, keys ...string) string {
+		switch {
+		case flag != "" && flags.Changed(flag):
+			return "flag --" + flag
+		case cfg.Source(keys...) != "":
+			return cfg.Source(keys...)
+		default:
+			return "default"
+		}
+	}
+
+	root := mappingNode()
+
+	profileName := opts.profile
+	if profileName == "" {
+		profileName = profile.Default
+	}
+	addSetting(root, "profile", profileName, source("profile", "profile"))
+	if opts.failOn != "" {
+		addSetting(root, "fail_on", strings.ToLower(opts.failOn), source("fail-on", "fail_on"))
+	}
+
+	platformSource := source("platform", "platforms")
+	addSetting(root, "platform", opts.platform, platformSource)
+
+	checks := addMapping(root, "checks")
+	addSetting(checks, "skip", opts.skip, joinSources(source("skip"), source("", "checks", "skip"), source("", "checks", "overrides")))
+	addSetting(checks, "include", opts.checks, source("checks", "checks", "include"))
+	if cfg.Checks != nil && len(cfg.Checks.Overrides) > 0 {
+		overrides := addMapping(checks, "overrides")
+		for _, id := range sortedIDs(cfg.Checks.Overrides) {
+			override := cfg.Checks.Overrides[id]
+			if override == nil {
+				continue
+			}
+			node := addMapping(overrides, id)
+			if override.Enabled != nil {
+				addSetting(node, "enabled", *override.Enabled, cfg.Source("checks", "overrides", id, "enabled"))
+			}
+			if override.Severity != "" {
+				addSetting(node, "severity", override.Severity, cfg.Source("checks", "overrides", id, "severity"))
+			}
+		}
+	}
+
+	limits := thresholds(cfg).WithDefaults()
+	t := addMapping(root, "thresholds")
+	addSetting(t, "min_target_sdk", limits.MinTargetSDK, source("", "thresholds", "min_target_sdk"))
+	addSetting(t, "min_ios_deployment_target", limits.MinIOSDeploymentTarget, source("", "thresholds", "min_ios_deployment_target"))
+	addSetting(t, "max_file_lines", limits.MaxFileLines, source("", "thresholds", "max_file_lines"))
+	addSetting(t, "min_test_ratio", limits.MinTestRatio, source("", "thresholds", "min_test_ratio"))
+
+	var include, exclude []string
+	if cfg.Paths != nil {
+		include, exclude = cfg.Paths.Include, cfg.Paths.Exclude
+	}
+	paths := addMapping(root, "paths")
+	addSetting(paths, "include", include, source("", "paths", "include"))
+	addSetting(paths, "exclude", exclude, source("", "paths", "exclude"))
+	addSetting(paths, "include_generated", opts.includeGenerated, source("include-generated"))
+
+	aiCfg, aiSources := resolveAIConfig(cfg, opts)
+	ai := addMapping(root, "ai")
+	addSetting(ai, "offline", opts.offline, source("offline", "ai", "offline"))
+	addSetting(ai, "provider", aiCfg.Provider, aiSources["provider"])
+	addSetting(ai, "url", aiCfg.BaseURL, aiSources["url"])
+	addSetting(ai, "model", aiCfg.Model, aiSources["model"])
+	addSetting(ai, "api_key", aiCfg.MaskedAPIKey(), aiSources["api_key"])
+
+	kbFiles, kbSource := knowledgeBaseSources(cfg)
+	addSetting(root, "knowledge_base", kbFiles, kbSource)
+
+	addSetting(root, "jobs", opts.jobs, source("jobs"))
+	addSetting(root, "timeout", opts.timeout.String(), source("timeout"))
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+}
+
+// knowledgeBaseSources returns the knowledge bases a check run merges, in
+// order, and where they were set.
+func knowledgeBaseSources(cfg *config.Config) (files []string, source string) {
+	files = []string{"built-in"}
+	sources := []string{"default"}
+	if path, err := kb.UserPath(); err == nil {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+			sources = append(sources, "fsct kb update")
+		}
+	}
+	if file := cfg.KnowledgeBaseFile(); file != "" {
+		files = append(files, file)
+		sources = append(sources, cfg.Source("knowledge_base"))
+	}
+	return files, joinSources(sources...)
+}
+
+func joinSources(sources ...string) string {
+	var named []string
+	for _, s := range sources {
+		if s != "default" {
+			named = append(named, s)
+		}
+	}
+	if len(named) == 0 {
+		return "default"
+	}
+	return strings.Join(named, ", ")
+}
+
+func mappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode}
+}
+
+func addMapping(parent *yaml.Node, key string) *yaml.Node {
+	node := mappingNode()
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+	return node
+}
+
+func addSetting(parent *yaml.Node, key string, value any, source string) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		node = yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(value)}
+	}
+	if node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	node.LineComment = source
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+}
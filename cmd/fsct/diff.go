@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/diff"
+)
+
+func newDiffCmd() *cobra.Command {
+	var format, output string
+	var failOnAdded bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <old-report.json> <new-report.json>",
+		Short: "Compare two JSON reports",
+		Long: "Compare two JSON reports produced by `fsct check --format json`.\n\n" +
+			"Exit codes:\n" +
+			"  0  success\n" +
+			"  1  --fail-on-added is set and the new report has added findings\n" +
+			"  2  usage or runtime error",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "console" && format != "json" {
+				return &exitCodeError{code: exitError, err: fmt.Errorf("invalid --format %q (must be console or json)", format)}
+			}
+
+			result, err := diff.Diff(args[0], args[1])
+			if err != nil {
+				return &exitCodeError{code: exitError, err: err}
+			}
+
+			if output != "" {
+				if err := result.Save(output); err != nil {
+					return &exitCodeError{code: exitError, err: fmt.Errorf("write diff: %w", err)}
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Diff written to %s\n", output)
+			} else {
+				fmt.Fprintln(cmd.OutOrStdout(), result.Format(format))
+			}
+
+			if failOnAdded && result.Summary.Added > 0 {
+				return &exitCodeError{code: exitFindings}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "console", "Output format: console or json")
+	cmd.Flags().StringVar(&output, "output", "", "Write the diff as JSON to this file")
+	cmd.Flags().BoolVar(&failOnAdded, "fail-on-added", false, "Exit with code 1 when new findings were added")
+
+	return cmd
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/interactive"
+)
+
+func newInteractiveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "interactive",
+		Aliases: []string{"ui"},
+		Short:   "Launch the interactive terminal interface",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return interactive.Run()
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ricky-irfandi/fsct/internal/kb"
+)
+
+func newKbCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kb",
+		Short: "Manage the dependency knowledge base",
+		Long: "The dependency knowledge base records which Info.plist usage descriptions and\n" +
+			"Android permissions pub packages need. A copy is built into fsct; entries\n" +
+			"added with `fsct kb update` are kept in ~/" + kb.UserFile + " and take precedence,\n" +
+			"followed by the file named by knowledge_base in .fsct.yaml, if any.",
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(newKbUpdateCmd())
+	return cmd
+}
+
+func newKbUpdateCmd() *cobra.Command {
+	var from string
+
+	cmd := &cobra.Command{
+		Use:   "update --from <file>",
+		Short: "Add package entries to the user knowledge base",
+		Long: "Merge the packages in a knowledge base file into ~/" + kb.UserFile + ". Entries\n" +
+			"replace earlier ones of the same name, including built-in ones.\n\n" +
+			"The file uses the format of the built-in knowledge base:\n\n" +
+			"  {\n" +
+			"    \"schema\": 1,\n" +
+			"    \"packages\": {\n" +
+			"      \"acme_scanner\": {\n" +
+			"        \"usage_descriptions\": [\"NSCameraUsageDescription\"],\n" +
+			"        \"permissions\": [\"android.permission.CAMERA\"]\n" +
+			"      }\n" +
+			"    }\n" +
+			"  }",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := kb.UserPath()
+			if err != nil {
+				return &exitCodeError{code: exitError, err: err}
+			}
+			added, updated, err := kb.Update(path, from)
+			if err != nil {
+				return &exitCodeError{code: exitError, err: fmt.Errorf("update knowledge base: %w", err)}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added %d and updated %d package(s) in %s\n", len(added), len(updated), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Knowledge base file to merge")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	// Link the built-in checks into the registry. In-house checks register
+	// themselves the same way: add a blank import of their package here.
+	_ "github.com/ricky-irfandi/fsct/internal/registry/builtin"
+)
+
+// Set via -ldflags at build time (see Makefile).
+var (
+	version   = "dev"
+	buildTime = "unknown"
+)
+
+// Exit codes returned by the fsct binary.
+const (
+	exitOK       = 0
+	exitFindings = 1
+	exitError    = 2
+)
+
+// exitCodeError carries a specific process exit code out of a command.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func main() {
+	os.Exit(execute(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// execute runs the command line args, writing to stdout and stderr, and
+// returns the process exit code.
+func execute(args []string, stdout, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	root := newRootCmd()
+	root.SetArgs(args)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	err := root.ExecuteContext(ctx)
+	if err == nil {
+		return exitOK
+	}
+
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		if exitErr.err != nil {
+			fmt.Fprintln(stderr, "Error:", exitErr.err)
+		}
+		return exitErr.code
+	}
+
+	fmt.Fprintln(stderr, "Error:", err)
+	return exitError
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "fsct",
+		Short: "Flutter Store Compliance Tool",
+		Long: "FSCT scans Flutter projects for Google Play and App Store compliance issues\n" +
+			"before submission.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	root.AddCommand(
+		newCheckCmd(),
+		newChecksCmd(),
+		newDiffCmd(),
+		newBaselineCmd(),
+		newConfigCmd(),
+		newChecklistCmd(),
+		newInteractiveCmd(),
+		newKbCmd(),
+		newVersionCmd(),
+	)
+
+	return root
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/baseline"
+)
+
+// fsct runs the command line args against a home directory of its own and
+// returns the exit code and what was written to stdout and stderr.
+func fsct(t *testing.T, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("AI_API_KEY", "")
+
+	var out, errs bytes.Buffer
+	code = execute(args, &out, &errs)
+	return code, out.String(), errs.String()
+}
+
+// project writes a Flutter project with an insecure HTTP URL, which SEC-003
+// reports as high, and returns its directory.
+func project(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	all := map[string]string{
+		"pubspec.yaml":  "name: demo\ndependencies:\n  flutter:\n    sdk: flutter\n",
+		"lib/main.dart": "const api = 'http://api.example.com';\n",
+	}
+	for name, content := range files {
+		all[name] = content
+	}
+	for name, content := range all {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckExitCodes(t *testing.T) {
+	dir := project(t, nil)
+	scan := []string{"check", dir, "--offline", "--checks", "SEC-003"}
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"findings without --fail-on", nil, exitOK},
+		{"findings at --fail-on", []string{"--fail-on", "high"}, exitFindings},
+		{"findings below --fail-on", []string{"--fail-on", "critical"}, exitOK},
+		{"ci", []string{"--ci"}, exitFindings},
+		{"severity hides but fails", []string{"--severity", "critical", "--fail-on", "high"}, exitFindings},
+		{"invalid --fail-on", []string{"--fail-on", "severe"}, exitError},
+		{"invalid --format", []string{"--format", "xml"}, exitError},
+		{"invalid --platform", []string{"--platform", "web"}, exitError},
+		{"invalid --jobs", []string{"--jobs", "-1"}, exitError},
+		{"unknown flag", []string{"--nope"}, exitError},
+		{"unknown check", []string{"--checks", "SEC-999"}, exitError},
+		{"unknown skipped check", []string{"--skip", "sec-999"}, exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := fsct(t, append(append([]string(nil), scan...), tt.args...)...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d (stderr %q)", code, tt.code, stderr)
+			}
+		})
+	}
+
+	t.Run("missing project", func(t *testing.T) {
+		if code, _, _ := fsct(t, "check", filepath.Join(dir, "missing"), "--offline"); code != exitError {
+			t.Errorf("exit code %d, want %d", code, exitError)
+		}
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+		if code, _, _ := fsct(t, "check", dir, dir); code != exitError {
+			t.Errorf("exit code %d, want %d", code, exitError)
+		}
+	})
+}
+
+func TestBaselineExitCodes(t *testing.T) {
+	dir := project(t, nil)
+	scan := []string{"--offline", "--checks", "SEC-003"}
+
+	code, stdout, stderr := fsct(t, append([]string{"baseline", "create", dir}, scan...)...)
+	if code != exitOK || !strings.Contains(stdout, "Baseline of") {
+		t.Fatalf("baseline create: exit code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	check := append([]string{"check", dir, "--fail-on", "high"}, scan...)
+	if code, _, _ := fsct(t, append(check, "--baseline")...); code != exitOK {
+		t.Errorf("check --baseline: exit code %d, want %d for baselined findings", code, exitOK)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "lib", "api.dart"), []byte("const v2 = 'http://v2.example.com';\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, _ := fsct(t, append(check, "--baseline")...); code != exitFindings {
+		t.Errorf("check --baseline: exit code %d, want %d for a new finding", code, exitFindings)
+	}
+
+	if code, _, _ := fsct(t, append(check, "--baseline", filepath.Join(dir, "missing.json"))...); code != exitError {
+		t.Errorf("check with a missing baseline: exit code %d, want %d", code, exitError)
+	}
+}
+
+func TestUpdateBaselineKeepsExcludedPaths(t *testing.T) {
+	dir := project(t, map[string]string{"lib/legacy/api.dart": "const old = 'http://old.example.com';\n"})
+	scan := []string{"--offline", "--checks", "SEC-003"}
+	if code, _, stderr := fsct(t, append([]string{"baseline", "create", dir}, scan...)...); code != exitOK {
+		t.Fatalf("baseline create: exit code %d, stderr %q", code, stderr)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".fsct.yaml"), []byte("paths:\n  exclude: [\"lib/legacy/\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, _, stderr := fsct(t, append([]string{"check", dir, "--update-baseline"}, scan...)...)
+	if code != exitOK || !strings.Contains(stderr, "0 fixed finding(s) removed") {
+		t.Errorf("check --update-baseline: exit code %d, stderr %q", code, stderr)
+	}
+	base, err := baseline.Load(filepath.Join(dir, baseline.DefaultFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := false
+	for _, e := range base.Entries {
+		kept = kept || e.File == "lib/legacy/api.dart"
+	}
+	if !kept {
+		t.Errorf("expected the entry in the excluded path to be kept, got %+v", base.Entries)
+	}
+}
+
+func TestConfigExitCodes(t *testing.T) {
+	t.Run("no configuration", func(t *testing.T) {
+		code, stdout, _ := fsct(t, "config", "validate", project(t, nil))
+		if code != exitOK || !strings.Contains(stdout, "defaults apply") {
+			t.Errorf("exit code %d, stdout %q", code, stdout)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		dir := project(t, map[string]string{".fsct.yaml": "profile: full\nchecks:\n  skip: [AND-012]\n"})
+		code, stdout, _ := fsct(t, "config", "validate", dir)
+		if code != exitOK || !strings.Contains(stdout, "is valid") {
+			t.Errorf("exit code %d, stdout %q", code, stdout)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := project(t, map[string]string{".fsct.yaml": "profile: full\nchecks:\n  skp: [AND-012]\n"})
+		code, stdout, _ := fsct(t, "config", "validate", dir)
+		if code != exitFindings || !strings.Contains(stdout, ".fsct.yaml:3") {
+			t.Errorf("exit code %d, want %d with the error position, stdout %q", code, exitFindings, stdout)
+		}
+		if code, _, _ := fsct(t, "check", dir, "--offline"); code != exitError {
+			t.Errorf("check with an invalid configuration: exit code %d, want %d", code, exitError)
+		}
+	})
+
+	t.Run("print", func(t *testing.T) {
+		dir := project(t, map[string]string{".fsct.yaml": "fail_on: high\n"})
+		code, stdout, _ := fsct(t, "config", "print", dir)
+		if code != exitOK || !strings.Contains(stdout, "fail_on: high") {
+			t.Errorf("exit code %d, stdout %q", code, stdout)
+		}
+		code, stdout, _ = fsct(t, "config", "print", "--effective", "--profile", "full", dir)
+		if code != exitOK || !strings.Contains(stdout, "profile: full # flag --profile") || !strings.Contains(stdout, "fail_on: high # ") {
+			t.Errorf("--effective: exit code %d, stdout %q", code, stdout)
+		}
+	})
+
+	t.Run("fail_on from the configuration", func(t *testing.T) {
+		dir := project(t, map[string]string{".fsct.yaml": "fail_on: high\n"})
+		if code, _, _ := fsct(t, "check", dir, "--offline", "--checks", "SEC-003"); code != exitFindings {
+			t.Errorf("exit code %d, want %d", code, exitFindings)
+		}
+	})
+}
+
+func TestKbUpdateExitCodes(t *testing.T) {
+	from := filepath.Join(t.TempDir(), "acme.json")
+	if err := os.WriteFile(from, []byte(`{"schema": 1, "packages": {"acme_scanner": {"usage_descriptions": ["NSCameraUsageDescription"]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := fsct(t, "kb", "update", "--from", from)
+	if code != exitOK || !strings.Contains(stdout, "Added 1 and updated 0 package(s)") {
+		t.Errorf("exit code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), ".fsct", "kb.json")); err != nil {
+		t.Errorf("expected the user knowledge base to be written: %v", err)
+	}
+
+	if code, _, _ := fsct(t, "kb", "update"); code != exitError {
+		t.Errorf("without --from: exit code %d, want %d", code, exitError)
+	}
+	if err := os.WriteFile(from, []byte(`{"schema": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, _ := fsct(t, "kb", "update", "--from", from); code != exitError {
+		t.Errorf("unsupported schema: exit code %d, want %d", code, exitError)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	if code, _, _ := fsct(t, "nope"); code != exitError {
+		t.Errorf("exit code %d, want %d", code, exitError)
+	}
+}
@@ -3,13 +3,14 @@ package advanced
 import (
 	"math"
 
+	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
 type SecurityScore struct {
 	Overall  float64 `json:"overall"`
 	Android  float64 `json:"android"`
-	iOS      float64 `json:"ios"`
+	IOS      float64 `json:"ios"`
 	Flutter  float64 `json:"flutter"`
 	Security float64 `json:"security"`
 	Policy   float64 `json:"policy"`
@@ -34,12 +35,23 @@ const (
 	policyWeight   = 0.15
 )
 
-func CalculateScore(findings []report.Finding, totalChecks int) *SecurityScore {
-	breakdown := calculateBreakdown(findings)
+// CalculateScore scores findings against checks, the checks of the active
+// profile. Findings of other checks are left out, and categories the
+// profile has no checks in do not count towards the overall score.
+func CalculateScore(findings []report.Finding, checks []checker.Metadata) *SecurityScore {
+	breakdown := calculateBreakdown(findings, checks)
 
-	overall := 0.0
+	overall, weights := 0.0, 0.0
 	for _, b := range breakdown {
-		overall += b.Weighted
+		if b.Passed+b.Failed > 0 {
+			overall += b.Weighted
+			weights += b.Weight
+		}
+	}
+	if weights > 0 {
+		overall /= weights
+	} else {
+		overall = 1
 	}
 
 	grade := calculateGrade(overall)
@@ -47,7 +59,7 @@ func CalculateScore(findings []report.Finding, totalChecks int) *SecurityScore {
 	return &SecurityScore{
 		Overall:  math.Round(overall*100) / 100,
 		Android:  math.Round(breakdown[0].Score*100) / 100,
-		iOS:      math.Round(breakdown[1].Score*100) / 100,
+		IOS:      math.Round(breakdown[1].Score*100) / 100,
 		Flutter:  math.Round(breakdown[2].Score*100) / 100,
 		Security: math.Round(breakdown[3].Score*100) / 100,
 		Policy:   math.Round(breakdown[4].Score*100) / 100,
@@ -56,24 +68,33 @@ func CalculateScore(findings []report.Finding, totalChecks int) *SecurityScore {
 	}
 }
 
-func calculateBreakdown(findings []report.Finding) []ScoreBreakdown {
-	categoryFailed := make(map[string]int)
-
-	categories := []string{"Android", "iOS", "Flutter", "Security", "Policy"}
+func calculateBreakdown(findings []report.Finding, checks []checker.Metadata) []ScoreBreakdown {
+	categoryTotal := make(map[string]int)
+	categoryOf := make(map[string]string, len(checks))
+	for _, meta := range checks {
+		categoryOf[meta.ID] = string(meta.Category)
+		categoryTotal[string(meta.Category)]++
+	}
 
+	// A check fails once however many findings it reports.
+	categoryFailed := make(map[string]int)
+	failed := make(map[string]bool)
 	for _, f := range findings {
-		cat := categorize(f.ID)
+		cat, ok := categoryOf[f.ID]
+		if !ok || failed[f.ID] {
+			continue
+		}
+		failed[f.ID] = true
 		categoryFailed[cat]++
 	}
 
+	categories := []string{"Android", "iOS", "Flutter", "Security", "Policy"}
+
 	breakdown := make([]ScoreBreakdown, 0)
 
 	for _, cat := range categories {
-		total := getTotalChecksForCategory(cat)
+		total := categoryTotal[cat]
 		passed := total - categoryFailed[cat]
-		if passed < 0 {
-			passed = 0
-		}
 		score := 1.0
 		if total > 0 {
 			score = float64(passed) / float64(total)
@@ -106,40 +127,6 @@ func calculateBreakdown(findings []report.Finding) []ScoreBreakdown {
 	return breakdown
 }
 
-func categorize(checkID string) string {
-	switch {
-	case len(checkID) >= 4 && checkID[:3] == "AND":
-		return "Android"
-	case len(checkID) >= 4 && checkID[:3] == "IOS":
-		return "iOS"
-	case len(checkID) >= 4 && checkID[:3] == "FLT":
-		return "Flutter"
-	case len(checkID) >= 4 && checkID[:3] == "SEC":
-		return "Security"
-	case len(checkID) >= 4 && checkID[:3] == "POL":
-		return "Policy"
-	default:
-		return "Other"
-	}
-}
-
-func getTotalChecksForCategory(category string) int {
-	switch category {
-	case "Android":
-		return 12
-	case "iOS":
-		return 12
-	case "Flutter":
-		return 4
-	case "Security":
-		return 5
-	case "Policy":
-		return 5
-	default:
-		return 1
-	}
-}
-
 func calculateGrade(score float64) string {
 	if score >= 0.95 {
 		return "A+"
@@ -0,0 +1,39 @@
+package advanced
+
+import (
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func TestCalculateScoreUsesProfileChecks(t *testing.T) {
+	checks := []checker.Metadata{
+		{ID: "AND-001", Category: checker.CategoryAndroid},
+		{ID: "AND-002", Category: checker.CategoryAndroid},
+		{ID: "SEC-001", Category: checker.CategorySecurity},
+	}
+	findings := []report.Finding{
+		{ID: "AND-001"},
+		{ID: "AND-001"},
+		// Not in the profile: left out.
+		{ID: "IOS-001"},
+		{ID: "POL-001"},
+	}
+
+	score := CalculateScore(findings, checks)
+	if score.Android != 0.5 {
+		t.Errorf("Android = %v, want 0.5 for one of two checks failing", score.Android)
+	}
+	if score.IOS != 1 || score.Policy != 1 || score.Security != 1 {
+		t.Errorf("expected categories without failing profile checks to score 1, got %+v", score)
+	}
+	// Android and Security only, weighted 0.25 and 0.20.
+	if want := 0.72; score.Overall != want {
+		t.Errorf("Overall = %v, want %v", score.Overall, want)
+	}
+
+	if score := CalculateScore(findings, nil); score.Overall != 1 {
+		t.Errorf("expected an empty profile to score 1, got %v", score.Overall)
+	}
+}
@@ -3,6 +3,7 @@ package ai
 import (
 	"encoding/json"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -27,7 +28,9 @@ type ComplianceMetadata struct {
 	ComplianceScore int `json:"compliance_score"`
 	TotalChecks     int `json:"total_checks"`
 	PassedChecks    int `json:"passed_checks"`
+	CriticalCount   int `json:"critical_count"`
 	HighCount       int `json:"high_count"`
+	ErrorCount      int `json:"error_count"`
 	WarningCount    int `json:"warning_count"`
 	InfoCount       int `json:"info_count"`
 
@@ -35,6 +38,8 @@ type ComplianceMetadata struct {
 	AndroidTargetSDK    int    `json:"android_target_sdk,omitempty"`
 	AndroidMinSDK       int    `json:"android_min_sdk,omitempty"`
 	IOSDeploymentTarget string `json:"ios_deployment_target,omitempty"`
+	// IOSDeviceFamilies lists iPhone and/or iPad, from TARGETED_DEVICE_FAMILY
+	IOSDeviceFamilies []string `json:"ios_device_families,omitempty"`
 
 	// Finding summaries (safe - minimal info)
 	Findings []FindingMeta `json:"findings"`
@@ -46,6 +51,12 @@ type ComplianceMetadata struct {
 	// Dependencies (safe - names only, no versions for security)
 	Dependencies    []string `json:"dependencies,omitempty"`
 	DevDependencies []string `json:"dev_dependencies,omitempty"`
+	// TransitiveDependencies are the other packages built into the app,
+	// from pubspec.lock
+	TransitiveDependencies []string `json:"transitive_dependencies,omitempty"`
+	// NativeDependencies are the iOS SDKs installed as pods, from
+	// Podfile.lock
+	NativeDependencies []string `json:"native_dependencies,omitempty"`
 
 	// Feature flags (safe - booleans only)
 	Features AppFeatures `json:"features"`
@@ -171,7 +182,7 @@ func (m *ComplianceMetadata) extractFindings(findings []report.Finding) {
 	for _, f := range findings {
 		meta := FindingMeta{
 			ID:       f.ID,
-			Category: extractCategory(f.ID),
+			Category: string(checker.CategoryForID(f.ID)),
 			Severity: string(f.Severity),
 			Title:    f.Title,
 		}
@@ -179,8 +190,12 @@ func (m *ComplianceMetadata) extractFindings(findings []report.Finding) {
 
 		// Count by severity
 		switch f.Severity {
+		case report.SeverityCritical:
+			m.CriticalCount++
 		case report.SeverityHigh:
 			m.HighCount++
+		case report.SeverityError:
+			m.ErrorCount++
 		case report.SeverityWarning:
 			m.WarningCount++
 		case report.SeverityInfo:
@@ -203,7 +218,9 @@ func (m *ComplianceMetadata) calculateScores(findings []report.Finding, totalChe
 
 	// Simple scoring: base 100, deduct for issues
 	score := 100
+	score -= m.CriticalCount * 20
 	score -= m.HighCount * 10
+	score -= m.ErrorCount * 5
 	score -= m.WarningCount * 3
 	score -= m.InfoCount
 
@@ -226,8 +243,14 @@ func (m *ComplianceMetadata) extractPlatformInfo(project *checker.Project) {
 	}
 
 	// iOS
-	if project.InfoPlist != nil {
-		m.IOSDeploymentTarget = "12.0" // Default assumption
+	m.IOSDeploymentTarget = project.Xcode.Setting("IPHONEOS_DEPLOYMENT_TARGET")
+	for _, family := range strings.Split(project.Xcode.Setting("TARGETED_DEVICE_FAMILY"), ",") {
+		switch strings.TrimSpace(family) {
+		case "1":
+			m.IOSDeviceFamilies = append(m.IOSDeviceFamilies, "iPhone")
+		case "2":
+			m.IOSDeviceFamilies = append(m.IOSDeviceFamilies, "iPad")
+		}
 	}
 }
 
@@ -269,6 +292,9 @@ func (m *ComplianceMetadata) extractPermissions(project *checker.Project) {
 
 // extractDependencies extracts dependency names only (no versions)
 func (m *ComplianceMetadata) extractDependencies(project *checker.Project) {
+	// Native iOS SDKs, leaving out Flutter and the plugins
+	m.NativeDependencies = project.CocoaPods.NativeSDKs()
+
 	if project.Pubspec == nil {
 		return
 	}
@@ -277,11 +303,20 @@ func (m *ComplianceMetadata) extractDependencies(project *checker.Project) {
 	for name := range project.Pubspec.Dependencies {
 		m.Dependencies = append(m.Dependencies, name)
 	}
+	sort.Strings(m.Dependencies)
 
 	// Dev dependencies
 	for name := range project.Pubspec.DevDependencies {
 		m.DevDependencies = append(m.DevDependencies, name)
 	}
+	sort.Strings(m.DevDependencies)
+
+	// Packages the app pulls in through other packages
+	for _, name := range project.Pubspec.AppPackages() {
+		if _, ok := project.Pubspec.Dependencies[name]; !ok {
+			m.TransitiveDependencies = append(m.TransitiveDependencies, name)
+		}
+	}
 }
 
 // extractFeatures extracts feature flags from project
@@ -292,9 +327,9 @@ func (m *ComplianceMetadata) extractFeatures(project *checker.Project) {
 	m.Features.HasLocation = project.HasLocationDeps
 	m.Features.HasPhotoLibrary = project.HasImagePicker
 
-	// Detect from dependencies
+	// Detect from the packages built into the app
 	if project.Pubspec != nil {
-		for dep := range project.Pubspec.Dependencies {
+		for _, dep := range project.Pubspec.AppPackages() {
 			depLower := strings.ToLower(dep)
 			switch {
 			case depLower == "microphone" || depLower == "record":
@@ -388,41 +423,6 @@ func (m *ComplianceMetadata) Size() int {
 
 // Helper functions
 
-func extractCategory(id string) string {
-	if len(id) < 3 {
-		return "Other"
-	}
-
-	switch id[:3] {
-	case "AND":
-		return "Android"
-	case "IOS":
-		return "iOS"
-	case "FLT":
-		return "Flutter"
-	case "SEC":
-		return "Security"
-	case "POL":
-		return "Policy"
-	case "COD":
-		return "Code Quality"
-	case "TST":
-		return "Testing"
-	case "LIN":
-		return "Linting"
-	case "DOC":
-		return "Documentation"
-	case "PER":
-		return "Performance"
-	case "REV":
-		return "Reviewer"
-	case "AI-":
-		return "AI Analysis"
-	default:
-		return "Other"
-	}
-}
-
 func parseInt(s string) int {
 	var result int
 	for _, ch := range s {
@@ -45,6 +45,13 @@ func TestExtractMetadata(t *testing.T) {
 			HasCameraUsageDescription:   true,
 			HasLocationUsageDescription: false,
 		},
+		CocoaPods: &checker.CocoaPodsInfo{
+			Pods: []checker.PodInfo{
+				{Name: "Flutter", Version: "1.0.0", External: "Flutter"},
+				{Name: "GoogleUtilities", Version: "7.13.3"},
+				{Name: "camera_avfoundation", Version: "0.0.1", External: ".symlinks/plugins/camera_avfoundation/ios"},
+			},
+		},
 		HasCameraDeps:    true,
 		HasLocationDeps:  true,
 		HasNetworkDeps:   true,
@@ -116,6 +123,11 @@ func TestExtractMetadata(t *testing.T) {
 		t.Errorf("expected 3 android permissions, got %d", len(meta.AndroidPermissions))
 	}
 
+	// Test native dependencies
+	if len(meta.NativeDependencies) != 1 || meta.NativeDependencies[0] != "GoogleUtilities" {
+		t.Errorf("expected GoogleUtilities as the only native dependency, got %v", meta.NativeDependencies)
+	}
+
 	// Test features
 	if !meta.Features.HasCamera {
 		t.Error("expected HasCamera to be true")
@@ -224,9 +236,9 @@ func TestExtractCategory(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := extractCategory(tt.id)
+		result := string(checker.CategoryForID(tt.id))
 		if result != tt.expected {
-			t.Errorf("extractCategory(%q) = %q, want %q", tt.id, result, tt.expected)
+			t.Errorf("CategoryForID(%q) = %q, want %q", tt.id, result, tt.expected)
 		}
 	}
 }
@@ -0,0 +1,144 @@
+// Package baseline records the findings a project has accepted so that
+// later runs report only findings introduced since.
+//
+// Findings are matched by their report.Fingerprint rather than by line or
+// message, so a baselined finding stays matched when code above it moves or
+// when a check rewords its message. Several findings may share a
+// fingerprint; each baseline entry matches at most one finding.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// DefaultFile is the baseline file name, relative to the project root.
+const DefaultFile = ".fsct-baseline.json"
+
+const version = 1
+
+// Entry is a baselined finding.
+type Entry struct {
+	Fingerprint string          `json:"fingerprint"`
+	ID          string          `json:"id"`
+	Severity    report.Severity `json:"severity"`
+	Title       string          `json:"title"`
+	File        string          `json:"file,omitempty"`
+}
+
+// Baseline is the content of a baseline file.
+type Baseline struct {
+	Version int     `json:"version"`
+	Created string  `json:"created"`
+	Entries []Entry `json:"findings"`
+}
+
+// New returns a baseline accepting findings. Suppressed findings are left
+// out; their directive already accepts them.
+func New(findings []report.Finding) *Baseline {
+	b := &Baseline{
+		Version: version,
+		Created: time.Now().Format(time.RFC3339),
+		Entries: make([]Entry, 0, len(findings)),
+	}
+	for _, f := range findings {
+		if f.Suppression != nil {
+			continue
+		}
+		b.Entries = append(b.Entries, Entry{
+			Fingerprint: fingerprint(f),
+			ID:          f.ID,
+			Severity:    f.Severity,
+			Title:       f.Title,
+			File:        f.File,
+		})
+	}
+	b.sort()
+	return b
+}
+
+// Load reads a baseline file.
+func Load(file string) (*Baseline, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", file, err)
+	}
+	if b.Version > version {
+		return nil, fmt.Errorf("baseline %s has version %d; this fsct reads up to version %d", file, b.Version, version)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline to file.
+func (b *Baseline) Save(file string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// Filter splits findings into those the baseline does not cover, returned
+// in their original order, and the entries that matched. Suppressed
+// findings are always returned.
+func (b *Baseline) Filter(findings []report.Finding) (fresh []report.Finding, matched []Entry) {
+	remaining := make(map[string][]Entry)
+	for _, e := range b.Entries {
+		remaining[e.Fingerprint] = append(remaining[e.Fingerprint], e)
+	}
+
+	fresh = make([]report.Finding, 0, len(findings))
+	for _, f := range findings {
+		if f.Suppression == nil {
+			key := fingerprint(f)
+			if entries := remaining[key]; len(entries) > 0 {
+				matched = append(matched, entries[0])
+				remaining[key] = entries[1:]
+				continue
+			}
+		}
+		fresh = append(fresh, f)
+	}
+	return fresh, matched
+}
+
+// Prune keeps only the given entries, typically those Filter matched, and
+// returns how many entries were removed.
+func (b *Baseline) Prune(keep []Entry) int {
+	removed := len(b.Entries) - len(keep)
+	b.Entries = append([]Entry(nil), keep...)
+	b.sort()
+	return removed
+}
+
+func (b *Baseline) sort() {
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		ei, ej := b.Entries[i], b.Entries[j]
+		if ei.ID != ej.ID {
+			return ei.ID < ej.ID
+		}
+		if ei.File != ej.File {
+			return ei.File < ej.File
+		}
+		return ei.Fingerprint < ej.Fingerprint
+	})
+}
+
+// fingerprint returns the fingerprint of f, computing one without a line
+// snippet for findings that were not fingerprinted.
+func fingerprint(f report.Finding) string {
+	if f.Fingerprint != "" {
+		return f.Fingerprint
+	}
+	return report.Fingerprint(f.ID, f.File, f.Subject)
+}
@@ -0,0 +1,77 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func TestFilter(t *testing.T) {
+	camera := report.Finding{ID: "AND-004", File: "android/app/src/main/AndroidManifest.xml", Message: "CAMERA"}.WithSubject("CAMERA")
+	location := report.Finding{ID: "AND-004", File: "android/app/src/main/AndroidManifest.xml", Message: "LOCATION"}.WithSubject("ACCESS_FINE_LOCATION")
+	insecure := report.Finding{ID: "SEC-003", File: "lib/api.dart", Line: 6, Fingerprint: "f1"}
+
+	accepted := []report.Finding{
+		camera,
+		location,
+		insecure,
+		insecure,
+		{ID: "DOC-004", File: "project root"},
+		{ID: "SEC-004", File: "AndroidManifest.xml", Suppression: &report.Suppression{Reason: "accepted"}},
+	}
+	b := New(accepted)
+	if len(b.Entries) != 5 {
+		t.Fatalf("expected suppressed finding to be left out, got %d entries", len(b.Entries))
+	}
+
+	reworded := camera
+	reworded.Message = "Camera permission lacks a uses-feature declaration"
+	moved := insecure
+	moved.Line = 9
+
+	current := []report.Finding{
+		reworded,
+		moved,
+		moved,
+		moved,
+		{ID: "SEC-004", File: "AndroidManifest.xml", Suppression: &report.Suppression{Reason: "accepted"}},
+		{ID: "POL-001", File: "source files"},
+	}
+	fresh, matched := b.Filter(current)
+
+	if len(matched) != 3 {
+		t.Errorf("expected each entry to match one finding, got %d matches", len(matched))
+	}
+	if len(fresh) != 3 || fresh[0].ID != "SEC-003" || fresh[1].ID != "SEC-004" || fresh[2].ID != "POL-001" {
+		t.Errorf("unexpected fresh findings %+v", fresh)
+	}
+
+	if removed := b.Prune(matched); removed != 2 || len(b.Entries) != 3 {
+		t.Errorf("expected location and DOC-004 entries to be pruned, removed %d, %+v", removed, b.Entries)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), DefaultFile)
+	b := New([]report.Finding{{ID: "DOC-004", Severity: report.SeverityWarning, Title: "LICENSE File Presence", File: "project root"}})
+	if err := b.Save(file); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Version != version || len(loaded.Entries) != 1 || loaded.Entries[0] != b.Entries[0] {
+		t.Errorf("round trip mismatch: %+v", loaded)
+	}
+
+	if err := os.WriteFile(file, []byte(`{"version": 99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(file); err == nil {
+		t.Error("expected error for a newer baseline version")
+	}
+}
@@ -47,6 +47,17 @@ func (c *Check) Category() string {
 	return c.category
 }
 
+// Metadata describes the check
+func (c *Check) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAI,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     descriptions[c.id],
+		Rationale:       "AI analysis reviews the project metadata and static findings the way a store reviewer would, catching issues that fixed rules miss.",
+		Remediation:     "Review each suggestion; AI findings are advisory and may be wrong.",
+	}
+}
+
 // SetContext provides static findings context for AI analysis.
 func (c *Check) SetContext(findings []report.Finding, totalChecks int) {
 	c.baseFindings = findings
@@ -55,6 +66,19 @@ func (c *Check) SetContext(findings []report.Finding, totalChecks int) {
 
 // Run executes the AI check
 func (c *Check) Run(project *checker.Project) []report.Finding {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	findings, err := c.RunContext(ctx, project)
+	if err != nil {
+		return []report.Finding{c.createOfflineFinding(err)}
+	}
+	return findings
+}
+
+// RunContext executes the AI check, returning an error only when ctx is
+// done before the analysis completes.
+func (c *Check) RunContext(ctx context.Context, project *checker.Project) ([]report.Finding, error) {
 	// Extract metadata
 	metadata := aipkg.ExtractMetadata(project, c.baseFindings, c.totalChecks)
 
@@ -64,10 +88,6 @@ func (c *Check) Run(project *checker.Project) []report.Finding {
 	// Build user prompt
 	userPrompt := BuildUserPrompt(c.name, metadata)
 
-	// Call AI with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
 	resp, err := c.client.Complete(ctx, &aipkg.CompletionRequest{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
@@ -76,8 +96,11 @@ func (c *Check) Run(project *checker.Project) []report.Finding {
 	})
 
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// Return offline finding if AI fails
-		return []report.Finding{c.createOfflineFinding(err)}
+		return []report.Finding{c.createOfflineFinding(err)}, nil
 	}
 
 	// Parse response
@@ -88,7 +111,7 @@ func (c *Check) Run(project *checker.Project) []report.Finding {
 	}
 
 	// Convert to findings
-	return c.convertToFindings(analysis)
+	return c.convertToFindings(analysis), nil
 }
 
 // getSystemPrompt returns the appropriate system prompt
@@ -183,6 +206,15 @@ func (c *Check) createOfflineFinding(err error) report.Finding {
 	}
 }
 
+// descriptions holds the Metadata description for each AI check ID
+var descriptions = map[string]string{
+	"AI-001": "Asks an AI model whether each requested permission is justified by the app's features.",
+	"AI-002": "Asks an AI model to review the project against Google Play and App Store policies.",
+	"AI-003": "Asks an AI model to assess the risk of the app's dependencies.",
+	"AI-004": "Asks an AI model for store-specific submission guidance.",
+	"AI-005": "Asks an AI model to draft notes for app reviewers.",
+}
+
 // AI001PermissionJustificationCheck checks permission justification
 func AI001PermissionJustificationCheck(client *aipkg.Client) checker.Check {
 	return NewCheck(
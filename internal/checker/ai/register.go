@@ -0,0 +1,26 @@
+package ai
+
+import (
+	aipkg "github.com/ricky-irfandi/fsct/internal/ai"
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/registry"
+)
+
+// AI checks load only with a client; the catalog describes them without one.
+func init() {
+	for _, newCheck := range []func(*aipkg.Client) checker.Check{
+		AI001PermissionJustificationCheck,
+		AI002PolicyComplianceCheck,
+		AI003DependencyRiskCheck,
+		AI004StoreGuidanceCheck,
+		AI005ReviewerNotesCheck,
+	} {
+		newCheck := newCheck
+		registry.RegisterFactory(newCheck(nil), func(env registry.Environment) checker.Check {
+			if env.AI == nil {
+				return nil
+			}
+			return newCheck(env.AI)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package android
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -10,7 +11,7 @@ import (
 func TestTargetSDKCheck(t *testing.T) {
 	check := &TargetSDKCheck{}
 
-	t.Run("SDK 31 should generate HIGH finding", func(t *testing.T) {
+	t.Run("SDK 31 should generate CRITICAL finding", func(t *testing.T) {
 		project := &checker.Project{
 			GradleConfig: &checker.GradleConfigInfo{
 				TargetSDKVersion: "31",
@@ -24,8 +25,8 @@ func TestTargetSDKCheck(t *testing.T) {
 		}
 
 		if len(findings) > 0 {
-			if findings[0].Severity != report.SeverityHigh {
-				t.Errorf("Expected HIGH severity, got %s", findings[0].Severity)
+			if findings[0].Severity != report.SeverityCritical {
+				t.Errorf("Expected CRITICAL severity, got %s", findings[0].Severity)
 			}
 			if findings[0].ID != "AND-001" {
 				t.Errorf("Expected ID AND-001, got %s", findings[0].ID)
@@ -33,7 +34,7 @@ func TestTargetSDKCheck(t *testing.T) {
 		}
 	})
 
-	t.Run("SDK 34 should generate HIGH finding", func(t *testing.T) {
+	t.Run("SDK 34 should generate CRITICAL finding", func(t *testing.T) {
 		project := &checker.Project{
 			GradleConfig: &checker.GradleConfigInfo{
 				TargetSDKVersion: "34",
@@ -61,6 +62,47 @@ func TestTargetSDKCheck(t *testing.T) {
 		}
 	})
 
+	t.Run("SDK 35 below a stricter threshold should generate HIGH finding", func(t *testing.T) {
+		project := &checker.Project{
+			GradleConfig: &checker.GradleConfigInfo{
+				TargetSDKVersion: "35",
+			},
+			Thresholds: checker.Thresholds{MinTargetSDK: 36},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].Severity != report.SeverityHigh {
+			t.Errorf("Expected HIGH severity, got %s", findings[0].Severity)
+		}
+	})
+
+	t.Run("flutter default should point at the assignment", func(t *testing.T) {
+		project := &checker.Project{
+			GradleConfig: &checker.GradleConfigInfo{
+				File:             "android/app/build.gradle.kts",
+				TargetSDKVersion: "34",
+				Lines:            map[string]int{"targetSdkVersion": 15},
+				Sources:          map[string]string{"targetSdkVersion": "/opt/flutter/FlutterExtension.kt:11"},
+			},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].File != "android/app/build.gradle.kts" || findings[0].Line != 15 {
+			t.Errorf("Expected android/app/build.gradle.kts:15, got %s:%d", findings[0].File, findings[0].Line)
+		}
+		if !strings.Contains(findings[0].Message, "from /opt/flutter/FlutterExtension.kt:11") {
+			t.Errorf("Expected the value's source in the message, got %q", findings[0].Message)
+		}
+	})
+
 	t.Run("empty gradle config should not panic", func(t *testing.T) {
 		project := &checker.Project{
 			GradleConfig: &checker.GradleConfigInfo{},
@@ -158,10 +200,55 @@ func TestInternetPermissionCheck(t *testing.T) {
 	})
 }
 
+func TestDangerousPermissionsCheck(t *testing.T) {
+	check := &DangerousPermissionsCheck{}
+
+	t.Run("plugin permission should name the plugin", func(t *testing.T) {
+		project := &checker.Project{
+			AndroidManifest: &checker.AndroidManifestInfo{
+				Permissions: []string{"android.permission.ACCESS_FINE_LOCATION"},
+				UsesPermissions: []checker.PermissionInfo{
+					{Name: "android.permission.ACCESS_FINE_LOCATION", Line: 3, Origin: "geolocator_android"},
+				},
+			},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if !strings.Contains(findings[0].Message, "added by the geolocator_android plugin") {
+			t.Errorf("Expected the message to name the plugin, got %q", findings[0].Message)
+		}
+		if findings[0].Line != 0 {
+			t.Errorf("Expected no line in the app manifest for a plugin permission, got %d", findings[0].Line)
+		}
+	})
+
+	t.Run("declared feature should not generate finding", func(t *testing.T) {
+		project := &checker.Project{
+			AndroidManifest: &checker.AndroidManifestInfo{
+				UsesPermissions: []checker.PermissionInfo{
+					{Name: "android.permission.CAMERA", Line: 3},
+					{Name: "android.permission.RECORD_AUDIO", Line: 4},
+				},
+				Features: []checker.FeatureInfo{{Name: "android.hardware.camera"}},
+			},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 || findings[0].Subject != "RECORD_AUDIO" || findings[0].Line != 4 {
+			t.Errorf("Expected one RECORD_AUDIO finding on line 4, got %+v", findings)
+		}
+	})
+}
+
 func TestDebuggableCheck(t *testing.T) {
 	check := &DebuggableCheck{}
 
-	t.Run("debuggable true should generate HIGH finding", func(t *testing.T) {
+	t.Run("debuggable true should generate CRITICAL finding", func(t *testing.T) {
 		project := &checker.Project{
 			AndroidManifest: &checker.AndroidManifestInfo{
 				Debuggable: true,
@@ -174,8 +261,8 @@ func TestDebuggableCheck(t *testing.T) {
 			t.Errorf("Expected 1 finding, got %d", len(findings))
 		}
 
-		if len(findings) > 0 && findings[0].Severity != report.SeverityHigh {
-			t.Errorf("Expected HIGH severity, got %s", findings[0].Severity)
+		if len(findings) > 0 && findings[0].Severity != report.SeverityCritical {
+			t.Errorf("Expected CRITICAL severity, got %s", findings[0].Severity)
 		}
 	})
 
@@ -307,7 +394,10 @@ func TestExportedAttributeCheck(t *testing.T) {
 		findings := check.Run(project)
 
 		if len(findings) != 1 {
-			t.Errorf("Expected 1 finding, got %d", len(findings))
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].Subject != ".MainActivity" {
+			t.Errorf("Expected subject .MainActivity, got %q", findings[0].Subject)
 		}
 	})
 
@@ -330,6 +420,26 @@ func TestExportedAttributeCheck(t *testing.T) {
 			t.Errorf("Expected 0 findings, got %d", len(findings))
 		}
 	})
+
+	t.Run("receiver with intent-filter and exported=false should not generate finding", func(t *testing.T) {
+		project := &checker.Project{
+			AndroidManifest: &checker.AndroidManifestInfo{
+				Receivers: []checker.ComponentInfo{
+					{Kind: "receiver", Name: ".BootReceiver", HasIntentFilter: true, ExportedSet: true},
+					{Kind: "receiver", Name: ".AlarmReceiver", HasIntentFilter: true, Line: 12},
+				},
+			},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 || findings[0].Subject != ".AlarmReceiver" || findings[0].Line != 12 {
+			t.Fatalf("Expected one .AlarmReceiver finding on line 12, got %+v", findings)
+		}
+		if !strings.HasPrefix(findings[0].Message, "Receiver .AlarmReceiver") {
+			t.Errorf("Unexpected message %q", findings[0].Message)
+		}
+	})
 }
 
 func TestPackageVisibilityCheck(t *testing.T) {
@@ -377,3 +487,65 @@ func TestPackageVisibilityCheck(t *testing.T) {
 		}
 	})
 }
+
+func TestDependencyPermissionCheck(t *testing.T) {
+	check := &DependencyPermissionCheck{}
+
+	project := &checker.Project{
+		AndroidManifest: &checker.AndroidManifestInfo{
+			UsesPermissions: []checker.PermissionInfo{
+				{Name: "android.permission.ACCESS_COARSE_LOCATION", Line: 3},
+			},
+		},
+		PackageNeeds: &checker.PackageNeedsInfo{
+			Packages: []checker.PackageNeeds{
+				{Package: "geolocator", Permissions: []string{"android.permission.ACCESS_FINE_LOCATION|android.permission.ACCESS_COARSE_LOCATION"}},
+				{Package: "record", Permissions: []string{"android.permission.RECORD_AUDIO"}},
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "android.permission.RECORD_AUDIO" || findings[0].Severity != report.SeverityError || !strings.Contains(findings[0].Message, "record") {
+		t.Errorf("Expected an ERROR finding for RECORD_AUDIO, got %+v", findings[0])
+	}
+}
+
+func TestUnusedPermissionCheck(t *testing.T) {
+	check := &UnusedPermissionCheck{}
+
+	project := &checker.Project{
+		Pubspec: &checker.PubspecInfo{},
+		AndroidManifest: &checker.AndroidManifestInfo{
+			UsesPermissions: []checker.PermissionInfo{
+				{Name: "android.permission.INTERNET", Line: 2},
+				{Name: "android.permission.CAMERA", Line: 3},
+				{Name: "android.permission.READ_SMS", Line: 4},
+				{Name: "android.permission.RECORD_AUDIO", Line: 5, Origin: "camera"},
+			},
+		},
+		PackageNeeds: &checker.PackageNeedsInfo{
+			Packages: []checker.PackageNeeds{
+				{Package: "mobile_scanner", Injects: []string{"android.permission.CAMERA"}},
+			},
+			Sensitive: map[string]bool{
+				"android.permission.CAMERA":       true,
+				"android.permission.READ_SMS":     true,
+				"android.permission.RECORD_AUDIO": true,
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "android.permission.READ_SMS" || findings[0].Line != 4 || findings[0].Severity != report.SeverityWarning {
+		t.Errorf("Expected a WARNING finding for READ_SMS on line 4, got %+v", findings[0])
+	}
+}
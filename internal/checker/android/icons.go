@@ -19,6 +19,18 @@ func (c *MissingAppIconCheck) Name() string {
 	return "Missing App Icon Check"
 }
 
+func (c *MissingAppIconCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that an ic_launcher icon exists in the res/mipmap-* directories.",
+		Rationale:       "A missing launcher icon produces a generic icon on devices and is flagged during store review.",
+		Guideline:       "Google Play app icon requirements",
+		Remediation:     "Generate launcher icons for every density, for example with flutter_launcher_icons.",
+	}
+}
+
 func (c *MissingAppIconCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -81,6 +93,18 @@ func (c *PlaceholderIconCheck) Name() string {
 	return "Placeholder Icon Check"
 }
 
+func (c *PlaceholderIconCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks whether the launcher icon is still the default Flutter icon.",
+		Rationale:       "Shipping the template icon looks unfinished and can be rejected as misleading or low-quality metadata.",
+		Guideline:       "Google Play metadata policy",
+		Remediation:     "Replace the default ic_launcher images with your own artwork.",
+	}
+}
+
 func (c *PlaceholderIconCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
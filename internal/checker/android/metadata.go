@@ -18,6 +18,18 @@ func (c *DebuggableCheck) Name() string {
 	return "Debuggable Check"
 }
 
+func (c *DebuggableCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityCritical,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that android:debuggable is not enabled in the main manifest.",
+		Rationale:       "Google Play refuses debuggable uploads, and a debuggable release lets anyone attach a debugger and read app data.",
+		Guideline:       "Google Play Console upload requirements",
+		Remediation:     "Remove android:debuggable from the <application> element; the build type sets it for debug builds.",
+	}
+}
+
 func (c *DebuggableCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -32,8 +44,8 @@ func (c *DebuggableCheck) Run(project *checker.Project) []report.Finding {
 			"android:debuggable is set to true. This should be false for release builds.",
 			"android/app/src/main/AndroidManifest.xml",
 			"Set android:debuggable=\"false\" or remove the attribute",
-			report.SeverityHigh,
-			0,
+			report.SeverityCritical,
+			project.AndroidManifest.ApplicationLine,
 		))
 	}
 
@@ -50,6 +62,18 @@ func (c *ExportedAttributeCheck) Name() string {
 	return "Exported Attribute Check"
 }
 
+func (c *ExportedAttributeCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that activities with intent filters declare android:exported explicitly.",
+		Rationale:       "Apps targeting Android 12 or higher fail to install when a component with an intent filter omits android:exported.",
+		Guideline:       "Android 12 behaviour changes: safer component exporting",
+		Remediation:     "Add android:exported=\"true\" or \"false\" to every activity, service and receiver that has an <intent-filter>.",
+	}
+}
+
 func (c *ExportedAttributeCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -57,17 +81,25 @@ func (c *ExportedAttributeCheck) Run(project *checker.Project) []report.Finding
 		return findings
 	}
 
-	for _, activity := range project.AndroidManifest.Activities {
-		if activity.HasIntentFilter && !activity.Exported {
+	manifest := project.AndroidManifest
+	for _, components := range [][]checker.ComponentInfo{manifest.Activities, manifest.Services, manifest.Receivers} {
+		for _, component := range components {
+			if !component.HasIntentFilter || component.Exported || component.ExportedSet {
+				continue
+			}
+			kind := component.Kind
+			if kind == "" {
+				kind = "activity"
+			}
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Activity "+activity.Name+" has intent-filter but android:exported is not explicitly set",
+				strings.ToUpper(kind[:1])+kind[1:]+" "+component.Name+" has intent-filter but android:exported is not explicitly set"+pluginNote(component.Origin),
 				"android/app/src/main/AndroidManifest.xml",
-				"Set android:exported=\"true\" or android:exported=\"false\" for the activity",
+				"Set android:exported=\"true\" or android:exported=\"false\" for the "+kind,
 				report.SeverityHigh,
-				0,
-			))
+				component.AppLine(),
+			).WithSubject(component.Name))
 		}
 	}
 
@@ -84,6 +116,18 @@ func (c *ApplicationIDCheck) Name() string {
 	return "Application ID Check"
 }
 
+func (c *ApplicationIDCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that applicationId is not the com.example placeholder.",
+		Rationale:       "Google Play does not accept packages under com.example, and the application ID cannot be changed after the first release.",
+		Guideline:       "Google Play package name requirements",
+		Remediation:     "Set a unique reverse-domain applicationId (e.g. com.yourcompany.yourapp) in android/app/build.gradle.",
+	}
+}
+
 func (c *ApplicationIDCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -116,6 +160,17 @@ func (c *VersionCodeCheck) Name() string {
 	return "Version Code Check"
 }
 
+func (c *VersionCodeCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that a versionCode is set for the release.",
+		Rationale:       "Every upload to Google Play needs a versionCode higher than the previous one.",
+		Remediation:     "Set the build number in pubspec.yaml (version: 1.2.0+5) or versionCode in build.gradle, and increment it for each release.",
+	}
+}
+
 func (c *VersionCodeCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -132,11 +187,11 @@ func (c *VersionCodeCheck) Run(project *checker.Project) []report.Finding {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"versionCode is 1. Consider incrementing the version code for updates.",
-			"android/app/build.gradle",
+			"versionCode is 1"+gradleOrigin(project.GradleConfig, "versionCode")+". Consider incrementing the version code for updates.",
+			project.GradleConfig.Path(),
 			"Increment versionCode for subsequent releases",
 			report.SeverityWarning,
-			0,
+			project.GradleConfig.Lines["versionCode"],
 		))
 	}
 
@@ -153,6 +208,18 @@ func (c *AllowBackupCheck) Name() string {
 	return "Allow Backup Check"
 }
 
+func (c *AllowBackupCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks whether android:allowBackup is left enabled.",
+		Rationale:       "Auto Backup copies app data, including tokens and caches, to the user's cloud backup and to adb backups.",
+		Guideline:       "Android Auto Backup guidance",
+		Remediation:     "Set android:allowBackup=\"false\", or provide backup rules that exclude sensitive files.",
+	}
+}
+
 func (c *AllowBackupCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -168,7 +235,7 @@ func (c *AllowBackupCheck) Run(project *checker.Project) []report.Finding {
 			"android/app/src/main/AndroidManifest.xml",
 			"Set android:allowBackup=\"false\" or implement encryption for sensitive data",
 			report.SeverityWarning,
-			0,
+			project.AndroidManifest.ApplicationLine,
 		))
 	}
 
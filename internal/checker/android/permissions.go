@@ -17,6 +17,17 @@ func (c *InternetPermissionCheck) Name() string {
 	return "Internet Permission Check"
 }
 
+func (c *InternetPermissionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that the INTERNET permission is declared when the app depends on networking packages.",
+		Rationale:       "Debug builds get INTERNET from the debug manifest, so release builds without the declaration fail every network call while working in development.",
+		Remediation:     "Add <uses-permission android:name=\"android.permission.INTERNET\" /> to android/app/src/main/AndroidManifest.xml.",
+	}
+}
+
 func (c *InternetPermissionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -59,6 +70,18 @@ func (c *DangerousPermissionsCheck) Name() string {
 	return "Dangerous Permissions Check"
 }
 
+func (c *DangerousPermissionsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that camera, location and microphone permissions are paired with an optional uses-feature declaration.",
+		Rationale:       "Hardware permissions imply required features, which hides the app on Play Store devices without that hardware.",
+		Guideline:       "Google Play device compatibility filtering",
+		Remediation:     "Add <uses-feature android:name=\"...\" android:required=\"false\" /> for each hardware feature the permission implies.",
+	}
+}
+
 func (c *DangerousPermissionsCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -74,34 +97,24 @@ func (c *DangerousPermissionsCheck) Run(project *checker.Project) []report.Findi
 	}
 
 	for perm, feature := range dangerousPermissions {
-		hasPermission := false
-		hasFeature := false
-
-		for _, p := range project.AndroidManifest.Permissions {
-			if strings.Contains(p, perm) {
-				hasPermission = true
-				break
-			}
+		permission := project.AndroidManifest.Permission("android.permission." + perm)
+		if permission == nil || project.AndroidManifest.HasFeature(feature) {
+			continue
 		}
 
-		for _, activity := range project.AndroidManifest.Activities {
-			if strings.Contains(activity.Name, feature) {
-				hasFeature = true
-				break
-			}
-		}
-
-		if hasPermission && !hasFeature {
-			findings = append(findings, project.AddFinding(
-				c.ID(),
-				c.Name(),
-				"App uses "+perm+" permission without corresponding uses-feature declaration",
-				"android/app/src/main/AndroidManifest.xml",
-				"Add <uses-feature android:name=\""+feature+"\" android:required=\"false\" /> to AndroidManifest.xml",
-				report.SeverityWarning,
-				0,
-			))
+		message := "App uses " + perm + " permission without corresponding uses-feature declaration"
+		if permission.Origin != "" {
+			message = "App uses " + perm + " permission, added by the " + permission.Origin + " plugin, without corresponding uses-feature declaration"
 		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			message,
+			"android/app/src/main/AndroidManifest.xml",
+			"Add <uses-feature android:name=\""+feature+"\" android:required=\"false\" /> to AndroidManifest.xml",
+			report.SeverityWarning,
+			permission.AppLine(),
+		).WithSubject(perm))
 	}
 
 	return findings
@@ -117,6 +130,18 @@ func (c *PackageVisibilityCheck) Name() string {
 	return "Package Visibility Check"
 }
 
+func (c *PackageVisibilityCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that apps using url_launcher declare the intents they query in a <queries> element.",
+		Rationale:       "Since Android 11, canLaunchUrl returns false for apps the manifest does not declare, so links silently stop working.",
+		Guideline:       "Android 11 package visibility",
+		Remediation:     "Add a <queries> element listing the intents (VIEW https, SENDTO mailto, ...) the app launches.",
+	}
+}
+
 func (c *PackageVisibilityCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -143,3 +168,119 @@ func (c *PackageVisibilityCheck) Run(project *checker.Project) []report.Finding
 
 	return findings
 }
+
+type DependencyPermissionCheck struct{}
+
+func (c *DependencyPermissionCheck) ID() string {
+	return "AND-013"
+}
+
+func (c *DependencyPermissionCheck) Name() string {
+	return "Dependency Permission Check"
+}
+
+func (c *DependencyPermissionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityError,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that AndroidManifest.xml declares every permission the app's packages need but do not add themselves, according to the dependency knowledge base.",
+		Rationale:       "Android denies a runtime permission request for a permission the manifest does not declare, without asking the user, so the feature never works.",
+		Remediation:     "Add a <uses-permission> element for each listed permission to android/app/src/main/AndroidManifest.xml.",
+	}
+}
+
+func (c *DependencyPermissionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	if project.AndroidManifest == nil || project.PackageNeeds == nil {
+		return findings
+	}
+
+	for _, pkg := range project.PackageNeeds.Packages {
+		for _, entry := range pkg.Permissions {
+			alternatives := strings.Split(entry, "|")
+			declared := false
+			for _, name := range alternatives {
+				if project.AndroidManifest.Permission(name) != nil {
+					declared = true
+					break
+				}
+			}
+			if declared {
+				continue
+			}
+
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				"App uses "+pkg.Package+", which needs "+strings.Join(alternatives, " or ")+", but AndroidManifest.xml does not declare it",
+				"android/app/src/main/AndroidManifest.xml",
+				"Add <uses-permission android:name=\""+alternatives[0]+"\" /> to AndroidManifest.xml",
+				report.SeverityError,
+				0,
+			).WithSubject(entry))
+		}
+	}
+
+	return findings
+}
+
+type UnusedPermissionCheck struct{}
+
+func (c *UnusedPermissionCheck) ID() string {
+	return "AND-014"
+}
+
+func (c *UnusedPermissionCheck) Name() string {
+	return "Unused Permission Check"
+}
+
+func (c *UnusedPermissionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Flags sensitive permissions the app manifest declares that none of the app's packages need, according to the dependency knowledge base.",
+		Rationale:       "Google Play asks apps to justify sensitive permissions in the Play Console and rejects updates that request more than their features use.",
+		Guideline:       "Google Play Permissions and APIs that Access Sensitive Information policy",
+		Remediation:     "Remove the <uses-permission> if the app's own native code does not need it either, or add the package that needs it with fsct kb update.",
+	}
+}
+
+func (c *UnusedPermissionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	if project.AndroidManifest == nil || project.Pubspec == nil || project.PackageNeeds == nil {
+		return findings
+	}
+
+	for _, permission := range project.AndroidManifest.UsesPermissions {
+		if permission.Origin != "" || !project.PackageNeeds.Sensitive[permission.Name] {
+			continue
+		}
+		if project.PackageNeeds.Justified(permission.Name) {
+			continue
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"AndroidManifest.xml declares "+permission.Name+", but none of the app's packages need it",
+			"android/app/src/main/AndroidManifest.xml",
+			"Remove the permission unless the app's own native code uses it",
+			report.SeverityWarning,
+			permission.AppLine(),
+		).WithSubject(permission.Name))
+	}
+
+	return findings
+}
+
+// pluginNote returns " (added by the origin plugin)" for manifest elements
+// merged in from a Flutter plugin, and "" for those of the app.
+func pluginNote(origin string) string {
+	if origin == "" {
+		return ""
+	}
+	return " (added by the " + origin + " plugin)"
+}
@@ -0,0 +1,22 @@
+package android
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&TargetSDKCheck{},
+		&MinSDKCheck{},
+		&InternetPermissionCheck{},
+		&DangerousPermissionsCheck{},
+		&DebuggableCheck{},
+		&ExportedAttributeCheck{},
+		&MissingAppIconCheck{},
+		&PlaceholderIconCheck{},
+		&ApplicationIDCheck{},
+		&VersionCodeCheck{},
+		&PackageVisibilityCheck{},
+		&AllowBackupCheck{},
+		&DependencyPermissionCheck{},
+		&UnusedPermissionCheck{},
+	)
+}
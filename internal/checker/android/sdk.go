@@ -2,11 +2,16 @@ package android
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
+// playTargetSDK is the lowest target API level Google Play accepts for new
+// apps and updates.
+const playTargetSDK = 35
+
 type TargetSDKCheck struct{}
 
 func (c *TargetSDKCheck) ID() string {
@@ -17,6 +22,18 @@ func (c *TargetSDKCheck) Name() string {
 	return "Target SDK Version Check"
 }
 
+func (c *TargetSDKCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityCritical,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that targetSdkVersion in the app module build script meets Google Play's current minimum target API level.",
+		Rationale:       "Google Play rejects new apps and updates that target an API level below the yearly requirement, and older targets opt out of platform privacy and security behaviour.",
+		Guideline:       "Google Play target API level requirement",
+		Remediation:     "Raise targetSdkVersion to 35 or higher (thresholds.min_target_sdk) in android/app/build.gradle and test the app against the new platform behaviour changes.",
+	}
+}
+
 func (c *TargetSDKCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -29,15 +46,25 @@ func (c *TargetSDKCheck) Run(project *checker.Project) []report.Finding {
 		return findings
 	}
 
-	if targetSDK < 35 {
+	minSDK := project.Thresholds.WithDefaults().MinTargetSDK
+	if targetSDK < minSDK {
+		// Below Play's own minimum the upload is certain to be rejected;
+		// below a stricter configured minimum it is not.
+		severity := report.SeverityHigh
+		current := "Target SDK version is " + project.GradleConfig.TargetSDKVersion + gradleOrigin(project.GradleConfig, "targetSdkVersion")
+		message := current + ". The project requires targetSdkVersion " + strconv.Itoa(minSDK) + "+."
+		if targetSDK < playTargetSDK {
+			severity = report.SeverityCritical
+			message = current + ". Google Play Store requires targetSdkVersion " + strconv.Itoa(playTargetSDK) + "+."
+		}
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"Target SDK version is "+project.GradleConfig.TargetSDKVersion+". Google Play Store requires targetSdkVersion 35+.",
-			"android/app/build.gradle",
-			"Update targetSdkVersion to 35 or higher",
-			report.SeverityHigh,
-			0,
+			message,
+			project.GradleConfig.Path(),
+			"Update targetSdkVersion to "+strconv.Itoa(minSDK)+" or higher",
+			severity,
+			project.GradleConfig.Lines["targetSdkVersion"],
 		))
 	}
 
@@ -54,6 +81,17 @@ func (c *MinSDKCheck) Name() string {
 	return "Minimum SDK Version Check"
 }
 
+func (c *MinSDKCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryAndroid,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that minSdkVersion is at least API 21.",
+		Rationale:       "Current Flutter releases and most plugins no longer support devices below API 21, and older API levels lack modern TLS and permission handling.",
+		Remediation:     "Set minSdkVersion to 21 or higher in android/app/build.gradle.",
+	}
+}
+
 func (c *MinSDKCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -70,13 +108,24 @@ func (c *MinSDKCheck) Run(project *checker.Project) []report.Finding {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"Minimum SDK version is "+project.GradleConfig.MinSDKVersion+". Consider updating to API 21+ for better security and performance.",
-			"android/app/build.gradle",
+			"Minimum SDK version is "+project.GradleConfig.MinSDKVersion+gradleOrigin(project.GradleConfig, "minSdkVersion")+". Consider updating to API 21+ for better security and performance.",
+			project.GradleConfig.Path(),
 			"Update minSdkVersion to 21 or higher",
 			report.SeverityWarning,
-			0,
+			project.GradleConfig.Lines["minSdkVersion"],
 		))
 	}
 
 	return findings
 }
+
+// gradleOrigin returns " (from file:line)" when the value of the build
+// script setting name came from another file, such as local.properties or
+// the Flutter SDK, and "" otherwise.
+func gradleOrigin(g *checker.GradleConfigInfo, name string) string {
+	source := g.Sources[name]
+	if source == "" || strings.HasPrefix(source, g.Path()+":") {
+		return ""
+	}
+	return " (from " + source + ")"
+}
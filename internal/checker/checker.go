@@ -1,9 +1,12 @@
 package checker
 
 import (
+	"context"
+	"sort"
 	"strings"
 
 	"github.com/ricky-irfandi/fsct/internal/report"
+	"github.com/ricky-irfandi/fsct/internal/semver"
 )
 
 type Check interface {
@@ -12,16 +15,13 @@ type Check interface {
 	Run(project *Project) []report.Finding
 }
 
-type Category string
-
-const (
-	CategoryAndroid  Category = "Android"
-	CategoryIOS      Category = "iOS"
-	CategoryFlutter  Category = "Flutter"
-	CategorySecurity Category = "Security"
-	CategoryReviewer Category = "Reviewer"
-	CategoryAI       Category = "AI"
-)
+// ContextCheck is implemented by checks that do I/O and can honour
+// cancellation. Runners call RunContext instead of Run when available; an
+// error means the check could not complete.
+type ContextCheck interface {
+	Check
+	RunContext(ctx context.Context, project *Project) ([]report.Finding, error)
+}
 
 type Project struct {
 	Path        string
@@ -32,8 +32,33 @@ type Project struct {
 	AndroidManifest *AndroidManifestInfo
 	GradleConfig    *GradleConfigInfo
 	InfoPlist       *InfoPlistInfo
+	Entitlements    *EntitlementsInfo
+	SiteAssociation *SiteAssociationInfo
+	PrivacyManifest *PrivacyManifestInfo
+	Xcode           *XcodeProjectInfo
+	CocoaPods       *CocoaPodsInfo
 	Pubspec         *PubspecInfo
 	DartFiles       []string
+	Sources         *SourceIndex
+
+	// IOSSources holds the Swift and Objective-C sources of the iOS app
+	// under ios/Runner.
+	IOSSources *SourceIndex
+	// IOSPlugins are the Flutter plugins with iOS code built into release
+	// builds of the app.
+	IOSPlugins []IOSPluginInfo
+	// PackageNeeds is what the app's packages need it to declare.
+	PackageNeeds *PackageNeedsInfo
+
+	// Files lists the project-relative paths of the configuration and
+	// documentation files the loader found, such as pubspec.yaml and the
+	// Android manifest. Dart sources are in DartFiles.
+	Files []string
+
+	// RootFiles maps the names of documentation and tooling files in the
+	// project root, such as README.md or analysis_options.yaml, to their
+	// contents.
+	RootFiles map[string]string
 
 	HasNetworkDeps   bool
 	HasCameraDeps    bool
@@ -41,48 +66,85 @@ type Project struct {
 	HasImagePicker   bool
 	HasURLLauncher   bool
 	HasLoginPatterns bool
+
+	// Thresholds holds the limits configured in .fsct.yaml. Checks read it
+	// through WithDefaults.
+	Thresholds Thresholds
+
+	Diagnostics []Diagnostic
 }
 
-type AndroidManifestInfo struct {
-	PackageName     string
-	VersionCode     string
-	VersionName     string
-	Debuggable      bool
-	AllowBackup     bool
-	Permissions     []string
-	Activities      []ActivityInfo
-	QueriesPackages []string
+// Thresholds are the tunable limits of individual checks. A zero field
+// selects the value in DefaultThresholds.
+type Thresholds struct {
+	MinTargetSDK           int     // AND-001
+	MinIOSDeploymentTarget string  // IOS-012, e.g. "12.0"
+	MaxFileLines           int     // COD-001
+	MinTestRatio           float64 // TST-003, test files per library file
 }
 
-type ActivityInfo struct {
-	Name            string
-	Exported        bool
-	HasIntentFilter bool
+// DefaultThresholds are the limits used when none are configured.
+var DefaultThresholds = Thresholds{
+	MinTargetSDK:           35,
+	MinIOSDeploymentTarget: "12.0",
+	MaxFileLines:           400,
+	MinTestRatio:           0.2,
 }
 
-type GradleConfigInfo struct {
-	ApplicationID    string
-	MinSDKVersion    string
-	TargetSDKVersion string
-	VersionCode      string
-	VersionName      string
+// WithDefaults returns t with every zero field set from DefaultThresholds.
+func (t Thresholds) WithDefaults() Thresholds {
+	if t.MinTargetSDK == 0 {
+		t.MinTargetSDK = DefaultThresholds.MinTargetSDK
+	}
+	if t.MinIOSDeploymentTarget == "" {
+		t.MinIOSDeploymentTarget = DefaultThresholds.MinIOSDeploymentTarget
+	}
+	if t.MaxFileLines == 0 {
+		t.MaxFileLines = DefaultThresholds.MaxFileLines
+	}
+	if t.MinTestRatio == 0 {
+		t.MinTestRatio = DefaultThresholds.MinTestRatio
+	}
+	return t
+}
+
+// Diagnostic records a project file that was expected but could not be
+// loaded, so checks that depend on it are not silently run on zero values.
+type Diagnostic struct {
+	File     string
+	Platform string
+	Missing  bool
+	Err      error
 }
 
-type InfoPlistInfo struct {
-	CFBundleIdentifier         string
-	CFBundleVersion            string
-	CFBundleShortVersionString string
+// GradleConfigInfo summarises the app module build script. A setting that
+// could not be resolved, such as flutter.targetSdkVersion when no Flutter
+// SDK is available, is empty.
+type GradleConfigInfo struct {
+	// File is the build script relative to the project root, such as
+	// android/app/build.gradle.kts.
+	File string
 
-	HasCameraUsageDescription       bool
-	HasPhotoLibraryUsageDescription bool
-	HasLocationUsageDescription     bool
-	HasMicrophoneUsageDescription   bool
-	HasContactsUsageDescription     bool
-	HasCalendarsUsageDescription    bool
+	ApplicationID     string
+	CompileSDKVersion string
+	MinSDKVersion     string
+	TargetSDKVersion  string
+	VersionCode       string
+	VersionName       string
 
-	EncryptionDeclarationSet bool
-	EncryptionExempt         bool
-	RequiresFullScreen       bool
+	// Lines and Sources give, by setting name such as targetSdkVersion,
+	// the line it is assigned on in File and the file and line its value
+	// came from, such as android/local.properties:4.
+	Lines   map[string]int
+	Sources map[string]string
+}
+
+// Path returns File, or the conventional build script path if it is unset.
+func (g *GradleConfigInfo) Path() string {
+	if g == nil || g.File == "" {
+		return "android/app/build.gradle"
+	}
+	return g.File
 }
 
 type PubspecInfo struct {
@@ -92,14 +154,183 @@ type PubspecInfo struct {
 	Homepage    string
 	Repository  string
 
+	// Dependencies and DevDependencies map each package to its version
+	// constraint, or to a summary of its source such as "sdk: flutter".
 	Dependencies    map[string]string
 	DevDependencies map[string]string
+	// Specs lists the dependencies, dev_dependencies and
+	// dependency_overrides entries in file order.
+	Specs []DependencySpec
+
+	// SDKConstraint and FlutterConstraint are the environment sdk and
+	// flutter constraints.
+	SDKConstraint     string
+	FlutterConstraint string
+	Assets            []string
+	FontFamilies      []string
+
+	// Lines maps each top-level key of pubspec.yaml to its line.
+	Lines map[string]int
+
+	HasLinter          bool
+	HasIconConfig      bool
+	HasSplashConfig    bool
+	HasDeprecatedPkg   bool
+	DeprecatedPackages []string
+	HasDebugDeps       bool
+
+	// Resolved lists the packages pinned in pubspec.lock by name, or is
+	// nil if the project has no lock file.
+	Resolved []ResolvedPackage
+	// ResolvedSDKs maps dart and flutter to the SDK constraints the
+	// resolved packages allow.
+	ResolvedSDKs map[string]string
+	// Requires maps resolved packages to the packages they depend on. A
+	// package whose own pubspec.yaml could not be found has no entry.
+	Requires map[string][]string
+}
+
+// DependencySpec is a package declared in pubspec.yaml.
+type DependencySpec struct {
+	Name string
+	// Kind is the section: dependencies, dev_dependencies or
+	// dependency_overrides.
+	Kind string
+	// Source is hosted, git, path or sdk.
+	Source string
+	// Constraint is the version constraint as written, "" if none.
+	Constraint string
+	// Range is the parsed constraint; it allows any version when the
+	// constraint is missing or invalid.
+	Range semver.Range
+	Line  int
+}
+
+// Spec returns the dependencies or dev_dependencies entry for name, or
+// nil.
+func (p *PubspecInfo) Spec(name string) *DependencySpec {
+	for i := range p.Specs {
+		if p.Specs[i].Name == name && p.Specs[i].Kind != "dependency_overrides" {
+			return &p.Specs[i]
+		}
+	}
+	return nil
+}
+
+// ResolvedPackage is a package pinned in pubspec.lock.
+type ResolvedPackage struct {
+	Name    string
+	Version string
+	// Source is hosted, git, path or sdk.
+	Source string
+	// Dependency is "direct main", "direct dev", "direct overridden" or
+	// "transitive".
+	Dependency string
+	SHA256     string
+	Line       int
+}
+
+// IsDirect reports whether pubspec.yaml names the package.
+func (r ResolvedPackage) IsDirect() bool {
+	return r.Dependency != "transitive"
+}
+
+// Package returns the resolved package named name, or nil.
+func (p *PubspecInfo) Package(name string) *ResolvedPackage {
+	for i := range p.Resolved {
+		if p.Resolved[i].Name == name {
+			return &p.Resolved[i]
+		}
+	}
+	return nil
+}
+
+// AppPackages returns the names of the packages built into the app, in
+// order: the resolved packages other than dev_dependencies and the
+// packages only they pull in, or the dependencies if there is no
+// pubspec.lock. A transitive package whose dependents are unknown is
+// included.
+func (p *PubspecInfo) AppPackages() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	if p.Resolved == nil {
+		for name := range p.Dependencies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	var main, dev []string
+	for _, pkg := range p.Resolved {
+		switch {
+		case pkg.Dependency == "direct dev":
+			dev = append(dev, pkg.Name)
+		case pkg.IsDirect():
+			main = append(main, pkg.Name)
+		}
+	}
+	inMain, inDev := p.reachable(main), p.reachable(dev)
+	for _, pkg := range p.Resolved {
+		if inMain[pkg.Name] || !inDev[pkg.Name] && !pkg.IsDirect() {
+			names = append(names, pkg.Name)
+		}
+	}
+	return names
+}
+
+// reachable returns roots and the packages they require, directly or not.
+func (p *PubspecInfo) reachable(roots []string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		queue = append(queue, p.Requires[name]...)
+	}
+	return seen
+}
 
-	HasLinter        bool
-	HasIconConfig    bool
-	HasSplashConfig  bool
-	HasDeprecatedPkg bool
-	HasDebugDeps     bool
+// Why explains why the package name is resolved: for each direct
+// dependency that pulls it in, the shortest chain of packages from that
+// dependency to name, such as [image_picker image_picker_android]. It
+// returns nil if name is not reachable from a direct dependency.
+func (p *PubspecInfo) Why(name string) [][]string {
+	if p == nil {
+		return nil
+	}
+	var chains [][]string
+	for _, root := range p.Resolved {
+		if !root.IsDirect() {
+			continue
+		}
+		parent := map[string]string{root.Name: ""}
+		queue := []string{root.Name}
+		for len(queue) > 0 && queue[0] != name {
+			for _, dep := range p.Requires[queue[0]] {
+				if _, ok := parent[dep]; !ok {
+					parent[dep] = queue[0]
+					queue = append(queue, dep)
+				}
+			}
+			queue = queue[1:]
+		}
+		if len(queue) == 0 {
+			continue
+		}
+		chain := []string{name}
+		for at := parent[name]; at != ""; at = parent[at] {
+			chain = append([]string{at}, chain...)
+		}
+		chains = append(chains, chain)
+	}
+	return chains
 }
 
 func NewProject(path string) *Project {
@@ -112,12 +343,33 @@ func NewProject(path string) *Project {
 		AndroidManifest: &AndroidManifestInfo{},
 		GradleConfig:    &GradleConfigInfo{},
 		InfoPlist:       &InfoPlistInfo{},
+		Entitlements:    &EntitlementsInfo{},
+		SiteAssociation: &SiteAssociationInfo{},
+		PrivacyManifest: &PrivacyManifestInfo{},
+		PackageNeeds:    &PackageNeedsInfo{},
+		Xcode:           &XcodeProjectInfo{},
+		CocoaPods:       &CocoaPodsInfo{},
 		Pubspec:         &PubspecInfo{},
 
-		DartFiles: make([]string, 0),
+		DartFiles:  make([]string, 0),
+		Sources:    NewSourceIndex(),
+		IOSSources: NewSourceIndex(),
 	}
 }
 
+// RootFile returns the name and contents of the first of names present in
+// the project root. Names are matched case-insensitively.
+func (p *Project) RootFile(names ...string) (string, string, bool) {
+	for _, name := range names {
+		for file, content := range p.RootFiles {
+			if strings.EqualFold(file, name) {
+				return file, content, true
+			}
+		}
+	}
+	return "", "", false
+}
+
 func (p *Project) AddFinding(id, title, message, file, suggestion string, severity report.Severity, line int) report.Finding {
 	return report.Finding{
 		ID:         id,
@@ -129,40 +381,3 @@ func (p *Project) AddFinding(id, title, message, file, suggestion string, severi
 		Suggestion: suggestion,
 	}
 }
-
-type CheckRegistry struct {
-	checks map[string]Check
-}
-
-func NewCheckRegistry() *CheckRegistry {
-	return &CheckRegistry{
-		checks: make(map[string]Check),
-	}
-}
-
-func (r *CheckRegistry) Register(check Check) {
-	r.checks[check.ID()] = check
-}
-
-func (r *CheckRegistry) Get(id string) (Check, bool) {
-	check, ok := r.checks[id]
-	return check, ok
-}
-
-func (r *CheckRegistry) GetAll() []Check {
-	checks := make([]Check, 0, len(r.checks))
-	for _, check := range r.checks {
-		checks = append(checks, check)
-	}
-	return checks
-}
-
-func (r *CheckRegistry) GetByCategory(prefix string) []Check {
-	checks := make([]Check, 0)
-	for _, check := range r.checks {
-		if strings.HasPrefix(check.ID(), prefix) {
-			checks = append(checks, check)
-		}
-	}
-	return checks
-}
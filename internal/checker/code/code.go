@@ -2,7 +2,7 @@ package code
 
 import (
 	"regexp"
-	"strings"
+	"strconv"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/report"
@@ -18,17 +18,27 @@ func (c *FileLengthCheck) Name() string {
 	return "File Length Check"
 }
 
+func (c *FileLengthCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags Dart files longer than 400 lines (thresholds.max_file_lines).",
+		Rationale:       "Long files usually mix several responsibilities and are hard to review.",
+		Remediation:     "Split the file into smaller widgets or libraries.",
+	}
+}
+
 func (c *FileLengthCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	for _, file := range project.DartFiles {
-		lines := strings.Count(file, "\n")
-		if lines > 400 {
+	maxLines := project.Thresholds.WithDefaults().MaxFileLines
+	for _, file := range project.Sources.Files() {
+		if file.LineCount() > maxLines {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"File exceeds 400 lines: "+file,
-				file,
+				"File exceeds "+strconv.Itoa(maxLines)+" lines: "+file.Path,
+				file.Path,
 				"Consider splitting this file into smaller, focused modules",
 				report.SeverityWarning,
 				0,
@@ -49,37 +59,47 @@ func (c *ClassLengthCheck) Name() string {
 	return "Class Length Check"
 }
 
+func (c *ClassLengthCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags files that declare more than 10 classes or 20 functions.",
+		Rationale:       "Files with many declarations are hard to navigate and test.",
+		Remediation:     "Group related classes into their own files.",
+	}
+}
+
 func (c *ClassLengthCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	classPattern := regexp.MustCompile(`(?i)class\s+\w+`)
 	functionPattern := regexp.MustCompile(`(?i)(void|String|int|bool|List|Map)\s+\w+\s*\(`)
 
-	for _, file := range project.DartFiles {
-		classCount := len(classPattern.FindAllString(file, -1))
+	for _, file := range project.Sources.Files() {
+		classCount := len(classPattern.FindAllString(file.Content, -1))
 		if classCount > 10 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"File may have too many classes: "+file,
-				file,
+				"File may have too many classes: "+file.Path,
+				file.Path,
 				"Consider separating classes into different files",
 				report.SeverityWarning,
 				0,
-			))
+			).WithSubject("classes"))
 		}
 
-		funcCount := len(functionPattern.FindAllString(file, -1))
+		funcCount := len(functionPattern.FindAllString(file.Content, -1))
 		if funcCount > 20 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"File may have too many functions: "+file,
-				file,
+				"File may have too many functions: "+file.Path,
+				file.Path,
 				"Consider grouping related functions or extracting to separate classes",
 				report.SeverityWarning,
 				0,
-			))
+			).WithSubject("functions"))
 		}
 	}
 
@@ -96,23 +116,34 @@ func (c *MethodComplexityCheck) Name() string {
 	return "Method Complexity Check"
 }
 
+func (c *MethodComplexityCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags code nested more than five blocks deep.",
+		Rationale:       "Deep nesting makes control flow hard to follow and is a common source of bugs.",
+		Remediation:     "Extract nested blocks into methods or return early.",
+	}
+}
+
 func (c *MethodComplexityCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	for _, file := range project.DartFiles {
+	for _, file := range project.Sources.Files() {
 		nestingLevel := 0
-		for _, char := range file {
+		for offset, char := range file.Content {
 			if char == '{' {
 				nestingLevel++
 				if nestingLevel > 5 {
+					line, _ := file.Position(offset)
 					findings = append(findings, project.AddFinding(
 						c.ID(),
 						c.Name(),
-						"Deep nesting detected in file: "+file,
-						file,
+						"Deep nesting detected in file: "+file.Path,
+						file.Path,
 						"Consider extracting nested code into separate methods",
 						report.SeverityWarning,
-						0,
+						line,
 					))
 					break
 				}
@@ -135,35 +166,45 @@ func (c *NamingConventionCheck) Name() string {
 	return "Naming Convention Check"
 }
 
+func (c *NamingConventionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags classes and variables that do not follow Dart naming conventions.",
+		Rationale:       "Consistent naming keeps code readable and matches the Effective Dart style guide.",
+		Remediation:     "Use UpperCamelCase for types and lowerCamelCase for variables and constants.",
+	}
+}
+
 func (c *NamingConventionCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	classPattern := regexp.MustCompile(`(?i)class\s+[a-z]`)
-	variablePattern := regexp.MustCompile(`(?i)\b(?:final|const|var|int|String|bool)\s+[A-Z]`)
+	classPattern := regexp.MustCompile(`\bclass\s+[a-z]`)
+	variablePattern := regexp.MustCompile(`\b(?:final|const|var)\s+(?:\w+\s+)?[A-Z]\w*\s*=`)
 
-	for _, file := range project.DartFiles {
-		if classPattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if match, ok := file.FindFirst(classPattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Class name should start with capital letter in: "+file,
-				file,
+				"Class name should start with capital letter in: "+file.Path,
+				file.Path,
 				"Follow Dart naming conventions (PascalCase for classes)",
 				report.SeverityWarning,
-				0,
-			))
+				match.Line,
+			).WithSubject("class"))
 		}
 
-		if variablePattern.MatchString(file) {
+		if match, ok := file.FindFirst(variablePattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Variable name should start with lowercase in: "+file,
-				file,
+				"Variable name should start with lowercase in: "+file.Path,
+				file.Path,
 				"Follow Dart naming conventions (camelCase for variables)",
 				report.SeverityWarning,
-				0,
-			))
+				match.Line,
+			).WithSubject("variable"))
 		}
 	}
 
@@ -180,19 +221,29 @@ func (c *ImportOrganizationCheck) Name() string {
 	return "Import Organization Check"
 }
 
+func (c *ImportOrganizationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags files with more than 15 imports.",
+		Rationale:       "A large import list points to a file with too many dependencies.",
+		Remediation:     "Split the file or introduce a barrel library for related imports.",
+	}
+}
+
 func (c *ImportOrganizationCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	importPattern := regexp.MustCompile(`^import\s+['"][^'"]+['"];`)
+	importPattern := regexp.MustCompile(`(?m)^import\s+['"][^'"]+['"]`)
 
-	for _, file := range project.DartFiles {
-		imports := importPattern.FindAllString(file, -1)
+	for _, file := range project.Sources.Files() {
+		imports := importPattern.FindAllString(file.Content, -1)
 		if len(imports) > 15 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Too many imports in file: "+file,
-				file,
+				"Too many imports in file: "+file.Path,
+				file.Path,
 				"Consider using package: imports and consolidating related imports",
 				report.SeverityWarning,
 				0,
@@ -213,6 +264,16 @@ func (c *CommentQualityCheck) Name() string {
 	return "Comment Quality Check"
 }
 
+func (c *CommentQualityCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags files with many TODO/FIXME comments and large files without comments.",
+		Rationale:       "Accumulated TODOs hide unfinished work and uncommented large files are hard to maintain.",
+		Remediation:     "Resolve or track TODOs in your issue tracker and document public APIs.",
+	}
+}
+
 func (c *CommentQualityCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -220,46 +281,45 @@ func (c *CommentQualityCheck) Run(project *checker.Project) []report.Finding {
 	fixmePattern := regexp.MustCompile(`(?i)//\s*FIXME`)
 	commentPattern := regexp.MustCompile(`//`)
 
-	for _, file := range project.DartFiles {
-		todos := len(todoPattern.FindAllString(file, -1))
-		fixmes := len(fixmePattern.FindAllString(file, -1))
-		comments := len(commentPattern.FindAllString(file, -1))
+	for _, file := range project.Sources.Files() {
+		todos := len(todoPattern.FindAllString(file.Content, -1))
+		fixmes := len(fixmePattern.FindAllString(file.Content, -1))
+		comments := len(commentPattern.FindAllString(file.Content, -1))
 
 		if todos > 5 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Many TODO comments found in: "+file,
-				file,
+				"Many TODO comments found in: "+file.Path,
+				file.Path,
 				"Address TODO items or create issues for tracking",
 				report.SeverityWarning,
 				0,
-			))
+			).WithSubject("TODO"))
 		}
 
 		if fixmes > 3 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Many FIXME comments found in: "+file,
-				file,
+				"Many FIXME comments found in: "+file.Path,
+				file.Path,
 				"Fixme items indicate technical debt that should be addressed",
 				report.SeverityWarning,
 				0,
-			))
+			).WithSubject("FIXME"))
 		}
 
-		lines := strings.Count(file, "\n")
-		if lines > 50 && comments == 0 {
+		if file.LineCount() > 50 && comments == 0 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"No comments found in large file: "+file,
-				file,
+				"No comments found in large file: "+file.Path,
+				file.Path,
 				"Consider adding documentation comments for public APIs",
 				report.SeverityWarning,
 				0,
-			))
+			).WithSubject("comments"))
 		}
 	}
 
@@ -276,6 +336,16 @@ func (c *CyclomaticComplexityCheck) Name() string {
 	return "Cyclomatic Complexity Check"
 }
 
+func (c *CyclomaticComplexityCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Estimates cyclomatic complexity per file from branch statements.",
+		Rationale:       "Code with many branches needs many tests to cover and is hard to change safely.",
+		Remediation:     "Break complex logic into smaller functions.",
+	}
+}
+
 func (c *CyclomaticComplexityCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -285,20 +355,20 @@ func (c *CyclomaticComplexityCheck) Run(project *checker.Project) []report.Findi
 	casePattern := regexp.MustCompile(`(?i)\bcase\s+`)
 	ternaryPattern := regexp.MustCompile(`\?[^:]+:`)
 
-	for _, file := range project.DartFiles {
-		ifCount := len(ifPattern.FindAllString(file, -1))
-		forCount := len(forPattern.FindAllString(file, -1))
-		whileCount := len(whilePattern.FindAllString(file, -1))
-		caseCount := len(casePattern.FindAllString(file, -1))
-		ternaryCount := len(ternaryPattern.FindAllString(file, -1))
+	for _, file := range project.Sources.Files() {
+		ifCount := len(ifPattern.FindAllString(file.Content, -1))
+		forCount := len(forPattern.FindAllString(file.Content, -1))
+		whileCount := len(whilePattern.FindAllString(file.Content, -1))
+		caseCount := len(casePattern.FindAllString(file.Content, -1))
+		ternaryCount := len(ternaryPattern.FindAllString(file.Content, -1))
 
 		complexity := ifCount + forCount + whileCount + caseCount + ternaryCount
 		if complexity > 15 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"High cyclomatic complexity detected in: "+file,
-				file,
+				"High cyclomatic complexity detected in: "+file.Path,
+				file.Path,
 				"Consider extracting complex logic into separate functions",
 				report.SeverityWarning,
 				0,
@@ -319,6 +389,16 @@ func (c *DuplicateCodeCheck) Name() string {
 	return "Duplicate Code Detection"
 }
 
+func (c *DuplicateCodeCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryCodeQuality,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Detects duplicated blocks of code.",
+		Rationale:       "Duplicated code has to be fixed in several places.",
+		Remediation:     "Extract the shared code into a function or widget.",
+	}
+}
+
 func (c *DuplicateCodeCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -1,9 +1,11 @@
 package code
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/fingerprint"
 )
 
 func TestFileLengthCheck_ID(t *testing.T) {
@@ -62,6 +64,21 @@ func TestClassLengthCheck_Run(t *testing.T) {
 			t.Error("expected non-nil results")
 		}
 	})
+
+	t.Run("classes and functions findings are told apart", func(t *testing.T) {
+		sources := checker.NewSourceIndex()
+		sources.Add("lib/big.dart", strings.Repeat("class A {}\n", 11)+strings.Repeat("void f() {}\n", 21))
+		project := &checker.Project{DartFiles: []string{"lib/big.dart"}, Sources: sources}
+
+		results := c.Run(project)
+		if len(results) != 2 {
+			t.Fatalf("expected 2 findings, got %d", len(results))
+		}
+		fp := fingerprint.New(t.TempDir())
+		if fp.Fingerprint(results[0]) == fp.Fingerprint(results[1]) {
+			t.Errorf("expected distinct fingerprints, got subjects %q and %q", results[0].Subject, results[1].Subject)
+		}
+	})
 }
 
 func TestMethodComplexityCheck_ID(t *testing.T) {
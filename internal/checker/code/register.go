@@ -0,0 +1,15 @@
+package code
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&FileLengthCheck{},
+		&ClassLengthCheck{},
+		&MethodComplexityCheck{},
+		&NamingConventionCheck{},
+		&ImportOrganizationCheck{},
+		&CommentQualityCheck{},
+		&CyclomaticComplexityCheck{},
+	)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
+var readmeNames = []string{"README.md", "README"}
+
 type ReadmePresenceCheck struct{}
 
 func (c *ReadmePresenceCheck) ID() string {
@@ -18,18 +20,20 @@ func (c *ReadmePresenceCheck) Name() string {
 	return "README.md Presence"
 }
 
+func (c *ReadmePresenceCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that the project has a README.",
+		Rationale:       "A README explains how to build, configure and release the app.",
+		Remediation:     "Add README.md with setup, configuration and release instructions.",
+	}
+}
+
 func (c *ReadmePresenceCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasReadme := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "README.md") || strings.Contains(file, "README") {
-			hasReadme = true
-			break
-		}
-	}
-
-	if !hasReadme {
+	if _, _, ok := project.RootFile(readmeNames...); !ok {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -54,25 +58,32 @@ func (c *ReadmeContentCheck) Name() string {
 	return "README.md Content Quality"
 }
 
+func (c *ReadmeContentCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that the README covers installation and usage.",
+		Rationale:       "A README without setup steps does not help new contributors.",
+		Remediation:     "Add installation, usage and example sections.",
+	}
+}
+
 func (c *ReadmeContentCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	readmeContentPattern := regexp.MustCompile(`(?i)(install|setup|usage|example|feature)`)
-	hasContent := false
+	readmeContentPattern := regexp.MustCompile(`(?i)(install|setup|getting started|usage|example|feature)`)
 
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "README") && readmeContentPattern.MatchString(file) {
-			hasContent = true
-			break
-		}
+	name, content, ok := project.RootFile(readmeNames...)
+	if !ok {
+		name = "README.md"
 	}
 
-	if !hasContent {
+	if !readmeContentPattern.MatchString(content) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
 			"README.md may be missing content",
-			"README.md",
+			name,
 			"Add sections for installation, usage, and examples",
 			report.SeverityInfo,
 			0,
@@ -92,18 +103,20 @@ func (c *ChangelogPresenceCheck) Name() string {
 	return "CHANGELOG.md Presence"
 }
 
+func (c *ChangelogPresenceCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that the project has a CHANGELOG.",
+		Rationale:       "A changelog makes it easy to write store release notes.",
+		Remediation:     "Add CHANGELOG.md and update it for each release.",
+	}
+}
+
 func (c *ChangelogPresenceCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasChangelog := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "CHANGELOG") || strings.Contains(file, "CHANGES") {
-			hasChangelog = true
-			break
-		}
-	}
-
-	if !hasChangelog {
+	if _, _, ok := project.RootFile("CHANGELOG.md", "CHANGELOG", "CHANGES.md", "CHANGES"); !ok {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -128,18 +141,20 @@ func (c *LicensePresenceCheck) Name() string {
 	return "LICENSE File Presence"
 }
 
+func (c *LicensePresenceCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that the project has a LICENSE file.",
+		Rationale:       "Without a license the terms for using the code are unclear.",
+		Remediation:     "Add a LICENSE file.",
+	}
+}
+
 func (c *LicensePresenceCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasLicense := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "LICENSE") || strings.Contains(file, "COPYING") {
-			hasLicense = true
-			break
-		}
-	}
-
-	if !hasLicense {
+	if _, _, ok := project.RootFile("LICENSE", "LICENSE.md", "LICENSE.txt", "COPYING"); !ok {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -164,17 +179,25 @@ func (c *ApiDocumentationCheck) Name() string {
 	return "API Documentation"
 }
 
+func (c *ApiDocumentationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags projects where more than 10 source files have no /// doc comments.",
+		Rationale:       "Undocumented code is harder to maintain and review.",
+		Remediation:     "Add /// comments to public classes and functions.",
+	}
+}
+
 func (c *ApiDocumentationCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	docPattern := regexp.MustCompile(`///`)
 	undocumentedCount := 0
 
-	for _, file := range project.DartFiles {
-		if !strings.Contains(file, "test/") {
-			if !docPattern.MatchString(file) {
-				undocumentedCount++
-			}
+	for _, file := range project.Sources.Files() {
+		if !strings.Contains(file.Path, "test/") && !file.Contains(docPattern) {
+			undocumentedCount++
 		}
 	}
 
@@ -203,26 +226,30 @@ func (c *CodeCommentsCheck) Name() string {
 	return "Code Comment Quality"
 }
 
+func (c *CodeCommentsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryDocumentation,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Reports TODO and FIXME comments in source files.",
+		Rationale:       "Open TODOs often mark unfinished features that reviewers may hit.",
+		Remediation:     "Resolve the TODO or move it to your issue tracker.",
+	}
+}
+
 func (c *CodeCommentsCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasIssues := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "TODO") || strings.Contains(file, "FIXME") {
-			hasIssues = true
-			break
-		}
-	}
+	todoPattern := regexp.MustCompile(`//.*\b(?:TODO|FIXME)\b`)
 
-	if hasIssues {
+	if match, ok := project.Sources.FindFirst(todoPattern); ok {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
 			"TODO/FIXME comments found in code",
-			"source files",
+			match.File,
 			"Review and address TODO items or create issues",
 			report.SeverityInfo,
-			0,
+			match.Line,
 		))
 	}
 
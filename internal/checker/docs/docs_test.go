@@ -35,6 +35,25 @@ func TestReadmePresenceCheck_Run(t *testing.T) {
 	})
 }
 
+func TestReadmeChecks_RootFiles(t *testing.T) {
+	project := &checker.Project{
+		RootFiles: map[string]string{"readme.md": "# App\n\n## Getting started\n"},
+	}
+
+	if results := (&ReadmePresenceCheck{}).Run(project); len(results) != 0 {
+		t.Errorf("expected README to be found, got %d findings", len(results))
+	}
+	if results := (&ReadmeContentCheck{}).Run(project); len(results) != 0 {
+		t.Errorf("expected README content to be accepted, got %d findings", len(results))
+	}
+
+	project.RootFiles["readme.md"] = "# App\n"
+	results := (&ReadmeContentCheck{}).Run(project)
+	if len(results) != 1 || results[0].File != "readme.md" {
+		t.Errorf("expected a finding on readme.md, got %+v", results)
+	}
+}
+
 func TestReadmeContentCheck_ID(t *testing.T) {
 	c := &ReadmeContentCheck{}
 	if c.ID() != "DOC-002" {
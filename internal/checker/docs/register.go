@@ -0,0 +1,14 @@
+package docs
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&ReadmePresenceCheck{},
+		&ReadmeContentCheck{},
+		&ChangelogPresenceCheck{},
+		&LicensePresenceCheck{},
+		&ApiDocumentationCheck{},
+		&CodeCommentsCheck{},
+	)
+}
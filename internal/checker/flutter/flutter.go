@@ -1,6 +1,7 @@
 package flutter
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,16 @@ func (c *FlutterSDKVersionCheck) Name() string {
 	return "Flutter SDK Version Constraint"
 }
 
+func (c *FlutterSDKVersionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that pubspec.yaml declares the Flutter SDK dependency.",
+		Rationale:       "Without an SDK constraint, builds on other machines may use an incompatible Flutter version.",
+		Remediation:     "Add flutter: sdk: flutter under dependencies and an environment sdk constraint.",
+	}
+}
+
 func (c *FlutterSDKVersionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -58,8 +69,32 @@ func (c *Material3Check) Name() string {
 	return "Flutter Use Material Design 3"
 }
 
+func (c *Material3Check) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks whether the app opts into Material Design 3.",
+		Rationale:       "Material 3 is the default in current Flutter releases and Material 2 styling is being removed.",
+		Remediation:     "Set useMaterial3: true in your ThemeData and migrate custom components.",
+	}
+}
+
 func (c *Material3Check) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
+
+	material2Pattern := regexp.MustCompile(`\buseMaterial3\s*:\s*false\b`)
+	for _, match := range project.Sources.FindAll(material2Pattern) {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"Theme opts out of Material Design 3 (useMaterial3: false)",
+			match.File,
+			"Remove useMaterial3: false and migrate custom components to Material 3",
+			report.SeverityInfo,
+			match.Line,
+		))
+	}
+
 	return findings
 }
 
@@ -73,6 +108,17 @@ func (c *MinSDKVersionCheck) Name() string {
 	return "Flutter Min SDK Version"
 }
 
+func (c *MinSDKVersionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks that the Android minSdkVersion is supported by the Flutter engine.",
+		Rationale:       "The Flutter engine does not run below API 21.",
+		Remediation:     "Set minSdkVersion to 21 or higher, or use flutter.minSdkVersion.",
+	}
+}
+
 func (c *MinSDKVersionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -108,6 +154,16 @@ func (c *PackageNameCheck) Name() string {
 	return "Flutter Package Name Validation"
 }
 
+func (c *PackageNameCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks the package name format in pubspec.yaml.",
+		Rationale:       "Store identifiers are derived from the project name when they are not set explicitly.",
+		Remediation:     "Use a valid identifier for the package and set unique application and bundle identifiers.",
+	}
+}
+
 func (c *PackageNameCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -138,6 +194,16 @@ func (c *VersionCheck) Name() string {
 	return "Flutter Version Management"
 }
 
+func (c *VersionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that pubspec.yaml declares a version.",
+		Rationale:       "Flutter derives versionName/versionCode and CFBundleShortVersionString/CFBundleVersion from this field.",
+		Remediation:     "Add version: 1.0.0+1 to pubspec.yaml and bump it for every release.",
+	}
+}
+
 func (c *VersionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -166,21 +232,33 @@ func (c *DependencyConstraintCheck) Name() string {
 	return "Flutter Dependency Version Constraints"
 }
 
+func (c *DependencyConstraintCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks for dependencies declared without version constraints.",
+		Rationale:       "Unconstrained dependencies resolve to whatever is newest, so the same commit can build differently over time.",
+		Remediation:     "Give each dependency a caret constraint (e.g. http: ^1.2.0) and commit pubspec.lock.",
+	}
+}
+
 func (c *DependencyConstraintCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
-	if project.Pubspec != nil && project.Pubspec.Dependencies != nil {
-		unpinnedCount := 0
-		for _, version := range project.Pubspec.Dependencies {
-			if version == "any" || version == "" {
-				unpinnedCount++
+	if project.Pubspec != nil {
+		var unpinned []string
+		for _, spec := range project.Pubspec.Specs {
+			// Only hosted packages are resolved by version; sdk, git and
+			// path dependencies are pinned by their source.
+			if spec.Kind == "dependencies" && spec.Source == "hosted" && (spec.Constraint == "" || spec.Constraint == "any") {
+				unpinned = append(unpinned, spec.Name)
 			}
 		}
-		if unpinnedCount > 3 {
+		if len(unpinned) > 3 {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Multiple dependencies without version constraints",
+				"Multiple dependencies without version constraints: "+strings.Join(unpinned, ", "),
 				"pubspec.yaml",
 				"Use version constraints (^) to ensure reproducible builds",
 				report.SeverityWarning,
@@ -202,18 +280,36 @@ func (c *DeprecatedPackageCheck) Name() string {
 	return "Flutter Deprecated Package Usage"
 }
 
+func (c *DeprecatedPackageCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks for dependencies on discontinued or deprecated packages.",
+		Rationale:       "Discontinued packages stop receiving fixes for new OS versions and store requirements.",
+		Remediation:     "Migrate to the replacement package named on pub.dev.",
+	}
+}
+
 func (c *DeprecatedPackageCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
 	if project.Pubspec != nil && project.Pubspec.HasDeprecatedPkg {
+		message := "Potentially deprecated packages found"
+		line := 0
+		if deprecated := project.Pubspec.DeprecatedPackages; len(deprecated) > 0 {
+			message = "Deprecated packages found: " + strings.Join(deprecated, ", ")
+			if spec := project.Pubspec.Spec(deprecated[0]); spec != nil {
+				line = spec.Line
+			}
+		}
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"Potentially deprecated packages found",
+			message,
 			"pubspec.yaml",
-			"Check for latest FlutterFire packages and migration guides",
+			"Migrate to the maintained replacements, such as the plus_plugins packages",
 			report.SeverityHigh,
-			0,
+			line,
 		))
 	}
 
@@ -230,7 +326,42 @@ func (c *ProjectStructureCheck) Name() string {
 	return "Flutter Project Structure"
 }
 
+func (c *ProjectStructureCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryFlutter,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that the project follows the standard Flutter layout.",
+		Rationale:       "Tooling and CI scripts assume lib/, test/ and platform folders in their default locations.",
+		Remediation:     "Keep sources under lib/ with lib/main.dart as the entrypoint, and tests under test/.",
+	}
+}
+
 func (c *ProjectStructureCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
+
+	if project.Pubspec == nil || project.Pubspec.Name == "" {
+		return findings
+	}
+
+	hasMain := false
+	for _, file := range project.DartFiles {
+		if file == "lib/main.dart" {
+			hasMain = true
+			break
+		}
+	}
+
+	if !hasMain {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"lib/main.dart not found",
+			"lib/",
+			"Keep the app entrypoint at lib/main.dart so flutter run and build use it by default",
+			report.SeverityInfo,
+			0,
+		))
+	}
+
 	return findings
 }
@@ -1,6 +1,7 @@
 package flutter
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -68,6 +69,29 @@ func TestMaterial3Check_ID(t *testing.T) {
 	}
 }
 
+func TestMaterial3Check_Run(t *testing.T) {
+	c := &Material3Check{}
+
+	t.Run("no sources", func(t *testing.T) {
+		results := c.Run(&checker.Project{})
+		if len(results) != 0 {
+			t.Errorf("expected 0 findings, got %d", len(results))
+		}
+	})
+
+	t.Run("material 2 opt-out", func(t *testing.T) {
+		sources := checker.NewSourceIndex()
+		sources.Add("lib/main.dart", "final theme = ThemeData(\n  useMaterial3: false,\n);\n")
+		results := c.Run(&checker.Project{Sources: sources})
+		if len(results) != 1 {
+			t.Fatalf("expected 1 finding, got %d", len(results))
+		}
+		if results[0].File != "lib/main.dart" || results[0].Line != 2 {
+			t.Errorf("expected lib/main.dart:2, got %s:%d", results[0].File, results[0].Line)
+		}
+	})
+}
+
 func TestMinSDKVersionCheck_ID(t *testing.T) {
 	c := &MinSDKVersionCheck{}
 	if c.ID() != "FLT-003" {
@@ -194,6 +218,33 @@ func TestDependencyConstraintCheck_ID(t *testing.T) {
 	}
 }
 
+func TestDependencyConstraintCheck_Run(t *testing.T) {
+	c := &DependencyConstraintCheck{}
+	specs := []checker.DependencySpec{
+		{Name: "flutter", Kind: "dependencies", Source: "sdk"},
+		{Name: "shared", Kind: "dependencies", Source: "path"},
+		{Name: "charts", Kind: "dependencies", Source: "git"},
+		{Name: "http", Kind: "dependencies", Source: "hosted"},
+		{Name: "intl", Kind: "dependencies", Source: "hosted", Constraint: "any"},
+		{Name: "provider", Kind: "dependencies", Source: "hosted", Constraint: "^6.0.0"},
+		{Name: "mockito", Kind: "dev_dependencies", Source: "hosted"},
+	}
+
+	project := &checker.Project{Pubspec: &checker.PubspecInfo{Specs: specs}}
+	if results := c.Run(project); len(results) != 0 {
+		t.Errorf("sdk, path and git dependencies should not count as unconstrained, got %+v", results)
+	}
+
+	project.Pubspec.Specs = append(specs,
+		checker.DependencySpec{Name: "dio", Kind: "dependencies", Source: "hosted"},
+		checker.DependencySpec{Name: "uuid", Kind: "dependencies", Source: "hosted"},
+	)
+	results := c.Run(project)
+	if len(results) != 1 || !strings.HasSuffix(results[0].Message, "http, intl, dio, uuid") {
+		t.Errorf("expected one finding listing the packages, got %+v", results)
+	}
+}
+
 func TestDeprecatedPackageCheck_ID(t *testing.T) {
 	c := &DeprecatedPackageCheck{}
 	if c.ID() != "FLT-007" {
@@ -227,6 +278,21 @@ func TestDeprecatedPackageCheck_Run(t *testing.T) {
 			t.Errorf("expected 1 finding, got %d", len(results))
 		}
 	})
+	t.Run("names the packages", func(t *testing.T) {
+		project := &checker.Project{
+			Pubspec: &checker.PubspecInfo{
+				HasDeprecatedPkg:   true,
+				DeprecatedPackages: []string{"package_info", "connectivity"},
+				Specs: []checker.DependencySpec{
+					{Name: "package_info", Kind: "dependencies", Line: 14},
+				},
+			},
+		}
+		results := c.Run(project)
+		if len(results) != 1 || results[0].Line != 14 || results[0].Message != "Deprecated packages found: package_info, connectivity" {
+			t.Errorf("unexpected findings %+v", results)
+		}
+	})
 }
 
 func TestProjectStructureCheck_ID(t *testing.T) {
@@ -235,3 +301,36 @@ func TestProjectStructureCheck_ID(t *testing.T) {
 		t.Errorf("expected FLT-008, got %s", c.ID())
 	}
 }
+
+func TestProjectStructureCheck_Run(t *testing.T) {
+	c := &ProjectStructureCheck{}
+
+	t.Run("no pubspec", func(t *testing.T) {
+		results := c.Run(&checker.Project{})
+		if len(results) != 0 {
+			t.Errorf("expected 0 findings, got %d", len(results))
+		}
+	})
+
+	t.Run("missing main", func(t *testing.T) {
+		project := &checker.Project{
+			Pubspec:   &checker.PubspecInfo{Name: "app"},
+			DartFiles: []string{"lib/app.dart"},
+		}
+		results := c.Run(project)
+		if len(results) != 1 {
+			t.Errorf("expected 1 finding, got %d", len(results))
+		}
+	})
+
+	t.Run("standard layout", func(t *testing.T) {
+		project := &checker.Project{
+			Pubspec:   &checker.PubspecInfo{Name: "app"},
+			DartFiles: []string{"lib/main.dart", "test/app_test.dart"},
+		}
+		results := c.Run(project)
+		if len(results) != 0 {
+			t.Errorf("expected 0 findings, got %d", len(results))
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package flutter
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&FlutterSDKVersionCheck{},
+		&Material3Check{},
+		&MinSDKVersionCheck{},
+		&PackageNameCheck{},
+		&VersionCheck{},
+		&DependencyConstraintCheck{},
+		&DeprecatedPackageCheck{},
+		&ProjectStructureCheck{},
+	)
+}
@@ -1,10 +1,8 @@
 package ios
 
 import (
-	"os"
-	"path/filepath"
-	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/report"
@@ -20,6 +18,18 @@ func (c *FullScreenConflictCheck) Name() string {
 	return "Full Screen Conflict Check"
 }
 
+func (c *FullScreenConflictCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks whether UIRequiresFullScreen is enabled.",
+		Rationale:       "Full-screen-only apps opt out of iPad multitasking, which App Review questions for iPad-capable apps.",
+		Guideline:       "App Review Guideline 2.4.1 (iPad compatibility)",
+		Remediation:     "Remove UIRequiresFullScreen, or set it to false unless the app genuinely needs the whole screen.",
+	}
+}
+
 func (c *FullScreenConflictCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -35,7 +45,7 @@ func (c *FullScreenConflictCheck) Run(project *checker.Project) []report.Finding
 			"ios/Runner/Info.plist",
 			"Either set UIRequiresFullScreen to false or ensure iPad is not in the target device list",
 			report.SeverityWarning,
-			0,
+			project.InfoPlist.Line("UIRequiresFullScreen"),
 		))
 	}
 
@@ -52,6 +62,18 @@ func (c *EncryptionDeclarationCheck) Name() string {
 	return "Encryption Declaration Check"
 }
 
+func (c *EncryptionDeclarationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that ITSAppUsesNonExemptEncryption is declared in Info.plist.",
+		Rationale:       "Without the key every build is held in App Store Connect until export compliance questions are answered by hand.",
+		Guideline:       "App Store Connect export compliance",
+		Remediation:     "Add ITSAppUsesNonExemptEncryption: false when the app only uses exempt encryption such as HTTPS, or true with export documentation.",
+	}
+}
+
 func (c *EncryptionDeclarationCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -84,53 +106,60 @@ func (c *DeploymentTargetCheck) Name() string {
 	return "Deployment Target Check"
 }
 
-func (c *DeploymentTargetCheck) Run(project *checker.Project) []report.Finding {
-	var findings []report.Finding
-
-	iosPath := project.IOSPath
-	if iosPath == "" {
-		return findings
-	}
-
-	xcodeprojPath := filepath.Join(iosPath, "Runner.xcodeproj", "project.pbxproj")
-
-	content, err := os.ReadFile(xcodeprojPath)
-	if err != nil {
-		return findings
+func (c *DeploymentTargetCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the Release IPHONEOS_DEPLOYMENT_TARGET of the app target is at least iOS 12.0 (thresholds.min_ios_deployment_target).",
+		Rationale:       "Current Flutter releases and Xcode versions no longer build for older deployment targets.",
+		Remediation:     "Raise IPHONEOS_DEPLOYMENT_TARGET in the Runner project and the Podfile platform line.",
 	}
+}
 
-	pattern := regexp.MustCompile(`IPHONEOS_DEPLOYMENT_TARGET\s*=\s*([0-9.]+)`)
-	matches := pattern.FindStringSubmatch(string(content))
-
-	if len(matches) < 2 {
-		return findings
-	}
-
-	deploymentTarget := matches[1]
-
-	pattern = regexp.MustCompile(`^(\d+)\.(\d+)`)
-	matches = pattern.FindStringSubmatch(deploymentTarget)
+func (c *DeploymentTargetCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
 
-	if len(matches) < 3 {
+	deploymentTarget := project.Xcode.Setting("IPHONEOS_DEPLOYMENT_TARGET")
+	if deploymentTarget == "" {
 		return findings
 	}
 
-	major, _ := strconv.Atoi(matches[1])
-	minor, _ := strconv.Atoi(matches[2])
+	minTarget := project.Thresholds.WithDefaults().MinIOSDeploymentTarget
 
-	version := float64(major) + float64(minor)*0.1
-
-	if version < 12.0 {
+	if compareVersions(deploymentTarget, minTarget) < 0 {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"IPHONEOS_DEPLOYMENT_TARGET is less than 12.0. Consider updating to support modern iOS versions.",
-			"ios/Runner.xcodeproj/project.pbxproj",
-			"Update IPHONEOS_DEPLOYMENT_TARGET to 12.0 or higher",
+			"IPHONEOS_DEPLOYMENT_TARGET is "+deploymentTarget+", below "+minTarget+". Consider updating to support modern iOS versions.",
+			project.Xcode.Path(),
+			"Update IPHONEOS_DEPLOYMENT_TARGET to "+minTarget+" or higher",
 			report.SeverityWarning,
-			0,
+			project.Xcode.Lines["IPHONEOS_DEPLOYMENT_TARGET"],
 		))
 	}
 
 	return findings
 }
+
+// compareVersions compares dotted version numbers such as "12.0" and
+// "11.4.1", treating missing components as zero.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
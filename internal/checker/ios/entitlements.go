@@ -0,0 +1,239 @@
+package ios
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+type PushEnvironmentCheck struct{}
+
+func (c *PushEnvironmentCheck) ID() string {
+	return "IOS-016"
+}
+
+func (c *PushEnvironmentCheck) Name() string {
+	return "Push Environment Check"
+}
+
+func (c *PushEnvironmentCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the entitlements the Release configuration is signed with do not set aps-environment to development.",
+		Rationale:       "Release builds installed outside the App Store, such as ad hoc and enterprise builds, register with the sandbox push service, and tokens they send to a production push server are rejected.",
+		Remediation:     "Give the Release configuration its own entitlements file, such as Runner/RunnerRelease.entitlements, with aps-environment set to production.",
+	}
+}
+
+func (c *PushEnvironmentCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	entitlements := project.Entitlements
+	if entitlements == nil || entitlements.APSEnvironment != "development" {
+		return findings
+	}
+
+	findings = append(findings, project.AddFinding(
+		c.ID(),
+		c.Name(),
+		"aps-environment is development in the entitlements of the Release configuration",
+		entitlements.File,
+		"Set aps-environment to production for Release builds",
+		report.SeverityWarning,
+		entitlements.Line("aps-environment"),
+	).WithSubject("aps-environment"))
+
+	return findings
+}
+
+type HealthKitUsageDescriptionCheck struct{}
+
+func (c *HealthKitUsageDescriptionCheck) ID() string {
+	return "IOS-017"
+}
+
+func (c *HealthKitUsageDescriptionCheck) Name() string {
+	return "HealthKit Usage Description Check"
+}
+
+func (c *HealthKitUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSHealthShareUsageDescription is set when the app has the HealthKit entitlement.",
+		Rationale:       "iOS terminates an app that asks for HealthKit access without a purpose string, and App Review rejects HealthKit apps that do not explain their use of health data.",
+		Guideline:       "App Review Guideline 5.1.3 (Health and Health Research)",
+		Remediation:     "Add NSHealthShareUsageDescription, and NSHealthUpdateUsageDescription if the app writes health data, to ios/Runner/Info.plist.",
+	}
+}
+
+func (c *HealthKitUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	if project.Entitlements == nil || !project.Entitlements.HealthKit {
+		return findings
+	}
+
+	if project.InfoPlist == nil || strings.TrimSpace(project.InfoPlist.Values.Get("NSHealthShareUsageDescription").String()) == "" {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"App has the HealthKit entitlement but does not have NSHealthShareUsageDescription in Info.plist",
+			"ios/Runner/Info.plist",
+			"Add NSHealthShareUsageDescription explaining which health data the app reads and why",
+			report.SeverityHigh,
+			0,
+		).WithSubject("NSHealthShareUsageDescription"))
+	}
+
+	return findings
+}
+
+type AssociatedDomainsCheck struct{}
+
+func (c *AssociatedDomainsCheck) ID() string {
+	return "IOS-018"
+}
+
+func (c *AssociatedDomainsCheck) Name() string {
+	return "Associated Domains Check"
+}
+
+func (c *AssociatedDomainsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that each applinks and webcredentials associated domain has an apple-app-site-association file in the project that lists the app.",
+		Rationale:       "iOS only opens universal links, and offers saved passwords, for domains whose apple-app-site-association file names the app. Without it the links open in Safari.",
+		Remediation:     "Serve https://<domain>/.well-known/apple-app-site-association listing <team ID>.<bundle ID>, and keep a copy in web/.well-known/ so it can be checked.",
+	}
+}
+
+func (c *AssociatedDomainsCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	entitlements := project.Entitlements
+	if entitlements == nil || len(entitlements.AssociatedDomains) == 0 {
+		return findings
+	}
+
+	association := project.SiteAssociation
+	bundleID := project.Xcode.Setting("PRODUCT_BUNDLE_IDENTIFIER")
+	if bundleID == "" && project.InfoPlist != nil {
+		bundleID = project.InfoPlist.CFBundleIdentifier
+	}
+
+	for i, entry := range entitlements.AssociatedDomains {
+		service, domain, _ := strings.Cut(entry, ":")
+		domain, _, _ = strings.Cut(domain, "?")
+		if service != "applinks" && service != "webcredentials" {
+			continue
+		}
+		line := entitlements.Line("com.apple.developer.associated-domains." + strconv.Itoa(i))
+
+		if association == nil || association.File == "" {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				entry+" has no apple-app-site-association file in the project. It only works if https://"+domain+"/.well-known/apple-app-site-association lists the app",
+				entitlements.File,
+				"Check that the domain serves the file, and keep a copy in web/.well-known/apple-app-site-association",
+				report.SeverityInfo,
+				line,
+			).WithSubject(entry))
+			continue
+		}
+		appIDs := association.AppLinks
+		if service == "webcredentials" {
+			appIDs = association.WebCredentials
+		}
+		if !listsApp(appIDs, bundleID) {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				association.File+" does not list the app for "+service+", so "+entry+" will not open it",
+				entitlements.File,
+				"Add <team ID>."+bundleID+" to the "+service+" section of "+association.File,
+				report.SeverityWarning,
+				line,
+			).WithSubject(entry))
+		}
+	}
+
+	return findings
+}
+
+// listsApp reports whether appIDs, such as ABCDE12345.com.example.app,
+// include the app with bundleID. Any app ID matches a bundle ID that is
+// unknown or not expanded.
+func listsApp(appIDs []string, bundleID string) bool {
+	if bundleID == "" || strings.Contains(bundleID, "$") {
+		return len(appIDs) > 0
+	}
+	for _, id := range appIDs {
+		if strings.HasSuffix(id, "."+bundleID) {
+			return true
+		}
+	}
+	return false
+}
+
+var firebaseMessagingImport = regexp.MustCompile(`import\s+['"]package:firebase_messaging/`)
+
+type PushEntitlementCheck struct{}
+
+func (c *PushEntitlementCheck) ID() string {
+	return "IOS-019"
+}
+
+func (c *PushEntitlementCheck) Name() string {
+	return "Push Entitlement Check"
+}
+
+func (c *PushEntitlementCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityError,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that apps whose Dart code uses firebase_messaging have the aps-environment entitlement.",
+		Rationale:       "Without the Push Notifications capability iOS never issues an APNs token, so Firebase Cloud Messaging delivers nothing to iPhones and iPads.",
+		Remediation:     "Add the Push Notifications capability to the Runner target in Xcode, which adds aps-environment to its entitlements.",
+	}
+}
+
+func (c *PushEntitlementCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	// Without an Xcode project or entitlements there is no iOS app to
+	// check.
+	if (project.Xcode == nil || project.Xcode.File == "") && (project.Entitlements == nil || project.Entitlements.File == "") {
+		return findings
+	}
+	if project.Entitlements != nil && project.Entitlements.APSEnvironment != "" {
+		return findings
+	}
+
+	match, ok := project.Sources.FindFirst(firebaseMessagingImport)
+	if !ok {
+		return findings
+	}
+
+	findings = append(findings, project.AddFinding(
+		c.ID(),
+		c.Name(),
+		"The app uses firebase_messaging, but the Runner target does not have the aps-environment entitlement, so iOS will not receive push notifications",
+		match.File,
+		"Add the Push Notifications capability to the Runner target in Xcode",
+		report.SeverityError,
+		match.Line,
+	).WithSubject("aps-environment"))
+
+	return findings
+}
@@ -19,6 +19,18 @@ func (c *MissingAppIconCheck) Name() string {
 	return "Missing App Icon Check"
 }
 
+func (c *MissingAppIconCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the AppIcon asset catalog exists and lists icon images.",
+		Rationale:       "Builds without a complete app icon set fail App Store Connect validation.",
+		Guideline:       "App Store Connect app icon requirements",
+		Remediation:     "Populate ios/Runner/Assets.xcassets/AppIcon.appiconset, for example with flutter_launcher_icons.",
+	}
+}
+
 func (c *MissingAppIconCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -53,6 +65,18 @@ func (c *Missing1024IconCheck) Name() string {
 	return "Missing 1024x1024 Icon Check"
 }
 
+func (c *Missing1024IconCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityCritical,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the AppIcon set includes the 1024x1024 App Store icon.",
+		Rationale:       "App Store Connect rejects uploads that lack the marketing icon.",
+		Guideline:       "App Store Connect app icon requirements",
+		Remediation:     "Add a 1024x1024 PNG without transparency to the AppIcon asset catalog.",
+	}
+}
+
 func (c *Missing1024IconCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -108,7 +132,7 @@ func (c *Missing1024IconCheck) Run(project *checker.Project) []report.Finding {
 			"No 1024x1024 (ios-marketing) icon found in AppIcon.appiconset Contents.json",
 			"ios/Runner/Assets.xcassets/AppIcon.appiconset/Contents.json",
 			"Add a 1024x1024 icon for iOS marketing size",
-			report.SeverityHigh,
+			report.SeverityCritical,
 			0,
 		))
 	}
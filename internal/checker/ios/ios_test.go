@@ -1,9 +1,11 @@
 package ios
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/plist"
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
@@ -263,6 +265,33 @@ func TestDeploymentTargetCheck(t *testing.T) {
 			t.Errorf("Expected 0 findings for nonexistent path, got %d", len(findings))
 		}
 	})
+
+	t.Run("uses the Release setting of the app target", func(t *testing.T) {
+		project := &checker.Project{
+			Xcode: &checker.XcodeProjectInfo{
+				File: "ios/Runner.xcodeproj/project.pbxproj",
+				Configurations: map[string]map[string]string{
+					"Debug":   {"IPHONEOS_DEPLOYMENT_TARGET": "9.0"},
+					"Release": {"IPHONEOS_DEPLOYMENT_TARGET": "11.0"},
+				},
+				Lines: map[string]int{"IPHONEOS_DEPLOYMENT_TARGET": 412},
+			},
+		}
+
+		findings := check.Run(project)
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].Line != 412 || !strings.Contains(findings[0].Message, "11.0") {
+			t.Errorf("Expected the Release value on line 412, got %+v", findings[0])
+		}
+
+		project.Xcode.Configurations["Release"]["IPHONEOS_DEPLOYMENT_TARGET"] = "13.0"
+		if findings := check.Run(project); len(findings) != 0 {
+			t.Errorf("Expected 0 findings for 13.0, got %d", len(findings))
+		}
+	})
 }
 
 func TestMicrophoneUsageDescriptionCheck(t *testing.T) {
@@ -305,6 +334,23 @@ func TestMicrophoneUsageDescriptionCheck(t *testing.T) {
 			t.Errorf("Expected 0 findings, got %d", len(findings))
 		}
 	})
+
+	t.Run("transitive packages are not matched by name", func(t *testing.T) {
+		project := &checker.Project{
+			Pubspec: &checker.PubspecInfo{
+				Dependencies: map[string]string{"provider": "^6.0.0"},
+				Resolved: []checker.ResolvedPackage{
+					{Name: "provider", Dependency: "direct main"},
+					{Name: "dynamic_color", Dependency: "transitive"},
+				},
+			},
+			InfoPlist: &checker.InfoPlistInfo{},
+		}
+
+		if findings := check.Run(project); len(findings) != 0 {
+			t.Errorf("Expected 0 findings, got %v", findings)
+		}
+	})
 }
 
 func TestContactsUsageDescriptionCheck(t *testing.T) {
@@ -352,3 +398,421 @@ func TestCalendarsUsageDescriptionCheck(t *testing.T) {
 		}
 	})
 }
+
+func TestPodfilePlatformCheck(t *testing.T) {
+	check := &PodfilePlatformCheck{}
+
+	newProject := func(platform string, targets ...checker.PodfileSetting) *checker.Project {
+		return &checker.Project{
+			Xcode: &checker.XcodeProjectInfo{
+				Configurations: map[string]map[string]string{
+					"Release": {"IPHONEOS_DEPLOYMENT_TARGET": "13.0"},
+				},
+			},
+			CocoaPods: &checker.CocoaPodsInfo{
+				Podfile:           "ios/Podfile",
+				Platform:          platform,
+				PlatformLine:      2,
+				DeploymentTargets: targets,
+			},
+		}
+	}
+
+	t.Run("platform lower than the deployment target", func(t *testing.T) {
+		findings := check.Run(newProject("12.0"))
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].File != "ios/Podfile" || findings[0].Line != 2 || !strings.Contains(findings[0].Message, "12.0") {
+			t.Errorf("Expected the platform line to be reported, got %+v", findings[0])
+		}
+	})
+
+	t.Run("post_install override lower than the deployment target", func(t *testing.T) {
+		findings := check.Run(newProject("13.0", checker.PodfileSetting{Value: "11.0", Line: 44}, checker.PodfileSetting{Value: "13.0", Line: 45}))
+
+		if len(findings) != 1 || findings[0].Line != 44 {
+			t.Errorf("Expected 1 finding on line 44, got %+v", findings)
+		}
+	})
+
+	t.Run("matching or missing platform passes", func(t *testing.T) {
+		for _, platform := range []string{"13.0", "14", ""} {
+			if findings := check.Run(newProject(platform)); len(findings) != 0 {
+				t.Errorf("Expected 0 findings for platform %q, got %d", platform, len(findings))
+			}
+		}
+	})
+}
+
+func TestPrivacyManifestPodsCheck(t *testing.T) {
+	check := &PrivacyManifestPodsCheck{}
+
+	project := &checker.Project{
+		CocoaPods: &checker.CocoaPodsInfo{
+			Lockfile: "ios/Podfile.lock",
+			Pods: []checker.PodInfo{
+				{Name: "AFNetworking", Version: "4.0.1", Line: 2},
+				{Name: "FirebaseCore", Version: "10.29.0", Line: 5},
+				{Name: "GoogleUtilities", Version: "7.11.5", Line: 8},
+				{Name: "SDWebImage", Version: "5.18.0", Line: 12},
+				{Name: "Toast", Version: "4.0.0", External: ".symlinks/plugins/toast/ios", Line: 14},
+				{Name: "Unlisted", Version: "0.1.0", Line: 16},
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Line != 2 || !strings.Contains(findings[0].Message, "no release") {
+		t.Errorf("Expected AFNetworking to have no fixed release, got %+v", findings[0])
+	}
+	if findings[1].Line != 8 || !strings.Contains(findings[1].Suggestion, "7.13.0") {
+		t.Errorf("Expected GoogleUtilities to be updated to 7.13.0, got %+v", findings[1])
+	}
+
+	if findings := check.Run(&checker.Project{CocoaPods: &checker.CocoaPodsInfo{}}); len(findings) != 0 {
+		t.Errorf("Expected 0 findings without Podfile.lock, got %d", len(findings))
+	}
+}
+
+func TestKnownBadPodVersionCheck(t *testing.T) {
+	check := &KnownBadPodVersionCheck{}
+
+	project := &checker.Project{
+		CocoaPods: &checker.CocoaPodsInfo{
+			Lockfile: "ios/Podfile.lock",
+			Pods: []checker.PodInfo{
+				{Name: "AFNetworking", Version: "4.0.1", Line: 2},
+				{Name: "BoringSSL-GRPC", Version: "0.0.24", Line: 4},
+			},
+			CocoaPodsVersion: "1.12.1",
+			CocoaPodsLine:    40,
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Line != 4 || findings[0].Severity != report.SeverityError {
+		t.Errorf("Expected BoringSSL-GRPC as an error, got %+v", findings[0])
+	}
+	if findings[1].Line != 40 || !strings.Contains(findings[1].Message, "CocoaPods 1.12.1") {
+		t.Errorf("Expected the CocoaPods version to be reported, got %+v", findings[1])
+	}
+
+	project.CocoaPods.CocoaPodsVersion = "1.15.2"
+	if findings := check.Run(project); len(findings) != 1 {
+		t.Errorf("Expected 1 finding with CocoaPods 1.15.2, got %d", len(findings))
+	}
+}
+
+func TestPushEnvironmentCheck(t *testing.T) {
+	check := &PushEnvironmentCheck{}
+
+	project := &checker.Project{
+		Entitlements: &checker.EntitlementsInfo{
+			File:           "ios/Runner/Runner.entitlements",
+			APSEnvironment: "development",
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 || findings[0].File != "ios/Runner/Runner.entitlements" {
+		t.Fatalf("Expected 1 finding in the entitlements file, got %+v", findings)
+	}
+
+	project.Entitlements.APSEnvironment = "production"
+	if findings := check.Run(project); len(findings) != 0 {
+		t.Errorf("Expected 0 findings for production, got %d", len(findings))
+	}
+}
+
+func TestHealthKitUsageDescriptionCheck(t *testing.T) {
+	check := &HealthKitUsageDescriptionCheck{}
+
+	project := &checker.Project{
+		Entitlements: &checker.EntitlementsInfo{HealthKit: true},
+		InfoPlist:    &checker.InfoPlistInfo{},
+	}
+
+	if findings := check.Run(project); len(findings) != 1 || findings[0].Severity != report.SeverityHigh {
+		t.Errorf("Expected 1 HIGH finding without NSHealthShareUsageDescription, got %+v", findings)
+	}
+
+	project.Entitlements.HealthKit = false
+	if findings := check.Run(project); len(findings) != 0 {
+		t.Errorf("Expected 0 findings without the HealthKit entitlement, got %d", len(findings))
+	}
+}
+
+func TestAssociatedDomainsCheck(t *testing.T) {
+	check := &AssociatedDomainsCheck{}
+
+	newProject := func(association *checker.SiteAssociationInfo) *checker.Project {
+		return &checker.Project{
+			Xcode: &checker.XcodeProjectInfo{
+				Configurations: map[string]map[string]string{
+					"Release": {"PRODUCT_BUNDLE_IDENTIFIER": "com.example.app"},
+				},
+			},
+			Entitlements: &checker.EntitlementsInfo{
+				File:              "ios/Runner/Runner.entitlements",
+				AssociatedDomains: []string{"applinks:example.com", "webcredentials:example.com", "appclips:example.com"},
+			},
+			SiteAssociation: association,
+		}
+	}
+
+	t.Run("no apple-app-site-association file is INFO", func(t *testing.T) {
+		findings := check.Run(newProject(&checker.SiteAssociationInfo{}))
+
+		if len(findings) != 2 {
+			t.Fatalf("Expected 2 findings, got %d", len(findings))
+		}
+		if findings[0].Severity != report.SeverityInfo || !strings.Contains(findings[0].Message, "https://example.com/.well-known/apple-app-site-association") {
+			t.Errorf("Expected an INFO finding naming the URL, got %+v", findings[0])
+		}
+	})
+
+	t.Run("file that does not list the app is WARNING", func(t *testing.T) {
+		findings := check.Run(newProject(&checker.SiteAssociationInfo{
+			File:           "web/.well-known/apple-app-site-association",
+			AppLinks:       []string{"ABCDE12345.com.example.app"},
+			WebCredentials: []string{"ABCDE12345.com.example.other"},
+		}))
+
+		if len(findings) != 1 {
+			t.Fatalf("Expected 1 finding, got %d", len(findings))
+		}
+		if findings[0].Severity != report.SeverityWarning || !strings.Contains(findings[0].Message, "webcredentials") {
+			t.Errorf("Expected a WARNING for webcredentials, got %+v", findings[0])
+		}
+	})
+}
+
+func TestPushEntitlementCheck(t *testing.T) {
+	check := &PushEntitlementCheck{}
+
+	sources := checker.NewSourceIndex()
+	sources.Add("lib/main.dart", "import 'package:flutter/material.dart';\nimport 'package:firebase_messaging/firebase_messaging.dart';\n")
+
+	project := &checker.Project{
+		Xcode:        &checker.XcodeProjectInfo{File: "ios/Runner.xcodeproj/project.pbxproj"},
+		Entitlements: &checker.EntitlementsInfo{},
+		Sources:      sources,
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].File != "lib/main.dart" || findings[0].Line != 2 || findings[0].Severity != report.SeverityError {
+		t.Errorf("Expected an ERROR at the import, got %+v", findings[0])
+	}
+
+	project.Entitlements.APSEnvironment = "production"
+	if findings := check.Run(project); len(findings) != 0 {
+		t.Errorf("Expected 0 findings with aps-environment, got %d", len(findings))
+	}
+
+	if findings := check.Run(&checker.Project{Sources: sources}); len(findings) != 0 {
+		t.Errorf("Expected 0 findings without an iOS project, got %d", len(findings))
+	}
+}
+
+func TestPrivacyManifestReasonsCheck(t *testing.T) {
+	check := &PrivacyManifestReasonsCheck{}
+
+	project := &checker.Project{
+		PrivacyManifest: &checker.PrivacyManifestInfo{
+			File:    "ios/Runner/PrivacyInfo.xcprivacy",
+			Bundled: true,
+			AccessedAPIs: []checker.AccessedAPIInfo{
+				{Type: "NSPrivacyAccessedAPICategoryUserDefaults", Reasons: []string{"CA92.1"}, Line: 8},
+				{Type: "NSPrivacyAccessedAPICategoryDiskSpace", Reasons: []string{"E174.1", "CA92.1"}, Line: 15},
+				{Type: "NSPrivacyAccessedAPICategoryFileTimestamp", Line: 22},
+				{Type: "NSPrivacyAccessedAPICategoryKeychain", Reasons: []string{"CA92.1"}, Line: 27},
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 3 {
+		t.Fatalf("Expected 3 findings, got %d: %+v", len(findings), findings)
+	}
+	for i, line := range []int{15, 22, 27} {
+		if findings[i].Line != line || findings[i].Severity != report.SeverityHigh {
+			t.Errorf("Expected a HIGH finding on line %d, got %+v", line, findings[i])
+		}
+	}
+	if !strings.Contains(findings[0].Message, "CA92.1 is not an approved reason") {
+		t.Errorf("Expected the reason to be named, got %q", findings[0].Message)
+	}
+
+	project.PrivacyManifest.AccessedAPIs = nil
+	project.PrivacyManifest.Bundled = false
+	findings = check.Run(project)
+	if len(findings) != 1 || findings[0].File != "ios/Runner.xcodeproj/project.pbxproj" {
+		t.Errorf("Expected a finding for a manifest outside the Runner target, got %+v", findings)
+	}
+}
+
+func TestRequiredReasonAPICheck(t *testing.T) {
+	check := &RequiredReasonAPICheck{}
+
+	sources := checker.NewSourceIndex()
+	sources.Add("ios/Runner/AppDelegate.swift", "import UIKit\n\nlet launches = UserDefaults.standard.integer(forKey: \"launches\")\nlet uptime = ProcessInfo.processInfo.systemUptime\n")
+
+	project := &checker.Project{
+		IOSSources: sources,
+		IOSPlugins: []checker.IOSPluginInfo{
+			{Name: "shared_preferences_foundation", Located: true, PrivacyManifest: "/cache/shared_preferences_foundation/darwin/PrivacyInfo.xcprivacy"},
+			{Name: "disk_space", Located: true},
+			{Name: "file_picker"},
+		},
+		CocoaPods: &checker.CocoaPodsInfo{
+			Lockfile: "ios/Podfile.lock",
+			Pods:     []checker.PodInfo{{Name: "disk_space", Version: "0.2.1", External: "path", Line: 4}},
+		},
+		PrivacyManifest: &checker.PrivacyManifestInfo{
+			File: "ios/Runner/PrivacyInfo.xcprivacy",
+			AccessedAPIs: []checker.AccessedAPIInfo{
+				{Type: "NSPrivacyAccessedAPICategoryUserDefaults", Reasons: []string{"CA92.1"}},
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "NSPrivacyAccessedAPICategorySystemBootTime" || findings[0].File != "ios/Runner/AppDelegate.swift" || findings[0].Line != 4 {
+		t.Errorf("Expected a finding at the systemUptime call, got %+v", findings[0])
+	}
+	if findings[1].Subject != "NSPrivacyAccessedAPICategoryDiskSpace" || findings[1].File != "ios/Podfile.lock" || findings[1].Line != 4 {
+		t.Errorf("Expected a finding at the disk_space pod, got %+v", findings[1])
+	}
+
+	project.PrivacyManifest = &checker.PrivacyManifestInfo{}
+	findings = check.Run(project)
+	if len(findings) != 3 || !strings.Contains(findings[2].Message, "no privacy manifest") {
+		t.Errorf("Expected 3 findings without a privacy manifest, got %+v", findings)
+	}
+}
+
+func TestPluginPrivacyManifestCheck(t *testing.T) {
+	check := &PluginPrivacyManifestCheck{}
+
+	project := &checker.Project{
+		IOSPlugins: []checker.IOSPluginInfo{
+			{Name: "wakelock", Located: true},
+			{Name: "url_launcher_ios", Located: true, PrivacyManifest: "/cache/url_launcher_ios/ios/Resources/PrivacyInfo.xcprivacy"},
+			{Name: "fluttertoast"},
+			{Name: "my_plugin", Located: true},
+		},
+		CocoaPods: &checker.CocoaPodsInfo{},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "wakelock" || findings[0].File != "pubspec.yaml" || findings[0].Severity != report.SeverityHigh {
+		t.Errorf("Expected a HIGH finding for wakelock, got %+v", findings[0])
+	}
+}
+
+func TestDependencyUsageDescriptionCheck(t *testing.T) {
+	check := &DependencyUsageDescriptionCheck{}
+
+	values, err := plist.Decode([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>NSBluetoothAlwaysUsageDescription</key>
+	<string> </string>
+	<key>NSCameraUsageDescription</key>
+	<string>Scan QR codes</string>
+</dict>
+</plist>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := &checker.Project{
+		InfoPlist: &checker.InfoPlistInfo{Values: values},
+		PackageNeeds: &checker.PackageNeedsInfo{
+			Packages: []checker.PackageNeeds{
+				{Package: "flutter_blue_plus", UsageDescriptions: []string{"NSBluetoothAlwaysUsageDescription"}},
+				{Package: "mobile_scanner", UsageDescriptions: []string{"NSCameraUsageDescription"}},
+				{Package: "record", UsageDescriptions: []string{"NSMicrophoneUsageDescription"}},
+				{Package: "speech_to_text", UsageDescriptions: []string{"NSMicrophoneUsageDescription", "NSSpeechRecognitionUsageDescription"}},
+			},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "NSBluetoothAlwaysUsageDescription" || findings[0].Line != 4 || !strings.Contains(findings[0].Message, "empty") {
+		t.Errorf("Expected the empty Bluetooth description on line 4, got %+v", findings[0])
+	}
+	if findings[1].Subject != "NSSpeechRecognitionUsageDescription" || !strings.Contains(findings[1].Message, "speech_to_text") {
+		t.Errorf("Expected the missing speech recognition description, got %+v", findings[1])
+	}
+}
+
+func TestUnusedUsageDescriptionCheck(t *testing.T) {
+	check := &UnusedUsageDescriptionCheck{}
+
+	values, err := plist.Decode([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>NSCameraUsageDescription</key>
+	<string>Scan QR codes</string>
+	<key>NSContactsUsageDescription</key>
+	<string>Invite friends</string>
+	<key>NSFaceIDUsageDescription</key>
+	<string>Unlock the app</string>
+</dict>
+</plist>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := &checker.Project{
+		Pubspec:   &checker.PubspecInfo{},
+		InfoPlist: &checker.InfoPlistInfo{Values: values},
+		PackageNeeds: &checker.PackageNeedsInfo{
+			Packages: []checker.PackageNeeds{
+				{Package: "mobile_scanner", UsageDescriptions: []string{"NSCameraUsageDescription"}},
+			},
+			Known: map[string]bool{"NSCameraUsageDescription": true, "NSContactsUsageDescription": true},
+		},
+	}
+
+	findings := check.Run(project)
+
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Subject != "NSContactsUsageDescription" || findings[0].Line != 6 || findings[0].Severity != report.SeverityInfo {
+		t.Errorf("Expected an INFO finding for NSContactsUsageDescription on line 6, got %+v", findings[0])
+	}
+
+	project.PackageNeeds.Packages = append(project.PackageNeeds.Packages, checker.PackageNeeds{Package: "permission_handler", Allows: []string{"*"}})
+	if findings := check.Run(project); len(findings) != 0 {
+		t.Errorf("Expected 0 findings with permission_handler, got %d", len(findings))
+	}
+}
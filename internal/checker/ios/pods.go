@@ -0,0 +1,251 @@
+package ios
+
+import (
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+type PodfilePlatformCheck struct{}
+
+func (c *PodfilePlatformCheck) ID() string {
+	return "IOS-013"
+}
+
+func (c *PodfilePlatformCheck) Name() string {
+	return "Podfile Platform Check"
+}
+
+func (c *PodfilePlatformCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the Podfile platform and the deployment targets its post_install hook assigns are not lower than the app's IPHONEOS_DEPLOYMENT_TARGET.",
+		Rationale:       "CocoaPods resolves and builds the pods for the Podfile's platform. When it is lower than the app's, pod install may pick releases the app was never tested with, and Xcode warns about every pod.",
+		Remediation:     "Set platform :ios in ios/Podfile, and any IPHONEOS_DEPLOYMENT_TARGET the post_install hook assigns, to the Runner deployment target, then run pod install.",
+	}
+}
+
+func (c *PodfilePlatformCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	pods := project.CocoaPods
+	target := project.Xcode.Setting("IPHONEOS_DEPLOYMENT_TARGET")
+	if pods == nil || pods.Podfile == "" || target == "" {
+		return findings
+	}
+
+	if pods.Platform != "" && compareVersions(pods.Platform, target) < 0 {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"The Podfile platform is iOS "+pods.Platform+", lower than the app's deployment target "+target,
+			pods.Podfile,
+			"Change the platform line to platform :ios, '"+target+"'",
+			report.SeverityWarning,
+			pods.PlatformLine,
+		).WithSubject("platform"))
+	}
+	for _, setting := range pods.DeploymentTargets {
+		if compareVersions(setting.Value, target) >= 0 {
+			continue
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"The Podfile sets IPHONEOS_DEPLOYMENT_TARGET of the pods to "+setting.Value+", lower than the app's deployment target "+target,
+			pods.Podfile,
+			"Assign '"+target+"', or delete the setting so the pods use the Podfile platform",
+			report.SeverityWarning,
+			setting.Line,
+		).WithSubject("IPHONEOS_DEPLOYMENT_TARGET"))
+	}
+
+	return findings
+}
+
+// privacyManifestPods maps pods on Apple's list of commonly used
+// third-party SDKs to their first release that ships a privacy manifest,
+// or to "" if none does.
+var privacyManifestPods = map[string]string{
+	"AFNetworking":            "",
+	"Alamofire":               "5.9.0",
+	"AppAuth":                 "1.7.0",
+	"FBAEMKit":                "17.0.0",
+	"FBSDKCoreKit":            "17.0.0",
+	"FBSDKCoreKit_Basics":     "17.0.0",
+	"FBSDKLoginKit":           "17.0.0",
+	"FBSDKShareKit":           "17.0.0",
+	"FirebaseABTesting":       "10.22.0",
+	"FirebaseAuth":            "10.22.0",
+	"FirebaseCore":            "10.22.0",
+	"FirebaseCoreDiagnostics": "",
+	"FirebaseCoreExtension":   "10.22.0",
+	"FirebaseCoreInternal":    "10.22.0",
+	"FirebaseCrashlytics":     "10.22.0",
+	"FirebaseDynamicLinks":    "10.22.0",
+	"FirebaseFirestore":       "10.22.0",
+	"FirebaseInstallations":   "10.22.0",
+	"FirebaseMessaging":       "10.22.0",
+	"FirebaseRemoteConfig":    "10.22.0",
+	"GTMAppAuth":              "4.1.0",
+	"GTMSessionFetcher":       "3.3.0",
+	"GoogleDataTransport":     "9.4.0",
+	"GoogleSignIn":            "7.1.0",
+	"GoogleUtilities":         "7.13.0",
+	"Kingfisher":              "7.10.0",
+	"MBProgressHUD":           "",
+	"PromisesObjC":            "2.4.0",
+	"PromisesSwift":           "2.4.0",
+	"SDWebImage":              "5.18.0",
+	"SnapKit":                 "5.7.0",
+	"SwiftyGif":               "5.4.5",
+	"SwiftyJSON":              "5.0.2",
+	"Toast":                   "4.1.0",
+	"lottie-ios":              "4.4.0",
+	"nanopb":                  "2.30910.0",
+}
+
+type PrivacyManifestPodsCheck struct{}
+
+func (c *PrivacyManifestPodsCheck) ID() string {
+	return "IOS-014"
+}
+
+func (c *PrivacyManifestPodsCheck) Name() string {
+	return "Pod Privacy Manifest Check"
+}
+
+func (c *PrivacyManifestPodsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that pods Apple lists as commonly used third-party SDKs are locked at a release that ships a privacy manifest.",
+		Rationale:       "App Store Connect rejects uploads that include one of these SDKs without its privacy manifest (ITMS-91061).",
+		Guideline:       "Apple Developer: Third-party SDK requirements",
+		Remediation:     "Upgrade the plugin that pulls the pod in, or run pod update for the pod, until Podfile.lock has a release with a privacy manifest.",
+	}
+}
+
+func (c *PrivacyManifestPodsCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	pods := project.CocoaPods
+	if pods == nil || pods.Lockfile == "" {
+		return findings
+	}
+
+	for _, pod := range pods.Pods {
+		fixed, ok := privacyManifestPods[pod.Name]
+		if !ok || pod.External != "" || fixed != "" && compareVersions(pod.Version, fixed) >= 0 {
+			continue
+		}
+		message := pod.Name + " " + pod.Version + " has no privacy manifest; " + fixed + " is the first release with one"
+		suggestion := "Update " + pod.Name + " to " + fixed + " or later"
+		if fixed == "" {
+			message = pod.Name + " " + pod.Version + " has no privacy manifest, and no release of it ships one"
+			suggestion = "Replace " + pod.Name + ", or the plugin that depends on it"
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			message,
+			pods.Lockfile,
+			suggestion,
+			report.SeverityHigh,
+			pod.Line,
+		).WithSubject(pod.Name))
+	}
+
+	return findings
+}
+
+// podIssue is a problem with the releases of a pod below Fixed.
+type podIssue struct {
+	Fixed    string
+	Problem  string
+	Severity report.Severity
+}
+
+// knownBadPods maps pods to problems with some of their releases.
+var knownBadPods = map[string]podIssue{
+	"AFNetworking": {
+		Fixed:    "4.0.0",
+		Problem:  "uses UIWebView, which App Store Connect rejects (ITMS-90809)",
+		Severity: report.SeverityHigh,
+	},
+	"BoringSSL-GRPC": {
+		Fixed:    "0.0.36",
+		Problem:  "does not build with Xcode 16 (unsupported option '-G')",
+		Severity: report.SeverityError,
+	},
+}
+
+// cocoaPodsIssue is the problem with the CocoaPods releases that wrote
+// Podfile.lock files below Fixed.
+var cocoaPodsIssue = podIssue{
+	Fixed:    "1.13.0",
+	Problem:  "generates projects that do not build with Xcode 15 (DT_TOOLCHAIN_DIR cannot be used to evaluate LIBRARY_SEARCH_PATHS)",
+	Severity: report.SeverityError,
+}
+
+type KnownBadPodVersionCheck struct{}
+
+func (c *KnownBadPodVersionCheck) ID() string {
+	return "IOS-015"
+}
+
+func (c *KnownBadPodVersionCheck) Name() string {
+	return "Known Bad Pod Version Check"
+}
+
+func (c *KnownBadPodVersionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks Podfile.lock for pod releases, and CocoaPods versions, with known build or review problems.",
+		Rationale:       "These releases fail to build with current Xcode versions or use APIs App Store Connect rejects.",
+		Remediation:     "Update the pod, or CocoaPods itself, to the release named in the finding and run pod install.",
+	}
+}
+
+func (c *KnownBadPodVersionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	pods := project.CocoaPods
+	if pods == nil || pods.Lockfile == "" {
+		return findings
+	}
+
+	for _, pod := range pods.Pods {
+		issue, ok := knownBadPods[pod.Name]
+		if !ok || compareVersions(pod.Version, issue.Fixed) >= 0 {
+			continue
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			pod.Name+" "+pod.Version+" "+issue.Problem,
+			pods.Lockfile,
+			"Update "+pod.Name+" to "+issue.Fixed+" or later",
+			issue.Severity,
+			pod.Line,
+		).WithSubject(pod.Name))
+	}
+
+	if v := pods.CocoaPodsVersion; v != "" && compareVersions(v, cocoaPodsIssue.Fixed) < 0 {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"CocoaPods "+v+" "+cocoaPodsIssue.Problem,
+			pods.Lockfile,
+			"Update CocoaPods to "+cocoaPodsIssue.Fixed+" or later and run pod install",
+			cocoaPodsIssue.Severity,
+			pods.CocoaPodsLine,
+		).WithSubject("CocoaPods"))
+	}
+
+	return findings
+}
@@ -1,6 +1,7 @@
 package ios
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -17,6 +18,18 @@ func (c *CameraUsageDescriptionCheck) Name() string {
 	return "Camera Usage Description Check"
 }
 
+func (c *CameraUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSCameraUsageDescription is set when camera packages are used.",
+		Rationale:       "iOS terminates the app the first time it accesses the camera without a purpose string, and App Review rejects the build.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSCameraUsageDescription to ios/Runner/Info.plist explaining what the camera is used for.",
+	}
+}
+
 func (c *CameraUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -33,7 +46,7 @@ func (c *CameraUsageDescriptionCheck) Run(project *checker.Project) []report.Fin
 			"Add NSCameraUsageDescription with a clear explanation of why camera access is needed",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSCameraUsageDescription"))
 	}
 
 	return findings
@@ -49,6 +62,18 @@ func (c *PhotoLibraryUsageDescriptionCheck) Name() string {
 	return "Photo Library Usage Description Check"
 }
 
+func (c *PhotoLibraryUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSPhotoLibraryUsageDescription is set when image_picker is used.",
+		Rationale:       "Accessing the photo library without a purpose string crashes the app and is rejected in review.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSPhotoLibraryUsageDescription to ios/Runner/Info.plist.",
+	}
+}
+
 func (c *PhotoLibraryUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -65,7 +90,7 @@ func (c *PhotoLibraryUsageDescriptionCheck) Run(project *checker.Project) []repo
 			"Add NSPhotoLibraryUsageDescription with a clear explanation",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSPhotoLibraryUsageDescription"))
 	}
 
 	return findings
@@ -81,6 +106,18 @@ func (c *LocationUsageDescriptionCheck) Name() string {
 	return "Location Usage Description Check"
 }
 
+func (c *LocationUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that a location usage description is set when location packages are used.",
+		Rationale:       "Location requests without NSLocationWhenInUseUsageDescription fail silently or crash, and App Review rejects vague or missing reasons.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSLocationWhenInUseUsageDescription (and NSLocationAlwaysAndWhenInUseUsageDescription for background use) to Info.plist.",
+	}
+}
+
 func (c *LocationUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
@@ -97,7 +134,7 @@ func (c *LocationUsageDescriptionCheck) Run(project *checker.Project) []report.F
 			"Add NSLocationWhenInUseUsageDescription with a clear explanation",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSLocationWhenInUseUsageDescription"))
 	}
 
 	return findings
@@ -113,18 +150,24 @@ func (c *MicrophoneUsageDescriptionCheck) Name() string {
 	return "Microphone Usage Description Check"
 }
 
+func (c *MicrophoneUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSMicrophoneUsageDescription is set when audio recording packages are used.",
+		Rationale:       "Recording without a purpose string crashes the app and is rejected in review.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSMicrophoneUsageDescription to ios/Runner/Info.plist.",
+	}
+}
+
 func (c *MicrophoneUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
-	hasMicDep := false
-	if project.Pubspec != nil {
-		for dep := range project.Pubspec.Dependencies {
-			lowerDep := strings.ToLower(dep)
-			if strings.Contains(lowerDep, "mic") || strings.Contains(lowerDep, "audio") || strings.Contains(lowerDep, "record") || strings.Contains(lowerDep, "sound") || strings.Contains(lowerDep, "voice") {
-				hasMicDep = true
-				break
-			}
-		}
+	hasMicDep := project.PackageNeeds.NeededBy("NSMicrophoneUsageDescription") != nil
+	if !hasMicDep {
+		hasMicDep = dependsOnNamed(project, "mic", "audio", "record", "sound", "voice")
 	}
 
 	if !hasMicDep {
@@ -140,12 +183,31 @@ func (c *MicrophoneUsageDescriptionCheck) Run(project *checker.Project) []report
 			"Add NSMicrophoneUsageDescription with a clear explanation",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSMicrophoneUsageDescription"))
 	}
 
 	return findings
 }
 
+// dependsOnNamed reports whether the name of one of the app's direct
+// dependencies contains one of words. Packages found only through other
+// packages are left to the knowledge base: names such as dynamic_color
+// say little about what a package pulled in several levels down does.
+func dependsOnNamed(project *checker.Project, words ...string) bool {
+	if project.Pubspec == nil {
+		return false
+	}
+	for dep := range project.Pubspec.Dependencies {
+		name := strings.ToLower(dep)
+		for _, word := range words {
+			if strings.Contains(name, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type ContactsUsageDescriptionCheck struct{}
 
 func (c *ContactsUsageDescriptionCheck) ID() string {
@@ -156,17 +218,24 @@ func (c *ContactsUsageDescriptionCheck) Name() string {
 	return "Contacts Usage Description Check"
 }
 
+func (c *ContactsUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSContactsUsageDescription is set when contacts packages are used.",
+		Rationale:       "Reading contacts without a purpose string crashes the app and is rejected in review.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSContactsUsageDescription to ios/Runner/Info.plist.",
+	}
+}
+
 func (c *ContactsUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
-	hasContactsDep := false
-	if project.Pubspec != nil {
-		for dep := range project.Pubspec.Dependencies {
-			if strings.Contains(strings.ToLower(dep), "contact") {
-				hasContactsDep = true
-				break
-			}
-		}
+	hasContactsDep := project.PackageNeeds.NeededBy("NSContactsUsageDescription") != nil
+	if !hasContactsDep {
+		hasContactsDep = dependsOnNamed(project, "contact")
 	}
 
 	if !hasContactsDep {
@@ -182,7 +251,7 @@ func (c *ContactsUsageDescriptionCheck) Run(project *checker.Project) []report.F
 			"Add NSContactsUsageDescription with a clear explanation",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSContactsUsageDescription"))
 	}
 
 	return findings
@@ -198,17 +267,24 @@ func (c *CalendarsUsageDescriptionCheck) Name() string {
 	return "Calendars Usage Description Check"
 }
 
+func (c *CalendarsUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that NSCalendarsUsageDescription is set when calendar packages are used.",
+		Rationale:       "Calendar access without a purpose string crashes the app and is rejected in review.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add NSCalendarsUsageDescription to ios/Runner/Info.plist.",
+	}
+}
+
 func (c *CalendarsUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
-	hasCalendarDep := false
-	if project.Pubspec != nil {
-		for dep := range project.Pubspec.Dependencies {
-			if strings.Contains(strings.ToLower(dep), "calendar") || strings.Contains(strings.ToLower(dep), "event") {
-				hasCalendarDep = true
-				break
-			}
-		}
+	hasCalendarDep := project.PackageNeeds.NeededBy("NSCalendarsUsageDescription") != nil
+	if !hasCalendarDep {
+		hasCalendarDep = dependsOnNamed(project, "calendar", "event")
 	}
 
 	if !hasCalendarDep {
@@ -224,7 +300,7 @@ func (c *CalendarsUsageDescriptionCheck) Run(project *checker.Project) []report.
 			"Add NSCalendarsUsageDescription with a clear explanation",
 			report.SeverityHigh,
 			0,
-		))
+		).WithSubject("NSCalendarsUsageDescription"))
 	}
 
 	return findings
@@ -240,8 +316,152 @@ func (c *EmptyUsageDescriptionCheck) Name() string {
 	return "Empty Usage Description Check"
 }
 
+func (c *EmptyUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityWarning,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that usage description strings in Info.plist are not empty.",
+		Rationale:       "App Review rejects permission prompts whose text does not explain why the data is needed.",
+		Guideline:       "App Review Guideline 5.1.1(ii) (Permission)",
+		Remediation:     "Write a specific, user-facing sentence for every NS*UsageDescription key.",
+	}
+}
+
 func (c *EmptyUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
 
 	return findings
 }
+
+// ownedUsageDescriptions are the keys IOS-001 to IOS-006 check, which
+// DependencyUsageDescriptionCheck leaves to them.
+var ownedUsageDescriptions = map[string]bool{
+	"NSCameraUsageDescription":            true,
+	"NSPhotoLibraryUsageDescription":      true,
+	"NSLocationWhenInUseUsageDescription": true,
+	"NSMicrophoneUsageDescription":        true,
+	"NSContactsUsageDescription":          true,
+	"NSCalendarsUsageDescription":         true,
+}
+
+type DependencyUsageDescriptionCheck struct{}
+
+func (c *DependencyUsageDescriptionCheck) ID() string {
+	return "IOS-023"
+}
+
+func (c *DependencyUsageDescriptionCheck) Name() string {
+	return "Dependency Usage Description Check"
+}
+
+func (c *DependencyUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that Info.plist sets every usage description the app's packages need, according to the dependency knowledge base.",
+		Rationale:       "iOS terminates the app the first time a package uses a protected API without its purpose string, such as Bluetooth, speech recognition or tracking, and App Review rejects the build.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Add each listed key to ios/Runner/Info.plist with a sentence explaining why the app needs the access.",
+	}
+}
+
+func (c *DependencyUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	needs := project.PackageNeeds.UsageDescriptionNeeds()
+	keys := make([]string, 0, len(needs))
+	for key := range needs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if ownedUsageDescriptions[key] {
+			continue
+		}
+		// IOS-017 reports this one for apps with the HealthKit entitlement.
+		if key == "NSHealthShareUsageDescription" && project.Entitlements != nil && project.Entitlements.HealthKit {
+			continue
+		}
+
+		packages := strings.Join(needs[key], ", ")
+		if project.InfoPlist == nil || !project.InfoPlist.Values.Has(key) {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				"App uses "+packages+", which needs "+key+", but it is not in Info.plist",
+				"ios/Runner/Info.plist",
+				"Add "+key+" with a clear explanation of why the app needs the access",
+				report.SeverityHigh,
+				0,
+			).WithSubject(key))
+			continue
+		}
+		if strings.TrimSpace(project.InfoPlist.Values.Get(key).String()) == "" {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				"App uses "+packages+", which needs "+key+", but its value in Info.plist is empty",
+				"ios/Runner/Info.plist",
+				"Write a specific, user-facing sentence for "+key,
+				report.SeverityHigh,
+				project.InfoPlist.Line(key),
+			).WithSubject(key))
+		}
+	}
+
+	return findings
+}
+
+type UnusedUsageDescriptionCheck struct{}
+
+func (c *UnusedUsageDescriptionCheck) ID() string {
+	return "IOS-024"
+}
+
+func (c *UnusedUsageDescriptionCheck) Name() string {
+	return "Unused Usage Description Check"
+}
+
+func (c *UnusedUsageDescriptionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityInfo,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Flags usage descriptions in Info.plist that none of the app's packages need, according to the dependency knowledge base.",
+		Rationale:       "App Review asks about access the app declares but never requests, and leftover purpose strings often outlive the package that needed them.",
+		Guideline:       "App Review Guideline 5.1.1 (Data Collection and Storage)",
+		Remediation:     "Remove the key if the app's own native code does not use the API either, or add the package that needs it with fsct kb update.",
+	}
+}
+
+func (c *UnusedUsageDescriptionCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	if project.InfoPlist == nil || project.Pubspec == nil || project.PackageNeeds == nil {
+		return findings
+	}
+	healthKit := project.Entitlements != nil && project.Entitlements.HealthKit
+
+	for _, key := range project.InfoPlist.Values.Keys() {
+		if !strings.HasSuffix(key, "UsageDescription") || !project.PackageNeeds.Known[key] {
+			continue
+		}
+		if project.PackageNeeds.Justified(key) || healthKit && strings.HasPrefix(key, "NSHealth") {
+			continue
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"Info.plist sets "+key+", but none of the app's packages need it",
+			"ios/Runner/Info.plist",
+			"Remove "+key+" unless the app's own native code uses the API",
+			report.SeverityInfo,
+			project.InfoPlist.Line(key),
+		).WithSubject(key))
+	}
+
+	return findings
+}
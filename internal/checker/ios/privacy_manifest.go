@@ -0,0 +1,333 @@
+package ios
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// requiredReasonAPI is a category of APIs that apps and SDKs may only use
+// for one of the reasons Apple approves, declared in a privacy manifest.
+type requiredReasonAPI struct {
+	Category string
+	// Reasons are the approved reason codes.
+	Reasons []string
+	// Pattern matches uses of the APIs in Swift and Objective-C.
+	Pattern *regexp.Regexp
+}
+
+var requiredReasonAPIs = []requiredReasonAPI{
+	{
+		Category: "NSPrivacyAccessedAPICategoryFileTimestamp",
+		Reasons:  []string{"DDA9.1", "C617.1", "3B52.1", "0A2A.1"},
+		Pattern:  regexp.MustCompile(`\b(?:NSFile(?:Creation|Modification)Date|NSURL(?:Creation|ContentModification|AttributeModification|ContentAccess)DateKey|(?:creation|contentModification|attributeModification|contentAccess)DateKey)\b|FileAttributeKey\.(?:creation|modification)Date\b|\[\s*\.(?:creation|modification)Date\s*\]|\b(?:[fl]?stat|fstatat|f?getattrlist(?:bulk|at)?)\s*\(`),
+	},
+	{
+		Category: "NSPrivacyAccessedAPICategorySystemBootTime",
+		Reasons:  []string{"35F9.1", "8FFB.1", "3D61.1"},
+		Pattern:  regexp.MustCompile(`\bsystemUptime\b|\bmach_absolute_time\s*\(`),
+	},
+	{
+		Category: "NSPrivacyAccessedAPICategoryDiskSpace",
+		Reasons:  []string{"85F4.1", "E174.1", "7D9E.1", "B728.1"},
+		Pattern:  regexp.MustCompile(`\b(?:NSURLVolume(?:Available|Total)Capacity\w*Key|volume(?:Available|Total)Capacity\w*Key|NSFileSystem(?:Free)?Size)\b|FileAttributeKey\.system(?:Free)?Size\b|\[\s*\.system(?:Free)?Size\s*\]|\bf?statv?fs\s*\(`),
+	},
+	{
+		Category: "NSPrivacyAccessedAPICategoryActiveKeyboards",
+		Reasons:  []string{"3EC4.1", "54BD.1"},
+		Pattern:  regexp.MustCompile(`\bactiveInputModes\b`),
+	},
+	{
+		Category: "NSPrivacyAccessedAPICategoryUserDefaults",
+		Reasons:  []string{"CA92.1", "1C8F.1", "C56D.1", "AC6B.1"},
+		Pattern:  regexp.MustCompile(`\b(?:NS)?UserDefaults\b`),
+	},
+}
+
+// findRequiredReasonAPI returns the category named category, or nil.
+func findRequiredReasonAPI(category string) *requiredReasonAPI {
+	for i := range requiredReasonAPIs {
+		if requiredReasonAPIs[i].Category == category {
+			return &requiredReasonAPIs[i]
+		}
+	}
+	return nil
+}
+
+// shortCategory strips the NSPrivacyAccessedAPICategory prefix, leaving a
+// name such as UserDefaults.
+func shortCategory(category string) string {
+	return strings.TrimPrefix(category, "NSPrivacyAccessedAPICategory")
+}
+
+type PrivacyManifestReasonsCheck struct{}
+
+func (c *PrivacyManifestReasonsCheck) ID() string {
+	return "IOS-020"
+}
+
+func (c *PrivacyManifestReasonsCheck) Name() string {
+	return "Privacy Manifest Reasons Check"
+}
+
+func (c *PrivacyManifestReasonsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the app's PrivacyInfo.xcprivacy is copied into the app and that each NSPrivacyAccessedAPITypes entry names a required reason API category with approved reasons.",
+		Rationale:       "App Store Connect only reads the privacy manifest in the app bundle, and treats unknown categories and reason codes as undeclared.",
+		Guideline:       "Apple Developer: Describing use of required reason API",
+		Remediation:     "Add PrivacyInfo.xcprivacy to the Runner target, and give each accessed API type one of the reason codes Apple lists for its category.",
+	}
+}
+
+func (c *PrivacyManifestReasonsCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	manifest := project.PrivacyManifest
+	if manifest == nil || manifest.File == "" {
+		return findings
+	}
+
+	if !manifest.Bundled {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			manifest.File+" is not in the Runner target, so it is not copied into the app",
+			project.Xcode.Path(),
+			"Add "+manifest.File+" to the Runner target in Xcode, under Copy Bundle Resources",
+			report.SeverityHigh,
+			0,
+		).WithSubject(manifest.File))
+	}
+
+	for _, entry := range manifest.AccessedAPIs {
+		api := findRequiredReasonAPI(entry.Type)
+		if api == nil {
+			message := entry.Type + " is not a required reason API category"
+			if entry.Type == "" {
+				message = "An NSPrivacyAccessedAPITypes entry has no NSPrivacyAccessedAPIType"
+			}
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				message,
+				manifest.File,
+				"Use one of the NSPrivacyAccessedAPICategory values Apple documents, such as NSPrivacyAccessedAPICategoryUserDefaults",
+				report.SeverityHigh,
+				entry.Line,
+			).WithSubject(entry.Type))
+			continue
+		}
+		if len(entry.Reasons) == 0 {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				entry.Type+" has no NSPrivacyAccessedAPITypeReasons",
+				manifest.File,
+				"Add the reason the app uses "+shortCategory(entry.Type)+" APIs, one of "+strings.Join(api.Reasons, ", "),
+				report.SeverityHigh,
+				entry.Line,
+			).WithSubject(entry.Type))
+			continue
+		}
+		for _, reason := range entry.Reasons {
+			if contains(api.Reasons, reason) {
+				continue
+			}
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				reason+" is not an approved reason for "+entry.Type,
+				manifest.File,
+				"Use one of "+strings.Join(api.Reasons, ", "),
+				report.SeverityHigh,
+				entry.Line,
+			).WithSubject(entry.Type))
+		}
+	}
+
+	return findings
+}
+
+// pluginAPIs maps plugins to the required reason API categories their iOS
+// code uses. Only plugins that ship without a privacy manifest of their
+// own, in older releases, make the app declare them.
+var pluginAPIs = map[string][]string{
+	"disk_space":                    {"NSPrivacyAccessedAPICategoryDiskSpace"},
+	"disk_space_plus":               {"NSPrivacyAccessedAPICategoryDiskSpace"},
+	"file_picker":                   {"NSPrivacyAccessedAPICategoryFileTimestamp"},
+	"shared_preferences":            {"NSPrivacyAccessedAPICategoryUserDefaults"},
+	"shared_preferences_foundation": {"NSPrivacyAccessedAPICategoryUserDefaults"},
+	"shared_preferences_ios":        {"NSPrivacyAccessedAPICategoryUserDefaults"},
+}
+
+// apiUse is where the app first uses a category of required reason APIs.
+type apiUse struct {
+	// What describes the use, such as UserDefaults or the
+	// shared_preferences_ios plugin.
+	What string
+	File string
+	Line int
+}
+
+type RequiredReasonAPICheck struct{}
+
+func (c *RequiredReasonAPICheck) ID() string {
+	return "IOS-021"
+}
+
+func (c *RequiredReasonAPICheck) Name() string {
+	return "Required Reason API Check"
+}
+
+func (c *RequiredReasonAPICheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that the app's privacy manifest declares each category of required reason APIs, such as UserDefaults, that the Swift and Objective-C code in ios/Runner, or a plugin without a manifest of its own, uses.",
+		Rationale:       "App Store Connect rejects uploads that use required reason APIs without declaring an approved reason in a privacy manifest (ITMS-91053).",
+		Guideline:       "Apple Developer: Describing use of required reason API",
+		Remediation:     "Add an NSPrivacyAccessedAPITypes entry for the category, with the reason the app uses it, to ios/Runner/PrivacyInfo.xcprivacy, or upgrade the plugin to a release with its own privacy manifest.",
+	}
+}
+
+func (c *RequiredReasonAPICheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	uses := make(map[string]apiUse)
+	for _, api := range requiredReasonAPIs {
+		if match, ok := project.IOSSources.FindFirst(api.Pattern); ok {
+			uses[api.Category] = apiUse{What: match.Text, File: match.File, Line: match.Line}
+		}
+	}
+	for _, plugin := range project.IOSPlugins {
+		if !plugin.Located || plugin.PrivacyManifest != "" {
+			continue
+		}
+		for _, category := range pluginAPIs[plugin.Name] {
+			if _, ok := uses[category]; !ok {
+				file, line := pluginLocation(project, plugin.Name)
+				uses[category] = apiUse{What: "the " + plugin.Name + " plugin", File: file, Line: line}
+			}
+		}
+	}
+
+	manifest := project.PrivacyManifest
+	file := "ios/Runner/PrivacyInfo.xcprivacy"
+	if manifest != nil && manifest.File != "" {
+		file = manifest.File
+	}
+	for _, api := range requiredReasonAPIs {
+		use, ok := uses[api.Category]
+		if !ok || manifest.Declares(api.Category) {
+			continue
+		}
+		message := use.What + " uses " + shortCategory(api.Category) + " required reason APIs, but the app has no privacy manifest"
+		if manifest != nil && manifest.File != "" {
+			message = use.What + " uses " + shortCategory(api.Category) + " required reason APIs, but " + file + " does not declare " + api.Category
+		}
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			message,
+			use.File,
+			"Declare "+api.Category+" in "+file+" with one of the reasons "+strings.Join(api.Reasons, ", "),
+			report.SeverityHigh,
+			use.Line,
+		).WithSubject(api.Category))
+	}
+
+	return findings
+}
+
+// privacyManifestPlugins are the Flutter plugins on Apple's list of
+// commonly used third-party SDKs, which must ship a privacy manifest.
+var privacyManifestPlugins = map[string]bool{
+	"connectivity_plus":           true,
+	"device_info_plus":            true,
+	"file_picker":                 true,
+	"flutter_inappwebview":        true,
+	"flutter_local_notifications": true,
+	"fluttertoast":                true,
+	"geolocator_apple":            true,
+	"image_picker_ios":            true,
+	"package_info":                true,
+	"package_info_plus":           true,
+	"path_provider":               true,
+	"path_provider_ios":           true,
+	"share_plus":                  true,
+	"shared_preferences_ios":      true,
+	"sqflite":                     true,
+	"url_launcher":                true,
+	"url_launcher_ios":            true,
+	"video_player_avfoundation":   true,
+	"wakelock":                    true,
+	"webview_flutter_wkwebview":   true,
+}
+
+type PluginPrivacyManifestCheck struct{}
+
+func (c *PluginPrivacyManifestCheck) ID() string {
+	return "IOS-022"
+}
+
+func (c *PluginPrivacyManifestCheck) Name() string {
+	return "Plugin Privacy Manifest Check"
+}
+
+func (c *PluginPrivacyManifestCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryIOS,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformIOS},
+		Description:     "Checks that Flutter plugins Apple lists as commonly used third-party SDKs ship a privacy manifest in their iOS code.",
+		Rationale:       "App Store Connect rejects uploads that include one of these SDKs without its privacy manifest (ITMS-91061). Discontinued plugins, such as wakelock and package_info, never received one.",
+		Guideline:       "Apple Developer: Third-party SDK requirements",
+		Remediation:     "Upgrade the plugin, or the package that depends on it, to a release with a privacy manifest, or move to its maintained replacement, such as wakelock_plus for wakelock.",
+	}
+}
+
+func (c *PluginPrivacyManifestCheck) Run(project *checker.Project) []report.Finding {
+	var findings []report.Finding
+
+	for _, plugin := range project.IOSPlugins {
+		if !privacyManifestPlugins[plugin.Name] || !plugin.Located || plugin.PrivacyManifest != "" {
+			continue
+		}
+		file, line := pluginLocation(project, plugin.Name)
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"The "+plugin.Name+" plugin is on Apple's list of commonly used SDKs but the installed release has no privacy manifest",
+			file,
+			"Upgrade "+plugin.Name+" to a release that ships PrivacyInfo.xcprivacy",
+			report.SeverityHigh,
+			line,
+		).WithSubject(plugin.Name))
+	}
+
+	return findings
+}
+
+// pluginLocation returns where the app pulls in the plugin named name: its
+// entry in Podfile.lock, or else pubspec.yaml.
+func pluginLocation(project *checker.Project, name string) (string, int) {
+	if pod := project.CocoaPods.Pod(name); pod != nil {
+		return project.CocoaPods.Lockfile, pod.Line
+	}
+	return "pubspec.yaml", 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package ios
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&CameraUsageDescriptionCheck{},
+		&PhotoLibraryUsageDescriptionCheck{},
+		&LocationUsageDescriptionCheck{},
+		&MicrophoneUsageDescriptionCheck{},
+		&ContactsUsageDescriptionCheck{},
+		&CalendarsUsageDescriptionCheck{},
+		&EmptyUsageDescriptionCheck{},
+		&MissingAppIconCheck{},
+		&Missing1024IconCheck{},
+		&FullScreenConflictCheck{},
+		&EncryptionDeclarationCheck{},
+		&DeploymentTargetCheck{},
+		&PodfilePlatformCheck{},
+		&PrivacyManifestPodsCheck{},
+		&KnownBadPodVersionCheck{},
+		&PushEnvironmentCheck{},
+		&HealthKitUsageDescriptionCheck{},
+		&AssociatedDomainsCheck{},
+		&PushEntitlementCheck{},
+		&PrivacyManifestReasonsCheck{},
+		&RequiredReasonAPICheck{},
+		&PluginPrivacyManifestCheck{},
+		&DependencyUsageDescriptionCheck{},
+		&UnusedUsageDescriptionCheck{},
+	)
+}
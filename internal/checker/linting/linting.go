@@ -8,6 +8,26 @@ import (
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
+// analysisOptions returns the contents of the project's analysis options
+// file, if it has one.
+func analysisOptions(project *checker.Project) (string, bool) {
+	_, content, ok := project.RootFile("analysis_options.yaml", "analysis_options.yml")
+	return content, ok
+}
+
+// lintPackagePattern matches an include of a published lint set, which
+// enables the core naming and style rules.
+var lintPackagePattern = regexp.MustCompile(`(?m)^include:\s*package:(?:flutter_lints|lints|very_good_analysis)/`)
+
+func containsAny(content string, terms ...string) bool {
+	for _, term := range terms {
+		if strings.Contains(content, term) {
+			return true
+		}
+	}
+	return false
+}
+
 type AnalysisOptionsCheck struct{}
 
 func (c *AnalysisOptionsCheck) ID() string {
@@ -18,18 +38,20 @@ func (c *AnalysisOptionsCheck) Name() string {
 	return "Analysis Options File Presence"
 }
 
+func (c *AnalysisOptionsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that analysis_options.yaml exists.",
+		Rationale:       "Without it the analyzer runs with default settings and lint rules are not enforced.",
+		Remediation:     "Add analysis_options.yaml that includes package:flutter_lints/flutter.yaml.",
+	}
+}
+
 func (c *AnalysisOptionsCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasAnalysisOptions := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options.yaml") || strings.Contains(file, "analysis_options.yml") {
-			hasAnalysisOptions = true
-			break
-		}
-	}
-
-	if !hasAnalysisOptions {
+	if _, ok := analysisOptions(project); !ok {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -54,20 +76,21 @@ func (c *LinterRulesCheck) Name() string {
 	return "Linter Rules Configuration"
 }
 
+func (c *LinterRulesCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that analysis_options.yaml configures linter rules.",
+		Rationale:       "Lint rules catch common mistakes before review.",
+		Remediation:     "Add a linter: rules: section or include a lint package.",
+	}
+}
+
 func (c *LinterRulesCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasLinterRules := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options") {
-			if strings.Contains(file, "linter:") || strings.Contains(file, "rules:") {
-				hasLinterRules = true
-				break
-			}
-		}
-	}
-
-	if !hasLinterRules {
+	content, _ := analysisOptions(project)
+	if !lintPackagePattern.MatchString(content) && !containsAny(content, "linter:", "rules:") {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -92,26 +115,27 @@ func (c *StrongModeCheck) Name() string {
 	return "Strong Mode Analysis"
 }
 
+func (c *StrongModeCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that strict analyzer modes are configured.",
+		Rationale:       "Strict casts and inference surface type errors at analysis time.",
+		Remediation:     "Enable analyzer: language: strict-casts and strict-inference.",
+	}
+}
+
 func (c *StrongModeCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasStrongMode := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options") {
-			if strings.Contains(file, "strong-mode") || strings.Contains(file, "implicit-casts") {
-				hasStrongMode = true
-				break
-			}
-		}
-	}
-
-	if !hasStrongMode {
+	content, _ := analysisOptions(project)
+	if !containsAny(content, "strict-casts", "strict-inference", "strict-raw-types", "strong-mode", "implicit-casts") {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"Strong mode analysis not explicitly configured",
+			"Strict analyzer modes not configured",
 			"analysis_options.yaml",
-			"Enable strong mode for better type safety",
+			"Enable strict-casts and strict-inference under analyzer: language: for better type safety",
 			report.SeverityInfo,
 			0,
 		))
@@ -130,20 +154,21 @@ func (c *FileNamingCheck) Name() string {
 	return "File Naming Rules"
 }
 
+func (c *FileNamingCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that file and type naming lint rules are enabled.",
+		Rationale:       "Naming rules keep the codebase consistent with Effective Dart.",
+		Remediation:     "Enable file_names and camel_case_types.",
+	}
+}
+
 func (c *FileNamingCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasFileNamingRules := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options") {
-			if strings.Contains(file, "file_names") || strings.Contains(file, "camel_case_types") {
-				hasFileNamingRules = true
-				break
-			}
-		}
-	}
-
-	if !hasFileNamingRules {
+	content, _ := analysisOptions(project)
+	if !lintPackagePattern.MatchString(content) && !containsAny(content, "file_names", "camel_case_types") {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -168,24 +193,21 @@ func (c *StyleGuideCheck) Name() string {
 	return "Style Guide Rules"
 }
 
+func (c *StyleGuideCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that common style lint rules are enabled.",
+		Rationale:       "Style rules such as prefer_const_constructors also improve performance.",
+		Remediation:     "Enable prefer_const_constructors and related style rules.",
+	}
+}
+
 func (c *StyleGuideCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	styleRules := []string{"lines_longer_than_80_chars", "avoid_as", "prefer_const_constructors"}
-	hasStyleRules := false
-
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options") {
-			for _, rule := range styleRules {
-				if strings.Contains(file, rule) {
-					hasStyleRules = true
-					break
-				}
-			}
-		}
-	}
-
-	if !hasStyleRules {
+	content, _ := analysisOptions(project)
+	if !containsAny(content, "lines_longer_than_80_chars", "avoid_as", "prefer_const_constructors") {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -210,20 +232,21 @@ func (c *PublicAPIDocCheck) Name() string {
 	return "Public API Documentation Rules"
 }
 
+func (c *PublicAPIDocCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks that documentation lint rules are enabled.",
+		Rationale:       "Documentation rules keep public APIs explained.",
+		Remediation:     "Enable public_member_api_docs for packages with a public API.",
+	}
+}
+
 func (c *PublicAPIDocCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasDocRules := false
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "analysis_options") {
-			if strings.Contains(file, "public_member_api_docs") || strings.Contains(file, "comment_references") {
-				hasDocRules = true
-				break
-			}
-		}
-	}
-
-	if !hasDocRules {
+	content, _ := analysisOptions(project)
+	if !containsAny(content, "public_member_api_docs", "comment_references") {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -248,15 +271,21 @@ func (c *IgnoreCommentsCheck) Name() string {
 	return "Ignore Comments Configuration"
 }
 
+func (c *IgnoreCommentsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryLinting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags projects with more than 10 // ignore: comments.",
+		Rationale:       "Widespread ignore comments hide real analyzer problems.",
+		Remediation:     "Fix the underlying lint violations or disable the rule project-wide with a reason.",
+	}
+}
+
 func (c *IgnoreCommentsCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	ignoreCount := 0
 	ignorePattern := regexp.MustCompile(`//\s*ignore:`)
-
-	for _, file := range project.DartFiles {
-		ignoreCount += len(ignorePattern.FindAllString(file, -1))
-	}
+	ignoreCount := len(project.Sources.FindAll(ignorePattern))
 
 	if ignoreCount > 10 {
 		findings = append(findings, project.AddFinding(
@@ -208,3 +208,26 @@ func TestIgnoreCommentsCheck_Run(t *testing.T) {
 		}
 	})
 }
+
+func TestAnalysisOptions_RootFiles(t *testing.T) {
+	project := &checker.Project{
+		RootFiles: map[string]string{
+			"analysis_options.yaml": "include: package:flutter_lints/flutter.yaml\n\nanalyzer:\n  language:\n    strict-casts: true\n",
+		},
+	}
+
+	for _, c := range []checker.Check{
+		&AnalysisOptionsCheck{},
+		&LinterRulesCheck{},
+		&StrongModeCheck{},
+		&FileNamingCheck{},
+	} {
+		if results := c.Run(project); len(results) != 0 {
+			t.Errorf("%s: expected 0 findings, got %d", c.ID(), len(results))
+		}
+	}
+
+	if results := (&PublicAPIDocCheck{}).Run(project); len(results) != 1 {
+		t.Errorf("expected public_member_api_docs to be reported missing, got %d findings", len(results))
+	}
+}
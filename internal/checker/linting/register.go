@@ -0,0 +1,15 @@
+package linting
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&AnalysisOptionsCheck{},
+		&LinterRulesCheck{},
+		&StrongModeCheck{},
+		&FileNamingCheck{},
+		&StyleGuideCheck{},
+		&PublicAPIDocCheck{},
+		&IgnoreCommentsCheck{},
+	)
+}
@@ -0,0 +1,143 @@
+package checker
+
+// AndroidManifestInfo summarises the app manifest merged with the
+// manifests of Flutter plugins. Line fields give the line in
+// android/app/src/main/AndroidManifest.xml, or 0 for elements a plugin
+// added.
+type AndroidManifestInfo struct {
+	PackageName string
+	VersionCode string
+	VersionName string
+	Debuggable  bool
+	AllowBackup bool
+	// UsesCleartextTraffic is set by android:usesCleartextTraffic="true".
+	UsesCleartextTraffic  bool
+	NetworkSecurityConfig string
+	ApplicationLine       int
+
+	Permissions     []string
+	UsesPermissions []PermissionInfo
+	Features        []FeatureInfo
+	Activities      []ActivityInfo
+	Services        []ComponentInfo
+	Receivers       []ComponentInfo
+	Providers       []ComponentInfo
+	// MetaData maps the names of <application> meta-data to their value
+	// or resource.
+	MetaData        map[string]string
+	QueriesPackages []string
+}
+
+// Permission returns the <uses-permission> named name, or nil.
+func (m *AndroidManifestInfo) Permission(name string) *PermissionInfo {
+	for i := range m.UsesPermissions {
+		if m.UsesPermissions[i].Name == name {
+			return &m.UsesPermissions[i]
+		}
+	}
+	return nil
+}
+
+// HasFeature reports whether a <uses-feature> declares name.
+func (m *AndroidManifestInfo) HasFeature(name string) bool {
+	for _, f := range m.Features {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type PermissionInfo struct {
+	Name string
+	// MaxSDKVersion is 0 when the permission applies to all API levels.
+	MaxSDKVersion int
+	// SDK23 is set for <uses-permission-sdk-23>.
+	SDK23 bool
+	Line  int
+	// Origin is the Flutter plugin that added the permission, or "" if the
+	// app manifest declares it.
+	Origin string
+}
+
+// AppLine returns the line of the permission in the app manifest, or 0 if
+// a plugin added it, since Line is then a line of the plugin's manifest.
+func (p PermissionInfo) AppLine() int {
+	if p.Origin != "" {
+		return 0
+	}
+	return p.Line
+}
+
+type FeatureInfo struct {
+	Name     string
+	Required bool
+	Line     int
+	// Origin is the Flutter plugin that added the feature, or "" if the app
+	// manifest declares it.
+	Origin string
+}
+
+// AppLine returns the line of the feature in the app manifest, or 0 if a
+// plugin added it, since Line is then a line of the plugin's manifest.
+func (f FeatureInfo) AppLine() int {
+	if f.Origin != "" {
+		return 0
+	}
+	return f.Line
+}
+
+// ComponentInfo is an activity, service, broadcast receiver or content
+// provider.
+type ComponentInfo struct {
+	// Kind is the manifest element, such as activity or receiver.
+	Kind string
+	Name string
+	// Exported is set by android:exported="true", and ExportedSet when
+	// android:exported is declared at all.
+	Exported        bool
+	ExportedSet     bool
+	HasIntentFilter bool
+	// Permission is the android:permission callers must hold.
+	Permission            string
+	ForegroundServiceType string
+	DeepLinks             []DeepLinkInfo
+	Line                  int
+	// Origin is the Flutter plugin that declares the component, or "" if
+	// the app manifest does.
+	Origin string
+}
+
+// AppLine returns the line of the component in the app manifest, or 0 if
+// a plugin declares it, since Line is then a line of the plugin's manifest.
+func (c ComponentInfo) AppLine() int {
+	if c.Origin != "" {
+		return 0
+	}
+	return c.Line
+}
+
+type ActivityInfo = ComponentInfo
+
+// DeepLinkInfo is a browsable VIEW intent filter, such as
+// https://example.com/orders.
+type DeepLinkInfo struct {
+	URL string
+	// AutoVerify is set for Android App Links, which are verified against
+	// the domain's assetlinks.json.
+	AutoVerify bool
+	Line       int
+	// Origin is the Flutter plugin that declares the intent filter, or "" if
+	// the app manifest does.
+	Origin string
+}
+
+// AppLine returns the line of the intent filter in the app manifest, or 0
+// if a plugin declares it, since Line is then a line of the plugin's
+// manifest.
+func (d DeepLinkInfo) AppLine() int {
+	if d.Origin != "" {
+		return 0
+	}
+	return d.Line
+}
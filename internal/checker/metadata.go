@@ -0,0 +1,154 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+type Category string
+
+const (
+	CategoryAndroid       Category = "Android"
+	CategoryIOS           Category = "iOS"
+	CategoryFlutter       Category = "Flutter"
+	CategorySecurity      Category = "Security"
+	CategoryPolicy        Category = "Policy"
+	CategoryCodeQuality   Category = "Code Quality"
+	CategoryTesting       Category = "Testing"
+	CategoryLinting       Category = "Linting"
+	CategoryDocumentation Category = "Documentation"
+	CategoryPerformance   Category = "Performance"
+	CategoryReviewer      Category = "Reviewer"
+	CategoryAI            Category = "AI Analysis"
+	CategoryOther         Category = "Other"
+)
+
+// categoryOrder is the order categories are presented in.
+var categoryOrder = []Category{
+	CategoryAndroid,
+	CategoryIOS,
+	CategoryFlutter,
+	CategorySecurity,
+	CategoryPolicy,
+	CategoryCodeQuality,
+	CategoryTesting,
+	CategoryLinting,
+	CategoryDocumentation,
+	CategoryPerformance,
+	CategoryReviewer,
+	CategoryAI,
+	CategoryOther,
+}
+
+// ParseCategory resolves a category name such as "Code Quality" or an ID
+// prefix such as "COD", both case-insensitive.
+func ParseCategory(s string) (Category, bool) {
+	s = strings.TrimSpace(s)
+	for _, category := range categoryOrder {
+		if strings.EqualFold(string(category), s) {
+			return category, true
+		}
+	}
+	category, ok := categoryPrefixes[strings.ToUpper(strings.TrimSuffix(s, "-"))]
+	return category, ok
+}
+
+// Categories returns every category in presentation order.
+func Categories() []Category {
+	return append([]Category(nil), categoryOrder...)
+}
+
+// Platforms a check can apply to. A check with no platforms applies to both.
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+)
+
+var categoryPrefixes = map[string]Category{
+	"AND":  CategoryAndroid,
+	"IOS":  CategoryIOS,
+	"FLT":  CategoryFlutter,
+	"SEC":  CategorySecurity,
+	"POL":  CategoryPolicy,
+	"COD":  CategoryCodeQuality,
+	"TST":  CategoryTesting,
+	"LINT": CategoryLinting,
+	"DOC":  CategoryDocumentation,
+	"PERF": CategoryPerformance,
+	"REV":  CategoryReviewer,
+	"AI":   CategoryAI,
+}
+
+// CategoryForID returns the category implied by a check ID prefix such as
+// "AND-" or "PERF-", or CategoryOther when the prefix is unknown.
+func CategoryForID(id string) Category {
+	prefix, _, ok := strings.Cut(strings.ToUpper(id), "-")
+	if !ok {
+		return CategoryOther
+	}
+	if category, ok := categoryPrefixes[prefix]; ok {
+		return category
+	}
+	return CategoryOther
+}
+
+// Metadata describes a check for listings, explanations and report rule
+// descriptors. Guideline cites the store policy the check enforces, e.g.
+// "App Review Guideline 5.1.1".
+type Metadata struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Category        Category        `json:"category"`
+	DefaultSeverity report.Severity `json:"default_severity"`
+	Platforms       []string        `json:"platforms,omitempty"`
+	Description     string          `json:"description"`
+	Rationale       string          `json:"rationale,omitempty"`
+	Guideline       string          `json:"guideline,omitempty"`
+	Remediation     string          `json:"remediation,omitempty"`
+}
+
+// Describer is implemented by checks that provide Metadata. ID and Name may
+// be left empty; Describe fills them from the check.
+type Describer interface {
+	Metadata() Metadata
+}
+
+// Describe returns the metadata for c, falling back to values derived from
+// its ID and name when c does not implement Describer.
+func Describe(c Check) Metadata {
+	var meta Metadata
+	if d, ok := c.(Describer); ok {
+		meta = d.Metadata()
+	}
+	if meta.ID == "" {
+		meta.ID = c.ID()
+	}
+	if meta.Name == "" {
+		meta.Name = c.Name()
+	}
+	if meta.Category == "" {
+		meta.Category = CategoryForID(meta.ID)
+	}
+	if meta.DefaultSeverity == "" {
+		meta.DefaultSeverity = report.SeverityWarning
+	}
+	if meta.Description == "" {
+		meta.Description = meta.Name
+	}
+	return meta
+}
+
+// AppliesTo reports whether the check runs for platform ("android", "ios"
+// or "both").
+func (m Metadata) AppliesTo(platform string) bool {
+	if len(m.Platforms) == 0 || platform == "" || platform == "both" {
+		return true
+	}
+	for _, p := range m.Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
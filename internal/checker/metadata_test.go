@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+type plainCheck struct{}
+
+func (c *plainCheck) ID() string                            { return "PERF-009" }
+func (c *plainCheck) Name() string                          { return "Plain Check" }
+func (c *plainCheck) Run(project *Project) []report.Finding { return nil }
+
+type describedCheck struct{ plainCheck }
+
+func (c *describedCheck) Metadata() Metadata {
+	return Metadata{
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{PlatformIOS},
+		Description:     "Described",
+	}
+}
+
+func TestCategoryForID(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected Category
+	}{
+		{"AND-001", CategoryAndroid},
+		{"IOS-002", CategoryIOS},
+		{"FLT-003", CategoryFlutter},
+		{"SEC-004", CategorySecurity},
+		{"POL-005", CategoryPolicy},
+		{"COD-006", CategoryCodeQuality},
+		{"TST-007", CategoryTesting},
+		{"LINT-008", CategoryLinting},
+		{"DOC-009", CategoryDocumentation},
+		{"PERF-010", CategoryPerformance},
+		{"REV-011", CategoryReviewer},
+		{"AI-012", CategoryAI},
+		{"and-001", CategoryAndroid},
+		{"LOAD-001", CategoryOther},
+		{"UNKNOWN", CategoryOther},
+		{"", CategoryOther},
+	}
+
+	for _, tt := range tests {
+		if got := CategoryForID(tt.id); got != tt.expected {
+			t.Errorf("CategoryForID(%q) = %q, want %q", tt.id, got, tt.expected)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("fallback", func(t *testing.T) {
+		meta := Describe(&plainCheck{})
+		if meta.ID != "PERF-009" || meta.Name != "Plain Check" {
+			t.Errorf("expected ID and name from check, got %s %s", meta.ID, meta.Name)
+		}
+		if meta.Category != CategoryPerformance {
+			t.Errorf("expected category from ID, got %s", meta.Category)
+		}
+		if meta.DefaultSeverity != report.SeverityWarning {
+			t.Errorf("expected default WARNING severity, got %s", meta.DefaultSeverity)
+		}
+		if meta.Description != "Plain Check" {
+			t.Errorf("expected name as description, got %q", meta.Description)
+		}
+	})
+
+	t.Run("describer", func(t *testing.T) {
+		meta := Describe(&describedCheck{})
+		if meta.ID != "PERF-009" || meta.Category != CategoryPerformance {
+			t.Errorf("expected missing fields to be filled, got %+v", meta)
+		}
+		if meta.DefaultSeverity != report.SeverityHigh || meta.Description != "Described" {
+			t.Errorf("expected metadata from check, got %+v", meta)
+		}
+	})
+}
+
+func TestMetadataAppliesTo(t *testing.T) {
+	all := Metadata{}
+	ios := Metadata{Platforms: []string{PlatformIOS}}
+
+	if !all.AppliesTo(PlatformAndroid) || !all.AppliesTo(PlatformIOS) {
+		t.Error("expected metadata without platforms to apply everywhere")
+	}
+	if ios.AppliesTo(PlatformAndroid) {
+		t.Error("expected ios check not to apply to android")
+	}
+	if !ios.AppliesTo(PlatformIOS) || !ios.AppliesTo("both") {
+		t.Error("expected ios check to apply to ios and both")
+	}
+}
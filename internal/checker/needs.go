@@ -0,0 +1,73 @@
+package checker
+
+import "strings"
+
+// PackageNeedsInfo is what the packages built into the app need it to
+// declare, according to the dependency knowledge base.
+type PackageNeedsInfo struct {
+	// Version is the version of the knowledge base.
+	Version string
+	// Packages are the app's packages that have an entry, by name.
+	Packages []PackageNeeds
+	// Known holds every Info.plist key and Android permission the
+	// knowledge base names. Whether the app needs others is unknown.
+	Known map[string]bool
+	// Sensitive are the Android permissions an app should only declare
+	// when one of its packages needs them.
+	Sensitive map[string]bool
+}
+
+// PackageNeeds is the knowledge base entry of a package.
+type PackageNeeds struct {
+	Package string
+	// UsageDescriptions are the Info.plist keys the app must set.
+	UsageDescriptions []string
+	// Permissions are the Android permissions the app must declare
+	// itself. An entry may list alternatives separated by |.
+	Permissions []string
+	// Injects are the Android permissions the plugin's manifest adds.
+	Injects []string
+	// Allows are keys and permissions the package justifies without
+	// requiring them. A trailing * matches any suffix.
+	Allows []string
+}
+
+// UsageDescriptionNeeds maps each Info.plist key the app's packages need
+// to the packages that need it, in name order.
+func (p *PackageNeedsInfo) UsageDescriptionNeeds() map[string][]string {
+	needs := make(map[string][]string)
+	if p == nil {
+		return needs
+	}
+	for _, pkg := range p.Packages {
+		for _, key := range pkg.UsageDescriptions {
+			needs[key] = append(needs[key], pkg.Package)
+		}
+	}
+	return needs
+}
+
+// NeededBy returns the packages that need the Info.plist key, or nil.
+func (p *PackageNeedsInfo) NeededBy(key string) []string {
+	return p.UsageDescriptionNeeds()[key]
+}
+
+// Justified reports whether one of the app's packages needs, adds or
+// allows the Info.plist key or Android permission name.
+func (p *PackageNeedsInfo) Justified(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, pkg := range p.Packages {
+		for _, names := range [][]string{pkg.UsageDescriptions, pkg.Permissions, pkg.Injects, pkg.Allows} {
+			for _, entry := range names {
+				for _, pattern := range strings.Split(entry, "|") {
+					if pattern == name || strings.HasSuffix(pattern, "*") && strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
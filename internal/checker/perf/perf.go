@@ -18,21 +18,31 @@ func (c *ConstConstructorCheck) Name() string {
 	return "Const Constructor Usage"
 }
 
+func (c *ConstConstructorCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Suggests const constructors for immutable widgets.",
+		Rationale:       "Const widgets are canonicalized and skipped during rebuilds.",
+		Remediation:     "Add const to widget constructors and constructor calls where possible.",
+	}
+}
+
 func (c *ConstConstructorCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	constructorPattern := regexp.MustCompile(`(?i)class\s+\w+\s*\{[^}]*const\s+\w+\(`)
 
-	for _, file := range project.DartFiles {
-		if constructorPattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if match, ok := file.FindFirst(constructorPattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
 				"Consider using const constructors for immutable widgets",
-				file,
+				file.Path,
 				"Add 'const' keyword to constructors for better performance",
 				report.SeverityInfo,
-				0,
+				match.Line,
 			))
 		}
 	}
@@ -50,21 +60,34 @@ func (c *BuildOptimizationCheck) Name() string {
 	return "Build Method Optimization"
 }
 
+func (c *BuildOptimizationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags synchronous heavy operations such as JSON decoding or file reads in files without async code.",
+		Rationale:       "Blocking work on the UI isolate causes dropped frames.",
+		Remediation:     "Move heavy work out of build methods into async code or an isolate with compute().",
+	}
+}
+
 func (c *BuildOptimizationCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	heavyPattern := regexp.MustCompile(`(?i)(JSON\.decode|HttpClient|File\.read|Database\.query)`)
 
-	for _, file := range project.DartFiles {
-		if heavyPattern.MatchString(file) && !strings.Contains(file, "async") {
+	for _, file := range project.Sources.Files() {
+		if strings.Contains(file.Content, "async") {
+			continue
+		}
+		if match, ok := file.FindFirst(heavyPattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
 				"Heavy operations detected in file",
-				file,
+				file.Path,
 				"Move heavy operations out of build method, use async/await",
 				report.SeverityWarning,
-				0,
+				match.Line,
 			))
 		}
 	}
@@ -82,26 +105,39 @@ func (c *ListBuilderCheck) Name() string {
 	return "List Builder Usage"
 }
 
+func (c *ListBuilderCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Flags children lists built with .map or for loops.",
+		Rationale:       "Building every child up front is slow for long lists; builders create children lazily.",
+		Remediation:     "Use ListView.builder or another builder constructor for dynamic lists.",
+	}
+}
+
 func (c *ListBuilderCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	mapPattern := regexp.MustCompile(`\.map\s*\(\s*\w+\s*=>\s*[A-Z]`)
 	forPattern := regexp.MustCompile(`(?i)for\s*\(`)
 
-	for _, file := range project.DartFiles {
-		childrenPattern := regexp.MustCompile(`children:\s*\[`)
-		if childrenPattern.MatchString(file) {
-			if mapPattern.MatchString(file) || forPattern.MatchString(file) {
-				findings = append(findings, project.AddFinding(
-					c.ID(),
-					c.Name(),
-					"Consider using ListView.builder for dynamic lists",
-					file,
-					"Use ListView.builder instead of .map/.for for better performance",
-					report.SeverityInfo,
-					0,
-				))
-			}
+	childrenPattern := regexp.MustCompile(`children:\s*\[`)
+
+	for _, file := range project.Sources.Files() {
+		match, ok := file.FindFirst(childrenPattern)
+		if !ok {
+			continue
+		}
+		if file.Contains(mapPattern) || file.Contains(forPattern) {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				"Consider using ListView.builder for dynamic lists",
+				file.Path,
+				"Use ListView.builder instead of .map/.for for better performance",
+				report.SeverityInfo,
+				match.Line,
+			))
 		}
 	}
 
@@ -118,22 +154,35 @@ func (c *ImageOptimizationCheck) Name() string {
 	return "Image Caching"
 }
 
+func (c *ImageOptimizationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Flags image loading without caching or precaching.",
+		Rationale:       "Network images reloaded on every build waste bandwidth and cause flicker.",
+		Remediation:     "Use cached_network_image or precacheImage for frequently shown images.",
+	}
+}
+
 func (c *ImageOptimizationCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	imagePattern := regexp.MustCompile(`(?i)Image\.(asset|network|file)`)
 	cachePattern := regexp.MustCompile(`(?i)precacheImage|CacheManager`)
 
-	for _, file := range project.DartFiles {
-		if imagePattern.MatchString(file) && !cachePattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if file.Contains(cachePattern) {
+			continue
+		}
+		if match, ok := file.FindFirst(imagePattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
 				"Image loading without explicit caching",
-				file,
+				file.Path,
 				"Consider using cached_network_image or precaching",
 				report.SeverityInfo,
-				0,
+				match.Line,
 			))
 		}
 	}
@@ -151,6 +200,16 @@ func (c *StateManagementCheck) Name() string {
 	return "State Management Optimization"
 }
 
+func (c *StateManagementCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags files with heavy setState usage when no state management library is used.",
+		Rationale:       "Frequent setState calls rebuild large subtrees.",
+		Remediation:     "Adopt Provider, Riverpod or BLoC for shared or complex state.",
+	}
+}
+
 func (c *StateManagementCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -159,21 +218,21 @@ func (c *StateManagementCheck) Run(project *checker.Project) []report.Finding {
 	riverpodPattern := regexp.MustCompile(`(?i)Riverpod|useProvider|StateProvider`)
 	blocPattern := regexp.MustCompile(`(?i)Bloc|BlocProvider|BlocBuilder`)
 
-	hasStateManagement := providerPattern.MatchString(strings.Join(project.DartFiles, " ")) ||
-		riverpodPattern.MatchString(strings.Join(project.DartFiles, " ")) ||
-		blocPattern.MatchString(strings.Join(project.DartFiles, " "))
+	hasStateManagement := project.Sources.Contains(providerPattern) ||
+		project.Sources.Contains(riverpodPattern) ||
+		project.Sources.Contains(blocPattern)
 
-	for _, file := range project.DartFiles {
-		setStateCount := len(setStatePattern.FindAllString(file, -1))
-		if setStateCount > 15 && !hasStateManagement {
+	for _, file := range project.Sources.Files() {
+		matches := file.FindAll(setStatePattern)
+		if len(matches) > 15 && !hasStateManagement {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
 				"Excessive setState usage detected",
-				file,
+				file.Path,
 				"Consider using Provider, Riverpod, or BLoc for complex state",
 				report.SeverityWarning,
-				0,
+				matches[0].Line,
 			))
 		}
 	}
@@ -181,6 +240,16 @@ func (c *StateManagementCheck) Run(project *checker.Project) []report.Finding {
 	return findings
 }
 
+// heavyPackages are common packages with a large footprint in the app
+// binary.
+var heavyPackages = map[string]bool{
+	"firebase_auth":      true,
+	"cloud_firestore":    true,
+	"dio":                true,
+	"http":               true,
+	"shared_preferences": true,
+}
+
 type DependencyOptimizationCheck struct{}
 
 func (c *DependencyOptimizationCheck) ID() string {
@@ -191,28 +260,27 @@ func (c *DependencyOptimizationCheck) Name() string {
 	return "Dependency Optimization"
 }
 
+func (c *DependencyOptimizationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPerformance,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Reports larger projects that build in none of the common heavy dependencies, directly or through other packages.",
+		Rationale:       "This is a sanity check that dependency detection is working for the project.",
+		Remediation:     "No action is needed if the app is intentionally lightweight.",
+	}
+}
+
 func (c *DependencyOptimizationCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	heavyPatterns := []string{
-		`(?i)firebase_?auth`,
-		`(?i)cloud_?firestore`,
-		`(?i)dio|http`,
-		`(?i)shared_?preferences`,
-	}
-
 	foundHeavy := []string{}
-	for _, pattern := range heavyPatterns {
-		re := regexp.MustCompile(pattern)
-		for _, file := range project.DartFiles {
-			if re.MatchString(file) {
-				foundHeavy = append(foundHeavy, pattern)
-				break
-			}
+	for _, name := range project.Pubspec.AppPackages() {
+		if heavyPackages[name] {
+			foundHeavy = append(foundHeavy, name)
 		}
 	}
 
-	if len(foundHeavy) == 0 && len(project.DartFiles) > 5 {
+	if len(foundHeavy) == 0 && project.Sources.Len() > 5 {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
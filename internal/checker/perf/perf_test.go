@@ -1,6 +1,7 @@
 package perf
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -178,4 +179,29 @@ func TestDependencyOptimizationCheck_Run(t *testing.T) {
 			t.Error("expected non-nil results")
 		}
 	})
+
+	t.Run("counts packages pulled in by other packages", func(t *testing.T) {
+		sources := checker.NewSourceIndex()
+		for i := 0; i < 6; i++ {
+			sources.Add(fmt.Sprintf("lib/file%d.dart", i), "void main() {}\n")
+		}
+		project := &checker.Project{
+			Sources: sources,
+			Pubspec: &checker.PubspecInfo{
+				Dependencies: map[string]string{"google_fonts": "^6.0.0"},
+			},
+		}
+		if results := c.Run(project); len(results) != 1 {
+			t.Errorf("expected 1 finding without heavy packages, got %d", len(results))
+		}
+
+		project.Pubspec.Resolved = []checker.ResolvedPackage{
+			{Name: "google_fonts", Dependency: "direct main"},
+			{Name: "http", Dependency: "transitive"},
+		}
+		project.Pubspec.Requires = map[string][]string{"google_fonts": {"http"}}
+		if results := c.Run(project); len(results) != 0 {
+			t.Errorf("expected a transitive http package to count, got %d findings", len(results))
+		}
+	})
 }
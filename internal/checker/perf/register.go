@@ -0,0 +1,14 @@
+package perf
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&ConstConstructorCheck{},
+		&BuildOptimizationCheck{},
+		&ListBuilderCheck{},
+		&ImageOptimizationCheck{},
+		&StateManagementCheck{},
+		&DependencyOptimizationCheck{},
+	)
+}
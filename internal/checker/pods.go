@@ -0,0 +1,70 @@
+package checker
+
+import "sort"
+
+// CocoaPodsInfo summarises ios/Podfile and ios/Podfile.lock, which hold
+// the native iOS dependencies.
+type CocoaPodsInfo struct {
+	// Podfile and Lockfile are relative to the project root, or "" if the
+	// file is missing.
+	Podfile  string
+	Lockfile string
+
+	// Platform is the version of the Podfile's platform :ios line, or "".
+	Platform     string
+	PlatformLine int
+	// DeploymentTargets are the IPHONEOS_DEPLOYMENT_TARGET values the
+	// Podfile's post_install hook assigns to the pods.
+	DeploymentTargets []PodfileSetting
+
+	// Pods lists the pods in Podfile.lock by name.
+	Pods []PodInfo
+	// CocoaPodsVersion is the version of CocoaPods that wrote Lockfile.
+	CocoaPodsVersion string
+	CocoaPodsLine    int
+}
+
+// PodfileSetting is a value the Podfile assigns, and its line.
+type PodfileSetting struct {
+	Value string
+	Line  int
+}
+
+// PodInfo is a pod installed from Podfile.lock.
+type PodInfo struct {
+	Name    string
+	Version string
+	// External is the path or repository a pod from an external source
+	// was installed from, such as a Flutter plugin's
+	// .symlinks/plugins/camera_avfoundation/ios, or "" for a pod from a
+	// spec repo.
+	External string
+	Line     int
+}
+
+// Pod returns the installed pod named name, or nil.
+func (c *CocoaPodsInfo) Pod(name string) *PodInfo {
+	if c == nil {
+		return nil
+	}
+	i := sort.Search(len(c.Pods), func(i int) bool { return c.Pods[i].Name >= name })
+	if i < len(c.Pods) && c.Pods[i].Name == name {
+		return &c.Pods[i]
+	}
+	return nil
+}
+
+// NativeSDKs returns the names of the installed pods that come from a spec
+// repo: the native SDKs, leaving out Flutter and the plugins themselves.
+func (c *CocoaPodsInfo) NativeSDKs() []string {
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for _, pod := range c.Pods {
+		if pod.External == "" {
+			names = append(names, pod.Name)
+		}
+	}
+	return names
+}
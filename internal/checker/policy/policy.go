@@ -8,8 +8,8 @@ import (
 )
 
 var (
-	privacyPattern   = regexp.MustCompile(`(?i)(privacy|policy)[^\s]*\.?(com|org|net|io)`)
-	tosPattern       = regexp.MustCompile(`(?i)(terms|service|conditions)[^\s]*\.?(com|org|net|io)`)
+	privacyPattern   = regexp.MustCompile(`(?i)https?://[^\s'"]*privacy[^\s'"]*`)
+	tosPattern       = regexp.MustCompile(`(?i)https?://[^\s'"]*(terms|tos|conditions)[^\s'"]*`)
 	deletionPatterns = []*regexp.Regexp{regexp.MustCompile(`(?i)delete.*data`), regexp.MustCompile(`(?i)data.*deletion`), regexp.MustCompile(`(?i)remove.*account`), regexp.MustCompile(`(?i)gdpr`), regexp.MustCompile(`(?i)ccpa`)}
 	logoutPatterns   = []*regexp.Regexp{regexp.MustCompile(`(?i)signOut`), regexp.MustCompile(`(?i)logout`), regexp.MustCompile(`(?i)sign_?out`), regexp.MustCompile(`(?i)log_?out`)}
 	recoveryPatterns = []*regexp.Regexp{regexp.MustCompile(`(?i)resetPassword`), regexp.MustCompile(`(?i)forgotPassword`), regexp.MustCompile(`(?i)recoverAccount`), regexp.MustCompile(`(?i)passwordReset`)}
@@ -25,19 +25,21 @@ func (c *PrivacyPolicyCheck) Name() string {
 	return "Privacy Policy URL"
 }
 
+func (c *PrivacyPolicyCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPolicy,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks that the app links to a privacy policy.",
+		Rationale:       "Both stores require a privacy policy reachable from inside the app for apps that collect any user data.",
+		Guideline:       "App Review Guideline 5.1.1(i); Google Play User Data policy",
+		Remediation:     "Add a privacy policy link to your settings or onboarding screen and to the store listing.",
+	}
+}
+
 func (c *PrivacyPolicyCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	foundPrivacyURL := false
-
-	for _, file := range project.DartFiles {
-		if privacyPattern.MatchString(file) {
-			foundPrivacyURL = true
-			break
-		}
-	}
-
-	if !foundPrivacyURL {
+	if !project.Sources.App().Contains(privacyPattern) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -62,19 +64,21 @@ func (c *TermsOfServiceCheck) Name() string {
 	return "Terms of Service URL"
 }
 
+func (c *TermsOfServiceCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPolicy,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that the app links to terms of service.",
+		Rationale:       "Apps with accounts or subscriptions are expected to present terms of use.",
+		Guideline:       "App Review Guideline 3.1.2 (Subscriptions)",
+		Remediation:     "Add a terms of service link next to the privacy policy.",
+	}
+}
+
 func (c *TermsOfServiceCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	foundTOSURL := false
-
-	for _, file := range project.DartFiles {
-		if tosPattern.MatchString(file) {
-			foundTOSURL = true
-			break
-		}
-	}
-
-	if !foundTOSURL {
+	if !project.Sources.App().Contains(tosPattern) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -99,21 +103,21 @@ func (c *DataDeletionCheck) Name() string {
 	return "Data Deletion Contact"
 }
 
+func (c *DataDeletionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPolicy,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks that apps with login provide a way to delete the account and its data.",
+		Rationale:       "Both stores require in-app account deletion for apps that support account creation.",
+		Guideline:       "App Review Guideline 5.1.1(v); Google Play account deletion requirements",
+		Remediation:     "Add a delete-account flow in the app and a web deletion link in the Play Console data safety form.",
+	}
+}
+
 func (c *DataDeletionCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasDeletionContact := false
-
-	for _, file := range project.DartFiles {
-		for _, re := range deletionPatterns {
-			if re.MatchString(file) {
-				hasDeletionContact = true
-				break
-			}
-		}
-	}
-
-	if !hasDeletionContact {
+	if !containsAny(project.Sources.App(), deletionPatterns) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -138,21 +142,20 @@ func (c *LogoutCheck) Name() string {
 	return "Logout Functionality"
 }
 
+func (c *LogoutCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPolicy,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that apps with login offer a way to sign out.",
+		Rationale:       "Reviewers test account switching, and users expect to remove their session from a shared device.",
+		Remediation:     "Add a logout action that clears stored tokens.",
+	}
+}
+
 func (c *LogoutCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasLogout := false
-
-	for _, file := range project.DartFiles {
-		for _, re := range logoutPatterns {
-			if re.MatchString(file) {
-				hasLogout = true
-				break
-			}
-		}
-	}
-
-	if !hasLogout {
+	if !containsAny(project.Sources.App(), logoutPatterns) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -177,21 +180,20 @@ func (c *AccountRecoveryCheck) Name() string {
 	return "Account Recovery Options"
 }
 
+func (c *AccountRecoveryCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryPolicy,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that apps with password login offer password reset.",
+		Rationale:       "Users who cannot recover their account contact support or leave negative reviews, and reviewers may test the flow.",
+		Remediation:     "Add a forgot-password flow, or rely on a sign-in provider that offers recovery.",
+	}
+}
+
 func (c *AccountRecoveryCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	hasRecovery := false
-
-	for _, file := range project.DartFiles {
-		for _, re := range recoveryPatterns {
-			if re.MatchString(file) {
-				hasRecovery = true
-				break
-			}
-		}
-	}
-
-	if !hasRecovery {
+	if !containsAny(project.Sources.App(), recoveryPatterns) {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
@@ -205,3 +207,12 @@ func (c *AccountRecoveryCheck) Run(project *checker.Project) []report.Finding {
 
 	return findings
 }
+
+func containsAny(sources *checker.SourceIndex, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if sources.Contains(re) {
+			return true
+		}
+	}
+	return false
+}
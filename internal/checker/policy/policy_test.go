@@ -42,6 +42,47 @@ func TestPrivacyPolicyCheck_Run(t *testing.T) {
 	})
 }
 
+func TestPolicyChecks_ReadSourceContents(t *testing.T) {
+	sources := checker.NewSourceIndex()
+	sources.Add("lib/settings.dart", `
+const privacyUrl = 'https://example.com/privacy-policy';
+const termsUrl = 'https://example.com/terms';
+Future<void> deleteAccount() => api.delete('/me/data');
+Future<void> logout() => auth.signOut();
+Future<void> resetPassword(String email) => auth.sendPasswordResetEmail(email: email);
+`)
+	project := &checker.Project{DartFiles: []string{"lib/settings.dart"}, Sources: sources}
+
+	checks := []checker.Check{
+		&PrivacyPolicyCheck{},
+		&TermsOfServiceCheck{},
+		&DataDeletionCheck{},
+		&LogoutCheck{},
+		&AccountRecoveryCheck{},
+	}
+	for _, c := range checks {
+		if results := c.Run(project); len(results) != 0 {
+			t.Errorf("%s: expected 0 findings, got %d", c.ID(), len(results))
+		}
+	}
+}
+
+func TestPolicyChecks_IgnoreTestFiles(t *testing.T) {
+	sources := checker.NewSourceIndex()
+	sources.Add("lib/main.dart", "void main() => runApp(const App());\n")
+	sources.Add("test/fixtures.dart", "const privacyUrl = 'https://example.com/privacy-policy';\n")
+	project := &checker.Project{Sources: sources}
+
+	if results := (&PrivacyPolicyCheck{}).Run(project); len(results) != 1 {
+		t.Errorf("expected a privacy policy URL in test/ not to count, got %d findings", len(results))
+	}
+
+	sources.Add("lib/settings.dart", "const privacyUrl = 'https://example.com/privacy-policy';\n")
+	if results := (&PrivacyPolicyCheck{}).Run(project); len(results) != 0 {
+		t.Errorf("expected a privacy policy URL in lib/ to count, got %d findings", len(results))
+	}
+}
+
 func TestTermsOfServiceCheck_ID(t *testing.T) {
 	c := &TermsOfServiceCheck{}
 	if c.ID() != "POL-002" {
@@ -0,0 +1,13 @@
+package policy
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&PrivacyPolicyCheck{},
+		&TermsOfServiceCheck{},
+		&DataDeletionCheck{},
+		&LogoutCheck{},
+		&AccountRecoveryCheck{},
+	)
+}
@@ -0,0 +1,45 @@
+package checker
+
+import "github.com/ricky-irfandi/fsct/internal/plist"
+
+// PrivacyManifestInfo summarises the app's privacy manifest, usually
+// ios/Runner/PrivacyInfo.xcprivacy. Values is the whole decoded property
+// list.
+type PrivacyManifestInfo struct {
+	// File is relative to the project root, or "" if the app has no
+	// privacy manifest.
+	File   string
+	Values *plist.Value
+	// Bundled reports whether the app target copies File into the app.
+	// It is also set when there is no Xcode project to tell.
+	Bundled bool
+
+	Tracking        bool
+	TrackingDomains []string
+	AccessedAPIs    []AccessedAPIInfo
+}
+
+// AccessedAPIInfo is an entry of NSPrivacyAccessedAPITypes, declaring why
+// the app uses a category of required reason APIs.
+type AccessedAPIInfo struct {
+	// Type is the category, such as
+	// NSPrivacyAccessedAPICategoryUserDefaults.
+	Type string
+	// Reasons are reason codes, such as CA92.1.
+	Reasons []string
+	Line    int
+}
+
+// Declares reports whether the manifest has an entry for the required
+// reason API category, such as NSPrivacyAccessedAPICategoryUserDefaults.
+func (m *PrivacyManifestInfo) Declares(category string) bool {
+	if m == nil || m.File == "" {
+		return false
+	}
+	for _, api := range m.AccessedAPIs {
+		if api.Type == category {
+			return true
+		}
+	}
+	return false
+}
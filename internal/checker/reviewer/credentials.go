@@ -29,6 +29,18 @@ func (c *NoCredentialsCheck) Name() string {
 	return "No Reviewer Credentials Configured"
 }
 
+// Metadata describes the check
+func (c *NoCredentialsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryReviewer,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that a demo account for app reviewers is configured.",
+		Rationale:       "Apps with login are rejected when reviewers cannot sign in.",
+		Guideline:       "App Review Guideline 2.1 (App Completeness); Google Play App access declaration",
+		Remediation:     "Configure reviewer credentials in .fsct.yaml or the REVIEWER_EMAIL and REVIEWER_PASSWORD environment variables.",
+	}
+}
+
 // Run executes the check
 func (c *NoCredentialsCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
@@ -107,6 +119,18 @@ func (c *PlaceholderEmailCheck) Name() string {
 	return "Placeholder Reviewer Email Detected"
 }
 
+// Metadata describes the check
+func (c *PlaceholderEmailCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryReviewer,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks that the reviewer email is not a placeholder such as test@example.com.",
+		Rationale:       "Placeholder accounts do not exist, so the reviewer cannot sign in.",
+		Guideline:       "App Review Guideline 2.1 (App Completeness)",
+		Remediation:     "Create a real dedicated reviewer account and use its email.",
+	}
+}
+
 // Run executes the check
 func (c *PlaceholderEmailCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
@@ -181,6 +205,17 @@ func (c *WeakPasswordCheck) Name() string {
 	return "Weak Reviewer Password Detected"
 }
 
+// Metadata describes the check
+func (c *WeakPasswordCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryReviewer,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Checks that the reviewer password is not short or trivially guessable.",
+		Rationale:       "Reviewer accounts are shared outside your team and must not be easy to take over.",
+		Remediation:     "Use a generated password of at least 12 characters.",
+	}
+}
+
 // Run executes the check
 func (c *WeakPasswordCheck) Run(project *checker.Project) []report.Finding {
 	var findings []report.Finding
@@ -307,33 +342,5 @@ func LoadConfigFromFile(path string) *config.ReviewerConfig {
 
 // GetConfigFromEnv loads reviewer config from environment variables
 func GetConfigFromEnv() *config.ReviewerConfig {
-	cfg := &config.ReviewerConfig{}
-
-	// Try to get from common env vars
-	if email := os.Getenv("REVIEWER_EMAIL"); email != "" {
-		cfg.Email = email
-	}
-	if password := os.Getenv("REVIEWER_PASSWORD"); password != "" {
-		cfg.Password = password
-	}
-
-	// If not set directly, check for env var names
-	if cfg.Email == "" && os.Getenv("REVIEWER_EMAIL_ENV") != "" {
-		cfg.EmailEnv = os.Getenv("REVIEWER_EMAIL_ENV")
-		cfg.Email = os.Getenv(cfg.EmailEnv)
-	}
-	if cfg.Password == "" && os.Getenv("REVIEWER_PASSWORD_ENV") != "" {
-		cfg.PasswordEnv = os.Getenv("REVIEWER_PASSWORD_ENV")
-		cfg.Password = os.Getenv(cfg.PasswordEnv)
-	}
-
-	// Default to standard env var names if nothing else
-	if cfg.Email == "" && cfg.EmailEnv == "" {
-		cfg.EmailEnv = "REVIEWER_EMAIL"
-	}
-	if cfg.Password == "" && cfg.PasswordEnv == "" {
-		cfg.PasswordEnv = "REVIEWER_PASSWORD"
-	}
-
-	return cfg
+	return config.ReviewerConfigFromEnv()
 }
@@ -0,0 +1,40 @@
+package reviewer
+
+import (
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/registry"
+)
+
+// Reviewer checks load only when reviewer credentials are configured; login
+// verification additionally needs verification enabled.
+func init() {
+	registry.RegisterFactory(NewNoCredentialsCheck(nil), func(env registry.Environment) checker.Check {
+		if env.Reviewer == nil {
+			return nil
+		}
+		return NewNoCredentialsCheck(env.Reviewer)
+	})
+	registry.RegisterFactory(NewPlaceholderEmailCheck(nil), func(env registry.Environment) checker.Check {
+		if env.Reviewer == nil {
+			return nil
+		}
+		return NewPlaceholderEmailCheck(env.Reviewer)
+	})
+	registry.RegisterFactory(NewWeakPasswordCheck(nil), func(env registry.Environment) checker.Check {
+		if env.Reviewer == nil {
+			return nil
+		}
+		return NewWeakPasswordCheck(env.Reviewer)
+	})
+	registry.RegisterFactory(NewLoginVerificationCheck(nil), func(env registry.Environment) checker.Check {
+		if !verificationEnabled(env.Reviewer) {
+			return nil
+		}
+		return NewLoginVerificationCheck(env.Reviewer)
+	})
+}
+
+func verificationEnabled(cfg *config.ReviewerConfig) bool {
+	return cfg != nil && cfg.Verification != nil && cfg.Verification.Enabled
+}
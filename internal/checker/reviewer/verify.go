@@ -35,13 +35,31 @@ func (c *LoginVerificationCheck) Name() string {
 	return "Reviewer Login Verification"
 }
 
+// Metadata describes the check
+func (c *LoginVerificationCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryReviewer,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Signs in with the reviewer credentials against the configured auth endpoint.",
+		Rationale:       "Expired or wrong credentials are one of the most common causes of review rejection.",
+		Guideline:       "App Review Guideline 2.1 (App Completeness)",
+		Remediation:     "Fix the credentials or endpoint configuration until the login succeeds.",
+	}
+}
+
 // Run executes the check
 func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding {
+	findings, _ := c.RunContext(context.Background(), project)
+	return findings
+}
+
+// RunContext executes the check, aborting the login request when ctx is done
+func (c *LoginVerificationCheck) RunContext(ctx context.Context, project *checker.Project) ([]report.Finding, error) {
 	var findings []report.Finding
 
 	// Skip if verification is not enabled or not configured
 	if c.config == nil || c.config.Verification == nil || !c.config.Verification.Enabled {
-		return findings
+		return findings, nil
 	}
 
 	// Get credentials
@@ -56,13 +74,16 @@ func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding
 			Message:  "Login verification is enabled but credentials are missing.",
 			Suggestion: "Set REVIEWER_EMAIL and REVIEWER_PASSWORD environment variables.",
 		})
-		return findings
+		return findings, nil
 	}
 
 	// Perform login verification
-	result := c.verifyLogin(email, password)
+	result := c.verifyLogin(ctx, email, password)
 
 	if result.Error != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// Network or system error (REV-005)
 		findings = append(findings, report.Finding{
 			ID:       "REV-005",
@@ -72,7 +93,7 @@ func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding
 			Suggestion: "Check the auth endpoint URL and network connectivity. " +
 				"Ensure the endpoint is accessible from this environment.",
 		})
-		return findings
+		return findings, nil
 	}
 
 	if !result.Success {
@@ -85,7 +106,7 @@ func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding
 			Suggestion: "Verify the email and password are correct. " +
 				"Try logging in manually to confirm the credentials work.",
 		})
-		return findings
+		return findings, nil
 	}
 
 	if result.TokenExpired {
@@ -98,7 +119,7 @@ func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding
 			Suggestion: "Generate fresh credentials for app reviewers. " +
 				"Update the REVIEWER_EMAIL and REVIEWER_PASSWORD environment variables.",
 		})
-		return findings
+		return findings, nil
 	}
 
 	// Login successful
@@ -110,7 +131,7 @@ func (c *LoginVerificationCheck) Run(project *checker.Project) []report.Finding
 		Suggestion: "The test account is ready for app reviewers.",
 	})
 
-	return findings
+	return findings, nil
 }
 
 // VerificationResult holds the result of a login verification
@@ -122,7 +143,7 @@ type VerificationResult struct {
 }
 
 // verifyLogin attempts to verify login credentials
-func (c *LoginVerificationCheck) verifyLogin(email, password string) *VerificationResult {
+func (c *LoginVerificationCheck) verifyLogin(ctx context.Context, email, password string) *VerificationResult {
 	cfg := c.config.Verification
 
 	// Build request body
@@ -143,7 +164,7 @@ func (c *LoginVerificationCheck) verifyLogin(email, password string) *Verificati
 		method = "POST"
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, method, cfg.AuthEndpoint, bytes.NewBufferString(body))
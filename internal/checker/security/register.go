@@ -0,0 +1,13 @@
+package security
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&HardcodedCredentialsCheck{},
+		&DebugModeCheck{},
+		&InsecureHTTPCheck{},
+		&ExportedActivityCheck{},
+		&SQLInjectionCheck{},
+	)
+}
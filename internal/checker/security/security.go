@@ -15,6 +15,10 @@ var (
 		regexp.MustCompile(`(?i)password['"]?\s*[:=]\s*['"]?[^'"]{8,}['"]?`),
 		regexp.MustCompile(`(?i)auth_?token['"]?\s*[:=]\s*['"]?[a-zA-Z0-9_\-\.]{20,}['"]?`),
 	}
+
+	debugPrintPattern = regexp.MustCompile(`\b(?:print|debugPrint)\(`)
+	httpURLPattern    = regexp.MustCompile(`(?i)http://[^\s"'<>]+`)
+	rawQueryPattern   = regexp.MustCompile(`(?i)rawQuery\s*\(\s*["']\s*(?:SELECT|INSERT|UPDATE|DELETE).*["']\s*\)`)
 )
 
 type HardcodedCredentialsCheck struct{}
@@ -27,20 +31,36 @@ func (c *HardcodedCredentialsCheck) Name() string {
 	return "Hardcoded Credentials Detection"
 }
 
+func (c *HardcodedCredentialsCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategorySecurity,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Scans Dart sources for API keys, secrets, tokens and passwords assigned to string literals.",
+		Rationale:       "Anything compiled into the app can be extracted from the binary, so embedded credentials must be treated as public.",
+		Guideline:       "OWASP MASVS-STORAGE / MASVS-CRYPTO",
+		Remediation:     "Move secrets to a backend, or inject non-secret configuration at build time with --dart-define; rotate any key that was committed.",
+	}
+}
+
 func (c *HardcodedCredentialsCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	for _, file := range project.DartFiles {
+	for _, file := range project.Sources.App().Files() {
+		reported := make(map[int]bool)
 		for _, re := range credentialPatterns {
-			if re.MatchString(file) {
+			for _, match := range file.FindAll(re) {
+				if reported[match.Line] {
+					continue
+				}
+				reported[match.Line] = true
 				findings = append(findings, project.AddFinding(
 					c.ID(),
 					c.Name(),
 					"Potential hardcoded credentials found in file",
-					file,
+					file.Path,
 					"Use environment variables or secure configuration storage",
 					report.SeverityHigh,
-					0,
+					match.Line,
 				))
 			}
 		}
@@ -59,19 +79,30 @@ func (c *DebugModeCheck) Name() string {
 	return "Debug Mode Check"
 }
 
+func (c *DebugModeCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategorySecurity,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Scans Dart sources for print and debugPrint calls.",
+		Rationale:       "Release builds keep print output in the device log, which can leak tokens and personal data.",
+		Guideline:       "OWASP MASVS-CODE",
+		Remediation:     "Use a logger that is silenced in release mode, or guard calls with kDebugMode.",
+	}
+}
+
 func (c *DebugModeCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "print(") || strings.Contains(file, "debugPrint(") {
+	for _, file := range project.Sources.App().Files() {
+		if match, ok := file.FindFirst(debugPrintPattern); ok {
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
 				"Debug statements found in file",
-				file,
+				file.Path,
 				"Remove debug print statements or use logger that respects build mode",
 				report.SeverityWarning,
-				0,
+				match.Line,
 			))
 		}
 	}
@@ -89,25 +120,31 @@ func (c *InsecureHTTPCheck) Name() string {
 	return "Insecure HTTP URL Usage"
 }
 
+func (c *InsecureHTTPCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategorySecurity,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Scans Dart sources for http:// URLs other than localhost.",
+		Rationale:       "Cleartext traffic is blocked by default on Android 9+ and by App Transport Security on iOS, and exposes user data in transit.",
+		Guideline:       "OWASP MASVS-NETWORK-1",
+		Remediation:     "Switch the endpoint to https://.",
+	}
+}
+
 func (c *InsecureHTTPCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	httpPattern := regexp.MustCompile(`(?i)http://[^\s"'<>]+`)
-
-	for _, file := range project.DartFiles {
-		matches := httpPattern.FindAllString(file, -1)
-		for _, match := range matches {
-			if !strings.Contains(match, "localhost") && !strings.Contains(match, "127.0.0.1") {
-				findings = append(findings, project.AddFinding(
-					c.ID(),
-					c.Name(),
-					"Found insecure HTTP URL: "+match,
-					file,
-					"Replace HTTP URLs with HTTPS for secure communication",
-					report.SeverityHigh,
-					0,
-				))
-			}
+	for _, match := range project.Sources.App().FindAll(httpURLPattern) {
+		if !strings.Contains(match.Text, "localhost") && !strings.Contains(match.Text, "127.0.0.1") {
+			findings = append(findings, project.AddFinding(
+				c.ID(),
+				c.Name(),
+				"Found insecure HTTP URL: "+match.Text,
+				match.File,
+				"Replace HTTP URLs with HTTPS for secure communication",
+				report.SeverityHigh,
+				match.Line,
+			))
 		}
 	}
 
@@ -124,24 +161,50 @@ func (c *ExportedActivityCheck) Name() string {
 	return "Android Exportable Activity Security"
 }
 
+func (c *ExportedActivityCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategorySecurity,
+		DefaultSeverity: report.SeverityHigh,
+		Platforms:       []string{checker.PlatformAndroid},
+		Description:     "Checks for exported activities, services and receivers that other apps can start without a permission.",
+		Rationale:       "An exported component without a permission can be invoked by any app on the device.",
+		Guideline:       "OWASP MASVS-PLATFORM-1",
+		Remediation:     "Set android:exported=\"false\" unless the activity must be launched externally, and protect it with a permission if so.",
+	}
+}
+
 func (c *ExportedActivityCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	if project.AndroidManifest == nil || project.AndroidManifest.Activities == nil {
+	if project.AndroidManifest == nil {
 		return findings
 	}
 
-	for _, activity := range project.AndroidManifest.Activities {
-		if activity.Exported && !activity.HasIntentFilter {
+	manifest := project.AndroidManifest
+	for _, components := range [][]checker.ComponentInfo{manifest.Activities, manifest.Services, manifest.Receivers} {
+		for _, component := range components {
+			if !component.Exported || component.HasIntentFilter || component.Permission != "" {
+				continue
+			}
+			kind := component.Kind
+			if kind == "" {
+				kind = "activity"
+			}
+			message := "Exported " + kind + " without intent filter: " + component.Name
+			suggestion := "Set android:exported=\"false\" for components that don't need external access, or protect them with android:permission"
+			if component.Origin != "" {
+				message += " (added by the " + component.Origin + " plugin)"
+				suggestion = "Override it in the app manifest with <" + kind + " android:name=\"" + component.Name + "\" android:exported=\"false\" tools:replace=\"android:exported\" /> if it doesn't need external access"
+			}
 			findings = append(findings, project.AddFinding(
 				c.ID(),
 				c.Name(),
-				"Exported activity without intent filter: "+activity.Name,
-				"AndroidManifest.xml",
-				"Set android:exported=\"false\" for activities that don't need external access",
+				message,
+				"android/app/src/main/AndroidManifest.xml",
+				suggestion,
 				report.SeverityHigh,
-				0,
-			))
+				component.AppLine(),
+			).WithSubject(component.Name))
 		}
 	}
 
@@ -158,23 +221,30 @@ func (c *SQLInjectionCheck) Name() string {
 	return "SQL Injection Prevention"
 }
 
+func (c *SQLInjectionCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategorySecurity,
+		DefaultSeverity: report.SeverityHigh,
+		Description:     "Scans Dart sources for rawQuery calls whose SQL is a SELECT, INSERT, UPDATE or DELETE string literal, for review.",
+		Rationale:       "Raw SQL that interpolates or concatenates values allows SQL injection against the local database; every rawQuery call is reported so it can be checked.",
+		Guideline:       "OWASP MASVS-CODE-4",
+		Remediation:     "Use parameterized queries with ? placeholders and the arguments list.",
+	}
+}
+
 func (c *SQLInjectionCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	sqlPattern := regexp.MustCompile(`(?i)rawQuery\s*\(\s*["']\s*(?:SELECT|INSERT|UPDATE|DELETE).*["']\s*\)`)
-
-	for _, file := range project.DartFiles {
-		if sqlPattern.MatchString(file) {
-			findings = append(findings, project.AddFinding(
-				c.ID(),
-				c.Name(),
-				"Potential SQL injection vulnerability in file",
-				file,
-				"Use parameterized queries instead of string concatenation",
-				report.SeverityHigh,
-				0,
-			))
-		}
+	for _, match := range project.Sources.App().FindAll(rawQueryPattern) {
+		findings = append(findings, project.AddFinding(
+			c.ID(),
+			c.Name(),
+			"Potential SQL injection vulnerability in file",
+			match.File,
+			"Use parameterized queries instead of string concatenation",
+			report.SeverityHigh,
+			match.Line,
+		))
 	}
 
 	return findings
@@ -1,6 +1,8 @@
 package security
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/ricky-irfandi/fsct/internal/checker"
@@ -43,7 +45,7 @@ func TestHardcodedCredentialsCheck_Run(t *testing.T) {
 
 	t.Run("detects api_key", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"const String api_key = '12345678901234567890';"},
+			Sources: sourcesOf("const String api_key = '12345678901234567890';"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -53,7 +55,7 @@ func TestHardcodedCredentialsCheck_Run(t *testing.T) {
 
 	t.Run("detects secret", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"final String secret = 'abc123XYZ456defGHI';"},
+			Sources: sourcesOf("final String secret = 'abc123XYZ456defGHI';"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -63,7 +65,7 @@ func TestHardcodedCredentialsCheck_Run(t *testing.T) {
 
 	t.Run("detects password", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String password = 'securepass123';"},
+			Sources: sourcesOf("String password = 'securepass123';"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -73,7 +75,7 @@ func TestHardcodedCredentialsCheck_Run(t *testing.T) {
 
 	t.Run("detects auth_token", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String auth_token = 'eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9';"},
+			Sources: sourcesOf("String auth_token = 'eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9';"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -83,7 +85,7 @@ func TestHardcodedCredentialsCheck_Run(t *testing.T) {
 
 	t.Run("no false positives", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"void main() { print('Hello'); }"},
+			Sources: sourcesOf("void main() { print('Hello'); }"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -112,7 +114,7 @@ func TestDebugModeCheck_Run(t *testing.T) {
 
 	t.Run("detects print statement", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"void main() { print('debug'); }"},
+			Sources: sourcesOf("void main() { print('debug'); }"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -122,7 +124,7 @@ func TestDebugModeCheck_Run(t *testing.T) {
 
 	t.Run("detects debugPrint", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"debugPrint('log message');"},
+			Sources: sourcesOf("debugPrint('log message');"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -132,7 +134,7 @@ func TestDebugModeCheck_Run(t *testing.T) {
 
 	t.Run("no false positives", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"void main() { return; }"},
+			Sources: sourcesOf("void main() { return; }"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -161,7 +163,7 @@ func TestInsecureHTTPCheck_Run(t *testing.T) {
 
 	t.Run("detects http url", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String url = 'http://example.com/api';"},
+			Sources: sourcesOf("String url = 'http://example.com/api';"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -171,7 +173,7 @@ func TestInsecureHTTPCheck_Run(t *testing.T) {
 
 	t.Run("ignores localhost", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String url = 'http://localhost:8080/api';"},
+			Sources: sourcesOf("String url = 'http://localhost:8080/api';"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -181,7 +183,7 @@ func TestInsecureHTTPCheck_Run(t *testing.T) {
 
 	t.Run("ignores 127.0.0.1", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String url = 'http://127.0.0.1:3000/api';"},
+			Sources: sourcesOf("String url = 'http://127.0.0.1:3000/api';"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -189,9 +191,21 @@ func TestInsecureHTTPCheck_Run(t *testing.T) {
 		}
 	})
 
+	t.Run("reports file and line", func(t *testing.T) {
+		sources := checker.NewSourceIndex()
+		sources.Add("lib/api.dart", "class Api {\n  final url = 'http://api.example.com';\n}\n")
+		results := c.Run(&checker.Project{Sources: sources})
+		if len(results) != 1 {
+			t.Fatalf("expected 1 finding, got %d", len(results))
+		}
+		if results[0].File != "lib/api.dart" || results[0].Line != 2 {
+			t.Errorf("expected lib/api.dart:2, got %s:%d", results[0].File, results[0].Line)
+		}
+	})
+
 	t.Run("https is allowed", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"String url = 'https://api.example.com';"},
+			Sources: sourcesOf("String url = 'https://api.example.com';"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -253,6 +267,47 @@ func TestExportedActivityCheck_Run(t *testing.T) {
 			t.Errorf("expected 1 finding, got %d", len(results))
 		}
 	})
+
+	t.Run("exported receivers and services", func(t *testing.T) {
+		project := &checker.Project{
+			AndroidManifest: &checker.AndroidManifestInfo{
+				Receivers: []checker.ComponentInfo{
+					{Kind: "receiver", Name: ".SyncReceiver", Exported: true, Line: 30},
+					{Kind: "receiver", Name: ".PushReceiver", Exported: true, Permission: "com.google.android.c2dm.permission.SEND"},
+				},
+				Services: []checker.ComponentInfo{
+					{Kind: "service", Name: ".UploadService", Exported: false},
+				},
+			},
+		}
+		results := c.Run(project)
+		if len(results) != 1 {
+			t.Fatalf("expected 1 finding, got %d", len(results))
+		}
+		if results[0].Subject != ".SyncReceiver" || results[0].Line != 30 || !strings.Contains(results[0].Message, "Exported receiver") {
+			t.Errorf("unexpected finding %+v", results[0])
+		}
+		if results[0].File != "android/app/src/main/AndroidManifest.xml" {
+			t.Errorf("expected the app manifest path, got %q", results[0].File)
+		}
+	})
+
+	t.Run("plugin service has no app manifest line", func(t *testing.T) {
+		project := &checker.Project{
+			AndroidManifest: &checker.AndroidManifestInfo{
+				Services: []checker.ComponentInfo{
+					{Kind: "service", Name: "com.example.plug.BgService", Exported: true, Line: 12, Origin: "plug"},
+				},
+			},
+		}
+		results := c.Run(project)
+		if len(results) != 1 {
+			t.Fatalf("expected 1 finding, got %d", len(results))
+		}
+		if results[0].Line != 0 || results[0].Subject != "com.example.plug.BgService" {
+			t.Errorf("expected line 0 and the service as subject, got %+v", results[0])
+		}
+	})
 }
 
 func TestSQLInjectionCheck_ID(t *testing.T) {
@@ -275,7 +330,7 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 
 	t.Run("detects rawQuery SELECT", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"await rawQuery('SELECT * FROM users');"},
+			Sources: sourcesOf("await rawQuery('SELECT * FROM users');"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -285,7 +340,7 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 
 	t.Run("detects rawQuery INSERT", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"rawQuery('INSERT INTO users (name) VALUES (?)');"},
+			Sources: sourcesOf("rawQuery('INSERT INTO users (name) VALUES (?)');"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -295,7 +350,7 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 
 	t.Run("detects rawQuery UPDATE", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"rawQuery('UPDATE users SET name = ?');"},
+			Sources: sourcesOf("rawQuery('UPDATE users SET name = ?');"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -305,7 +360,7 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 
 	t.Run("detects rawQuery DELETE", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"rawQuery('DELETE FROM users WHERE id = ?');"},
+			Sources: sourcesOf("rawQuery('DELETE FROM users WHERE id = ?');"),
 		}
 		results := c.Run(project)
 		if len(results) != 1 {
@@ -315,7 +370,7 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 
 	t.Run("no false positives", func(t *testing.T) {
 		project := &checker.Project{
-			DartFiles: []string{"final query = 'SELECT';"},
+			Sources: sourcesOf("final query = 'SELECT';"),
 		}
 		results := c.Run(project)
 		if len(results) != 0 {
@@ -323,3 +378,45 @@ func TestSQLInjectionCheck_Run(t *testing.T) {
 		}
 	})
 }
+
+func TestSecurityChecks_IgnoreTestFiles(t *testing.T) {
+	code := map[string]string{
+		"SEC-001": "const apiKey = 'sk_test_abcdefghijklmnop1234';\n",
+		"SEC-002": "void log(String m) => print(m);\n",
+		"SEC-003": "const url = 'http://api.example.com';\n",
+		"SEC-005": "db.rawQuery('SELECT * FROM users WHERE id = $id');\n",
+	}
+	checks := []checker.Check{
+		&HardcodedCredentialsCheck{},
+		&DebugModeCheck{},
+		&InsecureHTTPCheck{},
+		&SQLInjectionCheck{},
+	}
+
+	for _, c := range checks {
+		t.Run(c.ID(), func(t *testing.T) {
+			sources := checker.NewSourceIndex()
+			sources.Add("test/api_test.dart", code[c.ID()])
+			sources.Add("integration_test/app_test.dart", code[c.ID()])
+			sources.Add("test_driver/main.dart", code[c.ID()])
+			project := &checker.Project{Sources: sources}
+			if results := c.Run(project); len(results) != 0 {
+				t.Errorf("expected no findings in test files, got %v", results)
+			}
+
+			sources.Add("lib/api.dart", code[c.ID()])
+			results := c.Run(project)
+			if len(results) != 1 || results[0].File != "lib/api.dart" {
+				t.Errorf("expected one finding in lib/api.dart, got %v", results)
+			}
+		})
+	}
+}
+
+func sourcesOf(contents ...string) *checker.SourceIndex {
+	sources := checker.NewSourceIndex()
+	for i, content := range contents {
+		sources.Add(fmt.Sprintf("lib/file_%d.dart", i), content)
+	}
+	return sources
+}
@@ -0,0 +1,229 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SourceFile is a loaded source file with precomputed line offsets.
+type SourceFile struct {
+	Path    string
+	Content string
+
+	lineStarts []int
+}
+
+// SourceMatch is a regular expression match located in a source file.
+// Line and Column are 1-based.
+type SourceMatch struct {
+	File     string
+	Line     int
+	Column   int
+	Text     string
+	LineText string
+}
+
+// NewSourceFile indexes content for line lookups.
+func NewSourceFile(path, content string) *SourceFile {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &SourceFile{Path: path, Content: content, lineStarts: starts}
+}
+
+// LineCount returns the number of lines in the file. A trailing newline
+// does not start a new line.
+func (f *SourceFile) LineCount() int {
+	if f.Content == "" {
+		return 0
+	}
+	n := len(f.lineStarts)
+	if strings.HasSuffix(f.Content, "\n") {
+		n--
+	}
+	return n
+}
+
+// Position converts a byte offset into a 1-based line and column.
+func (f *SourceFile) Position(offset int) (line, column int) {
+	i := sort.Search(len(f.lineStarts), func(i int) bool {
+		return f.lineStarts[i] > offset
+	}) - 1
+	if i < 0 {
+		i = 0
+	}
+	return i + 1, offset - f.lineStarts[i] + 1
+}
+
+// Line returns the text of the 1-based line n without its newline.
+func (f *SourceFile) Line(n int) string {
+	if n < 1 || n > len(f.lineStarts) {
+		return ""
+	}
+	start := f.lineStarts[n-1]
+	end := len(f.Content)
+	if n < len(f.lineStarts) {
+		end = f.lineStarts[n] - 1
+	}
+	return strings.TrimSuffix(f.Content[start:end], "\r")
+}
+
+// FindAll returns every match of re in the file.
+func (f *SourceFile) FindAll(re *regexp.Regexp) []SourceMatch {
+	var matches []SourceMatch
+	for _, loc := range re.FindAllStringIndex(f.Content, -1) {
+		matches = append(matches, f.match(loc))
+	}
+	return matches
+}
+
+// FindFirst returns the first match of re in the file.
+func (f *SourceFile) FindFirst(re *regexp.Regexp) (SourceMatch, bool) {
+	loc := re.FindStringIndex(f.Content)
+	if loc == nil {
+		return SourceMatch{}, false
+	}
+	return f.match(loc), true
+}
+
+// Contains reports whether re matches anywhere in the file.
+func (f *SourceFile) Contains(re *regexp.Regexp) bool {
+	return re.MatchString(f.Content)
+}
+
+func (f *SourceFile) match(loc []int) SourceMatch {
+	line, column := f.Position(loc[0])
+	return SourceMatch{
+		File:     f.Path,
+		Line:     line,
+		Column:   column,
+		Text:     f.Content[loc[0]:loc[1]],
+		LineText: strings.TrimSpace(f.Line(line)),
+	}
+}
+
+// testDirs are the top-level directories that hold test code rather than
+// code built into the app.
+var testDirs = []string{"test/", "integration_test/", "test_driver/"}
+
+// IsTest reports whether the file is test code: a file under test/,
+// integration_test/ or test_driver/, or one ending in _test.dart.
+func (f *SourceFile) IsTest() bool {
+	for _, dir := range testDirs {
+		if strings.HasPrefix(f.Path, dir) {
+			return true
+		}
+	}
+	return strings.HasSuffix(f.Path, "_test.dart")
+}
+
+// SourceIndex holds the contents of every Dart source in a project so checks
+// read each file once. A nil index behaves as an empty one.
+type SourceIndex struct {
+	files  []*SourceFile
+	byPath map[string]*SourceFile
+}
+
+// NewSourceIndex returns an empty index.
+func NewSourceIndex() *SourceIndex {
+	return &SourceIndex{byPath: make(map[string]*SourceFile)}
+}
+
+// LoadSourceIndex reads paths (relative to root) into a new index. Files
+// that cannot be read are skipped and returned in the failed map.
+func LoadSourceIndex(root string, paths []string) (*SourceIndex, map[string]error) {
+	index := NewSourceIndex()
+	failed := make(map[string]error)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil {
+			failed[path] = err
+			continue
+		}
+		index.Add(path, string(data))
+	}
+
+	return index, failed
+}
+
+// Add indexes content under path, replacing any existing entry.
+func (i *SourceIndex) Add(path, content string) *SourceFile {
+	file := NewSourceFile(path, content)
+	if existing, ok := i.byPath[path]; ok {
+		*existing = *file
+		return existing
+	}
+	i.files = append(i.files, file)
+	i.byPath[path] = file
+	return file
+}
+
+// Files returns the indexed files in insertion order.
+func (i *SourceIndex) Files() []*SourceFile {
+	if i == nil {
+		return nil
+	}
+	return i.files
+}
+
+// File returns the file indexed under path, or nil.
+func (i *SourceIndex) File(path string) *SourceFile {
+	if i == nil {
+		return nil
+	}
+	return i.byPath[path]
+}
+
+// Len returns the number of indexed files.
+func (i *SourceIndex) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.files)
+}
+
+// App returns an index of the files that are not test code, such as those
+// under lib/ and bin/. Checks for problems in the shipped app use it so
+// that test fixtures are not reported.
+func (i *SourceIndex) App() *SourceIndex {
+	app := NewSourceIndex()
+	for _, f := range i.Files() {
+		if !f.IsTest() {
+			app.files = append(app.files, f)
+			app.byPath[f.Path] = f
+		}
+	}
+	return app
+}
+
+// FindAll returns every match of re across all files.
+func (i *SourceIndex) FindAll(re *regexp.Regexp) []SourceMatch {
+	var matches []SourceMatch
+	for _, f := range i.Files() {
+		matches = append(matches, f.FindAll(re)...)
+	}
+	return matches
+}
+
+// FindFirst returns the first match of re across all files.
+func (i *SourceIndex) FindFirst(re *regexp.Regexp) (SourceMatch, bool) {
+	for _, f := range i.Files() {
+		if m, ok := f.FindFirst(re); ok {
+			return m, true
+		}
+	}
+	return SourceMatch{}, false
+}
+
+// Contains reports whether re matches in any file.
+func (i *SourceIndex) Contains(re *regexp.Regexp) bool {
+	_, ok := i.FindFirst(re)
+	return ok
+}
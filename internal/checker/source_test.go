@@ -0,0 +1,157 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSourceFile(t *testing.T) {
+	file := NewSourceFile("lib/main.dart", "import 'a.dart';\n\nvoid main() {\r\n  print('hi');\n}\n")
+
+	t.Run("line count", func(t *testing.T) {
+		if file.LineCount() != 5 {
+			t.Errorf("expected 5 lines, got %d", file.LineCount())
+		}
+		if NewSourceFile("empty.dart", "").LineCount() != 0 {
+			t.Error("expected empty file to have 0 lines")
+		}
+		if NewSourceFile("a.dart", "a\nb").LineCount() != 2 {
+			t.Error("expected file without trailing newline to have 2 lines")
+		}
+	})
+
+	t.Run("position", func(t *testing.T) {
+		tests := []struct {
+			offset, line, column int
+		}{
+			{0, 1, 1},
+			{16, 1, 17},
+			{17, 2, 1},
+			{18, 3, 1},
+			{35, 4, 3},
+		}
+		for _, tt := range tests {
+			line, column := file.Position(tt.offset)
+			if line != tt.line || column != tt.column {
+				t.Errorf("offset %d: expected %d:%d, got %d:%d", tt.offset, tt.line, tt.column, line, column)
+			}
+		}
+	})
+
+	t.Run("line", func(t *testing.T) {
+		if got := file.Line(3); got != "void main() {" {
+			t.Errorf("expected line 3 without CR, got %q", got)
+		}
+		if got := file.Line(2); got != "" {
+			t.Errorf("expected empty line 2, got %q", got)
+		}
+		if got := file.Line(0); got != "" {
+			t.Errorf("expected empty string for line 0, got %q", got)
+		}
+		if got := file.Line(100); got != "" {
+			t.Errorf("expected empty string for line 100, got %q", got)
+		}
+	})
+
+	t.Run("find", func(t *testing.T) {
+		match, ok := file.FindFirst(regexp.MustCompile(`print\(`))
+		if !ok {
+			t.Fatal("expected a match")
+		}
+		if match.File != "lib/main.dart" || match.Line != 4 || match.Column != 3 {
+			t.Errorf("unexpected match position %s:%d:%d", match.File, match.Line, match.Column)
+		}
+		if match.LineText != "print('hi');" {
+			t.Errorf("unexpected line text %q", match.LineText)
+		}
+
+		if n := len(file.FindAll(regexp.MustCompile(`'`))); n != 4 {
+			t.Errorf("expected 4 matches, got %d", n)
+		}
+		if file.Contains(regexp.MustCompile(`http`)) {
+			t.Error("expected no match")
+		}
+	})
+}
+
+func TestSourceIndex(t *testing.T) {
+	t.Run("nil index", func(t *testing.T) {
+		var index *SourceIndex
+		if index.Len() != 0 || index.Files() != nil || index.File("a.dart") != nil {
+			t.Error("expected nil index to behave as empty")
+		}
+		if index.Contains(regexp.MustCompile(`.`)) {
+			t.Error("expected nil index to match nothing")
+		}
+	})
+
+	t.Run("add and find", func(t *testing.T) {
+		index := NewSourceIndex()
+		index.Add("lib/a.dart", "class A {}\n")
+		index.Add("lib/b.dart", "class B {}\n// TODO\n")
+
+		if index.Len() != 2 {
+			t.Fatalf("expected 2 files, got %d", index.Len())
+		}
+
+		match, ok := index.FindFirst(regexp.MustCompile(`TODO`))
+		if !ok || match.File != "lib/b.dart" || match.Line != 2 {
+			t.Errorf("unexpected match %+v", match)
+		}
+		if n := len(index.FindAll(regexp.MustCompile(`class`))); n != 2 {
+			t.Errorf("expected 2 matches, got %d", n)
+		}
+	})
+
+	t.Run("add replaces", func(t *testing.T) {
+		index := NewSourceIndex()
+		first := index.Add("lib/a.dart", "old")
+		index.Add("lib/a.dart", "new")
+
+		if index.Len() != 1 {
+			t.Errorf("expected 1 file, got %d", index.Len())
+		}
+		if first.Content != "new" || index.File("lib/a.dart").Content != "new" {
+			t.Error("expected content to be replaced")
+		}
+	})
+
+	t.Run("app", func(t *testing.T) {
+		index := NewSourceIndex()
+		for _, path := range []string{"lib/a.dart", "bin/tool.dart", "test/a_test.dart", "integration_test/app.dart", "test_driver/main.dart", "lib/a_test.dart"} {
+			index.Add(path, "")
+		}
+
+		var paths []string
+		for _, f := range index.App().Files() {
+			paths = append(paths, f.Path)
+		}
+		if want := []string{"lib/a.dart", "bin/tool.dart"}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("App() = %v, want %v", paths, want)
+		}
+		if index.Len() != 6 {
+			t.Errorf("expected App to leave the index alone, got %d files", index.Len())
+		}
+	})
+
+	t.Run("load", func(t *testing.T) {
+		root := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(root, "lib"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "lib", "a.dart"), []byte("void a() {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		index, failed := LoadSourceIndex(root, []string{"lib/a.dart", "lib/missing.dart"})
+		if index.Len() != 1 || index.File("lib/a.dart") == nil {
+			t.Errorf("expected lib/a.dart to be indexed")
+		}
+		if _, ok := failed["lib/missing.dart"]; !ok || len(failed) != 1 {
+			t.Errorf("expected lib/missing.dart to fail, got %v", failed)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package testing
+
+import "github.com/ricky-irfandi/fsct/internal/registry"
+
+func init() {
+	registry.Register(
+		&TestDirectoryCheck{},
+		&TestFileNamingCheck{},
+		&TestCoverageCheck{},
+		&WidgetTestCheck{},
+		&MockDependenciesCheck{},
+		&GoldenTestCheck{},
+	)
+}
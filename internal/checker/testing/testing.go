@@ -19,6 +19,16 @@ func (c *TestDirectoryCheck) Name() string {
 	return "Test Directory Existence"
 }
 
+func (c *TestDirectoryCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that the project has tests.",
+		Rationale:       "Automated tests catch regressions before they reach the stores.",
+		Remediation:     "Add a test/ directory with unit and widget tests.",
+	}
+}
+
 func (c *TestDirectoryCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -55,6 +65,16 @@ func (c *TestFileNamingCheck) Name() string {
 	return "Test File Naming Convention"
 }
 
+func (c *TestFileNamingCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that files under test/ end with _test.dart.",
+		Rationale:       "flutter test only runs files with the _test.dart suffix.",
+		Remediation:     "Rename test files to end with _test.dart.",
+	}
+}
+
 func (c *TestFileNamingCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
@@ -87,15 +107,28 @@ func (c *TestCoverageCheck) Name() string {
 	return "Test Coverage Check"
 }
 
+func (c *TestCoverageCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Flags projects with fewer than one test file per five library files (thresholds.min_test_ratio).",
+		Rationale:       "A handful of tests rarely covers the flows reviewers exercise.",
+		Remediation:     "Add tests for the main user flows.",
+	}
+}
+
 func (c *TestCoverageCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
-	testCount := 0
+	testCount, libCount := 0, 0
 	for _, file := range project.DartFiles {
 		if strings.Contains(file, "test/") {
 			testCount++
+		} else if strings.HasPrefix(file, "lib/") {
+			libCount++
 		}
 	}
+	minRatio := project.Thresholds.WithDefaults().MinTestRatio
 
 	if testCount == 0 {
 		findings = append(findings, project.AddFinding(
@@ -107,11 +140,11 @@ func (c *TestCoverageCheck) Run(project *checker.Project) []report.Finding {
 			report.SeverityWarning,
 			0,
 		))
-	} else if testCount < 3 {
+	} else if libCount > 0 && float64(testCount)/float64(libCount) < minRatio {
 		findings = append(findings, project.AddFinding(
 			c.ID(),
 			c.Name(),
-			"Low number of test files: "+strconv.Itoa(testCount),
+			"Low number of test files: "+strconv.Itoa(testCount)+" for "+strconv.Itoa(libCount)+" library files",
 			"test/",
 			"Consider adding more tests for better coverage",
 			report.SeverityWarning,
@@ -132,14 +165,24 @@ func (c *WidgetTestCheck) Name() string {
 	return "Widget Test Presence"
 }
 
+func (c *WidgetTestCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that at least one test uses testWidgets.",
+		Rationale:       "Widget tests verify UI behaviour without a device.",
+		Remediation:     "Add widget tests for key screens.",
+	}
+}
+
 func (c *WidgetTestCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	widgetTestPattern := regexp.MustCompile(`(?i)testWidgets`)
 	hasWidgetTests := false
 
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "test/") && widgetTestPattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if strings.Contains(file.Path, "test/") && file.Contains(widgetTestPattern) {
 			hasWidgetTests = true
 			break
 		}
@@ -170,14 +213,24 @@ func (c *MockDependenciesCheck) Name() string {
 	return "Mock Dependencies Usage"
 }
 
+func (c *MockDependenciesCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityWarning,
+		Description:     "Checks that tests use mocks for dependencies.",
+		Rationale:       "Tests that hit real services are slow and flaky.",
+		Remediation:     "Use mockito or mocktail to replace network and storage dependencies.",
+	}
+}
+
 func (c *MockDependenciesCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	mockPattern := regexp.MustCompile(`(?i)(mockito|Mock|when|verify)`)
 	hasMocks := false
 
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "test/") && mockPattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if strings.Contains(file.Path, "test/") && file.Contains(mockPattern) {
 			hasMocks = true
 			break
 		}
@@ -208,14 +261,24 @@ func (c *GoldenTestCheck) Name() string {
 	return "Golden Test Presence"
 }
 
+func (c *GoldenTestCheck) Metadata() checker.Metadata {
+	return checker.Metadata{
+		Category:        checker.CategoryTesting,
+		DefaultSeverity: report.SeverityInfo,
+		Description:     "Checks for golden (screenshot) tests.",
+		Rationale:       "Golden tests catch unintended visual changes.",
+		Remediation:     "Add matchesGoldenFile tests for important screens.",
+	}
+}
+
 func (c *GoldenTestCheck) Run(project *checker.Project) []report.Finding {
 	findings := []report.Finding{}
 
 	goldenPattern := regexp.MustCompile(`(?i)matchesGoldenFile`)
 	hasGoldenTests := false
 
-	for _, file := range project.DartFiles {
-		if strings.Contains(file, "test/") && goldenPattern.MatchString(file) {
+	for _, file := range project.Sources.Files() {
+		if strings.Contains(file.Path, "test/") && file.Contains(goldenPattern) {
 			hasGoldenTests = true
 			break
 		}
@@ -0,0 +1,127 @@
+package checker
+
+import "github.com/ricky-irfandi/fsct/internal/plist"
+
+// InfoPlistInfo summarises ios/Runner/Info.plist. Values is the whole
+// decoded property list, for checks that need keys without a field here.
+type InfoPlistInfo struct {
+	Values *plist.Value
+
+	CFBundleIdentifier         string
+	CFBundleVersion            string
+	CFBundleShortVersionString string
+
+	HasCameraUsageDescription       bool
+	HasPhotoLibraryUsageDescription bool
+	HasLocationUsageDescription     bool
+	HasMicrophoneUsageDescription   bool
+	HasContactsUsageDescription     bool
+	HasCalendarsUsageDescription    bool
+
+	EncryptionDeclarationSet bool
+	EncryptionExempt         bool
+	RequiresFullScreen       bool
+
+	BackgroundModes      []string
+	AllowsArbitraryLoads bool
+}
+
+// Line returns the line of the Info.plist key at path, such as
+// NSAppTransportSecurity.NSAllowsArbitraryLoads, or 0 if it is unknown.
+func (i *InfoPlistInfo) Line(path string) int {
+	if i == nil {
+		return 0
+	}
+	return i.Values.Lookup(path).Line()
+}
+
+// EntitlementsInfo summarises the entitlements file the app target's
+// Release configuration is signed with, named by CODE_SIGN_ENTITLEMENTS.
+// Values is the whole decoded property list.
+type EntitlementsInfo struct {
+	// File is relative to the project root, or "" if the app has no
+	// entitlements.
+	File   string
+	Values *plist.Value
+
+	// APSEnvironment is aps-environment, development or production, or
+	// "" without the push notification capability.
+	APSEnvironment string
+	// AssociatedDomains are entries such as applinks:example.com.
+	AssociatedDomains []string
+	AppGroups         []string
+	ICloudContainers  []string
+	SignInWithApple   bool
+	HealthKit         bool
+}
+
+// Line returns the line of the entitlement at path, such as
+// aps-environment, or 0 if it is unknown.
+func (e *EntitlementsInfo) Line(path string) int {
+	if e == nil {
+		return 0
+	}
+	return e.Values.Lookup(path).Line()
+}
+
+// SiteAssociationInfo summarises an apple-app-site-association file kept in
+// the project, such as web/.well-known/apple-app-site-association.
+type SiteAssociationInfo struct {
+	// File is relative to the project root, or "" if there is none.
+	File string
+	// AppLinks and WebCredentials are the app IDs, such as
+	// ABCDE12345.com.example.app, the file lists for universal links and
+	// shared web credentials.
+	AppLinks       []string
+	WebCredentials []string
+}
+
+// IOSPluginInfo is a Flutter plugin with iOS code, as listed in
+// .flutter-plugins-dependencies.
+type IOSPluginInfo struct {
+	Name string
+	// Located reports whether the plugin's package was found on disk.
+	// Without it nothing is known about PrivacyManifest.
+	Located bool
+	// PrivacyManifest is the path of the PrivacyInfo.xcprivacy the
+	// plugin ships, or "".
+	PrivacyManifest string
+}
+
+// XcodeProjectInfo summarises the app target of the Xcode project,
+// usually ios/Runner.xcodeproj.
+type XcodeProjectInfo struct {
+	// File is the project.pbxproj relative to the project root.
+	File   string
+	Target string
+	// Configurations maps each build configuration of the target, such as
+	// Debug, Release and Profile, to its build settings with xcconfig
+	// files applied and references expanded.
+	Configurations map[string]map[string]string
+	// Lines maps the build settings the target's Release configuration
+	// sets in File to their line.
+	Lines map[string]int
+	// Resources are the files, relative to the project root, that the
+	// target copies into the app bundle.
+	Resources []string
+}
+
+// ReleaseConfiguration is the build configuration App Store builds use.
+const ReleaseConfiguration = "Release"
+
+// Setting returns the value of a build setting in the Release
+// configuration, such as IPHONEOS_DEPLOYMENT_TARGET, or "".
+func (x *XcodeProjectInfo) Setting(name string) string {
+	if x == nil {
+		return ""
+	}
+	return x.Configurations[ReleaseConfiguration][name]
+}
+
+// Path returns File, or the conventional project path if it is unset.
+func (x *XcodeProjectInfo) Path() string {
+	if x == nil || x.File == "" {
+		return "ios/Runner.xcodeproj/project.pbxproj"
+	}
+	return x.File
+}
@@ -1,31 +1,92 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
-// LoadConfig loads configuration from .fsct.yaml file if it exists
-func LoadConfig(projectPath string) *Config {
-	cfg := &Config{}
+// FileName is the name of the configuration file in the project root.
+const FileName = ".fsct.yaml"
 
-	configPath := filepath.Join(projectPath, ".fsct.yaml")
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return cfg
+// LoadConfig loads configuration from the .fsct.yaml file in projectPath,
+// merged over the configurations it extends. A missing file yields an empty
+// configuration. Unknown keys, type errors and invalid values are returned
+// as Errors, each with its file and line.
+func LoadConfig(projectPath string) (*Config, error) {
+	configPath := filepath.Join(projectPath, FileName)
+	if _, err := os.Stat(configPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
 	}
 
-	_ = yaml.Unmarshal(data, cfg)
-	return cfg
+	l := newLoader()
+	root := l.load(configPath, nil)
+	if len(l.errs) > 0 {
+		return &Config{}, l.errs
+	}
+
+	cfg := &Config{File: configPath, Files: l.files, root: root, fileOf: l.fileOf}
+	if err := root.Decode(cfg); err != nil {
+		return &Config{}, Errors{yamlError(configPath, err.Error())}
+	}
+	return cfg, nil
 }
 
 type Config struct {
-	AI        *AIConfig        `yaml:"ai,omitempty"`
-	Reviewer  *ReviewerConfig  `yaml:"reviewer,omitempty"`
-	Checks    *ChecksConfig    `yaml:"checks,omitempty"`
-	Platforms *PlatformsConfig `yaml:"platforms,omitempty"`
+	Extends    Extends                   `yaml:"extends,omitempty"`
+	Profile    string                    `yaml:"profile,omitempty"`
+	FailOn     string                    `yaml:"fail_on,omitempty"`
+	Profiles   map[string]*ProfileConfig `yaml:"profiles,omitempty"`
+	AI         *AIConfig                 `yaml:"ai,omitempty"`
+	Reviewer   *ReviewerConfig           `yaml:"reviewer,omitempty"`
+	Checks     *ChecksConfig             `yaml:"checks,omitempty"`
+	Thresholds *ThresholdsConfig         `yaml:"thresholds,omitempty"`
+	Paths      *PathsConfig              `yaml:"paths,omitempty"`
+	Platforms  *PlatformsConfig          `yaml:"platforms,omitempty"`
+	// KnowledgeBase is a dependency knowledge base file merged over the
+	// built-in and user ones. A relative path is relative to the file
+	// that sets it, so a configuration shared with extends can carry it.
+	KnowledgeBase string `yaml:"knowledge_base,omitempty"`
+
+	// File is the path the configuration was read from, if any.
+	File string `yaml:"-"`
+	// Files lists every file merged into the configuration, bases first
+	// and File last.
+	Files []string `yaml:"-"`
+
+	root   *yaml.Node
+	fileOf map[*yaml.Node]string
+}
+
+// Extends names the configurations a file builds on. It may be written as
+// a single string or a list.
+type Extends []string
+
+func (e *Extends) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*e = Extends{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*e = list
+	return nil
 }
 
 type AIConfig struct {
@@ -54,11 +115,362 @@ type VerificationConfig struct {
 }
 
 type ChecksConfig struct {
-	Skip    []string `yaml:"skip"`
-	Include []string `yaml:"include"`
+	Skip      []string                  `yaml:"skip"`
+	Include   []string                  `yaml:"include"`
+	Overrides map[string]*CheckOverride `yaml:"overrides,omitempty"`
+}
+
+// CheckOverride changes how a single check runs. Enabled: false skips the
+// check; Severity replaces the severity of its findings.
+type CheckOverride struct {
+	Enabled  *bool  `yaml:"enabled,omitempty"`
+	Severity string `yaml:"severity,omitempty"`
+}
+
+// ThresholdsConfig tunes the limits of individual checks. Unset values keep
+// the check's default.
+type ThresholdsConfig struct {
+	MinTargetSDK           int     `yaml:"min_target_sdk,omitempty"`
+	MinIOSDeploymentTarget string  `yaml:"min_ios_deployment_target,omitempty"`
+	MaxFileLines           int     `yaml:"max_file_lines,omitempty"`
+	MinTestRatio           float64 `yaml:"min_test_ratio,omitempty"`
+}
+
+// PathsConfig limits findings to files matching Include, if set, and drops
+// findings in files matching Exclude. Patterns are globs relative to the
+// project root; ** matches any number of directories.
+type PathsConfig struct {
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// ProfileConfig defines a custom check profile. It starts from the checks
+// of Base, if set, then adds whole categories and individual checks and
+// removes excluded ones.
+type ProfileConfig struct {
+	Base       string   `yaml:"base,omitempty"`
+	Categories []string `yaml:"categories,omitempty"`
+	Checks     []string `yaml:"checks,omitempty"`
+	Exclude    []string `yaml:"exclude,omitempty"`
 }
 
 type PlatformsConfig struct {
 	Android bool `yaml:"android"`
 	IOS     bool `yaml:"ios"`
 }
+
+// Error is a problem at a position in a configuration file.
+type Error struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Message)
+}
+
+// Errors lists every problem found in a configuration file.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parse strictly decodes data, read from file, into c.
+func (c *Config) parse(file string, data []byte) error {
+	c.File = file
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return Errors{yamlError(file, err.Error())}
+	}
+	c.root = &root
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	err := dec.Decode(c)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		errs := make(Errors, 0, len(typeErr.Errors))
+		for _, msg := range typeErr.Errors {
+			errs = append(errs, yamlError(file, msg))
+		}
+		return errs
+	}
+	return Errors{yamlError(file, err.Error())}
+}
+
+// yamlError turns a yaml.v3 message such as "line 3: field x not found in
+// type config.Config" into an Error at that line.
+func yamlError(file, msg string) *Error {
+	if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+		var line int
+		fmt.Sscanf(m[1], "%d", &line)
+		return &Error{File: file, Line: line, Message: strings.Replace(m[2], " in type config.", " in ", 1)}
+	}
+	return &Error{File: file, Message: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// Line returns the line of the key at the given path, such as "checks",
+// "overrides", "AND-001", or 0 when the path is not in the file. The last
+// key may also name an item of a list, such as "checks", "skip", "AND-012".
+func (c *Config) Line(keys ...string) int {
+	key, _, depth := c.lookup(keys)
+	if depth < len(keys) || key == nil {
+		return 0
+	}
+	return key.Line
+}
+
+// Source returns where the value at keys was set as "file:line", or "" when
+// it is not set. A list merged from several files names each of them, e.g.
+// "fsct:strict:4, .fsct.yaml:3".
+func (c *Config) Source(keys ...string) string {
+	key, value, depth := c.lookup(keys)
+	if depth < len(keys) || key == nil {
+		return ""
+	}
+	if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+		return c.position(key)
+	}
+
+	var sources []string
+	seen := make(map[string]bool)
+	for _, item := range value.Content {
+		if file := c.file(item); !seen[file] {
+			seen[file] = true
+			sources = append(sources, c.position(item))
+		}
+	}
+	return strings.Join(sources, ", ")
+}
+
+// KnowledgeBaseFile returns the path of the KnowledgeBase file, resolved
+// against the directory of the configuration file that sets it, or "".
+func (c *Config) KnowledgeBaseFile() string {
+	_, value, depth := c.lookup([]string{"knowledge_base"})
+	if depth == 0 || value.Value == "" {
+		return ""
+	}
+	if filepath.IsAbs(value.Value) {
+		return value.Value
+	}
+	return filepath.Join(filepath.Dir(c.file(value)), filepath.FromSlash(value.Value))
+}
+
+// Errorf returns an Error at the key path keys, or at the closest enclosing
+// key that is in the file.
+func (c *Config) Errorf(keys []string, format string, args ...any) *Error {
+	err := &Error{File: c.File, Message: fmt.Sprintf(format, args...)}
+	if key, _, _ := c.lookup(keys); key != nil {
+		err.File, err.Line = c.file(key), key.Line
+	}
+	return err
+}
+
+// lookup follows keys from the document root and returns the key and value
+// nodes of the deepest key found and how many keys were followed.
+func (c *Config) lookup(keys []string) (key, value *yaml.Node, depth int) {
+	if c.root == nil || len(c.root.Content) == 0 {
+		return nil, nil, 0
+	}
+	node := c.root.Content[0]
+	for _, k := range keys {
+		next, nextValue := child(node, k)
+		if next == nil {
+			break
+		}
+		key, value, node = next, nextValue, nextValue
+		depth++
+	}
+	return key, value, depth
+}
+
+// child returns the key and value of name in a mapping, or the item equal
+// to name, as both key and value, in a list.
+func child(node *yaml.Node, name string) (key, value *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode && item.Value == name {
+				return item, item
+			}
+		}
+	}
+	return nil, nil
+}
+
+// file returns the file node was read from.
+func (c *Config) file(node *yaml.Node) string {
+	if file, ok := c.fileOf[node]; ok {
+		return file
+	}
+	return c.File
+}
+
+func (c *Config) position(node *yaml.Node) string {
+	return fmt.Sprintf("%s:%d", c.file(node), node.Line)
+}
+
+var (
+	checkIDPattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-[0-9]+$`)
+	iosTargetPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+)
+
+// Validate checks values that decode but make no sense, such as a
+// malformed check ID or a ratio above 1. Whether check IDs and profiles
+// exist is left to the caller, which knows the registered checks.
+func (c *Config) Validate() Errors {
+	var errs Errors
+
+	if c.FailOn != "" {
+		if _, ok := report.ParseSeverity(c.FailOn); !ok {
+			errs = append(errs, c.Errorf([]string{"fail_on"}, "invalid fail_on %q (must be %s)", c.FailOn, report.SeverityNames()))
+		}
+	}
+
+	if c.Checks != nil {
+		for _, id := range c.Checks.Skip {
+			if !checkIDPattern.MatchString(strings.TrimSpace(id)) {
+				errs = append(errs, c.Errorf([]string{"checks", "skip", id}, "malformed check ID %q", id))
+			}
+		}
+		for _, id := range c.Checks.Include {
+			if !checkIDPattern.MatchString(strings.TrimSpace(id)) {
+				errs = append(errs, c.Errorf([]string{"checks", "include", id}, "malformed check ID %q", id))
+			}
+		}
+		for _, id := range sortedKeys(c.Checks.Overrides) {
+			keys := []string{"checks", "overrides", id}
+			switch {
+			case !checkIDPattern.MatchString(id):
+				errs = append(errs, c.Errorf(keys, "malformed check ID %q", id))
+			case id != strings.ToUpper(id):
+				// Overrides are looked up by the exact ID of a finding.
+				errs = append(errs, c.Errorf(keys, "check ID %q must be written %q", id, strings.ToUpper(id)))
+			}
+			override := c.Checks.Overrides[id]
+			if override == nil || override.Severity == "" {
+				continue
+			}
+			if _, ok := report.ParseSeverity(override.Severity); !ok {
+				errs = append(errs, c.Errorf(append(keys, "severity"), "invalid severity %q (must be %s)", override.Severity, report.SeverityNames()))
+			}
+		}
+	}
+
+	if t := c.Thresholds; t != nil {
+		if t.MinTargetSDK < 0 {
+			errs = append(errs, c.Errorf([]string{"thresholds", "min_target_sdk"}, "min_target_sdk must be a positive API level"))
+		}
+		if t.MinIOSDeploymentTarget != "" && !iosTargetPattern.MatchString(t.MinIOSDeploymentTarget) {
+			errs = append(errs, c.Errorf([]string{"thresholds", "min_ios_deployment_target"}, "min_ios_deployment_target %q is not a version such as 13.0", t.MinIOSDeploymentTarget))
+		}
+		if t.MaxFileLines < 0 {
+			errs = append(errs, c.Errorf([]string{"thresholds", "max_file_lines"}, "max_file_lines must be positive"))
+		}
+		if t.MinTestRatio < 0 || t.MinTestRatio > 1 {
+			errs = append(errs, c.Errorf([]string{"thresholds", "min_test_ratio"}, "min_test_ratio must be between 0 and 1"))
+		}
+	}
+
+	if c.Paths != nil {
+		for _, p := range c.Paths.Include {
+			if _, err := path.Match(p, ""); err != nil {
+				errs = append(errs, c.Errorf([]string{"paths", "include", p}, "invalid glob %q", p))
+			}
+		}
+		for _, p := range c.Paths.Exclude {
+			if _, err := path.Match(p, ""); err != nil {
+				errs = append(errs, c.Errorf([]string{"paths", "exclude", p}, "invalid glob %q", p))
+			}
+		}
+	}
+
+	if file := c.KnowledgeBaseFile(); file != "" {
+		if _, err := os.Stat(file); err != nil {
+			errs = append(errs, c.Errorf([]string{"knowledge_base"}, "knowledge_base %q: %v", c.KnowledgeBase, errors.Unwrap(err)))
+		}
+	}
+
+	for _, name := range sortedKeys(c.Profiles) {
+		p := c.Profiles[name]
+		if p == nil {
+			continue
+		}
+		for _, id := range p.Checks {
+			if !checkIDPattern.MatchString(strings.TrimSpace(id)) {
+				errs = append(errs, c.Errorf([]string{"profiles", name, "checks", id}, "profile %q: malformed check ID %q", name, id))
+			}
+		}
+		for _, id := range p.Exclude {
+			if !checkIDPattern.MatchString(strings.TrimSpace(id)) {
+				errs = append(errs, c.Errorf([]string{"profiles", name, "exclude", id}, "profile %q: malformed check ID %q", name, id))
+			}
+		}
+	}
+
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ReviewerConfigFromEnv loads reviewer config from environment variables
+func ReviewerConfigFromEnv() *ReviewerConfig {
+	cfg := &ReviewerConfig{}
+
+	// Try to get from common env vars
+	if email := os.Getenv("REVIEWER_EMAIL"); email != "" {
+		cfg.Email = email
+	}
+	if password := os.Getenv("REVIEWER_PASSWORD"); password != "" {
+		cfg.Password = password
+	}
+
+	// If not set directly, check for env var names
+	if cfg.Email == "" && os.Getenv("REVIEWER_EMAIL_ENV") != "" {
+		cfg.EmailEnv = os.Getenv("REVIEWER_EMAIL_ENV")
+		cfg.Email = os.Getenv(cfg.EmailEnv)
+	}
+	if cfg.Password == "" && os.Getenv("REVIEWER_PASSWORD_ENV") != "" {
+		cfg.PasswordEnv = os.Getenv("REVIEWER_PASSWORD_ENV")
+		cfg.Password = os.Getenv(cfg.PasswordEnv)
+	}
+
+	// Default to standard env var names if nothing else
+	if cfg.Email == "" && cfg.EmailEnv == "" {
+		cfg.EmailEnv = "REVIEWER_EMAIL"
+	}
+	if cfg.Password == "" && cfg.PasswordEnv == "" {
+		cfg.PasswordEnv = "REVIEWER_PASSWORD"
+	}
+
+	return cfg
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func loadErrors(t *testing.T, content string) Errors {
+	t.Helper()
+	_, err := LoadConfig(writeConfig(t, content))
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected config.Errors, got %v", err)
+	}
+	return errs
+}
+
+func TestLoadConfigMissing(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.File != "" || cfg.Checks != nil || cfg.Line("profile") != 0 {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := writeConfig(t, `profile: full
+checks:
+  skip: [AND-012]
+  overrides:
+    SEC-003:
+      severity: Warning
+    DOC-003:
+      enabled: false
+thresholds:
+  min_target_sdk: 36
+  min_ios_deployment_target: "13.0"
+  max_file_lines: 600
+  min_test_ratio: 0.5
+paths:
+  exclude: ["lib/generated/**"]
+`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.File != filepath.Join(dir, FileName) {
+		t.Errorf("expected File to be set, got %q", cfg.File)
+	}
+	if got := cfg.Checks.Overrides["SEC-003"].Severity; got != "Warning" {
+		t.Errorf("expected SEC-003 severity override, got %q", got)
+	}
+	if e := cfg.Checks.Overrides["DOC-003"].Enabled; e == nil || *e {
+		t.Errorf("expected DOC-003 disabled, got %v", e)
+	}
+	if cfg.Thresholds.MinTargetSDK != 36 || cfg.Thresholds.MinTestRatio != 0.5 {
+		t.Errorf("unexpected thresholds %+v", cfg.Thresholds)
+	}
+	if len(cfg.Paths.Exclude) != 1 {
+		t.Errorf("expected one exclude pattern, got %v", cfg.Paths.Exclude)
+	}
+}
+
+func TestLine(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, `profile: full
+checks:
+  overrides:
+    SEC-003:
+      severity: info
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	tests := []struct {
+		keys []string
+		want int
+	}{
+		{[]string{"profile"}, 1},
+		{[]string{"checks", "overrides", "SEC-003"}, 4},
+		{[]string{"checks", "overrides", "SEC-003", "severity"}, 5},
+		{[]string{"checks", "include"}, 0},
+		{[]string{"profile", "name"}, 0},
+		{[]string{"thresholds"}, 0},
+	}
+	for _, tt := range tests {
+		if got := cfg.Line(tt.keys...); got != tt.want {
+			t.Errorf("Line(%v) = %d, want %d", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigUnknownKey(t *testing.T) {
+	errs := loadErrors(t, `profile: full
+checks:
+  skip: [AND-001]
+  overides:
+    SEC-003: {}
+`)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Line != 4 || !strings.Contains(errs[0].Message, "overides") {
+		t.Errorf("expected unknown key at line 4, got %v", errs[0])
+	}
+	if !strings.HasPrefix(errs[0].Error(), errs[0].File+":4: ") {
+		t.Errorf("expected file:line prefix, got %q", errs[0].Error())
+	}
+}
+
+func TestLoadConfigTypeError(t *testing.T) {
+	errs := loadErrors(t, `thresholds:
+  min_target_sdk: latest
+`)
+	if len(errs) != 1 || errs[0].Line != 2 {
+		t.Fatalf("expected type error at line 2, got %v", errs)
+	}
+}
+
+func TestLoadConfigSyntaxError(t *testing.T) {
+	errs := loadErrors(t, "checks: [\n")
+	if len(errs) != 1 || errs[0].File == "" {
+		t.Fatalf("expected one syntax error, got %v", errs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errs := loadErrors(t, `checks:
+  skip: [bogus]
+  overrides:
+    SEC-003:
+      severity: loud
+    sec-002:
+      severity: critical
+thresholds:
+  min_ios_deployment_target: latest
+  min_test_ratio: 3
+paths:
+  exclude: ["lib/[src"]
+profiles:
+  mine:
+    checks: [AND_001]
+`)
+
+	want := map[int]string{
+		2:  `malformed check ID "bogus"`,
+		5:  `invalid severity "loud"`,
+		6:  `check ID "sec-002" must be written "SEC-002"`,
+		9:  "min_ios_deployment_target",
+		10: "min_test_ratio",
+		12: "invalid glob",
+		15: `malformed check ID "AND_001"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for _, e := range errs {
+		if msg, ok := want[e.Line]; !ok || !strings.Contains(e.Message, msg) {
+			t.Errorf("unexpected error %v", e)
+		}
+	}
+}
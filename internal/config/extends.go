@@ -0,0 +1,342 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PresetPrefix marks a built-in preset in extends, as in fsct:strict.
+const PresetPrefix = "fsct:"
+
+// PresetDir is the directory, relative to the home directory, holding the
+// presets that extends names without a path, as in extends: acme.
+const PresetDir = ".fsct/presets"
+
+// replaceTag on a list or mapping replaces the inherited value instead of
+// appending to or merging with it.
+const replaceTag = "!replace"
+
+// presets are the built-in configurations extends can name with the
+// PresetPrefix.
+var presets = map[string]string{
+	"store-minimum": `# Only what app review is likely to reject.
+profile: store
+checks:
+  overrides:
+    AND-003:
+      enabled: false
+    AND-008:
+      severity: info
+    AND-010:
+      severity: info
+    AND-012:
+      severity: info
+    IOS-010:
+      severity: info
+    POL-002:
+      severity: info
+`,
+	"strict": `# Every check, with warnings that often become rejections raised to high.
+profile: full
+checks:
+  overrides:
+    AND-012:
+      severity: high
+    IOS-011:
+      severity: high
+    SEC-002:
+      severity: high
+thresholds:
+  min_ios_deployment_target: "13.0"
+  max_file_lines: 300
+  min_test_ratio: 0.5
+`,
+}
+
+// Presets returns the names of the built-in presets, with their prefix.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for _, name := range sortedKeys(presets) {
+		names = append(names, PresetPrefix+name)
+	}
+	return names
+}
+
+// loader reads a configuration file and the files it extends and merges
+// them into one document.
+//
+// A file is merged over its bases in the order they are listed, so later
+// bases and the file itself win. Mappings are merged key by key and lists
+// are appended, skipping duplicates, unless the overriding value is tagged
+// !replace. Every file is merged at most once: a base shared by two others
+// is applied where it first appears.
+type loader struct {
+	fileOf  map[*yaml.Node]string
+	replace map[*yaml.Node]bool
+	files   []string
+	merged  map[string]bool
+	stack   []string
+	errs    Errors
+}
+
+func newLoader() *loader {
+	return &loader{
+		fileOf:  make(map[*yaml.Node]string),
+		replace: make(map[*yaml.Node]bool),
+		merged:  make(map[string]bool),
+	}
+}
+
+// load reads file, or uses data if it is not nil, and returns its document
+// merged over the files it extends. Problems are collected in l.errs.
+func (l *loader) load(file string, data []byte) *yaml.Node {
+	l.stack = append(l.stack, file)
+	l.merged[identity(file)] = true
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	if data == nil {
+		var err error
+		if data, err = os.ReadFile(file); err != nil {
+			l.errs = append(l.errs, &Error{File: file, Message: err.Error()})
+			return emptyDocument()
+		}
+	}
+
+	cfg := &Config{}
+	if err := cfg.parse(file, data); err != nil {
+		var errs Errors
+		if !errors.As(err, &errs) {
+			errs = Errors{{File: file, Message: err.Error()}}
+		}
+		l.errs = append(l.errs, errs...)
+		return emptyDocument()
+	}
+	l.errs = append(l.errs, cfg.Validate()...)
+
+	root := emptyMapping()
+	if len(cfg.root.Content) > 0 {
+		root = cfg.root.Content[0]
+	}
+	l.mark(file, root)
+
+	merged := emptyMapping()
+	for _, entry := range cfg.Extends {
+		base, data, err := resolve(file, entry)
+		switch {
+		case err != nil:
+			l.errs = append(l.errs, cfg.Errorf([]string{"extends", entry}, "extends %q: %v", entry, err))
+			continue
+		case l.onStack(base):
+			l.errs = append(l.errs, cfg.Errorf([]string{"extends", entry}, "extends cycle: %s -> %s", strings.Join(l.stack, " -> "), base))
+			continue
+		case l.merged[identity(base)]:
+			continue
+		}
+		merged = l.merge(merged, l.load(base, data).Content[0])
+	}
+
+	l.files = append(l.files, file)
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{l.merge(merged, withoutKey(root, "extends"))}}
+}
+
+// resolve returns the file an extends entry in from names and, for built-in
+// presets, its content.
+func resolve(from, entry string) (string, []byte, error) {
+	switch {
+	case strings.HasPrefix(entry, PresetPrefix):
+		data, ok := presets[strings.TrimPrefix(entry, PresetPrefix)]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown preset (available: %s)", strings.Join(Presets(), ", "))
+		}
+		return entry, []byte(data), nil
+	case strings.Contains(entry, "://"):
+		return "", nil, fmt.Errorf("remote configurations are not supported; copy the file into the repository and extend it by path")
+	case entry == "~" || strings.HasPrefix(entry, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil, err
+		}
+		return filepath.Join(home, entry[1:]), nil, nil
+	case !strings.ContainsAny(entry, `/\`) && filepath.Ext(entry) == "":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil, err
+		}
+		return filepath.Join(home, PresetDir, entry+".yaml"), nil, nil
+	case filepath.IsAbs(entry):
+		return entry, nil, nil
+	default:
+		return filepath.Join(filepath.Dir(from), entry), nil, nil
+	}
+}
+
+// identity returns a key that is the same for every spelling of file.
+func identity(file string) string {
+	if strings.HasPrefix(file, PresetPrefix) {
+		return file
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
+}
+
+func (l *loader) onStack(file string) bool {
+	for _, f := range l.stack {
+		if identity(f) == identity(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// mark records file as the source of node and everything below it, and
+// takes !replace tags off, remembering them for merge.
+func (l *loader) mark(file string, node *yaml.Node) {
+	l.fileOf[node] = file
+	if node.Tag == replaceTag {
+		if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+			l.replace[node] = true
+		} else {
+			l.errs = append(l.errs, &Error{File: file, Line: node.Line, Message: replaceTag + " applies only to lists and mappings"})
+		}
+		node.Tag = ""
+	}
+	for _, child := range node.Content {
+		l.mark(file, child)
+	}
+}
+
+// merge returns the mapping src laid over the mapping dst.
+func (l *loader) merge(dst, src *yaml.Node) *yaml.Node {
+	out := emptyMapping()
+	out.Content = append(out.Content, dst.Content...)
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		j := indexOf(out, key.Value)
+		if j < 0 {
+			out.Content = append(out.Content, key, value)
+			continue
+		}
+
+		old := out.Content[j+1]
+		switch {
+		case l.replace[value]:
+			out.Content[j], out.Content[j+1] = key, value
+		case old.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			out.Content[j+1] = l.merge(old, value)
+		case old.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			out.Content[j+1] = appendItems(old, value)
+		default:
+			out.Content[j], out.Content[j+1] = key, value
+		}
+	}
+	return out
+}
+
+// appendItems returns the items of dst followed by those of src that are
+// not already in dst.
+func appendItems(dst, src *yaml.Node) *yaml.Node {
+	out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: dst.Style}
+	out.Content = append(out.Content, dst.Content...)
+
+	seen := make(map[string]bool)
+	for _, item := range dst.Content {
+		if item.Kind == yaml.ScalarNode {
+			seen[item.Value] = true
+		}
+	}
+	for _, item := range src.Content {
+		if item.Kind == yaml.ScalarNode {
+			if seen[item.Value] {
+				continue
+			}
+			seen[item.Value] = true
+		}
+		out.Content = append(out.Content, item)
+	}
+	return out
+}
+
+func indexOf(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func withoutKey(mapping *yaml.Node, key string) *yaml.Node {
+	out := emptyMapping()
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			out.Content = append(out.Content, mapping.Content[i], mapping.Content[i+1])
+		}
+	}
+	return out
+}
+
+func emptyMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func emptyDocument() *yaml.Node {
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{emptyMapping()}}
+}
+
+// Document returns the merged configuration as a YAML document whose values
+// are commented with the file and line they came from.
+func (c *Config) Document() *yaml.Node {
+	if c.root == nil || len(c.root.Content) == 0 {
+		return emptyDocument()
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{c.annotate(c.root.Content[0], nil)}}
+}
+
+func (c *Config) annotate(mapping *yaml.Node, path []string) *yaml.Node {
+	out := emptyMapping()
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		keys := append(append([]string(nil), path...), key.Value)
+
+		var node *yaml.Node
+		if value.Kind == yaml.MappingNode {
+			node = c.annotate(value, keys)
+		} else {
+			node = copyNode(value)
+			node.LineComment = c.Source(keys...)
+			if node.Kind == yaml.SequenceNode {
+				node.Style = yaml.FlowStyle
+			}
+		}
+		out.Content = append(out.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key.Value}, node)
+	}
+	return out
+}
+
+// copyNode returns a copy of node without its comments.
+func copyNode(node *yaml.Node) *yaml.Node {
+	out := *node
+	out.HeadComment, out.LineComment, out.FootComment = "", "", ""
+	out.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		out.Content[i] = copyNode(child)
+	}
+	return &out
+}
+
+// Bases returns the files c extends, directly or indirectly, in the order
+// they were merged.
+func (c *Config) Bases() []string {
+	if len(c.Files) == 0 {
+		return nil
+	}
+	return c.Files[:len(c.Files)-1]
+}
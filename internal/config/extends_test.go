@@ -0,0 +1,277 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtendsRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "shared", "base.yaml"), `profile: quality
+checks:
+  skip: [AND-012, DOC-003]
+  overrides:
+    SEC-003:
+      severity: warning
+thresholds:
+  max_file_lines: 500
+  min_test_ratio: 0.3
+`)
+	app := filepath.Join(dir, "app")
+	writeFile(t, filepath.Join(app, FileName), `extends: ../shared/base.yaml
+profile: full
+checks:
+  skip: [DOC-003, AND-011]
+  overrides:
+    SEC-003:
+      enabled: false
+thresholds:
+  max_file_lines: 300
+`)
+
+	cfg, err := LoadConfig(app)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Profile != "full" {
+		t.Errorf("expected profile overridden to full, got %q", cfg.Profile)
+	}
+	if got := strings.Join(cfg.Checks.Skip, ","); got != "AND-012,DOC-003,AND-011" {
+		t.Errorf("expected skip lists appended without duplicates, got %s", got)
+	}
+	override := cfg.Checks.Overrides["SEC-003"]
+	if override.Severity != "warning" || override.Enabled == nil || *override.Enabled {
+		t.Errorf("expected overrides merged key by key, got %+v", override)
+	}
+	if cfg.Thresholds.MaxFileLines != 300 || cfg.Thresholds.MinTestRatio != 0.3 {
+		t.Errorf("expected thresholds merged, got %+v", cfg.Thresholds)
+	}
+
+	base := filepath.Join(app, "..", "shared", "base.yaml")
+	if len(cfg.Files) != 2 || cfg.Files[0] != base || cfg.Files[1] != cfg.File {
+		t.Errorf("expected files [base, .fsct.yaml], got %v", cfg.Files)
+	}
+
+	sources := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"profile"}, cfg.File + ":2"},
+		{[]string{"checks", "overrides", "SEC-003", "severity"}, base + ":6"},
+		{[]string{"checks", "overrides", "SEC-003", "enabled"}, cfg.File + ":7"},
+		{[]string{"thresholds", "max_file_lines"}, cfg.File + ":9"},
+		{[]string{"thresholds", "min_test_ratio"}, base + ":9"},
+	}
+	for _, tt := range sources {
+		if got := cfg.Source(tt.keys...); got != tt.want {
+			t.Errorf("Source(%v) = %q, want %q", tt.keys, got, tt.want)
+		}
+	}
+	if got, want := cfg.Source("checks", "skip"), base+":3, "+cfg.File+":4"; got != want {
+		t.Errorf("Source(checks.skip) = %q, want %q", got, want)
+	}
+	if got := cfg.Source("checks", "include"); got != "" {
+		t.Errorf("expected no source for unset key, got %q", got)
+	}
+}
+
+func TestExtendsReplace(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "base.yaml"), `checks:
+  skip: [AND-012, DOC-003]
+  overrides:
+    SEC-003:
+      severity: warning
+`)
+	writeFile(t, filepath.Join(dir, FileName), `extends: base.yaml
+checks:
+  skip: !replace [AND-011]
+  overrides: !replace
+    SEC-001:
+      severity: info
+`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if got := strings.Join(cfg.Checks.Skip, ","); got != "AND-011" {
+		t.Errorf("expected skip replaced, got %s", got)
+	}
+	if _, ok := cfg.Checks.Overrides["SEC-003"]; ok || len(cfg.Checks.Overrides) != 1 {
+		t.Errorf("expected overrides replaced, got %v", cfg.Checks.Overrides)
+	}
+}
+
+func TestExtendsPresets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeFile(t, filepath.Join(home, PresetDir, "acme.yaml"), `extends: fsct:strict
+checks:
+  skip: [DOC-003]
+`)
+	writeFile(t, filepath.Join(home, "team.yaml"), `thresholds:
+  min_test_ratio: 0.25
+`)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, FileName), `extends: [acme, ~/team.yaml]
+`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Profile != "full" || cfg.Checks.Overrides["SEC-002"] == nil {
+		t.Errorf("expected fsct:strict settings, got profile %q", cfg.Profile)
+	}
+	if cfg.Thresholds.MinTestRatio != 0.25 || cfg.Thresholds.MaxFileLines != 300 {
+		t.Errorf("expected later base to win, got %+v", cfg.Thresholds)
+	}
+	want := []string{"fsct:strict", filepath.Join(home, PresetDir, "acme.yaml"), filepath.Join(home, "team.yaml"), cfg.File}
+	if strings.Join(cfg.Files, "|") != strings.Join(want, "|") {
+		t.Errorf("expected merge order %v, got %v", want, cfg.Files)
+	}
+	if got := cfg.Source("profile"); got != "fsct:strict:2" {
+		t.Errorf("expected profile from fsct:strict, got %q", got)
+	}
+}
+
+func TestBuiltinPresetsValid(t *testing.T) {
+	for _, name := range Presets() {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, FileName), "extends: "+name+"\n")
+		if _, err := LoadConfig(dir); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		message string
+		line    int
+	}{
+		{
+			name:    "missing file",
+			files:   map[string]string{FileName: "profile: full\nextends: [fsct:strict, nope.yaml]\n"},
+			message: "nope.yaml",
+		},
+		{
+			name:    "unknown preset",
+			files:   map[string]string{FileName: "extends: fsct:nope\n"},
+			message: "fsct:store-minimum, fsct:strict",
+			line:    1,
+		},
+		{
+			name:    "remote",
+			files:   map[string]string{FileName: "extends: https://example.com/fsct.yaml\n"},
+			message: "remote configurations are not supported",
+			line:    1,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				FileName: "extends: a.yaml\n",
+				"a.yaml": "extends: b.yaml\n",
+				"b.yaml": "\nextends: a.yaml\n",
+			},
+			message: "extends cycle",
+			line:    2,
+		},
+		{
+			name: "error in base",
+			files: map[string]string{
+				FileName:    "extends: base.yaml\n",
+				"base.yaml": "checks:\n  skp: [AND-001]\n",
+			},
+			message: "skp",
+			line:    2,
+		},
+		{
+			name:    "missing knowledge base",
+			files:   map[string]string{FileName: "profile: full\nknowledge_base: kb.json\n"},
+			message: "knowledge_base \"kb.json\": no such file",
+			line:    2,
+		},
+		{
+			name:    "replace scalar",
+			files:   map[string]string{FileName: "profile: !replace full\n"},
+			message: "!replace",
+			line:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			_, err := LoadConfig(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected error containing %q, got %v", tt.message, err)
+			}
+			errs, ok := err.(Errors)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("expected one error, got %v", err)
+			}
+			if tt.line > 0 && errs[0].Line != tt.line {
+				t.Errorf("expected error at line %d, got %v", tt.line, errs[0])
+			}
+		})
+	}
+}
+
+func TestExtendsKnowledgeBase(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "shared", "base.yaml"), "knowledge_base: acme-kb.json\n")
+	writeFile(t, filepath.Join(dir, "shared", "acme-kb.json"), `{"schema": 1, "packages": {}}`)
+	app := filepath.Join(dir, "app")
+	writeFile(t, filepath.Join(app, FileName), "extends: ../shared/base.yaml\n")
+
+	cfg, err := LoadConfig(app)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if want := filepath.Join(dir, "shared", "acme-kb.json"); cfg.KnowledgeBaseFile() != want {
+		t.Errorf("KnowledgeBaseFile() = %q, want %q relative to the base", cfg.KnowledgeBaseFile(), want)
+	}
+	if want := filepath.Join(dir, "shared", "base.yaml") + ":1"; cfg.Source("knowledge_base") != want {
+		t.Errorf("Source(knowledge_base) = %q, want %q", cfg.Source("knowledge_base"), want)
+	}
+}
+
+func TestExtendsSharedBaseMergedOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "base.yaml"), "thresholds:\n  max_file_lines: 500\n")
+	writeFile(t, filepath.Join(dir, "a.yaml"), "extends: base.yaml\nthresholds:\n  max_file_lines: 200\n")
+	writeFile(t, filepath.Join(dir, "b.yaml"), "extends: ./base.yaml\n")
+	writeFile(t, filepath.Join(dir, FileName), "extends: [a.yaml, b.yaml]\n")
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Thresholds.MaxFileLines != 200 {
+		t.Errorf("expected base applied once, before a.yaml; got %d", cfg.Thresholds.MaxFileLines)
+	}
+	if len(cfg.Files) != 4 {
+		t.Errorf("expected 4 files, got %v", cfg.Files)
+	}
+}
@@ -57,7 +57,14 @@ func loadFindings(path string) ([]report.Finding, error) {
 		return nil, err
 	}
 
-	return report.Findings, nil
+	// Suppressed findings are accepted; they are not part of the comparison.
+	findings := report.Findings[:0]
+	for _, f := range report.Findings {
+		if f.Suppression == nil {
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
 }
 
 func Compare(oldFindings, newFindings []report.Finding) *DiffResult {
@@ -71,12 +78,14 @@ func Compare(oldFindings, newFindings []report.Finding) *DiffResult {
 	oldMap := make(map[string]report.Finding)
 	newMap := make(map[string]report.Finding)
 
+	oldCount := make(map[string]int)
 	for _, f := range oldFindings {
-		oldMap[generateKey(f)] = f
+		oldMap[occurrenceKey(f, oldCount)] = f
 	}
 
+	newCount := make(map[string]int)
 	for _, f := range newFindings {
-		newMap[generateKey(f)] = f
+		newMap[occurrenceKey(f, newCount)] = f
 	}
 
 	for key, newF := range newMap {
@@ -115,10 +124,27 @@ func Compare(oldFindings, newFindings []report.Finding) *DiffResult {
 	return result
 }
 
+// generateKey identifies a finding across reports by its fingerprint.
+// Reports written before findings carried fingerprints fall back to the
+// check, file and message.
 func generateKey(f report.Finding) string {
+	if f.Fingerprint != "" {
+		return f.Fingerprint
+	}
 	return fmt.Sprintf("%s|%s|%s", f.ID, f.File, f.Message)
 }
 
+// occurrenceKey numbers findings that share a key, so that a report with
+// two identical findings differs from one with a single finding.
+func occurrenceKey(f report.Finding, seen map[string]int) string {
+	key := generateKey(f)
+	seen[key]++
+	if n := seen[key]; n > 1 {
+		return fmt.Sprintf("%s#%d", key, n)
+	}
+	return key
+}
+
 func (d *DiffResult) Format(style string) string {
 	switch style {
 	case "json":
@@ -1,15 +1,21 @@
 package filter
 
-import (
-	"strings"
-
-	"github.com/ricky-irfandi/fsct/internal/report"
-)
+import "github.com/ricky-irfandi/fsct/internal/report"
 
 type Filter struct {
+	// IgnorePatterns are gitignore-style patterns, see Ignore. Set them
+	// with SetIgnorePatterns.
 	IgnorePatterns []string
 	MinSeverity    report.Severity
 	AllowedChecks  map[string]bool
+
+	// IncludePaths and ExcludePaths are gitignore-style patterns, see
+	// Ignore, matched by ShouldIncludePath. Set them with SetPaths.
+	IncludePaths []string
+	ExcludePaths []string
+
+	ignore  *Ignore
+	include *Ignore
 }
 
 func NewFilter() *Filter {
@@ -20,17 +26,21 @@ func NewFilter() *Filter {
 	}
 }
 
+// ShouldIgnore reports whether the project-relative file, or a directory
+// above it, matches IgnorePatterns. A pattern such as build matches a file
+// or directory named build, not lib/build_utils.dart.
 func (f *Filter) ShouldIgnore(file string) bool {
-	for _, pattern := range f.IgnorePatterns {
-		if strings.Contains(file, pattern) {
-			return true
-		}
+	if f.ignore == nil {
+		f.ignore = NewIgnore(f.IgnorePatterns...)
 	}
-	return false
+	return f.ignore.Ignored(file)
 }
 
+// ShouldInclude reports whether a finding of the given severity from the
+// check checkID is reported: it must be at least MinSeverity and, when
+// AllowedChecks is set, from an allowed check.
 func (f *Filter) ShouldInclude(severity report.Severity, checkID string) bool {
-	if f.MinSeverity == report.SeverityHigh && severity != report.SeverityHigh {
+	if !severity.AtLeast(f.MinSeverity) {
 		return false
 	}
 
@@ -41,19 +51,44 @@ func (f *Filter) ShouldInclude(severity report.Severity, checkID string) bool {
 	return true
 }
 
+// SetPaths sets the patterns a finding's file must match, if any, and
+// those it must not match. The excluded paths are also the IgnorePatterns.
+func (f *Filter) SetPaths(include, exclude []string) {
+	f.IncludePaths = include
+	f.ExcludePaths = exclude
+	f.include = NewIgnore(include...)
+	f.SetIgnorePatterns(exclude)
+}
+
+// ShouldIncludePath reports whether findings in the project-relative file
+// are reported under IncludePaths and ExcludePaths. As in a .gitignore, a
+// later pattern starting with ! takes a file back out of a list.
+func (f *Filter) ShouldIncludePath(file string) bool {
+	if f.ShouldIgnore(file) {
+		return false
+	}
+	if len(f.IncludePaths) == 0 {
+		return true
+	}
+	if f.include == nil {
+		f.include = NewIgnore(f.IncludePaths...)
+	}
+	return f.include.Ignored(file)
+}
+
 func (f *Filter) SetIgnorePatterns(patterns []string) {
 	f.IgnorePatterns = patterns
+	f.ignore = NewIgnore(patterns...)
 }
 
+// SetMinSeverity sets MinSeverity from a name such as "warning". Unknown
+// names select report.SeverityInfo.
 func (f *Filter) SetMinSeverity(severity string) {
-	switch strings.ToLower(severity) {
-	case "high":
-		f.MinSeverity = report.SeverityHigh
-	case "warning":
-		f.MinSeverity = report.SeverityWarning
-	default:
-		f.MinSeverity = report.SeverityInfo
+	min, ok := report.ParseSeverity(severity)
+	if !ok {
+		min = report.SeverityInfo
 	}
+	f.MinSeverity = min
 }
 
 func (f *Filter) SetAllowedChecks(checkIDs []string) {
@@ -62,31 +97,3 @@ func (f *Filter) SetAllowedChecks(checkIDs []string) {
 		f.AllowedChecks[id] = true
 	}
 }
-
-type SeverityFilter struct {
-	MinSeverity report.Severity
-}
-
-func NewSeverityFilter(minSeverity report.Severity) *SeverityFilter {
-	return &SeverityFilter{MinSeverity: minSeverity}
-}
-
-func (s *SeverityFilter) Filter(findings []report.Finding) []report.Finding {
-	filtered := make([]report.Finding, 0)
-	for _, finding := range findings {
-		if s.shouldInclude(finding.Severity) {
-			filtered = append(filtered, finding)
-		}
-	}
-	return filtered
-}
-
-func (s *SeverityFilter) shouldInclude(severity report.Severity) bool {
-	if s.MinSeverity == report.SeverityHigh {
-		return severity == report.SeverityHigh
-	}
-	if s.MinSeverity == report.SeverityWarning {
-		return severity == report.SeverityHigh || severity == report.SeverityWarning
-	}
-	return true
-}
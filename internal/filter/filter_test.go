@@ -0,0 +1,38 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func TestShouldInclude(t *testing.T) {
+	f := NewFilter()
+	f.SetMinSeverity("warning")
+
+	tests := []struct {
+		severity report.Severity
+		want     bool
+	}{
+		{report.SeverityInfo, false},
+		{report.SeverityWarning, true},
+		{report.SeverityError, true},
+		{report.SeverityHigh, true},
+		{report.SeverityCritical, true},
+	}
+	for _, tt := range tests {
+		if got := f.ShouldInclude(tt.severity, "AND-001"); got != tt.want {
+			t.Errorf("ShouldInclude(%s) with min warning = %v, want %v", tt.severity, got, tt.want)
+		}
+	}
+
+	f.SetMinSeverity("critical")
+	if f.ShouldInclude(report.SeverityHigh, "AND-001") {
+		t.Error("HIGH should be excluded with min critical")
+	}
+
+	f.SetAllowedChecks([]string{"IOS-001"})
+	if f.ShouldInclude(report.SeverityCritical, "AND-001") {
+		t.Error("checks outside AllowedChecks should be excluded")
+	}
+}
@@ -0,0 +1,188 @@
+package filter
+
+import (
+	"path"
+	"strings"
+)
+
+// GeneratedPatterns match files written by code generators such as
+// build_runner, freezed, mockito and intl. Findings in them are rarely
+// actionable, so they are skipped unless asked for.
+var GeneratedPatterns = []string{
+	"*.g.dart",
+	"*.freezed.dart",
+	"*.mocks.dart",
+	"lib/generated/",
+}
+
+// Ignore matches project paths against gitignore-style patterns:
+//
+//   - a pattern without a slash, such as *.g.dart, matches a name at any
+//     depth below the directory it was added for;
+//   - a pattern with a leading or inner slash, such as /build or
+//     lib/generated, is relative to that directory;
+//   - a trailing slash matches directories only;
+//   - ** matches any number of directories;
+//   - a leading ! re-includes what an earlier pattern ignored.
+//
+// The last matching pattern wins. As in git, a file cannot be re-included
+// if a directory above it is ignored.
+type Ignore struct {
+	rules []rule
+}
+
+type rule struct {
+	dir      string
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// NewIgnore returns an Ignore with patterns relative to the project root.
+func NewIgnore(patterns ...string) *Ignore {
+	ig := &Ignore{}
+	ig.Add("", patterns...)
+	return ig
+}
+
+// Add adds patterns relative to dir, a slash-separated project-relative
+// directory or "" for the root. Blank patterns and comments are skipped.
+func (ig *Ignore) Add(dir string, patterns ...string) {
+	dir = strings.Trim(dir, "/")
+	for _, p := range patterns {
+		if r, ok := parseRule(dir, p); ok {
+			ig.rules = append(ig.rules, r)
+		}
+	}
+}
+
+// AddFile adds the patterns in content, the text of a .gitignore file in
+// dir.
+func (ig *Ignore) AddFile(dir, content string) {
+	ig.Add(dir, strings.Split(content, "\n")...)
+}
+
+func parseRule(dir, pattern string) (rule, bool) {
+	pattern = strings.TrimRight(strings.TrimSuffix(pattern, "\r"), " \t")
+	if strings.HasSuffix(pattern, `\`) {
+		pattern += " "
+	}
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return rule{}, false
+	}
+
+	r := rule{dir: dir}
+	switch {
+	case strings.HasPrefix(pattern, "!"):
+		r.negate = true
+		pattern = pattern[1:]
+	case strings.HasPrefix(pattern, `\!`), strings.HasPrefix(pattern, `\#`):
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		r.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if pattern == "" {
+		return rule{}, false
+	}
+
+	if strings.Contains(pattern, "/") {
+		r.segments = strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	} else {
+		r.segments = []string{"**", pattern}
+	}
+	return r, true
+}
+
+// Match reports whether the slash-separated project-relative path name is
+// ignored by its own patterns, without looking at its parent directories.
+// It suits directory walks that skip ignored directories.
+func (ig *Ignore) Match(name string, isDir bool) bool {
+	if ig == nil {
+		return false
+	}
+	name = cleanPath(name)
+	if name == "" {
+		return false
+	}
+
+	ignored := false
+	for _, r := range ig.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.matches(name) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Ignored reports whether the project-relative file name, or a directory
+// above it, is ignored.
+func (ig *Ignore) Ignored(name string) bool {
+	name = cleanPath(name)
+	segments := strings.Split(name, "/")
+	for n := 1; n < len(segments); n++ {
+		if ig.Match(strings.Join(segments[:n], "/"), true) {
+			return true
+		}
+	}
+	return ig.Match(name, false)
+}
+
+func (r rule) matches(name string) bool {
+	if r.dir != "" {
+		if !strings.HasPrefix(name, r.dir+"/") {
+			return false
+		}
+		name = name[len(r.dir)+1:]
+	}
+	segments := strings.Split(name, "/")
+
+	// A trailing ** matches everything inside a directory, but not the
+	// directory itself.
+	if last := len(r.segments) - 1; r.segments[last] == "**" {
+		for n := 1; n < len(segments); n++ {
+			if matchSegments(r.segments[:last], segments[:n]) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchSegments(r.segments, segments)
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func cleanPath(name string) string {
+	name = strings.TrimPrefix(strings.ReplaceAll(name, `\`, "/"), "./")
+	if name == "" {
+		return ""
+	}
+	name = strings.Trim(path.Clean(name), "/")
+	if name == "." {
+		return ""
+	}
+	return name
+}
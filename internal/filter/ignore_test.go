@@ -0,0 +1,76 @@
+package filter
+
+import "testing"
+
+func TestIgnore(t *testing.T) {
+	ig := NewIgnore(
+		"build",
+		"*.g.dart",
+		"/docs/",
+		"lib/generated/**",
+		"secrets/*",
+		"!secrets/README.md",
+		`\!important.dart`,
+		"# comment",
+		"",
+	)
+	ig.Add("packages/core", "fixtures/")
+
+	tests := []struct {
+		name    string
+		ignored bool
+	}{
+		{"build", true},
+		{"build/app.dart", true},
+		{"android/build/outputs/app.apk", true},
+		{"lib/build_utils.dart", false},
+		{"lib/models/user.g.dart", true},
+		{"lib/models/user.dart", false},
+		{"docs/guide.md", true},
+		{"lib/docs/guide.md", false},
+		{"lib/generated/intl/messages.dart", true},
+		{"lib/generated", false},
+		{"secrets/key.txt", true},
+		{"secrets/README.md", false},
+		{"!important.dart", true},
+		{"important.dart", false},
+		{"packages/core/fixtures/data.json", true},
+		{"fixtures/data.json", false},
+		{"./build/x.dart", true},
+	}
+	for _, tt := range tests {
+		if got := ig.Ignored(tt.name); got != tt.ignored {
+			t.Errorf("Ignored(%q) = %v, want %v", tt.name, got, tt.ignored)
+		}
+	}
+}
+
+func TestIgnoreParentDirectory(t *testing.T) {
+	ig := NewIgnore("vendor/", "!vendor/keep.dart")
+	if !ig.Ignored("vendor/keep.dart") {
+		t.Error("expected a file in an ignored directory to stay ignored")
+	}
+	if ig.Match("vendor/keep.dart", false) {
+		t.Error("expected Match to consider only the file's own patterns")
+	}
+	if ig.Match("vendor", false) || !ig.Match("vendor", true) {
+		t.Error("expected a trailing slash to match directories only")
+	}
+}
+
+func TestShouldIgnore(t *testing.T) {
+	f := NewFilter()
+	f.SetIgnorePatterns([]string{"build", "*.freezed.dart"})
+
+	if f.ShouldIgnore("lib/build_utils.dart") {
+		t.Error("expected build not to match lib/build_utils.dart")
+	}
+	if !f.ShouldIgnore("build/generated.dart") || !f.ShouldIgnore("lib/a.freezed.dart") {
+		t.Error("expected glob patterns to match")
+	}
+
+	f = &Filter{IgnorePatterns: []string{"tmp/"}}
+	if !f.ShouldIgnore("tmp/x.dart") {
+		t.Error("expected IgnorePatterns set directly to apply")
+	}
+}
@@ -0,0 +1,60 @@
+// Package fingerprint assigns report.Fingerprint values to the findings of
+// a run.
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// Fingerprinter computes fingerprints for the findings of the project at
+// root. A finding without a subject is identified by the text of its line,
+// read from the project, so it keeps its fingerprint when the line moves.
+type Fingerprinter struct {
+	root  string
+	lines map[string][]string
+}
+
+// New returns a fingerprinter for the project at root.
+func New(root string) *Fingerprinter {
+	return &Fingerprinter{root: root, lines: make(map[string][]string)}
+}
+
+// Assign sets the fingerprint of every finding that does not have one.
+func (fp *Fingerprinter) Assign(findings []report.Finding) {
+	for i := range findings {
+		if findings[i].Fingerprint == "" {
+			findings[i].Fingerprint = fp.Fingerprint(findings[i])
+		}
+	}
+}
+
+// Fingerprint returns the fingerprint of f.
+func (fp *Fingerprinter) Fingerprint(f report.Finding) string {
+	subject := f.Subject
+	if subject == "" {
+		subject = fp.snippet(report.NormalizePath(f.File), f.Line)
+	}
+	return report.Fingerprint(f.ID, f.File, subject)
+}
+
+func (fp *Fingerprinter) snippet(file string, line int) string {
+	if line <= 0 || file == "" {
+		return ""
+	}
+	lines, ok := fp.lines[file]
+	if !ok {
+		data, err := os.ReadFile(filepath.Join(fp.root, filepath.FromSlash(file)))
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		fp.lines[file] = lines
+	}
+	if line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
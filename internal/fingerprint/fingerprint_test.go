@@ -0,0 +1,98 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "lib/api.dart", "const a = 'http://a.example.com';\n  const   b = 'http://b.example.com';\n")
+
+	fp := New(root)
+	a := report.Finding{ID: "SEC-003", File: "lib/api.dart", Line: 1, Message: "Found insecure HTTP URL"}
+
+	t.Run("ignores message", func(t *testing.T) {
+		reworded := a
+		reworded.Message = "Insecure HTTP URL found"
+		if fp.Fingerprint(a) != fp.Fingerprint(reworded) {
+			t.Error("expected fingerprint to ignore the message")
+		}
+	})
+
+	t.Run("normalises path", func(t *testing.T) {
+		dotted := a
+		dotted.File = "./lib/api.dart"
+		if fp.Fingerprint(a) != fp.Fingerprint(dotted) {
+			t.Error("expected fingerprint to ignore ./ prefix")
+		}
+	})
+
+	t.Run("distinguishes lines by content", func(t *testing.T) {
+		b := a
+		b.Line = 2
+		if fp.Fingerprint(a) == fp.Fingerprint(b) {
+			t.Error("expected different lines to have different fingerprints")
+		}
+	})
+
+	t.Run("survives line shifts", func(t *testing.T) {
+		shifted := t.TempDir()
+		writeFile(t, shifted, "lib/api.dart", "// header\n\nconst b = 'http://b.example.com';\n")
+		moved := report.Finding{ID: "SEC-003", File: "lib/api.dart", Line: 3}
+		b := report.Finding{ID: "SEC-003", File: "lib/api.dart", Line: 2}
+		if New(shifted).Fingerprint(moved) != fp.Fingerprint(b) {
+			t.Error("expected fingerprint to follow the line content, not its number")
+		}
+	})
+
+	t.Run("distinguishes checks", func(t *testing.T) {
+		other := a
+		other.ID = "SEC-001"
+		if fp.Fingerprint(a) == fp.Fingerprint(other) {
+			t.Error("expected different checks to have different fingerprints")
+		}
+	})
+
+	t.Run("subject takes precedence over line", func(t *testing.T) {
+		camera := report.Finding{ID: "AND-004", File: "android/app/src/main/AndroidManifest.xml", Message: "CAMERA"}.WithSubject("CAMERA")
+		location := camera.WithSubject("ACCESS_FINE_LOCATION")
+		if fp.Fingerprint(camera) == fp.Fingerprint(location) {
+			t.Error("expected different subjects to have different fingerprints")
+		}
+		moved := camera
+		moved.Line = 12
+		if fp.Fingerprint(camera) != fp.Fingerprint(moved) {
+			t.Error("expected a finding with a subject to ignore its line")
+		}
+	})
+}
+
+func TestAssign(t *testing.T) {
+	findings := []report.Finding{
+		{ID: "DOC-004", File: "project root"},
+		{ID: "DOC-003", File: "project root", Fingerprint: "kept"},
+	}
+	New(t.TempDir()).Assign(findings)
+
+	if findings[0].Fingerprint != report.Fingerprint("DOC-004", "project root", "") {
+		t.Errorf("unexpected fingerprint %q", findings[0].Fingerprint)
+	}
+	if findings[1].Fingerprint != "kept" {
+		t.Errorf("expected existing fingerprint to be kept, got %q", findings[1].Fingerprint)
+	}
+}
@@ -47,12 +47,18 @@ func (f *YAMLFormatter) Format(results []report.Finding, summary report.Summary)
 	output := fmt.Sprintf(`version: "1.0.0"
 timestamp: "%s"
 summary:
+  critical: %d
   high: %d
+  error: %d
   warning: %d
   info: %d
   passed: %d
+  suppressed: %d
+  baselined: %d
+  checks: %d
+  profile: "%s"
 findings:
-`, time.Now().Format(time.RFC3339), summary.High, summary.Warning, summary.Info, summary.Passed)
+`, time.Now().Format(time.RFC3339), summary.Critical, summary.High, summary.Error, summary.Warning, summary.Info, summary.Passed, summary.Suppressed, summary.Baselined, summary.Checks, summary.Profile)
 
 	for _, finding := range results {
 		output += fmt.Sprintf(`  - id: "%s"
@@ -62,7 +68,15 @@ findings:
     file: "%s"
     line: %d
     suggestion: "%s"
-`, finding.ID, finding.Severity, finding.Title, finding.Message, finding.File, finding.Line, finding.Suggestion)
+    fingerprint: "%s"
+`, finding.ID, finding.Severity, finding.Title, finding.Message, finding.File, finding.Line, finding.Suggestion, finding.Fingerprint)
+		if s := finding.Suppression; s != nil {
+			output += fmt.Sprintf(`    suppression:
+      reason: "%s"
+      file: "%s"
+      line: %d
+`, s.Reason, s.File, s.Line)
+		}
 	}
 
 	return []byte(output), nil
@@ -91,19 +105,25 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
         .stat { text-align: center; padding: 10px 20px; background: white; border-radius: 4px; box-shadow: 0 1px 3px rgba(0,0,0,0.1); }
         .stat-value { font-size: 32px; font-weight: bold; }
         .stat-label { font-size: 12px; color: #666; text-transform: uppercase; }
+        .critical { color: #b71c1c; }
         .high { color: #f44336; }
+        .error { color: #e65100; }
         .warning { color: #ff9800; }
         .info { color: #2196F3; }
         .passed { color: #4CAF50; }
         .findings { padding: 20px; }
         .finding { padding: 15px; margin-bottom: 10px; border-radius: 4px; border-left: 4px solid #ddd; background: #fafafa; }
+        .finding.critical { border-left-color: #b71c1c; }
         .finding.high { border-left-color: #f44336; }
+        .finding.error { border-left-color: #e65100; }
         .finding.warning { border-left-color: #ff9800; }
         .finding.info { border-left-color: #2196F3; }
         .finding-header { display: flex; justify-content: space-between; align-items: center; margin-bottom: 5px; }
         .finding-id { font-weight: bold; color: #666; }
         .finding-severity { padding: 2px 8px; border-radius: 12px; font-size: 12px; font-weight: bold; text-transform: uppercase; }
+        .severity-critical { background: #b71c1c; color: white; }
         .severity-high { background: #ffebee; color: #c62828; }
+        .severity-error { background: #fbe9e7; color: #d84315; }
         .severity-warning { background: #fff3e0; color: #ef6c00; }
         .severity-info { background: #e3f2fd; color: #1565c0; }
         .finding-title { font-weight: 600; margin-bottom: 5px; }
@@ -111,6 +131,8 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
         .finding-file { font-size: 12px; color: #999; }
         .finding-suggestion { margin-top: 10px; padding: 10px; background: white; border-radius: 4px; font-size: 13px; }
         .finding-suggestion strong { color: #4CAF50; }
+        .finding.suppressed { opacity: 0.6; border-left-color: #9e9e9e; }
+        .finding-suppression { margin-top: 10px; font-size: 13px; color: #666; }
         .passed-message { text-align: center; padding: 40px; color: #4CAF50; font-size: 18px; }
     </style>
 </head>
@@ -118,14 +140,22 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
     <div class="container">
         <header>
             <h1>FSCT Report</h1>
-            <p>Generated at {{.Timestamp}}</p>
+            <p>Generated at {{.Timestamp}}{{if .Summary.Profile}} · {{.Summary.Profile}} profile, {{.Summary.Checks}} checks{{end}}</p>
         </header>
         <div class="summary">
-            <div class="stat high">
+            {{if .Summary.Critical}}<div class="stat critical">
+                <div class="stat-value">{{.Summary.Critical}}</div>
+                <div class="stat-label">Critical</div>
+            </div>
+            {{end}}<div class="stat high">
                 <div class="stat-value">{{.Summary.High}}</div>
                 <div class="stat-label">High</div>
             </div>
-            <div class="stat warning">
+            {{if .Summary.Error}}<div class="stat error">
+                <div class="stat-value">{{.Summary.Error}}</div>
+                <div class="stat-label">Error</div>
+            </div>
+            {{end}}<div class="stat warning">
                 <div class="stat-value">{{.Summary.Warning}}</div>
                 <div class="stat-label">Warning</div>
             </div>
@@ -141,7 +171,7 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
         <div class="findings">
             {{if .Findings}}
                 {{range .Findings}}
-                <div class="finding {{.SeverityClass}}">
+                <div class="finding {{.SeverityClass}}{{if .Suppression}} suppressed{{end}}">
                     <div class="finding-header">
                         <span class="finding-id">{{.ID}}</span>
                         <span class="finding-severity severity-{{.SeverityClass}}">{{.Severity}}</span>
@@ -152,6 +182,9 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
                     {{if .Suggestion}}
                     <div class="finding-suggestion"><strong>Suggestion:</strong> {{.Suggestion}}</div>
                     {{end}}
+                    {{with .Suppression}}
+                    <div class="finding-suppression">Suppressed at {{.File}}:{{.Line}}: {{.Reason}}</div>
+                    {{end}}
                 </div>
                 {{end}}
             {{else}}
@@ -172,6 +205,7 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
 		File          string
 		Line          int
 		Suggestion    string
+		Suppression   *report.Suppression
 	}
 
 	type OutputData struct {
@@ -183,10 +217,8 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
 	findings := make([]FormattedFinding, 0, len(results))
 	for _, f := range results {
 		severityClass := "info"
-		if f.Severity == report.SeverityHigh {
-			severityClass = "high"
-		} else if f.Severity == report.SeverityWarning {
-			severityClass = "warning"
+		if f.Severity.Valid() {
+			severityClass = strings.ToLower(string(f.Severity))
 		}
 		findings = append(findings, FormattedFinding{
 			ID:            f.ID,
@@ -197,6 +229,7 @@ func (f *HTMLFormatter) Format(results []report.Finding, summary report.Summary)
 			File:          f.File,
 			Line:          f.Line,
 			Suggestion:    f.Suggestion,
+			Suppression:   f.Suppression,
 		})
 	}
 
@@ -228,25 +261,51 @@ type ConsoleFormatter struct{}
 func (f *ConsoleFormatter) Format(results []report.Finding, summary report.Summary) ([]byte, error) {
 	output := "FSCT Report\n"
 	output += "────────────\n"
-	output += fmt.Sprintf("Summary  High %d  |  Warning %d  |  Info %d  |  Passed %d\n\n",
-		summary.High,
+	if summary.Profile != "" {
+		output += fmt.Sprintf("Profile  %s  |  %d checks run\n", summary.Profile, summary.Checks)
+	}
+	output += "Summary  "
+	if summary.Critical > 0 {
+		output += fmt.Sprintf("Critical %d  |  ", summary.Critical)
+	}
+	output += fmt.Sprintf("High %d  |  ", summary.High)
+	if summary.Error > 0 {
+		output += fmt.Sprintf("Error %d  |  ", summary.Error)
+	}
+	output += fmt.Sprintf("Warning %d  |  Info %d  |  Passed %d",
 		summary.Warning,
 		summary.Info,
 		summary.Passed,
 	)
-
-	if len(results) == 0 {
-		output += "No issues found. All checks passed.\n"
-		return []byte(output), nil
+	if summary.Suppressed > 0 {
+		output += fmt.Sprintf("  |  Suppressed %d", summary.Suppressed)
 	}
+	if summary.Baselined > 0 {
+		output += fmt.Sprintf("  |  Baselined %d", summary.Baselined)
+	}
+	output += "\n\n"
 
-	output += "Findings\n"
-	output += "────────\n"
+	var active, suppressed []report.Finding
 	for _, finding := range results {
+		if finding.Suppression != nil {
+			suppressed = append(suppressed, finding)
+		} else {
+			active = append(active, finding)
+		}
+	}
+
+	if len(active) == 0 {
+		output += "No issues found. All checks passed.\n"
+	} else {
+		output += "Findings\n"
+		output += "────────\n"
+	}
+	for _, finding := range active {
 		icon := "•"
-		if finding.Severity == report.SeverityHigh {
+		switch {
+		case finding.Severity.AtLeast(report.SeverityHigh):
 			icon = "×"
-		} else if finding.Severity == report.SeverityWarning {
+		case finding.Severity.AtLeast(report.SeverityWarning):
 			icon = "!"
 		}
 		output += fmt.Sprintf("\n%s %s  (%s)\n", icon, finding.ID, strings.ToUpper(string(finding.Severity)))
@@ -266,6 +325,15 @@ func (f *ConsoleFormatter) Format(results []report.Finding, summary report.Summa
 		}
 	}
 
+	if len(suppressed) > 0 {
+		output += "\nSuppressed\n"
+		output += "──────────\n"
+		for _, finding := range suppressed {
+			output += fmt.Sprintf("- %s  %s\n", finding.ID, finding.Title)
+			output += fmt.Sprintf("  %s:%d: %s\n", finding.Suppression.File, finding.Suppression.Line, finding.Suppression.Reason)
+		}
+	}
+
 	return []byte(output), nil
 }
 
@@ -281,6 +349,8 @@ func NewFormatter(format string) Formatter {
 		return &YAMLFormatter{}
 	case "html":
 		return &HTMLFormatter{}
+	case "sarif":
+		return &SARIFFormatter{}
 	case "prompt":
 		return NewPromptFormatter()
 	default:
@@ -1,6 +1,7 @@
 package formatter
 
 import (
+	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/prompt"
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
@@ -36,12 +37,18 @@ func (f *PromptFormatter) Format(results []report.Finding, summary report.Summar
 
 	// Create a basic prompt data from findings
 	data := prompt.NewPromptData()
-	data.TotalChecks = summary.Passed + summary.High + summary.Warning + summary.Info
+	data.TotalChecks = summary.Checks
+	if data.TotalChecks == 0 {
+		data.TotalChecks = summary.Passed + summary.Total()
+	}
 	data.PassedChecks = summary.Passed
 
 	// Convert findings to summary format
 	for _, f := range results {
-		category := extractCategoryFromID(f.ID)
+		if f.Suppression != nil {
+			continue
+		}
+		category := string(checker.CategoryForID(f.ID))
 		data.AddFinding(prompt.FindingSummary{
 			ID:         f.ID,
 			Severity:   f.Severity,
@@ -82,40 +89,3 @@ func (f *PromptFormatter) Format(results []report.Finding, summary report.Summar
 func (f *PromptFormatter) GetExtension() string {
 	return "md"
 }
-
-// Helper to extract category from finding ID
-func extractCategoryFromID(id string) string {
-	if len(id) < 3 {
-		return "Other"
-	}
-
-	prefix := id[:3]
-	switch prefix {
-	case "AND":
-		return "Android"
-	case "IOS":
-		return "iOS"
-	case "FLT":
-		return "Flutter"
-	case "SEC":
-		return "Security"
-	case "POL":
-		return "Policy"
-	case "COD":
-		return "Code Quality"
-	case "TST":
-		return "Testing"
-	case "LIN":
-		return "Linting"
-	case "DOC":
-		return "Documentation"
-	case "PER":
-		return "Performance"
-	case "REV":
-		return "Reviewer"
-	case "AI-":
-		return "AI Analysis"
-	default:
-		return "Other"
-	}
-}
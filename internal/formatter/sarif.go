@@ -3,16 +3,28 @@ package formatter
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/ricky-irfandi/fsct/internal/checker"
 	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
-type SARIFFormatter struct{}
+// SARIFFormatter writes SARIF 2.1.0. Rule descriptors come from the check
+// metadata passed to NewSARIFFormatter; findings without one get a minimal
+// descriptor.
+type SARIFFormatter struct {
+	rules []checker.Metadata
+}
+
+// NewSARIFFormatter returns a SARIF formatter describing rules.
+func NewSARIFFormatter(rules []checker.Metadata) *SARIFFormatter {
+	return &SARIFFormatter{rules: rules}
+}
 
 type SARIFReport struct {
-	Schema  string   `json:"$schema"`
-	Version string   `json:"version"`
-	Run     SARIFRun `json:"runs"`
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
 }
 
 type SARIFRun struct {
@@ -32,12 +44,24 @@ type SARIFDriver struct {
 }
 
 type SARIFRule struct {
-	ID               string       `json:"id"`
-	Name             string       `json:"name"`
-	ShortDescription SARIFMessage `json:"shortDescription"`
-	FullDescription  SARIFMessage `json:"fullDescription,omitempty"`
-	HelpURI          string       `json:"helpUri,omitempty"`
-	SeverityLevel    string       `json:"defaultConfiguration,omitempty"`
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     SARIFMessage        `json:"shortDescription"`
+	FullDescription      *SARIFMessage       `json:"fullDescription,omitempty"`
+	Help                 *SARIFMessage       `json:"help,omitempty"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration *SARIFConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *SARIFProperties    `json:"properties,omitempty"`
+}
+
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFProperties struct {
+	Category  string   `json:"category,omitempty"`
+	Guideline string   `json:"guideline,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
 }
 
 type SARIFMessage struct {
@@ -45,12 +69,25 @@ type SARIFMessage struct {
 }
 
 type SARIFResult struct {
-	RuleID    string          `json:"ruleId"`
-	RuleIndex int             `json:"ruleIndex,omitempty"`
-	Level     string          `json:"level"`
-	Message   SARIFMessage    `json:"message"`
-	Locations []SARIFLocation `json:"locations,omitempty"`
-	Artifacts []SARIFArtifact `json:"artifacts,omitempty"`
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             SARIFMessage       `json:"message"`
+	Locations           []SARIFLocation    `json:"locations,omitempty"`
+	Artifacts           []SARIFArtifact    `json:"artifacts,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Suppressions        []SARIFSuppression `json:"suppressions,omitempty"`
+}
+
+// sarifFingerprintKey names fsct fingerprints in partialFingerprints. The
+// version changes if the fingerprint algorithm does.
+const sarifFingerprintKey = "fsct/v1"
+
+// SARIFSuppression records an fsct-ignore directive that suppressed a
+// result.
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
 }
 
 type SARIFLocation struct {
@@ -59,7 +96,7 @@ type SARIFLocation struct {
 
 type SARIFPhysicalLocation struct {
 	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
-	Region           SARIFRegion           `json:"region,omitempty"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
 }
 
 type SARIFArtifactLocation struct {
@@ -80,55 +117,122 @@ type SARIFArtifact struct {
 }
 
 func (f *SARIFFormatter) Format(results []report.Finding, summary report.Summary) ([]byte, error) {
-	sarifReport := SARIFReport{
-		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
-		Version: "2.1.0",
-		Run: SARIFRun{
-			Tool: SARIFTool{
-				Driver: SARIFDriver{
-					Name:            "FSCT",
-					Version:         "1.0.0",
-					SemanticVersion: "1.0.0",
-				},
+	run := SARIFRun{
+		Tool: SARIFTool{
+			Driver: SARIFDriver{
+				Name:            "FSCT",
+				Version:         "1.0.0",
+				SemanticVersion: "1.0.0",
 			},
 		},
+		Results: []SARIFResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, meta := range f.rules {
+		if _, ok := ruleIndex[meta.ID]; ok {
+			continue
+		}
+		ruleIndex[meta.ID] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(meta))
 	}
 
 	for _, finding := range results {
+		index, ok := ruleIndex[finding.ID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[finding.ID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(checker.Metadata{
+				ID:              finding.ID,
+				Name:            finding.Title,
+				Category:        checker.CategoryForID(finding.ID),
+				DefaultSeverity: finding.Severity,
+				Description:     finding.Title,
+			}))
+		}
+
 		result := SARIFResult{
-			RuleID: finding.ID,
-			Level:  mapSeverity(finding.Severity),
+			RuleID:    finding.ID,
+			RuleIndex: index,
+			Level:     mapSeverity(finding.Severity),
 			Message: SARIFMessage{
 				Text: finding.Message,
 			},
-			Locations: []SARIFLocation{
-				{
-					PhysicalLocation: SARIFPhysicalLocation{
-						ArtifactLocation: SARIFArtifactLocation{
-							URI: finding.File,
-						},
-						Region: SARIFRegion{
-							StartLine: finding.Line,
-						},
-					},
+		}
+		if finding.File != "" {
+			location := SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{
+					URI: finding.File,
 				},
-			},
+			}
+			if finding.Line > 0 {
+				location.Region = &SARIFRegion{StartLine: finding.Line}
+			}
+			result.Locations = []SARIFLocation{{PhysicalLocation: location}}
+		}
+		if finding.Fingerprint != "" {
+			result.PartialFingerprints = map[string]string{sarifFingerprintKey: finding.Fingerprint}
+		}
+		if finding.Suppression != nil {
+			result.Suppressions = []SARIFSuppression{{
+				Kind:          "inSource",
+				Justification: finding.Suppression.Reason,
+			}}
 		}
-		sarifReport.Run.Results = append(sarifReport.Run.Results, result)
+		run.Results = append(run.Results, result)
+	}
+
+	sarifReport := SARIFReport{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []SARIFRun{run},
 	}
 
 	return json.MarshalIndent(sarifReport, "", "  ")
 }
 
+func sarifRule(meta checker.Metadata) SARIFRule {
+	rule := SARIFRule{
+		ID:   meta.ID,
+		Name: meta.Name,
+		ShortDescription: SARIFMessage{
+			Text: meta.Name,
+		},
+		DefaultConfiguration: &SARIFConfiguration{
+			Level: mapSeverity(meta.DefaultSeverity),
+		},
+		Properties: &SARIFProperties{
+			Category:  string(meta.Category),
+			Guideline: meta.Guideline,
+			Tags:      meta.Platforms,
+		},
+	}
+	if meta.Description != "" {
+		rule.FullDescription = &SARIFMessage{Text: meta.Description}
+	}
+
+	var help []string
+	if meta.Rationale != "" {
+		help = append(help, meta.Rationale)
+	}
+	if meta.Remediation != "" {
+		help = append(help, meta.Remediation)
+	}
+	if len(help) > 0 {
+		rule.Help = &SARIFMessage{Text: strings.Join(help, "\n\n")}
+	}
+	return rule
+}
+
 func (f *SARIFFormatter) GetExtension() string {
 	return "sarif"
 }
 
 func mapSeverity(severity report.Severity) string {
-	switch severity {
-	case report.SeverityHigh:
+	switch {
+	case severity.AtLeast(report.SeverityError):
 		return "error"
-	case report.SeverityWarning:
+	case severity.AtLeast(report.SeverityWarning):
 		return "warning"
 	default:
 		return "note"
@@ -153,12 +257,13 @@ func (f *GitHubSummaryFormatter) Format(results []report.Finding, summary report
 		Title: "FSCT Compliance Report",
 	}
 
-	if summary.High > 0 {
-		output.Summary = fmt.Sprintf("## Compliance Issues Found\n\n**High Severity:** %d\n**Warning:** %d\n**Info:** %d\n\n### Action Required\n\n%d high severity issues need to be addressed before submitting to the app store.", summary.High, summary.Warning, summary.Info, summary.High)
-	} else if summary.Warning > 0 {
-		output.Summary = fmt.Sprintf("## Compliance Report\n\n**High Severity:** 0\n**Warning:** %d\n**Info:** %d\n\n### Recommendations\n\n%d warning issues should be reviewed.", summary.Warning, summary.Info, summary.Warning)
+	counts := fmt.Sprintf("**Critical:** %d\n**High Severity:** %d\n**Error:** %d\n**Warning:** %d\n**Info:** %d", summary.Critical, summary.High, summary.Error, summary.Warning, summary.Info)
+	if blocking := summary.Critical + summary.High; blocking > 0 {
+		output.Summary = fmt.Sprintf("## Compliance Issues Found\n\n%s\n\n### Action Required\n\n%d critical or high severity issues need to be addressed before submitting to the app store.", counts, blocking)
+	} else if review := summary.Error + summary.Warning; review > 0 {
+		output.Summary = fmt.Sprintf("## Compliance Report\n\n%s\n\n### Recommendations\n\n%d error and warning issues should be reviewed.", counts, review)
 	} else {
-		output.Summary = fmt.Sprintf("## Compliance Passed\n\nAll checks passed! Your app is ready for submission.\n\n**Passed:** %d\n**High Severity:** 0\n**Warning:** 0\n**Info:** 0", summary.Passed)
+		output.Summary = fmt.Sprintf("## Compliance Passed\n\nAll checks passed! Your app is ready for submission.\n\n**Passed:** %d\n%s", summary.Passed, counts)
 	}
 
 	return json.MarshalIndent(output, "", "  ")
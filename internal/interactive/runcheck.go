@@ -23,7 +23,7 @@ func defaultOutputName(format string) string {
 	}
 }
 
-func runCheckCmd(path, platform, format, severity, aiMode string) tea.Cmd {
+func runCheckCmd(path, platform, profile, format, severity, aiMode string) tea.Cmd {
 	return func() tea.Msg {
 		exe, err := os.Executable()
 		if err != nil {
@@ -37,6 +37,9 @@ func runCheckCmd(path, platform, format, severity, aiMode string) tea.Cmd {
 			"--format", format,
 			"--severity", severity,
 		}
+		if profile != "" {
+			args = append(args, "--profile", profile)
+		}
 
 		switch aiMode {
 		case "skip":
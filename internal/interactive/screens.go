@@ -8,6 +8,10 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/profile"
+	"github.com/ricky-irfandi/fsct/internal/registry"
 )
 
 const tickInterval = 200 * time.Millisecond
@@ -17,6 +21,7 @@ type wizardStep int
 const (
 	stepPath wizardStep = iota
 	stepPlatform
+	stepProfile
 	stepFormat
 	stepSeverity
 	stepAI
@@ -29,6 +34,7 @@ type CheckWizard struct {
 	stepCursors []int
 	path        string
 	platform    string
+	profile     string
 	format      string
 	severity    string
 	aiMode      string
@@ -48,7 +54,7 @@ func NewCheckWizard() *CheckWizard {
 	return &CheckWizard{
 		step:        stepPath,
 		stepCursor:  0,
-		stepCursors: make([]int, 6),
+		stepCursors: make([]int, int(stepConfirm)+1),
 		path:        ".",
 		platform:    "both",
 		format:      "console",
@@ -81,7 +87,7 @@ func (m *CheckWizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.retreatStep()
 					return m, nil
 				}
-				next := NewRunCheckScreen(m.path, m.platform, m.format, m.severity, m.aiMode)
+				next := NewRunCheckScreen(m.path, m.platform, m.profile, m.format, m.severity, m.aiMode)
 				return transition(next)
 			}
 			if m.step == stepAI && m.aiMode == "config" {
@@ -135,6 +141,8 @@ func (m *CheckWizard) getMaxCursor() int {
 		return 1
 	case stepPlatform:
 		return 2
+	case stepProfile:
+		return len(wizardProfiles) - 1
 	case stepFormat:
 		return 4
 	case stepSeverity:
@@ -152,7 +160,7 @@ func (m *CheckWizard) advanceStep() {
 		return
 	}
 	m.stepCursors[m.step] = m.stepCursor
-	if m.step == stepSeverity && m.format == "prompt" {
+	if m.step == stepSeverity && (m.format == "prompt" || !m.profileRunsAI()) {
 		m.step = stepConfirm
 		m.stepCursor = m.stepCursors[m.step]
 		m.updateSelection()
@@ -191,6 +199,11 @@ func (m *CheckWizard) updateSelection() {
 	case stepPlatform:
 		platforms := []string{"both", "android", "ios"}
 		m.platform = platforms[m.stepCursor]
+	case stepProfile:
+		m.profile = wizardProfiles[m.stepCursor].name
+		if !m.profileRunsAI() {
+			m.aiMode = "skip"
+		}
 	case stepFormat:
 		formats := []string{"console", "json", "yaml", "html", "prompt"}
 		m.format = formats[m.stepCursor]
@@ -219,7 +232,7 @@ func (m *CheckWizard) View() string {
 }
 
 func (m *CheckWizard) renderProgress() string {
-	steps := []string{"Path", "Platform", "Format", "Severity", "AI", "Run"}
+	steps := []string{"Path", "Platform", "Profile", "Format", "Severity", "AI", "Run"}
 	stepLabel := steps[int(m.step)]
 	return fmt.Sprintf("%s %s",
 		Styles.WizardProgress.Render(fmt.Sprintf("Step %d of %d", m.step+1, len(steps))),
@@ -233,6 +246,8 @@ func (m *CheckWizard) renderStep() string {
 		return m.renderPathStep()
 	case stepPlatform:
 		return m.renderPlatformStep()
+	case stepProfile:
+		return m.renderProfileStep()
 	case stepFormat:
 		return m.renderFormatStep()
 	case stepSeverity:
@@ -324,6 +339,52 @@ func (m *CheckWizard) renderPlatformStep() string {
 	return s
 }
 
+// wizardProfiles are the profile choices; an empty name leaves the choice
+// to .fsct.yaml.
+var wizardProfiles = []struct {
+	name  string
+	label string
+	desc  string
+}{
+	{"", "Project default", "Use the profile from .fsct.yaml, or Store if none is set"},
+	{profile.Store, "Store", "Checks that affect store submission and review"},
+	{profile.Quality, "Quality", "Code quality, testing, linting, docs and performance"},
+	{profile.Full, "Full", "Every available check"},
+}
+
+// profileRunsAI reports whether the selected profile can include AI checks.
+func (m *CheckWizard) profileRunsAI() bool {
+	return m.profile != profile.Quality
+}
+
+func profileLabel(name string) string {
+	if name == "" {
+		return "Project default"
+	}
+	return strings.Title(name)
+}
+
+func (m *CheckWizard) renderProfileStep() string {
+	var s string
+	s += Styles.WizardTitle.Render("Check Profile")
+	s += "\n\n"
+
+	for i, opt := range wizardProfiles {
+		cursor := " "
+		if m.stepCursor == i {
+			cursor = cursorGlyph()
+			s += Styles.MenuItemSelected.Render(fmt.Sprintf("%s %s", cursor, opt.label))
+		} else {
+			s += Styles.MenuItem.Render(fmt.Sprintf("%s %s", cursor, opt.label))
+		}
+		s += "\n" + Styles.MenuDescription.Render(fmt.Sprintf("  %s", opt.desc)) + "\n"
+	}
+
+	s += "\n"
+	s += Styles.Info.Render(fmt.Sprintf("Selected: %s", profileLabel(m.profile)))
+	return s
+}
+
 func (m *CheckWizard) renderFormatStep() string {
 	var s string
 	s += Styles.WizardTitle.Render("Output Format")
@@ -365,9 +426,9 @@ func (m *CheckWizard) renderSeverityStep() string {
 		label string
 		desc  string
 	}{
-		{"All severities", "Show Info, Warning, and High severity issues"},
-		{"Warning & High only", "Filter out Info level issues"},
-		{"High only", "Show only critical issues"},
+		{"All severities", "Show every issue, from Info to Critical"},
+		{"Warning and above", "Filter out Info level issues"},
+		{"High and above", "Show only likely and certain store rejections"},
 	}
 
 	for i, opt := range options {
@@ -430,9 +491,10 @@ func (m *CheckWizard) renderConfirmStep() string {
 	s += Styles.MenuDescription.Render("Configuration Summary:")
 	s += "\n\n"
 
-	summary := fmt.Sprintf("%s • %s • %s • %s • %s",
+	summary := fmt.Sprintf("%s • %s • %s • %s • %s • %s",
 		m.path,
 		strings.Title(m.platform),
+		profileLabel(m.profile),
 		strings.Title(m.format),
 		strings.Title(m.severity),
 		strings.Title(m.aiMode),
@@ -445,6 +507,8 @@ func (m *CheckWizard) renderConfirmStep() string {
 	s += "\n"
 	s += Styles.MenuItem.Render(fmt.Sprintf("  Platform: %s", strings.Title(m.platform)))
 	s += "\n"
+	s += Styles.MenuItem.Render(fmt.Sprintf("  Profile:  %s", profileLabel(m.profile)))
+	s += "\n"
 	s += Styles.MenuItem.Render(fmt.Sprintf("  Format:   %s", strings.Title(m.format)))
 	s += "\n"
 	s += Styles.MenuItem.Render(fmt.Sprintf("  Severity: %s", strings.Title(m.severity)))
@@ -478,6 +542,7 @@ func (m *CheckWizard) renderConfirmStep() string {
 type RunCheckScreen struct {
 	path     string
 	platform string
+	profile  string
 	format   string
 	severity string
 	aiMode   string
@@ -486,10 +551,11 @@ type RunCheckScreen struct {
 	tick     int
 }
 
-func NewRunCheckScreen(path, platform, format, severity, aiMode string) *RunCheckScreen {
+func NewRunCheckScreen(path, platform, profile, format, severity, aiMode string) *RunCheckScreen {
 	return &RunCheckScreen{
 		path:     path,
 		platform: platform,
+		profile:  profile,
 		format:   format,
 		severity: severity,
 		aiMode:   aiMode,
@@ -500,7 +566,7 @@ func NewRunCheckScreen(path, platform, format, severity, aiMode string) *RunChec
 }
 
 func (m *RunCheckScreen) Init() tea.Cmd {
-	return tea.Batch(tickCmd(), runCheckCmd(m.path, m.platform, m.format, m.severity, m.aiMode))
+	return tea.Batch(tickCmd(), runCheckCmd(m.path, m.platform, m.profile, m.format, m.severity, m.aiMode))
 }
 
 func tickCmd() tea.Cmd {
@@ -608,8 +674,90 @@ func (m *MessageScreen) View() string {
 	return padToWidth(s)
 }
 
-func NewChecksScreen() *MessageScreen {
-	return NewMessageScreen("Available Checks", "Lists all available compliance checks")
+const checksVisibleRows = 12
+
+type ChecksScreen struct {
+	checks []checker.Metadata
+	cursor int
+}
+
+func NewChecksScreen() *ChecksScreen {
+	return &ChecksScreen{checks: registry.Catalog()}
+}
+
+func (m *ChecksScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ChecksScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, menuKeys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, menuKeys.Down):
+			if m.cursor < len(m.checks)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, menuKeys.Back), msg.Type == tea.KeyEsc, key.Matches(msg, menuKeys.Enter):
+			return transition(NewMenuModel())
+		case key.Matches(msg, menuKeys.Quit):
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *ChecksScreen) View() string {
+	var s string
+	s += renderHeader(fmt.Sprintf("Available Checks (%d)", len(m.checks)))
+	s += "\n\n"
+
+	start := m.cursor - checksVisibleRows/2
+	if start > len(m.checks)-checksVisibleRows {
+		start = len(m.checks) - checksVisibleRows
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + checksVisibleRows
+	if end > len(m.checks) {
+		end = len(m.checks)
+	}
+
+	for i := start; i < end; i++ {
+		meta := m.checks[i]
+		line := fmt.Sprintf("%-9s %-8s %s", meta.ID, meta.DefaultSeverity, meta.Name)
+		if i == m.cursor {
+			s += Styles.MenuItemSelected.Render(cursorGlyph() + " " + line)
+		} else {
+			s += Styles.MenuItem.Render("  " + line)
+		}
+		s += "\n"
+	}
+
+	if m.cursor < len(m.checks) {
+		meta := m.checks[m.cursor]
+		s += "\n"
+		s += Styles.Title.Render(meta.ID + " " + meta.Name)
+		s += "\n"
+		s += Styles.MenuDescription.Render(string(meta.Category) + " • " + string(meta.DefaultSeverity))
+		if meta.Guideline != "" {
+			s += Styles.MenuDescription.Render(" • " + meta.Guideline)
+		}
+		s += "\n\n"
+		s += Styles.MenuItem.Render(meta.Description)
+		s += "\n"
+		if meta.Remediation != "" {
+			s += "\n" + Styles.Info.Render("Fix: ") + Styles.MenuItem.Render(meta.Remediation) + "\n"
+		}
+	}
+
+	s += "\n"
+	s += renderFooter("↑↓ Navigate • ← Back • q Quit")
+	return padToWidth(s)
 }
 
 func NewChecklistScreen() *MessageScreen {
@@ -0,0 +1,252 @@
+// Package kb is the dependency knowledge base: what pub packages need the
+// app that uses them to declare, such as Info.plist usage descriptions and
+// Android permissions.
+//
+// A copy is built into fsct. Teams add their private packages, or correct
+// built-in entries, with fsct kb update, which merges a file in the same
+// format into UserFile, or with a file in the repository named by the
+// knowledge_base key of .fsct.yaml.
+package kb
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Schema is the version of the file format this fsct reads.
+const Schema = 1
+
+// UserFile is the path, relative to the home directory, of the entries
+// added with fsct kb update.
+const UserFile = ".fsct/kb.json"
+
+//go:embed packages.json
+var builtin []byte
+
+// KnowledgeBase maps pub packages to what they need the app to declare.
+type KnowledgeBase struct {
+	Schema int `json:"schema"`
+	// Version identifies the content, such as 2026.10.1. The built-in
+	// version changes whenever its entries do.
+	Version string `json:"version,omitempty"`
+	// Sensitive are the Android permissions an app should only declare
+	// when one of its packages needs them, such as CAMERA.
+	Sensitive []string           `json:"sensitive_permissions,omitempty"`
+	Packages  map[string]Package `json:"packages"`
+}
+
+// Package is what a package needs the app to declare. Names in Allows may
+// end in *, which matches any suffix; "*" alone allows everything.
+type Package struct {
+	// UsageDescriptions are the Info.plist keys the app must set.
+	UsageDescriptions []string `json:"usage_descriptions,omitempty"`
+	// Permissions are the Android permissions the app must declare
+	// itself. An entry may list alternatives separated by |, any one of
+	// which will do.
+	Permissions []string `json:"permissions,omitempty"`
+	// Injects are the Android permissions the plugin's own manifest adds
+	// to the app.
+	Injects []string `json:"injects,omitempty"`
+	// Allows are Info.plist keys and Android permissions the package
+	// justifies without requiring them, such as background location for
+	// a location plugin.
+	Allows []string `json:"allows,omitempty"`
+}
+
+// Builtin returns the knowledge base built into fsct.
+func Builtin() *KnowledgeBase {
+	kb, err := Parse(builtin)
+	if err != nil {
+		panic("kb: built-in knowledge base: " + err.Error())
+	}
+	return kb
+}
+
+// Parse decodes and validates a knowledge base file.
+func Parse(data []byte) (*KnowledgeBase, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var kb KnowledgeBase
+	if err := dec.Decode(&kb); err != nil {
+		return nil, err
+	}
+	if kb.Schema != Schema {
+		return nil, fmt.Errorf("schema %d is not supported; this fsct reads schema %d", kb.Schema, Schema)
+	}
+	for name, pkg := range kb.Packages {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("package with an empty name")
+		}
+		for _, names := range [][]string{pkg.UsageDescriptions, pkg.Permissions, pkg.Injects, pkg.Allows} {
+			for _, n := range names {
+				if strings.TrimSpace(n) == "" || strings.Contains(n, "||") {
+					return nil, fmt.Errorf("package %s: invalid name %q", name, n)
+				}
+			}
+		}
+	}
+	if kb.Packages == nil {
+		kb.Packages = make(map[string]Package)
+	}
+	return &kb, nil
+}
+
+// ReadFile reads the knowledge base file at path.
+func ReadFile(path string) (*KnowledgeBase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kb, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return kb, nil
+}
+
+// Save writes kb to path, creating its directory.
+func (kb *KnowledgeBase) Save(path string) error {
+	data, err := json.MarshalIndent(kb, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Merge returns kb with the entries of other added. Packages in other
+// replace those of the same name, and its sensitive permissions are
+// added. The version is kb's, marked +local when other has packages.
+func (kb *KnowledgeBase) Merge(other *KnowledgeBase) *KnowledgeBase {
+	merged := &KnowledgeBase{
+		Schema:    Schema,
+		Version:   kb.Version,
+		Sensitive: append([]string(nil), kb.Sensitive...),
+		Packages:  make(map[string]Package, len(kb.Packages)+len(other.Packages)),
+	}
+	for name, pkg := range kb.Packages {
+		merged.Packages[name] = pkg
+	}
+	for name, pkg := range other.Packages {
+		merged.Packages[name] = pkg
+	}
+	for _, name := range other.Sensitive {
+		if !contains(merged.Sensitive, name) {
+			merged.Sensitive = append(merged.Sensitive, name)
+		}
+	}
+	sort.Strings(merged.Sensitive)
+	if len(other.Packages) > 0 && merged.Version != "" && !strings.HasSuffix(merged.Version, "+local") {
+		merged.Version += "+local"
+	}
+	return merged
+}
+
+// Known returns every Info.plist key and Android permission the entries
+// name, leaving out patterns ending in *.
+func (kb *KnowledgeBase) Known() map[string]bool {
+	known := make(map[string]bool)
+	add := func(names []string) {
+		for _, name := range names {
+			for _, n := range strings.Split(name, "|") {
+				if !strings.HasSuffix(n, "*") {
+					known[n] = true
+				}
+			}
+		}
+	}
+	for _, pkg := range kb.Packages {
+		add(pkg.UsageDescriptions)
+		add(pkg.Permissions)
+		add(pkg.Injects)
+		add(pkg.Allows)
+	}
+	add(kb.Sensitive)
+	return known
+}
+
+// UserPath returns the absolute path of UserFile.
+func UserPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, filepath.FromSlash(UserFile)), nil
+}
+
+// Load returns the built-in knowledge base merged with UserFile, if it
+// exists, and then with each of files, which must exist.
+func Load(files ...string) (*KnowledgeBase, error) {
+	kb := Builtin()
+	if path, err := UserPath(); err == nil {
+		user, err := ReadFile(path)
+		switch {
+		case err == nil:
+			kb = kb.Merge(user)
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+	for _, file := range files {
+		project, err := ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		kb = kb.Merge(project)
+	}
+	return kb, nil
+}
+
+// Update merges the knowledge base file from into the user file at path.
+// It returns the names of the packages new to the user file and of those
+// that replaced an entry of the same name.
+func Update(path, from string) (added, updated []string, err error) {
+	additions, err := ReadFile(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		user = &KnowledgeBase{Schema: Schema, Packages: make(map[string]Package)}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	merged := user.Merge(additions)
+	merged.Version = additions.Version
+	if merged.Version == "" {
+		merged.Version = user.Version
+	}
+	if err := merged.Save(path); err != nil {
+		return nil, nil, err
+	}
+
+	for name := range additions.Packages {
+		if _, ok := user.Packages[name]; ok {
+			updated = append(updated, name)
+		} else {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(updated)
+	return added, updated, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
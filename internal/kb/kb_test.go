@@ -0,0 +1,174 @@
+package kb
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuiltin(t *testing.T) {
+	kb := Builtin()
+	if kb.Version == "" {
+		t.Error("expected the built-in knowledge base to have a version")
+	}
+
+	geolocator, ok := kb.Packages["geolocator"]
+	if !ok {
+		t.Fatal("expected an entry for geolocator")
+	}
+	if !reflect.DeepEqual(geolocator.UsageDescriptions, []string{"NSLocationWhenInUseUsageDescription"}) {
+		t.Errorf("unexpected geolocator usage descriptions %v", geolocator.UsageDescriptions)
+	}
+
+	known := kb.Known()
+	for _, name := range []string{"NSCameraUsageDescription", "android.permission.ACCESS_COARSE_LOCATION", "android.permission.READ_SMS"} {
+		if !known[name] {
+			t.Errorf("expected %s to be known", name)
+		}
+	}
+	if known["*"] || known["android.permission.health.*"] {
+		t.Error("expected patterns to be left out of the known names")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  `{"schema": 1, "packages": {"acme_scanner": {"usage": ["NSCameraUsageDescription"]}}}`,
+		"future schema":  `{"schema": 2, "packages": {}}`,
+		"missing schema": `{"packages": {}}`,
+		"empty name":     `{"schema": 1, "packages": {"acme_scanner": {"permissions": [""]}}}`,
+		"not JSON":       `schema: 1`,
+	}
+	for name, data := range tests {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := &KnowledgeBase{
+		Schema:    Schema,
+		Version:   "2026.10.1",
+		Sensitive: []string{"android.permission.CAMERA"},
+		Packages: map[string]Package{
+			"camera": {UsageDescriptions: []string{"NSCameraUsageDescription"}},
+			"record": {UsageDescriptions: []string{"NSMicrophoneUsageDescription"}},
+		},
+	}
+	local := &KnowledgeBase{
+		Schema:    Schema,
+		Sensitive: []string{"android.permission.READ_SMS"},
+		Packages: map[string]Package{
+			"record":       {UsageDescriptions: []string{"NSMicrophoneUsageDescription"}, Permissions: []string{"android.permission.RECORD_AUDIO"}},
+			"acme_scanner": {Permissions: []string{"android.permission.CAMERA"}},
+		},
+	}
+
+	merged := base.Merge(local)
+	if merged.Version != "2026.10.1+local" || len(merged.Packages) != 3 {
+		t.Errorf("unexpected merge %+v", merged)
+	}
+	if len(merged.Packages["record"].Permissions) != 1 {
+		t.Error("expected the local entry to replace the built-in one")
+	}
+	if want := []string{"android.permission.CAMERA", "android.permission.READ_SMS"}; !reflect.DeepEqual(merged.Sensitive, want) {
+		t.Errorf("Sensitive = %v, want %v", merged.Sensitive, want)
+	}
+	if len(base.Packages) != 2 {
+		t.Error("expected Merge to leave the receiver unchanged")
+	}
+}
+
+func TestUpdateAndLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	from := filepath.Join(t.TempDir(), "acme.json")
+	if err := os.WriteFile(from, []byte(`{
+  "schema": 1,
+  "version": "acme-3",
+  "packages": {
+    "acme_scanner": {
+      "usage_descriptions": ["NSCameraUsageDescription"],
+      "permissions": ["android.permission.CAMERA"]
+    }
+  }
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := UserPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, updated, err := Update(path, from)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if !reflect.DeepEqual(added, []string{"acme_scanner"}) || len(updated) != 0 {
+		t.Errorf("Update added %v and updated %v", added, updated)
+	}
+	added, updated, err = Update(path, from)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if len(added) != 0 || !reflect.DeepEqual(updated, []string{"acme_scanner"}) {
+		t.Errorf("second Update added %v and updated %v", added, updated)
+	}
+
+	kb, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, ok := kb.Packages["acme_scanner"]; !ok {
+		t.Error("expected the added package to be loaded")
+	}
+	if _, ok := kb.Packages["geolocator"]; !ok {
+		t.Error("expected the built-in packages to be kept")
+	}
+	if kb.Version != Builtin().Version+"+local" {
+		t.Errorf("unexpected version %q", kb.Version)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"schema": 1, "packages": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("expected an error for a malformed user file")
+	}
+}
+
+func TestLoadProjectFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	project := filepath.Join(t.TempDir(), "kb.json")
+	if err := os.WriteFile(project, []byte(`{
+  "schema": 1,
+  "packages": {
+    "geolocator": {"usage_descriptions": ["NSLocationAlwaysUsageDescription"]}
+  }
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	kb, err := Load(project)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := []string{"NSLocationAlwaysUsageDescription"}
+	if got := kb.Packages["geolocator"].UsageDescriptions; !reflect.DeepEqual(got, want) {
+		t.Errorf("geolocator usage descriptions = %v, want the project entry %v", got, want)
+	}
+	if _, ok := kb.Packages["camera"]; !ok {
+		t.Error("expected the built-in packages to be kept")
+	}
+
+	if _, err := Load(filepath.Join(filepath.Dir(project), "missing.json")); err == nil {
+		t.Error("expected an error for a missing project file")
+	}
+}
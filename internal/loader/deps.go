@@ -0,0 +1,80 @@
+package loader
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/kb"
+)
+
+// Packages that imply a capability the stores ask apps to declare. What
+// packages need in Info.plist and the Android manifest is in the knowledge
+// base.
+var (
+	networkPackages = []string{
+		"http", "dio", "chopper", "retrofit", "graphql", "graphql_flutter",
+		"web_socket_channel", "grpc", "supabase_flutter", "cloud_firestore",
+		"firebase_core", "firebase_auth", "firebase_messaging", "firebase_database",
+	}
+	urlLauncherPackages = []string{
+		"url_launcher",
+	}
+)
+
+// computeDependencyFlags looks up the packages built into the app,
+// including those only pulled in by other packages, in base and sets
+// Project.PackageNeeds and the capability flags from them.
+func computeDependencyFlags(project *checker.Project, base *kb.KnowledgeBase) {
+	needs := &checker.PackageNeedsInfo{
+		Version:   base.Version,
+		Known:     base.Known(),
+		Sensitive: make(map[string]bool, len(base.Sensitive)),
+	}
+	for _, name := range base.Sensitive {
+		needs.Sensitive[name] = true
+	}
+	project.PackageNeeds = needs
+
+	deps := make(map[string]bool)
+	for _, name := range project.Pubspec.AppPackages() {
+		deps[name] = true
+		if pkg, ok := base.Packages[name]; ok {
+			needs.Packages = append(needs.Packages, checker.PackageNeeds{
+				Package:           name,
+				UsageDescriptions: pkg.UsageDescriptions,
+				Permissions:       pkg.Permissions,
+				Injects:           pkg.Injects,
+				Allows:            pkg.Allows,
+			})
+		}
+	}
+	if len(deps) == 0 {
+		return
+	}
+	sort.Slice(needs.Packages, func(i, j int) bool { return needs.Packages[i].Package < needs.Packages[j].Package })
+
+	project.HasNetworkDeps = hasAny(deps, networkPackages) || hasPrefix(deps, "firebase_")
+	project.HasCameraDeps = needs.NeededBy("NSCameraUsageDescription") != nil
+	project.HasLocationDeps = needs.NeededBy("NSLocationWhenInUseUsageDescription") != nil
+	project.HasImagePicker = needs.NeededBy("NSPhotoLibraryUsageDescription") != nil
+	project.HasURLLauncher = hasAny(deps, urlLauncherPackages)
+}
+
+func hasAny(deps map[string]bool, names []string) bool {
+	for _, name := range names {
+		if deps[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefix(deps map[string]bool, prefix string) bool {
+	for name := range deps {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package loader
+
+import (
+	"fmt"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// Finding IDs reported for project files that could not be loaded.
+const (
+	MissingFileID   = "LOAD-001"
+	MalformedFileID = "LOAD-002"
+)
+
+// Findings converts load diagnostics into report findings so that gaps in
+// the project are visible instead of silently producing empty results.
+func Findings(diagnostics []checker.Diagnostic) []report.Finding {
+	var findings []report.Finding
+
+	for _, d := range diagnostics {
+		if !d.Missing {
+			findings = append(findings, report.Finding{
+				ID:         MalformedFileID,
+				Severity:   report.SeverityWarning,
+				Title:      "Project File Could Not Be Parsed",
+				Message:    fmt.Sprintf("%s could not be parsed: %v. Checks that depend on it were run without its data.", d.File, d.Err),
+				File:       d.File,
+				Suggestion: "Fix the syntax error so the file can be analysed.",
+			})
+			continue
+		}
+
+		switch d.File {
+		case "pubspec.yaml":
+			findings = append(findings, report.Finding{
+				ID:         MissingFileID,
+				Severity:   report.SeverityHigh,
+				Title:      "pubspec.yaml Not Found",
+				Message:    "No pubspec.yaml was found; this does not look like a Flutter project root.",
+				File:       d.File,
+				Suggestion: "Run fsct from the Flutter project root or pass its path to fsct check.",
+			})
+		case "android", "ios":
+			findings = append(findings, report.Finding{
+				ID:         MissingFileID,
+				Severity:   report.SeverityInfo,
+				Title:      "Platform Project Not Found",
+				Message:    fmt.Sprintf("No %s/ directory was found, so %s checks had nothing to inspect.", d.File, d.File),
+				File:       d.File + "/",
+				Suggestion: fmt.Sprintf("Use --platform to limit the scan, or run `flutter create --platforms=%s .` to add the platform.", d.File),
+			})
+		default:
+			findings = append(findings, report.Finding{
+				ID:         MissingFileID,
+				Severity:   report.SeverityWarning,
+				Title:      "Project File Not Found",
+				Message:    fmt.Sprintf("%s was not found. Checks that depend on it were run without its data.", d.File),
+				File:       d.File,
+				Suggestion: "Restore the file or check that the project uses the standard Flutter layout.",
+			})
+		}
+	}
+
+	return findings
+}
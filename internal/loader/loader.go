@@ -0,0 +1,957 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/filter"
+	"github.com/ricky-irfandi/fsct/internal/kb"
+	"github.com/ricky-irfandi/fsct/internal/parser"
+)
+
+// Platform names used in diagnostics.
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+)
+
+// skipDirs are directory names never descended into when collecting Dart
+// sources.
+var skipDirs = map[string]bool{
+	"build":        true,
+	"Pods":         true,
+	"node_modules": true,
+	".symlinks":    true,
+	".dart_tool":   true,
+	".git":         true,
+	".gradle":      true,
+	".idea":        true,
+	".fvm":         true,
+}
+
+// rootFiles are the project-root documentation and tooling files loaded
+// into Project.RootFiles, by lower-cased name.
+var rootFiles = map[string]bool{
+	"readme":                true,
+	"readme.md":             true,
+	"changelog":             true,
+	"changelog.md":          true,
+	"changes":               true,
+	"changes.md":            true,
+	"license":               true,
+	"license.md":            true,
+	"license.txt":           true,
+	"copying":               true,
+	"analysis_options.yaml": true,
+	"analysis_options.yml":  true,
+}
+
+// Options controls which files Load collects.
+type Options struct {
+	// IncludeGenerated keeps Dart files matching filter.GeneratedPatterns,
+	// which are skipped by default.
+	IncludeGenerated bool
+
+	// FlutterSDK is the Flutter SDK directory used to resolve flutter.*
+	// values in Gradle build scripts. If empty, $FLUTTER_ROOT or the
+	// flutter command on the PATH is used.
+	FlutterSDK string
+
+	// KnowledgeBase says what the app's packages need it to declare. If
+	// nil, the built-in knowledge base is used.
+	KnowledgeBase *kb.KnowledgeBase
+}
+
+// Load discovers the project files under path and returns a populated
+// project. Files that are missing or malformed are recorded in
+// Project.Diagnostics rather than aborting the load; an error is only
+// returned when path itself is unusable.
+func Load(path string) (*checker.Project, error) {
+	return LoadWithOptions(path, Options{})
+}
+
+// LoadWithOptions is Load with control over which files are collected.
+// Dart files ignored by a .gitignore in the project, excluded by the
+// analyzer: exclude: list of analysis_options.yaml, or generated are
+// skipped.
+func LoadWithOptions(path string, opts Options) (*checker.Project, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("project path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("project path %s is not a directory", path)
+	}
+
+	project := checker.NewProject(path)
+
+	l := &loader{root: path, project: project, opts: opts}
+	l.loadPubspec()
+	l.loadPubspecLock()
+	l.loadAndroid()
+	l.loadIOS()
+	l.loadDartFiles()
+	l.loadSources()
+	l.loadRootFiles()
+
+	base := opts.KnowledgeBase
+	if base == nil {
+		base = kb.Builtin()
+	}
+	computeDependencyFlags(project, base)
+	project.HasLoginPatterns = hasLoginPatterns(path)
+
+	return project, nil
+}
+
+type loader struct {
+	root    string
+	project *checker.Project
+	opts    Options
+}
+
+func (l *loader) rel(path string) string {
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// found records a project file that exists, whether or not it parses.
+func (l *loader) found(file string) {
+	l.project.Files = append(l.project.Files, file)
+}
+
+func (l *loader) missing(file, platform string) {
+	l.project.Diagnostics = append(l.project.Diagnostics, checker.Diagnostic{
+		File:     file,
+		Platform: platform,
+		Missing:  true,
+	})
+}
+
+func (l *loader) failed(file, platform string, err error) {
+	l.project.Diagnostics = append(l.project.Diagnostics, checker.Diagnostic{
+		File:     file,
+		Platform: platform,
+		Err:      err,
+	})
+}
+
+func (l *loader) loadPubspec() {
+	path := filepath.Join(l.root, "pubspec.yaml")
+	if !fileExists(path) {
+		l.missing("pubspec.yaml", "")
+		return
+	}
+	l.found("pubspec.yaml")
+
+	pubspec, err := parser.ParsePubspec(path)
+	if err != nil {
+		l.failed("pubspec.yaml", "", err)
+		return
+	}
+
+	info := &checker.PubspecInfo{
+		Name:               pubspec.Name,
+		Version:            pubspec.Version,
+		Description:        pubspec.Description,
+		Homepage:           pubspec.Homepage,
+		Repository:         pubspec.Repository,
+		Dependencies:       pubspec.Dependencies,
+		DevDependencies:    pubspec.DevDependencies,
+		SDKConstraint:      pubspec.Environment["sdk"],
+		FlutterConstraint:  pubspec.Environment["flutter"],
+		Lines:              pubspec.Lines,
+		HasLinter:          pubspec.HasLinter(),
+		HasIconConfig:      pubspec.HasIconConfig(),
+		HasSplashConfig:    pubspec.HasSplashConfig(),
+		HasDeprecatedPkg:   pubspec.HasDeprecatedPackage(),
+		DeprecatedPackages: pubspec.DeprecatedPackages(),
+		HasDebugDeps:       pubspec.HasDebugDepInMain(),
+	}
+	for _, spec := range pubspec.Specs {
+		info.Specs = append(info.Specs, checker.DependencySpec{
+			Name:       spec.Name,
+			Kind:       string(spec.Kind),
+			Source:     string(spec.Source),
+			Constraint: spec.Constraint,
+			Range:      spec.Range,
+			Line:       spec.Line,
+		})
+	}
+	if pubspec.Flutter != nil {
+		info.Assets = pubspec.Flutter.Assets
+		for _, font := range pubspec.Flutter.Fonts {
+			info.FontFamilies = append(info.FontFamilies, font.Family)
+		}
+	}
+	l.project.Pubspec = info
+}
+
+// loadPubspecLock adds the packages resolved in pubspec.lock to the
+// pubspec. A project that has not been fetched has no lock file, which is
+// not reported.
+func (l *loader) loadPubspecLock() {
+	path := filepath.Join(l.root, "pubspec.lock")
+	if !fileExists(path) {
+		return
+	}
+	l.found("pubspec.lock")
+
+	lock, err := parser.ParsePubspecLock(path)
+	if err != nil {
+		l.failed("pubspec.lock", "", err)
+		return
+	}
+
+	info := l.project.Pubspec
+	info.Resolved = make([]checker.ResolvedPackage, 0, len(lock.Packages))
+	for _, pkg := range lock.Packages {
+		info.Resolved = append(info.Resolved, checker.ResolvedPackage{
+			Name:       pkg.Name,
+			Version:    pkg.Version,
+			Source:     string(pkg.Source),
+			Dependency: string(pkg.Dependency),
+			SHA256:     pkg.SHA256,
+			Line:       pkg.Line,
+		})
+	}
+	info.ResolvedSDKs = lock.SDKs
+	info.Requires = lock.Requirements(l.flutterSDK())
+}
+
+func (l *loader) loadAndroid() {
+	if !dirExists(l.project.AndroidPath) {
+		l.missing("android", PlatformAndroid)
+		return
+	}
+
+	manifestPath := filepath.Join(l.project.AndroidPath, "app", "src", "main", "AndroidManifest.xml")
+	if !fileExists(manifestPath) {
+		l.missing(l.rel(manifestPath), PlatformAndroid)
+	} else {
+		l.found(l.rel(manifestPath))
+		if manifest, err := parser.MergeAndroidManifests(manifestPath, l.flutterPlugins(PlatformAndroid)); err != nil {
+			l.failed(l.rel(manifestPath), PlatformAndroid, err)
+		} else {
+			for _, plugin := range manifest.Plugins {
+				if plugin.Err != nil {
+					l.failed(plugin.File, PlatformAndroid, plugin.Err)
+				}
+			}
+			l.project.AndroidManifest = manifestInfo(manifest)
+		}
+	}
+
+	gradlePath := FindGradleFile(l.project.AndroidPath)
+	if gradlePath == "" {
+		l.missing("android/app/build.gradle", PlatformAndroid)
+		return
+	}
+	l.found(l.rel(gradlePath))
+
+	gradle, err := parser.EvaluateGradle(gradlePath, parser.GradleOptions{FlutterSDK: l.flutterSDK()})
+	if err != nil {
+		l.failed(l.rel(gradlePath), PlatformAndroid, err)
+		return
+	}
+	info := &checker.GradleConfigInfo{
+		File:              l.rel(gradlePath),
+		ApplicationID:     gradle.ApplicationID,
+		CompileSDKVersion: gradle.CompileSDKVersion,
+		MinSDKVersion:     gradle.MinSDKVersion,
+		TargetSDKVersion:  gradle.TargetSDKVersion,
+		VersionCode:       gradle.VersionCode,
+		VersionName:       gradle.VersionName,
+		Lines:             make(map[string]int),
+		Sources:           make(map[string]string),
+	}
+	for name, v := range gradle.Values {
+		info.Lines[name] = v.Line
+		info.Sources[name] = v.Source
+	}
+	l.project.GradleConfig = info
+}
+
+// flutterPlugins returns the plugins with code for platform, as recorded
+// by flutter pub get. A project that has not been fetched has none.
+func (l *loader) flutterPlugins(platform string) []parser.FlutterPlugin {
+	path := filepath.Join(l.root, ".flutter-plugins-dependencies")
+	if !fileExists(path) {
+		return nil
+	}
+	plugins, err := parser.ParseFlutterPlugins(path, platform)
+	if err != nil {
+		l.failed(".flutter-plugins-dependencies", platform, err)
+	}
+	return plugins
+}
+
+// flutterSDK returns the Flutter SDK directory that build scripts'
+// flutter.* values and Flutter SDK packages resolve against:
+// Options.FlutterSDK, $FLUTTER_ROOT, or the SDK of the flutter command on
+// the PATH. It returns "" if there is none.
+func (l *loader) flutterSDK() string {
+	if l.opts.FlutterSDK != "" {
+		return l.opts.FlutterSDK
+	}
+	if root := os.Getenv("FLUTTER_ROOT"); root != "" {
+		return root
+	}
+	bin, err := exec.LookPath("flutter")
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(bin); err == nil {
+		bin = resolved
+	}
+	sdk := filepath.Dir(filepath.Dir(bin))
+	if !dirExists(filepath.Join(sdk, "packages", "flutter_tools")) {
+		return ""
+	}
+	return sdk
+}
+
+func (l *loader) loadIOS() {
+	if !dirExists(l.project.IOSPath) {
+		l.missing("ios", PlatformIOS)
+		return
+	}
+
+	settings := l.loadXcodeProject()
+	l.loadCocoaPods()
+	l.loadEntitlements(settings)
+	l.loadSiteAssociation()
+	l.loadPrivacyManifest()
+	l.loadIOSSources()
+	l.loadIOSPlugins()
+
+	plistPath := FindInfoPlist(l.project.IOSPath)
+	if plistPath == "" {
+		l.missing("ios/Runner/Info.plist", PlatformIOS)
+		return
+	}
+	l.found(l.rel(plistPath))
+
+	plist, err := parser.ParseInfoPlist(plistPath)
+	if err != nil {
+		l.failed(l.rel(plistPath), PlatformIOS, err)
+		return
+	}
+	if settings != nil {
+		plist.Expand(settings)
+	}
+
+	l.project.InfoPlist = &checker.InfoPlistInfo{
+		Values:                          plist.Root,
+		CFBundleIdentifier:              plist.CFBundleIdentifier,
+		CFBundleVersion:                 plist.CFBundleVersion,
+		CFBundleShortVersionString:      plist.CFBundleShortVersionString,
+		HasCameraUsageDescription:       plist.HasCameraUsageDescription(),
+		HasPhotoLibraryUsageDescription: plist.HasPhotoLibraryUsageDescription(),
+		HasLocationUsageDescription:     plist.HasLocationUsageDescription(),
+		HasMicrophoneUsageDescription:   plist.HasMicrophoneUsageDescription(),
+		HasContactsUsageDescription:     plist.HasContactsUsageDescription(),
+		HasCalendarsUsageDescription:    plist.HasCalendarsUsageDescription(),
+		EncryptionDeclarationSet:        plist.IsEncryptionDeclarationSet(),
+		EncryptionExempt:                plist.IsEncryptionExempt(),
+		RequiresFullScreen:              plist.GetFullScreenRequirement(),
+		BackgroundModes:                 plist.UIBackgroundModes,
+		AllowsArbitraryLoads:            plist.AllowsArbitraryLoads(),
+	}
+}
+
+// loadXcodeProject loads the build settings of the app target and returns
+// those of its Release configuration, or nil if there is no Xcode project.
+func (l *loader) loadXcodeProject() parser.BuildSettings {
+	path := FindXcodeProject(l.project.IOSPath)
+	if path == "" {
+		return nil
+	}
+	l.found(l.rel(path))
+
+	xcode, err := parser.ParseXcodeProject(path)
+	if err != nil {
+		l.failed(l.rel(path), PlatformIOS, err)
+		return nil
+	}
+	target := xcode.AppTarget()
+	if target == nil {
+		return nil
+	}
+
+	info := &checker.XcodeProjectInfo{
+		File:           l.rel(path),
+		Target:         target.Name,
+		Configurations: make(map[string]map[string]string),
+		Lines:          make(map[string]int),
+	}
+	for _, path := range target.Resources {
+		info.Resources = append(info.Resources, l.rel(path))
+	}
+	var release parser.BuildSettings
+	for _, config := range target.Configurations {
+		settings := xcode.BuildSettings(target, config.Name)
+		l.flutterBuildSettings(settings)
+		expanded := make(map[string]string, len(settings))
+		for name := range settings {
+			expanded[name] = settings.Get(name)
+		}
+		info.Configurations[config.Name] = expanded
+		if config.Name == checker.ReleaseConfiguration {
+			release = settings
+			info.Lines = config.Lines
+		}
+	}
+	l.project.Xcode = info
+	return release
+}
+
+// loadCocoaPods loads ios/Podfile and ios/Podfile.lock. A project that
+// uses no plugins, or has not run pod install, lacks them, which is not
+// reported.
+func (l *loader) loadCocoaPods() {
+	info := l.project.CocoaPods
+
+	path := filepath.Join(l.project.IOSPath, "Podfile")
+	if fileExists(path) {
+		l.found(l.rel(path))
+		if podfile, err := parser.ParsePodfile(path); err != nil {
+			l.failed(l.rel(path), PlatformIOS, err)
+		} else {
+			info.Podfile = l.rel(path)
+			info.Platform, info.PlatformLine = podfile.Platform, podfile.PlatformLine
+			for _, s := range podfile.DeploymentTargets {
+				info.DeploymentTargets = append(info.DeploymentTargets, checker.PodfileSetting{Value: s.Value, Line: s.Line})
+			}
+		}
+	}
+
+	path = filepath.Join(l.project.IOSPath, "Podfile.lock")
+	if !fileExists(path) {
+		return
+	}
+	l.found(l.rel(path))
+	lock, err := parser.ParsePodfileLock(path)
+	if err != nil {
+		l.failed(l.rel(path), PlatformIOS, err)
+		return
+	}
+	info.Lockfile = l.rel(path)
+	info.CocoaPodsVersion, info.CocoaPodsLine = lock.CocoaPodsVersion, lock.CocoaPodsLine
+	info.Pods = make([]checker.PodInfo, 0, len(lock.Pods))
+	for _, pod := range lock.Pods {
+		info.Pods = append(info.Pods, checker.PodInfo{
+			Name:     pod.Name,
+			Version:  pod.Version,
+			External: pod.External,
+			Line:     pod.Line,
+		})
+	}
+}
+
+// loadEntitlements loads the entitlements file named by the
+// CODE_SIGN_ENTITLEMENTS setting of the Release configuration, which
+// settings holds. Without an Xcode project, ios/Runner/Runner.entitlements
+// is used if it exists.
+func (l *loader) loadEntitlements(settings parser.BuildSettings) {
+	var path string
+	if settings != nil {
+		path = filepath.FromSlash(settings.Get("CODE_SIGN_ENTITLEMENTS"))
+		if path == "" {
+			return
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(settings.Get("SRCROOT"), path)
+		}
+		if !fileExists(path) {
+			l.missing(l.rel(path), PlatformIOS)
+			return
+		}
+	} else {
+		path = filepath.Join(l.project.IOSPath, "Runner", "Runner.entitlements")
+		if !fileExists(path) {
+			return
+		}
+	}
+	l.found(l.rel(path))
+
+	entitlements, err := parser.ParseEntitlements(path)
+	if err != nil {
+		l.failed(l.rel(path), PlatformIOS, err)
+		return
+	}
+	if settings != nil {
+		entitlements.Expand(settings)
+	}
+
+	l.project.Entitlements = &checker.EntitlementsInfo{
+		File:              l.rel(path),
+		Values:            entitlements.Root,
+		APSEnvironment:    entitlements.APSEnvironment,
+		AssociatedDomains: entitlements.AssociatedDomains,
+		AppGroups:         entitlements.AppGroups,
+		ICloudContainers:  entitlements.ICloudContainers,
+		SignInWithApple:   entitlements.SignInWithApple,
+		HealthKit:         entitlements.HealthKit,
+	}
+}
+
+// siteAssociationPaths are where a project that hosts its own site, such
+// as a Flutter web build, keeps its apple-app-site-association file.
+var siteAssociationPaths = []string{
+	"web/.well-known/apple-app-site-association",
+	"web/apple-app-site-association",
+	".well-known/apple-app-site-association",
+	"public/.well-known/apple-app-site-association",
+}
+
+// loadSiteAssociation loads the first apple-app-site-association file in
+// siteAssociationPaths. Most apps serve it from another repository, so a
+// missing file is not reported.
+func (l *loader) loadSiteAssociation() {
+	for _, rel := range siteAssociationPaths {
+		path := filepath.Join(l.root, filepath.FromSlash(rel))
+		if !fileExists(path) {
+			continue
+		}
+		l.found(rel)
+
+		association, err := parser.ParseSiteAssociation(path)
+		if err != nil {
+			l.failed(rel, PlatformIOS, err)
+			return
+		}
+		l.project.SiteAssociation = &checker.SiteAssociationInfo{
+			File:           rel,
+			AppLinks:       association.AppLinks,
+			WebCredentials: association.WebCredentials,
+		}
+		return
+	}
+}
+
+// loadPrivacyManifest loads the privacy manifest the app target copies into
+// the bundle or, failing that, ios/Runner/PrivacyInfo.xcprivacy. Whether
+// the app needs one is up to the checks, so a missing manifest is not
+// reported.
+func (l *loader) loadPrivacyManifest() {
+	var path string
+	bundled := true
+	for _, rel := range l.project.Xcode.Resources {
+		if filepath.Base(rel) == parser.PrivacyManifestName {
+			path = filepath.Join(l.root, filepath.FromSlash(rel))
+			break
+		}
+	}
+	if path == "" {
+		path = filepath.Join(l.project.IOSPath, "Runner", parser.PrivacyManifestName)
+		bundled = l.project.Xcode.File == ""
+		if !fileExists(path) {
+			return
+		}
+	} else if !fileExists(path) {
+		l.missing(l.rel(path), PlatformIOS)
+		return
+	}
+	l.found(l.rel(path))
+
+	manifest, err := parser.ParsePrivacyManifest(path)
+	if err != nil {
+		l.failed(l.rel(path), PlatformIOS, err)
+		return
+	}
+	info := &checker.PrivacyManifestInfo{
+		File:            l.rel(path),
+		Values:          manifest.Root,
+		Bundled:         bundled,
+		Tracking:        manifest.Tracking,
+		TrackingDomains: manifest.TrackingDomains,
+	}
+	for _, api := range manifest.AccessedAPIs {
+		info.AccessedAPIs = append(info.AccessedAPIs, checker.AccessedAPIInfo{Type: api.Type, Reasons: api.Reasons, Line: api.Line})
+	}
+	l.project.PrivacyManifest = info
+}
+
+// iosSourceExtensions are the native sources of the iOS app that are
+// searched for required reason APIs.
+var iosSourceExtensions = map[string]bool{
+	".swift": true,
+	".m":     true,
+	".mm":    true,
+}
+
+// loadIOSSources indexes the Swift and Objective-C sources under
+// ios/Runner.
+func (l *loader) loadIOSSources() {
+	var paths []string
+	_ = filepath.WalkDir(filepath.Join(l.project.IOSPath, "Runner"), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && skipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && iosSourceExtensions[filepath.Ext(path)] {
+			paths = append(paths, l.rel(path))
+		}
+		return nil
+	})
+	sort.Strings(paths)
+
+	sources, failed := checker.LoadSourceIndex(l.root, paths)
+	l.project.IOSSources = sources
+	for _, file := range paths {
+		if err, ok := failed[file]; ok {
+			l.failed(file, PlatformIOS, err)
+		}
+	}
+}
+
+// loadIOSPlugins records the iOS plugins built into release builds and
+// whether each ships a privacy manifest.
+func (l *loader) loadIOSPlugins() {
+	for _, plugin := range l.flutterPlugins(PlatformIOS) {
+		if plugin.DevDependency {
+			continue
+		}
+		manifest, located := parser.FindPluginPrivacyManifest(plugin)
+		l.project.IOSPlugins = append(l.project.IOSPlugins, checker.IOSPluginInfo{
+			Name:            plugin.Name,
+			Located:         located,
+			PrivacyManifest: manifest,
+		})
+	}
+}
+
+// flutterBuildSettings sets FLUTTER_BUILD_NAME and FLUTTER_BUILD_NUMBER
+// from the pubspec version when Flutter/Generated.xcconfig, which flutter
+// pub get writes, has not set them.
+func (l *loader) flutterBuildSettings(settings parser.BuildSettings) {
+	name, number, _ := strings.Cut(l.project.Pubspec.Version, "+")
+	if _, ok := settings["FLUTTER_BUILD_NAME"]; !ok && name != "" {
+		settings["FLUTTER_BUILD_NAME"] = name
+	}
+	if _, ok := settings["FLUTTER_BUILD_NUMBER"]; !ok && number != "" {
+		settings["FLUTTER_BUILD_NUMBER"] = number
+	}
+}
+
+func (l *loader) loadDartFiles() {
+	ignore := l.ignore()
+	_ = filepath.WalkDir(l.root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel := l.rel(path)
+		if d.IsDir() {
+			if path != l.root && (skipDirs[d.Name()] || ignore.Match(rel, true)) {
+				return filepath.SkipDir
+			}
+			if data, err := os.ReadFile(filepath.Join(path, ".gitignore")); err == nil {
+				if path == l.root {
+					rel = ""
+				}
+				ignore.AddFile(rel, string(data))
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".dart") && !ignore.Match(rel, false) {
+			l.project.DartFiles = append(l.project.DartFiles, rel)
+		}
+		return nil
+	})
+	sort.Strings(l.project.DartFiles)
+}
+
+// ignore returns the patterns of files to leave out of DartFiles other
+// than those in .gitignore files, which are added during the walk.
+func (l *loader) ignore() *filter.Ignore {
+	ignore := filter.NewIgnore()
+	if !l.opts.IncludeGenerated {
+		ignore.Add("", filter.GeneratedPatterns...)
+	}
+	for _, pattern := range analyzerExcludes(l.root) {
+		// Analyzer globs are relative to the project root.
+		ignore.Add("", "/"+strings.TrimPrefix(pattern, "/"))
+	}
+	return ignore
+}
+
+// analyzerExcludes returns the analyzer: exclude: globs of the project's
+// analysis_options.yaml.
+func analyzerExcludes(root string) []string {
+	for _, name := range []string{"analysis_options.yaml", "analysis_options.yml"} {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		var options struct {
+			Analyzer struct {
+				Exclude []string `yaml:"exclude"`
+			} `yaml:"analyzer"`
+		}
+		if yaml.Unmarshal(data, &options) != nil {
+			return nil
+		}
+		return options.Analyzer.Exclude
+	}
+	return nil
+}
+
+func (l *loader) loadSources() {
+	sources, failed := checker.LoadSourceIndex(l.root, l.project.DartFiles)
+	l.project.Sources = sources
+	for _, file := range l.project.DartFiles {
+		if err, ok := failed[file]; ok {
+			l.failed(file, "", err)
+		}
+	}
+}
+
+func (l *loader) loadRootFiles() {
+	entries, err := os.ReadDir(l.root)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !rootFiles[strings.ToLower(entry.Name())] {
+			continue
+		}
+		l.found(entry.Name())
+		data, err := os.ReadFile(filepath.Join(l.root, entry.Name()))
+		if err != nil {
+			l.failed(entry.Name(), "", err)
+			continue
+		}
+		if l.project.RootFiles == nil {
+			l.project.RootFiles = make(map[string]string)
+		}
+		l.project.RootFiles[entry.Name()] = string(data)
+	}
+}
+
+func manifestInfo(manifest *parser.AndroidManifest) *checker.AndroidManifestInfo {
+	app := manifest.Application
+	info := &checker.AndroidManifestInfo{
+		PackageName:           manifest.Package,
+		VersionCode:           manifest.VersionCode,
+		VersionName:           manifest.VersionName,
+		Debuggable:            manifest.GetDebuggable(),
+		AllowBackup:           manifest.GetAllowBackup(),
+		UsesCleartextTraffic:  strings.EqualFold(app.UsesCleartextTraffic, "true"),
+		NetworkSecurityConfig: app.NetworkSecurityConfig,
+		ApplicationLine:       app.Line,
+	}
+	for _, p := range manifest.UsesPermissions {
+		info.Permissions = append(info.Permissions, p.Name)
+		maxSDK, _ := strconv.Atoi(p.MaxSDKVersion)
+		permission := checker.PermissionInfo{
+			Name:          p.Name,
+			MaxSDKVersion: maxSDK,
+			SDK23:         p.SDK23,
+			Line:          p.Line,
+			Origin:        p.Origin,
+		}
+		permission.Line = permission.AppLine()
+		info.UsesPermissions = append(info.UsesPermissions, permission)
+	}
+	for _, f := range manifest.UsesFeatures {
+		feature := checker.FeatureInfo{
+			Name:     f.Name,
+			Required: f.IsRequired(),
+			Line:     f.Line,
+			Origin:   f.Origin,
+		}
+		feature.Line = feature.AppLine()
+		info.Features = append(info.Features, feature)
+	}
+	for _, a := range manifest.Activities {
+		info.Activities = append(info.Activities, componentInfo(a))
+	}
+	for _, s := range manifest.Services {
+		info.Services = append(info.Services, componentInfo(s))
+	}
+	for _, r := range manifest.Receivers {
+		info.Receivers = append(info.Receivers, componentInfo(r))
+	}
+	for _, p := range manifest.Providers {
+		info.Providers = append(info.Providers, componentInfo(p))
+	}
+	for _, m := range app.MetaData {
+		if info.MetaData == nil {
+			info.MetaData = make(map[string]string)
+		}
+		value := m.Value
+		if value == "" {
+			value = m.Resource
+		}
+		info.MetaData[m.Name] = value
+	}
+	for _, q := range manifest.Queries {
+		for _, p := range q.Packages {
+			info.QueriesPackages = append(info.QueriesPackages, p.Name)
+		}
+	}
+	return info
+}
+
+func componentInfo(c parser.Component) checker.ComponentInfo {
+	info := checker.ComponentInfo{
+		Kind:                  c.Kind,
+		Name:                  c.Name,
+		Exported:              strings.EqualFold(c.Exported, "true"),
+		ExportedSet:           c.Exported != "",
+		HasIntentFilter:       len(c.IntentFilters) > 0,
+		Permission:            c.Permission,
+		ForegroundServiceType: c.ForegroundServiceType,
+		Line:                  c.Line,
+		Origin:                c.Origin,
+	}
+	info.Line = info.AppLine()
+	for _, filter := range c.DeepLinks() {
+		for _, url := range deepLinkURLs(filter.Data) {
+			link := checker.DeepLinkInfo{
+				URL:        url,
+				AutoVerify: strings.EqualFold(filter.AutoVerify, "true"),
+				Line:       filter.Line,
+				Origin:     c.Origin,
+			}
+			link.Line = link.AppLine()
+			info.DeepLinks = append(info.DeepLinks, link)
+		}
+	}
+	return info
+}
+
+// deepLinkURLs formats the URLs the <data> elements of an intent filter
+// match. Their schemes, hosts and paths combine across elements.
+func deepLinkURLs(data []parser.IntentData) []string {
+	var schemes, hosts, paths []string
+	for _, d := range data {
+		if d.Scheme != "" {
+			schemes = append(schemes, d.Scheme)
+		}
+		if d.Host != "" {
+			host := d.Host
+			if d.Port != "" {
+				host += ":" + d.Port
+			}
+			hosts = append(hosts, host)
+		}
+		switch {
+		case d.Path != "":
+			paths = append(paths, d.Path)
+		case d.PathPrefix != "":
+			paths = append(paths, d.PathPrefix+"*")
+		case d.PathPattern != "":
+			paths = append(paths, d.PathPattern)
+		}
+	}
+	if len(hosts) == 0 {
+		hosts = []string{""}
+	}
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	var urls []string
+	for _, scheme := range schemes {
+		for _, host := range hosts {
+			for _, path := range paths {
+				url := scheme + ":"
+				if host != "" {
+					url += "//" + host
+				}
+				urls = append(urls, url+path)
+			}
+		}
+	}
+	return urls
+}
+
+// FindGradleFile returns the app module build script under androidPath,
+// preferring the Kotlin DSL when both exist. It returns "" when neither is
+// present.
+func FindGradleFile(androidPath string) string {
+	for _, name := range []string{"build.gradle.kts", "build.gradle"} {
+		path := filepath.Join(androidPath, "app", name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// FindInfoPlist returns the application Info.plist under iosPath. The
+// standard Runner target is tried first, then any other app target folder.
+func FindInfoPlist(iosPath string) string {
+	runner := filepath.Join(iosPath, "Runner", "Info.plist")
+	if fileExists(runner) {
+		return runner
+	}
+
+	entries, err := os.ReadDir(iosPath)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == "Pods" || name == "Flutter" || strings.HasSuffix(name, "Tests") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		candidate := filepath.Join(iosPath, name, "Info.plist")
+		if fileExists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// FindXcodeProject returns the project.pbxproj of the app under iosPath:
+// Runner.xcodeproj's, or else that of the first other .xcodeproj. It
+// returns "" if there is none.
+func FindXcodeProject(iosPath string) string {
+	runner := filepath.Join(iosPath, "Runner.xcodeproj", "project.pbxproj")
+	if fileExists(runner) {
+		return runner
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(iosPath, "*.xcodeproj", "project.pbxproj"))
+	for _, match := range matches {
+		if filepath.Base(filepath.Dir(match)) != "Pods.xcodeproj" {
+			return match
+		}
+	}
+	return ""
+}
+
+func hasLoginPatterns(root string) bool {
+	lib := filepath.Join(root, "lib")
+	if !dirExists(lib) {
+		return false
+	}
+	matches, err := parser.FindLoginPatterns(lib)
+	return err == nil && len(matches) > 0
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,613 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/kb"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func TestLoad(t *testing.T) {
+	root := filepath.Join(getTestdataDir(t), "sample_flutter_app")
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(project.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", project.Diagnostics)
+	}
+
+	t.Run("pubspec", func(t *testing.T) {
+		if project.Pubspec.Name != "sample_app" {
+			t.Errorf("expected name sample_app, got %s", project.Pubspec.Name)
+		}
+		if _, ok := project.Pubspec.Dependencies["camera"]; !ok {
+			t.Error("expected camera dependency")
+		}
+		if !project.Pubspec.HasLinter {
+			t.Error("expected linter to be detected")
+		}
+	})
+
+	t.Run("android", func(t *testing.T) {
+		if len(project.AndroidManifest.Permissions) != 3 {
+			t.Errorf("expected 3 permissions, got %d", len(project.AndroidManifest.Permissions))
+		}
+		if len(project.AndroidManifest.Activities) != 1 {
+			t.Errorf("expected 1 activity, got %d", len(project.AndroidManifest.Activities))
+		}
+		if project.GradleConfig.TargetSDKVersion != "34" {
+			t.Errorf("expected targetSdk 34, got %q", project.GradleConfig.TargetSDKVersion)
+		}
+		if project.GradleConfig.ApplicationID != "com.example.sample_app" {
+			t.Errorf("unexpected applicationId %q", project.GradleConfig.ApplicationID)
+		}
+	})
+
+	t.Run("ios", func(t *testing.T) {
+		if project.InfoPlist.CFBundleIdentifier != "com.example.sampleApp" {
+			t.Errorf("unexpected bundle id %q", project.InfoPlist.CFBundleIdentifier)
+		}
+		if !project.InfoPlist.HasCameraUsageDescription {
+			t.Error("expected camera usage description")
+		}
+		if !project.InfoPlist.EncryptionDeclarationSet {
+			t.Error("expected encryption declaration")
+		}
+	})
+
+	t.Run("dart files", func(t *testing.T) {
+		expected := []string{"lib/main.dart", "lib/src/api.dart", "test/widget_test.dart"}
+		if len(project.DartFiles) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, project.DartFiles)
+		}
+		for i, file := range expected {
+			if project.DartFiles[i] != file {
+				t.Errorf("expected %s at %d, got %s", file, i, project.DartFiles[i])
+			}
+		}
+	})
+
+	t.Run("sources", func(t *testing.T) {
+		if project.Sources.Len() != len(project.DartFiles) {
+			t.Fatalf("expected %d sources, got %d", len(project.DartFiles), project.Sources.Len())
+		}
+		api := project.Sources.File("lib/src/api.dart")
+		if api == nil {
+			t.Fatal("expected lib/src/api.dart to be indexed")
+		}
+		if !strings.Contains(api.Content, "apiKey") {
+			t.Error("expected source content to be loaded")
+		}
+	})
+
+	t.Run("root files", func(t *testing.T) {
+		name, content, ok := project.RootFile("readme.md")
+		if !ok || name != "README.md" {
+			t.Fatalf("expected README.md to be loaded, got %q", name)
+		}
+		if !strings.Contains(content, "Setup") {
+			t.Error("expected README content to be loaded")
+		}
+		if _, _, ok := project.RootFile("analysis_options.yaml"); !ok {
+			t.Error("expected analysis_options.yaml to be loaded")
+		}
+		if _, _, ok := project.RootFile("LICENSE"); ok {
+			t.Error("expected no LICENSE")
+		}
+	})
+
+	t.Run("dependency flags", func(t *testing.T) {
+		if !project.HasNetworkDeps || !project.HasCameraDeps || !project.HasLocationDeps {
+			t.Error("expected network, camera and location flags")
+		}
+		if !project.HasImagePicker || !project.HasURLLauncher {
+			t.Error("expected image picker and url launcher flags")
+		}
+		if !project.HasLoginPatterns {
+			t.Error("expected login patterns")
+		}
+	})
+}
+
+func TestLoadDiagnostics(t *testing.T) {
+	t.Run("empty directory", func(t *testing.T) {
+		project, err := Load(t.TempDir())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		files := map[string]bool{}
+		for _, d := range project.Diagnostics {
+			if !d.Missing {
+				t.Errorf("expected missing diagnostic for %s", d.File)
+			}
+			files[d.File] = true
+		}
+		for _, file := range []string{"pubspec.yaml", "android", "ios"} {
+			if !files[file] {
+				t.Errorf("expected diagnostic for %s", file)
+			}
+		}
+	})
+
+	t.Run("malformed manifest", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: broken\n")
+		writeFile(t, filepath.Join(root, "android", "app", "src", "main", "AndroidManifest.xml"), "<manifest><application></manifest>")
+		writeFile(t, filepath.Join(root, "android", "app", "build.gradle.kts"), "android {}\n")
+
+		project, err := Load(root)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		var malformed int
+		for _, d := range project.Diagnostics {
+			if d.File == "android/app/src/main/AndroidManifest.xml" && d.Err != nil {
+				malformed++
+			}
+			if d.File == "android/app/build.gradle" {
+				t.Error("build.gradle.kts should have been found")
+			}
+		}
+		if malformed != 1 {
+			t.Errorf("expected 1 malformed manifest diagnostic, got %+v", project.Diagnostics)
+		}
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		writeFile(t, file, "")
+		if _, err := Load(file); err == nil {
+			t.Error("expected error for file path")
+		}
+	})
+}
+
+func TestLoadPluginManifests(t *testing.T) {
+	root := t.TempDir()
+	plugin := filepath.Join(t.TempDir(), "geolocator_android-4.6.1")
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: app\n")
+	writeFile(t, filepath.Join(root, "android", "app", "src", "main", "AndroidManifest.xml"),
+		`<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <uses-permission android:name="android.permission.INTERNET" />
+</manifest>`)
+	writeFile(t, filepath.Join(plugin, "android", "src", "main", "AndroidManifest.xml"),
+		`<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <uses-permission android:name="android.permission.ACCESS_BACKGROUND_LOCATION" />
+</manifest>`)
+	writeFile(t, filepath.Join(root, ".flutter-plugins-dependencies"),
+		`{"plugins":{"android":[{"name":"geolocator_android","path":`+strconv.Quote(plugin)+`,"native_build":true,"dependencies":[]}]}}`)
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	manifest := project.AndroidManifest
+	if len(manifest.Permissions) != 2 {
+		t.Errorf("expected the plugin permission to be merged, got %v", manifest.Permissions)
+	}
+	if p := manifest.Permission("android.permission.ACCESS_BACKGROUND_LOCATION"); p == nil || p.Origin != "geolocator_android" || p.Line != 0 {
+		t.Errorf("expected the permission to come from geolocator_android, got %+v", p)
+	}
+	if p := manifest.Permission("android.permission.INTERNET"); p == nil || p.Origin != "" || p.Line != 2 {
+		t.Errorf("expected the app's own permission on line 2, got %+v", p)
+	}
+}
+
+func TestLoadPubspecLock(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), `name: app
+dependencies:
+  scanner_kit: ^1.0.0
+dev_dependencies:
+  build_runner: ^2.4.0
+`)
+	lock := "packages:\n"
+	for _, pkg := range []struct{ name, dependency string }{
+		{"build_runner", "direct dev"},
+		{"camera", "transitive"},
+		{"http", "transitive"},
+		{"orphan", "transitive"},
+		{"scanner_kit", "direct main"},
+	} {
+		lock += "  " + pkg.name + ":\n    dependency: " + strconv.Quote(pkg.dependency) + "\n    source: hosted\n    version: \"1.0.0\"\n"
+	}
+	lock += "sdks:\n  dart: \">=3.4.0 <4.0.0\"\n"
+	writeFile(t, filepath.Join(root, "pubspec.lock"), lock)
+	writeFile(t, filepath.Join(root, ".dart_tool", "package_config.json"), `{"configVersion": 2, "packages": [
+  {"name": "build_runner", "rootUri": "../packages/build_runner"},
+  {"name": "camera", "rootUri": "../packages/camera"},
+  {"name": "http", "rootUri": "../packages/http"},
+  {"name": "scanner_kit", "rootUri": "../packages/scanner_kit"}
+]}`)
+	writeFile(t, filepath.Join(root, "packages", "build_runner", "pubspec.yaml"), "name: build_runner\ndependencies:\n  http: any\n")
+	writeFile(t, filepath.Join(root, "packages", "camera", "pubspec.yaml"), "name: camera\n")
+	writeFile(t, filepath.Join(root, "packages", "http", "pubspec.yaml"), "name: http\n")
+	writeFile(t, filepath.Join(root, "packages", "scanner_kit", "pubspec.yaml"), "name: scanner_kit\ndependencies:\n  camera: ^0.11.0\n")
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	pubspec := project.Pubspec
+	if len(pubspec.Resolved) != 5 || pubspec.ResolvedSDKs["dart"] != ">=3.4.0 <4.0.0" {
+		t.Fatalf("expected the lock file to be loaded, got %+v", pubspec.Resolved)
+	}
+	if got := strings.Join(pubspec.AppPackages(), ","); got != "camera,orphan,scanner_kit" {
+		t.Errorf("expected dev-only packages to be left out of the app, got %s", got)
+	}
+	if why := pubspec.Why("camera"); len(why) != 1 || strings.Join(why[0], " > ") != "scanner_kit > camera" {
+		t.Errorf("expected camera to come from scanner_kit, got %v", why)
+	}
+	if why := pubspec.Why("orphan"); why != nil {
+		t.Errorf("expected no chain for a package without known dependents, got %v", why)
+	}
+	if !project.HasCameraDeps {
+		t.Error("expected a transitive camera package to set HasCameraDeps")
+	}
+	if project.HasNetworkDeps {
+		t.Error("expected a dev-only http package to be ignored")
+	}
+}
+
+func TestLoadPackageNeeds(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), `name: app
+dependencies:
+  geolocator: ^13.0.0
+  scanner_kit: ^1.0.0
+  provider: ^6.1.0
+`)
+
+	private := &kb.KnowledgeBase{
+		Schema: kb.Schema,
+		Packages: map[string]kb.Package{
+			"scanner_kit": {UsageDescriptions: []string{"NSCameraUsageDescription"}, Permissions: []string{"android.permission.CAMERA"}},
+		},
+	}
+	project, err := LoadWithOptions(root, Options{KnowledgeBase: kb.Builtin().Merge(private)})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	needs := project.PackageNeeds
+	var names []string
+	for _, pkg := range needs.Packages {
+		names = append(names, pkg.Package)
+	}
+	if got := strings.Join(names, ","); got != "geolocator,scanner_kit" {
+		t.Errorf("expected entries for geolocator and scanner_kit, got %s", got)
+	}
+	if !strings.HasSuffix(needs.Version, "+local") || !needs.Known["android.permission.CAMERA"] || !needs.Sensitive["android.permission.READ_SMS"] {
+		t.Errorf("unexpected knowledge base details %+v", needs)
+	}
+	if !project.HasCameraDeps || !project.HasLocationDeps || project.HasImagePicker {
+		t.Errorf("expected the flags to follow the knowledge base, got camera=%v location=%v image picker=%v",
+			project.HasCameraDeps, project.HasLocationDeps, project.HasImagePicker)
+	}
+}
+
+func TestLoadXcodeProject(t *testing.T) {
+	root := t.TempDir()
+	pbxproj, err := os.ReadFile(filepath.Join(getTestdataDir(t), "ios", "Runner.xcodeproj", "project.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: app\nversion: 3.0.1+7\n")
+	writeFile(t, filepath.Join(root, "ios", "Runner.xcodeproj", "project.pbxproj"), string(pbxproj))
+	// Generated.xcconfig is missing until flutter pub get runs.
+	writeFile(t, filepath.Join(root, "ios", "Flutter", "Release.xcconfig"), `#include "Generated.xcconfig"`)
+	writeFile(t, filepath.Join(root, "ios", "Runner", "Info.plist"), `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	<key>CFBundleShortVersionString</key>
+	<string>$(FLUTTER_BUILD_NAME)</string>
+	<key>CFBundleVersion</key>
+	<string>$(FLUTTER_BUILD_NUMBER)</string>
+</dict>
+</plist>`)
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	xcode := project.Xcode
+	if xcode.File != "ios/Runner.xcodeproj/project.pbxproj" || xcode.Target != "Runner" {
+		t.Errorf("unexpected Xcode project %+v", xcode)
+	}
+	if got := xcode.Setting("IPHONEOS_DEPLOYMENT_TARGET"); got != "12.0" || xcode.Lines["IPHONEOS_DEPLOYMENT_TARGET"] != 145 {
+		t.Errorf("expected the Release deployment target on line 145, got %q on %d", got, xcode.Lines["IPHONEOS_DEPLOYMENT_TARGET"])
+	}
+	if got := xcode.Configurations["Debug"]["PRODUCT_BUNDLE_IDENTIFIER"]; got != "com.example.sampleApp.debug" {
+		t.Errorf("expected per-configuration settings, got Debug bundle ID %q", got)
+	}
+	if got := xcode.Setting("MARKETING_VERSION"); got != "3.0.1" {
+		t.Errorf("expected FLUTTER_BUILD_NAME from pubspec.yaml, got MARKETING_VERSION %q", got)
+	}
+
+	plist := project.InfoPlist
+	if plist.CFBundleIdentifier != "com.example.sampleApp" || plist.CFBundleShortVersionString != "3.0.1" || plist.CFBundleVersion != "7" {
+		t.Errorf("expected Info.plist references to be expanded, got %+v", plist)
+	}
+}
+
+func TestLoadCocoaPods(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: app\n")
+	writeFile(t, filepath.Join(root, "ios", "Podfile"), `platform :ios, '12.0'
+
+post_install do |installer|
+  installer.pods_project.targets.each do |target|
+    target.build_configurations.each do |config|
+      config.build_settings['IPHONEOS_DEPLOYMENT_TARGET'] = '11.0'
+    end
+  end
+end
+`)
+	writeFile(t, filepath.Join(root, "ios", "Podfile.lock"), `PODS:
+  - Flutter (1.0.0)
+  - GoogleUtilities/Environment (7.13.3)
+  - image_picker_ios (0.0.1):
+    - Flutter
+
+EXTERNAL SOURCES:
+  Flutter:
+    :path: Flutter
+  image_picker_ios:
+    :path: ".symlinks/plugins/image_picker_ios/ios"
+
+COCOAPODS: 1.15.2
+`)
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	pods := project.CocoaPods
+	if pods.Podfile != "ios/Podfile" || pods.Lockfile != "ios/Podfile.lock" {
+		t.Errorf("unexpected CocoaPods files %q and %q", pods.Podfile, pods.Lockfile)
+	}
+	if pods.Platform != "12.0" || pods.PlatformLine != 1 || len(pods.DeploymentTargets) != 1 || pods.DeploymentTargets[0].Line != 6 {
+		t.Errorf("unexpected Podfile settings %+v", pods)
+	}
+	if p := pods.Pod("GoogleUtilities"); p == nil || p.Version != "7.13.3" || p.Line != 3 {
+		t.Errorf("unexpected pod %+v", p)
+	}
+	if got := pods.NativeSDKs(); !reflect.DeepEqual(got, []string{"GoogleUtilities"}) {
+		t.Errorf("expected only GoogleUtilities as a native SDK, got %v", got)
+	}
+	if pods.CocoaPodsVersion != "1.15.2" {
+		t.Errorf("expected CocoaPods 1.15.2, got %q", pods.CocoaPodsVersion)
+	}
+}
+
+func TestLoadEntitlements(t *testing.T) {
+	root := t.TempDir()
+	pbxproj, err := os.ReadFile(filepath.Join(getTestdataDir(t), "ios", "Runner.xcodeproj", "project.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: app\n")
+	writeFile(t, filepath.Join(root, "ios", "Runner.xcodeproj", "project.pbxproj"), string(pbxproj))
+
+	// The Release configuration names Runner/Runner.entitlements, which
+	// does not exist yet.
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	var missing bool
+	for _, d := range project.Diagnostics {
+		missing = missing || d.File == "ios/Runner/Runner.entitlements" && d.Missing
+	}
+	if !missing {
+		t.Errorf("expected a diagnostic for the missing entitlements, got %+v", project.Diagnostics)
+	}
+
+	writeFile(t, filepath.Join(root, "ios", "Runner", "Runner.entitlements"), `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>aps-environment</key>
+	<string>development</string>
+	<key>com.apple.developer.associated-domains</key>
+	<array>
+		<string>applinks:example.com</string>
+	</array>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>group.$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	</array>
+</dict>
+</plist>`)
+	writeFile(t, filepath.Join(root, "web", ".well-known", "apple-app-site-association"),
+		`{"applinks": {"details": [{"appID": "ABCDE12345.com.example.sampleApp", "paths": ["*"]}]}}`)
+
+	project, err = Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	entitlements := project.Entitlements
+	if entitlements.File != "ios/Runner/Runner.entitlements" || entitlements.APSEnvironment != "development" || entitlements.Line("aps-environment") != 4 {
+		t.Errorf("unexpected entitlements %+v", entitlements)
+	}
+	if !reflect.DeepEqual(entitlements.AppGroups, []string{"group.com.example.sampleApp"}) {
+		t.Errorf("expected build settings to be expanded, got %v", entitlements.AppGroups)
+	}
+	association := project.SiteAssociation
+	if association.File != "web/.well-known/apple-app-site-association" || !reflect.DeepEqual(association.AppLinks, []string{"ABCDE12345.com.example.sampleApp"}) {
+		t.Errorf("unexpected site association %+v", association)
+	}
+}
+
+func TestLoadPrivacyManifest(t *testing.T) {
+	t.Setenv("PUB_CACHE", t.TempDir())
+	root := t.TempDir()
+	plugins := t.TempDir()
+	writeFile(t, filepath.Join(root, "pubspec.yaml"), "name: app\n")
+	writeFile(t, filepath.Join(root, "ios", "Runner", "PrivacyInfo.xcprivacy"), `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>NSPrivacyAccessedAPITypes</key>
+	<array>
+		<dict>
+			<key>NSPrivacyAccessedAPIType</key>
+			<string>NSPrivacyAccessedAPICategoryUserDefaults</string>
+			<key>NSPrivacyAccessedAPITypeReasons</key>
+			<array>
+				<string>CA92.1</string>
+			</array>
+		</dict>
+	</array>
+</dict>
+</plist>`)
+	writeFile(t, filepath.Join(root, "ios", "Runner", "AppDelegate.swift"), "import UIKit\n")
+	writeFile(t, filepath.Join(root, "ios", "Runner", "Bridge", "Native.m"), "#import <Foundation/Foundation.h>\n")
+	writeFile(t, filepath.Join(root, "ios", "Runner", "build", "Generated.swift"), "")
+	writeFile(t, filepath.Join(plugins, "shared_preferences_foundation", "darwin", "Resources", "PrivacyInfo.xcprivacy"), "")
+	writeFile(t, filepath.Join(plugins, "fluttertoast", "ios", "Classes", "FluttertoastPlugin.m"), "")
+	writeFile(t, filepath.Join(root, ".flutter-plugins-dependencies"), `{"plugins": {"ios": [
+		{"name": "shared_preferences_foundation", "path": `+strconv.Quote(filepath.Join(plugins, "shared_preferences_foundation"))+`},
+		{"name": "fluttertoast", "path": `+strconv.Quote(filepath.Join(plugins, "fluttertoast"))+`},
+		{"name": "integration_test", "path": "/sdk/packages/integration_test/", "dev_dependency": true}
+	]}}`)
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	manifest := project.PrivacyManifest
+	if manifest.File != "ios/Runner/PrivacyInfo.xcprivacy" || !manifest.Bundled || !manifest.Declares("NSPrivacyAccessedAPICategoryUserDefaults") {
+		t.Errorf("unexpected privacy manifest %+v", manifest)
+	}
+	if len(manifest.AccessedAPIs) != 1 || manifest.AccessedAPIs[0].Line != 6 {
+		t.Errorf("unexpected accessed APIs %+v", manifest.AccessedAPIs)
+	}
+
+	var files []string
+	for _, f := range project.IOSSources.Files() {
+		files = append(files, f.Path)
+	}
+	if want := []string{"ios/Runner/AppDelegate.swift", "ios/Runner/Bridge/Native.m"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("IOSSources = %v, want %v", files, want)
+	}
+
+	if len(project.IOSPlugins) != 2 {
+		t.Fatalf("expected the dev dependency to be left out, got %+v", project.IOSPlugins)
+	}
+	if p := project.IOSPlugins[0]; p.Name != "shared_preferences_foundation" || !p.Located || p.PrivacyManifest == "" {
+		t.Errorf("expected shared_preferences_foundation to ship a manifest, got %+v", p)
+	}
+	if p := project.IOSPlugins[1]; p.Name != "fluttertoast" || !p.Located || p.PrivacyManifest != "" {
+		t.Errorf("expected fluttertoast to ship no manifest, got %+v", p)
+	}
+}
+
+func TestLoadDartFilesIgnored(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"lib/main.dart",
+		"lib/build_utils.dart",
+		"lib/models/user.dart",
+		"lib/models/user.g.dart",
+		"lib/models/user.freezed.dart",
+		"lib/generated/l10n.dart",
+		"lib/legacy/old.dart",
+		"lib/legacy/keep.dart",
+		"lib/tmp/scratch.dart",
+		"test/user_test.mocks.dart",
+		"tool/gen/out.dart",
+	} {
+		writeFile(t, filepath.Join(root, file), "void main() {}\n")
+	}
+	writeFile(t, filepath.Join(root, ".gitignore"), "# local\n/tool/gen/\nbuild\n")
+	writeFile(t, filepath.Join(root, "lib", ".gitignore"), "legacy/*\n!legacy/keep.dart\n")
+	writeFile(t, filepath.Join(root, "analysis_options.yaml"), "analyzer:\n  exclude:\n    - lib/tmp/**\n")
+
+	project, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := "lib/build_utils.dart,lib/legacy/keep.dart,lib/main.dart,lib/models/user.dart"
+	if got := strings.Join(project.DartFiles, ","); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	project, err = LoadWithOptions(root, Options{IncludeGenerated: true})
+	if err != nil {
+		t.Fatalf("LoadWithOptions failed: %v", err)
+	}
+	if len(project.DartFiles) != 8 {
+		t.Errorf("expected generated files included, got %v", project.DartFiles)
+	}
+}
+
+func TestFindings(t *testing.T) {
+	project, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	findings := Findings(project.Diagnostics)
+	if len(findings) != len(project.Diagnostics) {
+		t.Fatalf("expected %d findings, got %d", len(project.Diagnostics), len(findings))
+	}
+
+	for _, f := range findings {
+		if f.ID != MissingFileID {
+			t.Errorf("expected %s, got %s", MissingFileID, f.ID)
+		}
+		if f.File == "pubspec.yaml" && f.Severity != report.SeverityHigh {
+			t.Errorf("expected missing pubspec to be HIGH, got %s", f.Severity)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getTestdataDir(t *testing.T) string {
+	t.Helper()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	dir := cwd
+	for i := 0; i < 5; i++ {
+		testdataPath := filepath.Join(dir, "testdata")
+		if _, err := os.Stat(testdataPath); err == nil {
+			return testdataPath
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	t.Fatalf("Could not find testdata directory from %s", cwd)
+	return ""
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ricky-irfandi/fsct/internal/plist"
+)
+
+// Entitlements holds the capabilities an .entitlements file grants the
+// app, such as ios/Runner/Runner.entitlements. Root is the whole decoded
+// property list, for entitlements without a field of their own.
+type Entitlements struct {
+	Root *plist.Value
+
+	// APSEnvironment is the push notification environment, development
+	// or production, or "" if the app cannot receive push notifications.
+	APSEnvironment string
+	// AssociatedDomains are entries such as applinks:example.com.
+	AssociatedDomains []string
+	AppGroups         []string
+	ICloudContainers  []string
+	SignInWithApple   bool
+	HealthKit         bool
+}
+
+// ParseEntitlements reads an .entitlements property list.
+func ParseEntitlements(path string) (*Entitlements, error) {
+	root, err := plist.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if root.Kind() != plist.KindDict {
+		return nil, fmt.Errorf("%s: expected a dictionary, found %s", path, root.Kind())
+	}
+
+	e := &Entitlements{Root: root}
+	e.read()
+	return e, nil
+}
+
+// Expand replaces the build setting references in the values, such as
+// $(AppIdentifierPrefix) and $(PRODUCT_BUNDLE_IDENTIFIER), as Xcode does
+// when it signs the app.
+func (e *Entitlements) Expand(settings BuildSettings) {
+	e.Root.ReplaceStrings(settings.Expand)
+	e.read()
+}
+
+// read sets the fields from Root.
+func (e *Entitlements) read() {
+	root := e.Root
+	e.APSEnvironment = root.Get("aps-environment").String()
+	e.AssociatedDomains = root.Get("com.apple.developer.associated-domains").Strings()
+	e.AppGroups = root.Get("com.apple.security.application-groups").Strings()
+	e.ICloudContainers = root.Get("com.apple.developer.icloud-container-identifiers").Strings()
+	e.SignInWithApple = len(root.Get("com.apple.developer.applesignin").Strings()) > 0
+	e.HealthKit, _ = root.Get("com.apple.developer.healthkit").Bool()
+}
+
+// SiteAssociation is the content of an apple-app-site-association file,
+// which a domain serves to name the apps its associated domains open.
+type SiteAssociation struct {
+	// AppLinks are the app IDs, such as ABCDE12345.com.example.app, that
+	// open the domain's universal links.
+	AppLinks []string
+	// WebCredentials are the app IDs that share the domain's passwords.
+	WebCredentials []string
+}
+
+// ParseSiteAssociation reads an apple-app-site-association file.
+func ParseSiteAssociation(path string) (*SiteAssociation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		AppLinks struct {
+			Details []struct {
+				AppID  string   `json:"appID"`
+				AppIDs []string `json:"appIDs"`
+			} `json:"details"`
+		} `json:"applinks"`
+		WebCredentials struct {
+			Apps []string `json:"apps"`
+		} `json:"webcredentials"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	s := &SiteAssociation{WebCredentials: doc.WebCredentials.Apps}
+	for _, detail := range doc.AppLinks.Details {
+		if detail.AppID != "" {
+			s.AppLinks = append(s.AppLinks, detail.AppID)
+		}
+		s.AppLinks = append(s.AppLinks, detail.AppIDs...)
+	}
+	return s, nil
+}
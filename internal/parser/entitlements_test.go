@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseEntitlements(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"Runner.entitlements": `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>aps-environment</key>
+	<string>development</string>
+	<key>com.apple.developer.associated-domains</key>
+	<array>
+		<string>applinks:example.com</string>
+		<string>webcredentials:example.com?mode=developer</string>
+	</array>
+	<key>com.apple.developer.applesignin</key>
+	<array>
+		<string>Default</string>
+	</array>
+	<key>com.apple.developer.healthkit</key>
+	<true/>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>group.$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	</array>
+	<key>com.apple.developer.icloud-container-identifiers</key>
+	<array>
+		<string>iCloud.com.example.app</string>
+	</array>
+</dict>
+</plist>`,
+		"list.entitlements": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><array/></plist>`,
+	})
+
+	e, err := ParseEntitlements(filepath.Join(dir, "Runner.entitlements"))
+	if err != nil {
+		t.Fatalf("ParseEntitlements failed: %v", err)
+	}
+	if e.APSEnvironment != "development" || !e.SignInWithApple || !e.HealthKit {
+		t.Errorf("unexpected entitlements %+v", e)
+	}
+	if want := []string{"applinks:example.com", "webcredentials:example.com?mode=developer"}; !reflect.DeepEqual(e.AssociatedDomains, want) {
+		t.Errorf("AssociatedDomains = %v, want %v", e.AssociatedDomains, want)
+	}
+	if want := []string{"iCloud.com.example.app"}; !reflect.DeepEqual(e.ICloudContainers, want) {
+		t.Errorf("ICloudContainers = %v, want %v", e.ICloudContainers, want)
+	}
+	if line := e.Root.Lookup("com.apple.developer.associated-domains.1").Line(); line != 10 {
+		t.Errorf("expected the second associated domain on line 10, got %d", line)
+	}
+
+	e.Expand(BuildSettings{"PRODUCT_BUNDLE_IDENTIFIER": "com.example.app"})
+	if want := []string{"group.com.example.app"}; !reflect.DeepEqual(e.AppGroups, want) {
+		t.Errorf("AppGroups = %v, want %v", e.AppGroups, want)
+	}
+
+	if _, err := ParseEntitlements(filepath.Join(dir, "list.entitlements")); err == nil {
+		t.Error("expected an error for entitlements that are not a dictionary")
+	}
+}
+
+func TestParseSiteAssociation(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"apple-app-site-association": `{
+  "applinks": {
+    "apps": [],
+    "details": [
+      {"appID": "ABCDE12345.com.example.app", "paths": ["/orders/*"]},
+      {"appIDs": ["ABCDE12345.com.example.app.beta"], "components": [{"/": "/beta/*"}]}
+    ]
+  },
+  "webcredentials": {"apps": ["ABCDE12345.com.example.app"]}
+}`,
+		"broken": `{"applinks": `,
+	})
+
+	s, err := ParseSiteAssociation(filepath.Join(dir, "apple-app-site-association"))
+	if err != nil {
+		t.Fatalf("ParseSiteAssociation failed: %v", err)
+	}
+	if want := []string{"ABCDE12345.com.example.app", "ABCDE12345.com.example.app.beta"}; !reflect.DeepEqual(s.AppLinks, want) {
+		t.Errorf("AppLinks = %v, want %v", s.AppLinks, want)
+	}
+	if want := []string{"ABCDE12345.com.example.app"}; !reflect.DeepEqual(s.WebCredentials, want) {
+		t.Errorf("WebCredentials = %v, want %v", s.WebCredentials, want)
+	}
+
+	if _, err := ParseSiteAssociation(filepath.Join(dir, "broken")); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
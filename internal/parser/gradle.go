@@ -0,0 +1,1059 @@
+package parser
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GradleConfig holds the android settings of an app module build script.
+type GradleConfig struct {
+	ApplicationID     string
+	Namespace         string
+	CompileSDKVersion string
+	MinSDKVersion     string
+	TargetSDKVersion  string
+	VersionCode       string
+	VersionName       string
+	NdkVersion        string
+	KotlinVersion     string
+	FlutterVersion    string
+
+	// Values records how each setting was resolved, keyed by its Groovy
+	// name such as targetSdkVersion. Settings the script does not assign
+	// are absent.
+	Values map[string]GradleValue
+}
+
+// BuildGradleKts holds the settings of a Kotlin DSL build script, which is
+// evaluated like a Groovy one.
+type BuildGradleKts = GradleConfig
+
+// GradleValue is a setting assigned in a build script.
+type GradleValue struct {
+	// Value is the resolved value, or "" if the expression could not be
+	// resolved, for instance flutter.targetSdkVersion without a Flutter SDK.
+	Value string
+	// Expr is the expression as written, such as flutter.minSdkVersion.
+	Expr string
+	// Line is the line of the assignment in the build script.
+	Line int
+	// Source is the file and line Value came from, such as
+	// android/local.properties:4, relative to the project root where
+	// possible.
+	Source string
+}
+
+// GradleOptions describe the environment a build script is evaluated in.
+type GradleOptions struct {
+	// FlutterSDK is the Flutter SDK directory whose defaults flutter.*
+	// values resolve to when local.properties does not name one.
+	FlutterSDK string
+}
+
+func ParseGradleFile(path string) (*GradleConfig, error) {
+	return EvaluateGradle(path, GradleOptions{})
+}
+
+func ParseGradleKtsFile(path string) (*BuildGradleKts, error) {
+	return EvaluateGradle(path, GradleOptions{})
+}
+
+// gradleSettings maps the android and defaultConfig settings fsct reads,
+// in both their current and older names, to the older name.
+var gradleSettings = map[string]string{
+	"applicationId":     "applicationId",
+	"namespace":         "namespace",
+	"compileSdk":        "compileSdkVersion",
+	"compileSdkVersion": "compileSdkVersion",
+	"minSdk":            "minSdkVersion",
+	"minSdkVersion":     "minSdkVersion",
+	"targetSdk":         "targetSdkVersion",
+	"targetSdkVersion":  "targetSdkVersion",
+	"versionCode":       "versionCode",
+	"versionName":       "versionName",
+	"ndkVersion":        "ndkVersion",
+}
+
+// EvaluateGradle reads the app module build script at path, Groovy or
+// Kotlin DSL, and resolves the settings fsct checks.
+//
+// Only the subset of the language build scripts use for these settings is
+// understood: def, val and var variables, ext and extra properties, including
+// those of the root build script, values read from local.properties and other
+// properties files, gradle.properties, the gradle/libs.versions.toml version
+// catalog, and flutter.* values, which resolve to the defaults of the Flutter
+// SDK named by local.properties or opts. Conditions are not evaluated: an
+// assignment inside an if block only fills a variable that has no value, as
+// in the if (flutterVersionCode == null) fallback of the Flutter template.
+func EvaluateGradle(path string, opts GradleOptions) (*GradleConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	androidDir := filepath.Dir(filepath.Dir(path))
+	e := &gradleEval{
+		androidDir: androidDir,
+		root:       filepath.Dir(androidDir),
+		opts:       opts,
+		ext:        make(map[string]gval),
+		propsFiles: make(map[string]string),
+		props:      make(map[string]map[string]gval),
+	}
+
+	for _, name := range []string{"build.gradle.kts", "build.gradle"} {
+		file := filepath.Join(androidDir, name)
+		if data, err := os.ReadFile(file); err == nil {
+			e.run(file, string(data), nil)
+			break
+		}
+	}
+
+	cfg := &GradleConfig{Values: make(map[string]GradleValue)}
+	e.run(path, string(data), cfg)
+	for name, field := range map[string]*string{
+		"applicationId":     &cfg.ApplicationID,
+		"namespace":         &cfg.Namespace,
+		"compileSdkVersion": &cfg.CompileSDKVersion,
+		"minSdkVersion":     &cfg.MinSDKVersion,
+		"targetSdkVersion":  &cfg.TargetSDKVersion,
+		"versionCode":       &cfg.VersionCode,
+		"versionName":       &cfg.VersionName,
+		"ndkVersion":        &cfg.NdkVersion,
+	} {
+		*field = cfg.Values[name].Value
+	}
+	return cfg, nil
+}
+
+type gkind int
+
+const (
+	gNone gkind = iota
+	gString
+	gProps // a java.util.Properties object
+	gFile  // a file; s is its path
+)
+
+// gval is the value of an expression and where it came from.
+type gval struct {
+	kind   gkind
+	s      string
+	source string
+}
+
+func (v gval) value() string {
+	if v.kind != gString {
+		return ""
+	}
+	return v.s
+}
+
+type gradleBlock struct {
+	name   string
+	header string
+}
+
+type gradleEval struct {
+	androidDir string
+	root       string
+	opts       GradleOptions
+
+	file string
+	vars map[string]gval
+	ext  map[string]gval
+
+	// propsFiles maps a Properties variable to the file loaded into it.
+	propsFiles map[string]string
+	props      map[string]map[string]gval
+	catalog    map[string]gval
+	flutter    map[string]gval
+	pubspec    map[string]gval
+}
+
+// run evaluates a build script, recording settings in cfg if it is not nil.
+func (e *gradleEval) run(file, content string, cfg *GradleConfig) {
+	e.file = file
+	e.vars = make(map[string]gval)
+
+	var blocks []gradleBlock
+	lines := strings.Split(stripGradleComments(content), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := i + 1
+		text := lines[i]
+		for openParens(text) > 0 && i+1 < len(lines) {
+			i++
+			text += " " + lines[i]
+		}
+
+		for _, p := range splitBraces(text) {
+			switch {
+			case p.open:
+				header := p.text
+				if j := strings.LastIndex(header, ";"); j >= 0 {
+					e.statements(header[:j], line, blocks, cfg)
+					header = header[j+1:]
+				}
+				blocks = append(blocks, gradleBlock{name: blockName(header), header: strings.TrimSpace(header)})
+			case p.close:
+				if len(blocks) > 0 {
+					blocks = blocks[:len(blocks)-1]
+				}
+			default:
+				e.statements(p.text, line, blocks, cfg)
+			}
+		}
+	}
+}
+
+var (
+	gradleDeclRe    = regexp.MustCompile(`^(?:(?:def|val|var|final|private|static|int|long|Integer|String)\s+)+([A-Za-z_]\w*)(?:\s*:\s*[\w.<>?]+)?\s*=\s*(.+)$`)
+	gradleExtRe     = regexp.MustCompile(`^(?:(?:rootProject|project)\.)?(?:ext|extra)\.(?:set\(\s*["'](\w+)["']\s*,\s*(.+)\)|(\w+)\s*=\s*(.+))$`)
+	gradleExtraRe   = regexp.MustCompile(`^(?:(?:rootProject|project)\.)?extra\[\s*["'](\w+)["']\s*\]\s*=\s*(.+)$`)
+	gradleLoadRe    = regexp.MustCompile(`^([A-Za-z_]\w*)\.load\s*\((.*)\)$`)
+	gradleIdentRe   = regexp.MustCompile(`^[A-Za-z_]\w*`)
+	gradleClosureRe = regexp.MustCompile(`^\w+(?:\s*,\s*\w+)*\s*->\s*`)
+	gradleFileRe    = regexp.MustCompile(`file\(\s*["']([^"']+)["']`)
+	gradleBlockRe   = regexp.MustCompile(`^[\w.]*`)
+
+	gradleTemplateRe = regexp.MustCompile(`^[A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*`)
+)
+
+func (e *gradleEval) statements(text string, line int, blocks []gradleBlock, cfg *GradleConfig) {
+	for _, stmt := range strings.Split(text, ";") {
+		stmt = strings.TrimSpace(gradleClosureRe.ReplaceAllString(strings.TrimSpace(stmt), ""))
+		if stmt != "" {
+			e.statement(stmt, line, blocks, cfg)
+		}
+	}
+}
+
+func (e *gradleEval) statement(stmt string, line int, blocks []gradleBlock, cfg *GradleConfig) {
+	if m := gradleDeclRe.FindStringSubmatch(stmt); m != nil {
+		e.vars[m[1]] = e.eval(m[2], line)
+		return
+	}
+	if m := gradleExtRe.FindStringSubmatch(stmt); m != nil {
+		if m[1] != "" {
+			e.ext[m[1]] = e.eval(m[2], line)
+		} else {
+			e.ext[m[3]] = e.eval(m[4], line)
+		}
+		return
+	}
+	if m := gradleExtraRe.FindStringSubmatch(stmt); m != nil {
+		e.ext[m[1]] = e.eval(m[2], line)
+		return
+	}
+	if m := gradleLoadRe.FindStringSubmatch(stmt); m != nil {
+		if file := e.loadedFile(m[2], line, blocks); file != "" {
+			e.propsFiles[m[1]] = file
+		}
+		return
+	}
+
+	name, expr, assign := splitGradleAssignment(stmt)
+	if name == "" {
+		return
+	}
+
+	path := blockPath(blocks)
+	switch setting, ok := gradleSettings[name]; {
+	case ok && cfg != nil && (path == "android" || path == "android.defaultConfig"):
+		v := e.eval(expr, line)
+		cfg.Values[setting] = GradleValue{Value: v.value(), Expr: expr, Line: line, Source: v.source}
+	case !assign:
+	case len(blocks) > 0 && blocks[len(blocks)-1].name == "ext":
+		e.ext[name] = e.eval(expr, line)
+	default:
+		old, ok := e.vars[name]
+		if ok && (old.kind == gNone || !conditional(blocks)) {
+			e.vars[name] = e.eval(expr, line)
+		}
+	}
+}
+
+// splitGradleAssignment splits name = expr, name.set(expr), name(expr) and
+// the Groovy command form name expr. assign is false for the last two, which
+// only set settings.
+func splitGradleAssignment(stmt string) (name, expr string, assign bool) {
+	name = gradleIdentRe.FindString(stmt)
+	if name == "" {
+		return "", "", false
+	}
+	rest := strings.TrimSpace(stmt[len(name):])
+	switch {
+	case strings.HasPrefix(rest, "=="):
+		return "", "", false
+	case strings.HasPrefix(rest, "="):
+		return name, strings.TrimSpace(rest[1:]), true
+	case strings.HasPrefix(rest, ".set(") && strings.HasSuffix(rest, ")"):
+		return name, strings.TrimSpace(rest[len(".set(") : len(rest)-1]), true
+	case strings.HasPrefix(rest, "(") && strings.HasSuffix(rest, ")"):
+		return name, strings.TrimSpace(rest[1 : len(rest)-1]), false
+	case rest != "" && len(stmt) > len(name) && (stmt[len(name)] == ' ' || stmt[len(name)] == '\t'):
+		return name, rest, false
+	}
+	return "", "", false
+}
+
+// loadedFile returns the file a props.load(args) statement reads: a file
+// named in its arguments or, inside a withReader or use closure, in the
+// closure's header.
+func (e *gradleEval) loadedFile(args string, line int, blocks []gradleBlock) string {
+	if v := e.eval(args, line); v.kind == gFile {
+		return v.s
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		header := blocks[i].header
+		if name := gradleIdentRe.FindString(header); name != "" && e.vars[name].kind == gFile {
+			return e.vars[name].s
+		}
+		if m := gradleFileRe.FindStringSubmatch(header); m != nil {
+			return e.resolveFile(strings.Contains(header, "rootProject"), m[1])
+		}
+	}
+	return ""
+}
+
+func blockName(header string) string {
+	header = strings.TrimSpace(header)
+	switch {
+	case strings.HasPrefix(header, "if"):
+		return "if"
+	case strings.HasPrefix(header, "else"):
+		return "else"
+	}
+	return gradleBlockRe.FindString(header)
+}
+
+func blockPath(blocks []gradleBlock) string {
+	names := make([]string, len(blocks))
+	for i, b := range blocks {
+		names[i] = b.name
+	}
+	return strings.Join(names, ".")
+}
+
+func conditional(blocks []gradleBlock) bool {
+	for _, b := range blocks {
+		if b.name == "if" || b.name == "else" {
+			return true
+		}
+	}
+	return false
+}
+
+// eval evaluates an expression on the given line of the current script.
+func (e *gradleEval) eval(expr string, line int) gval {
+	p := &gradleExpr{e: e, toks: scanGradle(expr), line: line}
+	return p.expr()
+}
+
+// literal returns a string value written on line of the current script.
+func (e *gradleEval) literal(s string, line int) gval {
+	return gval{kind: gString, s: s, source: e.rel(e.file) + ":" + strconv.Itoa(line)}
+}
+
+// resolve returns the value of a dotted reference such as
+// flutter.targetSdkVersion, libs.versions.android.minSdk, rootProject.ext.x
+// or a plain variable name.
+func (e *gradleEval) resolve(ref []string) gval {
+	switch {
+	case len(ref) == 2 && ref[0] == "flutter":
+		return e.flutterValue(ref[1])
+	case len(ref) > 2 && ref[0] == "libs" && ref[1] == "versions":
+		return e.versionCatalog()[strings.Join(ref[2:], ".")]
+	case len(ref) > 1 && (ref[0] == "rootProject" || ref[0] == "project"):
+		return e.resolve(ref[1:])
+	case len(ref) == 2 && (ref[0] == "ext" || ref[0] == "extra"):
+		return e.extValue(ref[1])
+	case len(ref) == 1:
+		if v, ok := e.vars[ref[0]]; ok {
+			return v
+		}
+		return e.extValue(ref[0])
+	}
+	return gval{}
+}
+
+// extValue returns an ext or extra property, falling back to
+// gradle.properties as Gradle does.
+func (e *gradleEval) extValue(name string) gval {
+	if v, ok := e.ext[name]; ok {
+		return v
+	}
+	return e.property(filepath.Join(e.androidDir, "gradle.properties"), name)
+}
+
+// call returns the result of calling name with args on a receiver, which is
+// either the unresolved reference recv or, if recv is nil, the value cur.
+func (e *gradleEval) call(cur gval, recv []string, name string, args []gval) gval {
+	receiver := func() gval {
+		if recv == nil {
+			return cur
+		}
+		return e.resolve(recv)
+	}
+	arg := func(i int) gval {
+		if i < len(args) {
+			return args[i]
+		}
+		return gval{}
+	}
+	last := ""
+	if len(recv) > 0 {
+		last = recv[len(recv)-1]
+	}
+
+	switch name {
+	case "toInteger", "toInt", "toLong", "toString", "trim", "get", "getOrNull", "orNull", "toIntOrNull":
+		if name == "get" && len(args) > 0 {
+			if last == "ext" || last == "extra" {
+				return e.extValue(arg(0).value())
+			}
+			return e.propsValue(recv, cur, arg(0).value(), gval{})
+		}
+		return receiver()
+	case "getOrElse", "orElse":
+		if v := receiver(); v.kind != gNone {
+			return v
+		}
+		return arg(0)
+	case "getProperty":
+		return e.propsValue(recv, cur, arg(0).value(), arg(1))
+	case "property", "findProperty", "gradleProperty":
+		return e.extValue(arg(0).value())
+	case "parseInt", "valueOf":
+		return arg(0)
+	case "file":
+		if a := arg(0); a.kind == gString {
+			return gval{kind: gFile, s: e.resolveFile(last == "rootProject", a.s)}
+		}
+	case "Properties":
+		return gval{kind: gProps}
+	case "File", "FileInputStream", "inputStream", "reader":
+		if name == "File" {
+			if a := args; len(a) > 0 && a[len(a)-1].kind == gString {
+				return gval{kind: gFile, s: e.resolveFile(true, a[len(a)-1].s)}
+			}
+		}
+		if v := receiver(); v.kind == gFile {
+			return v
+		}
+		return arg(0)
+	}
+	return gval{}
+}
+
+// propsValue looks key up in the Properties object recv or cur.
+func (e *gradleEval) propsValue(recv []string, cur gval, key string, fallback gval) gval {
+	file := filepath.Join(e.androidDir, "local.properties")
+	if len(recv) == 1 {
+		if f, ok := e.propsFiles[recv[0]]; ok {
+			file = f
+		}
+		cur = e.resolve(recv)
+	}
+	if cur.kind != gProps {
+		return gval{}
+	}
+	if v := e.property(file, key); v.kind != gNone {
+		return v
+	}
+	return fallback
+}
+
+// resolveFile returns the path of a file named in the root project when
+// root is true, or in the app module.
+func (e *gradleEval) resolveFile(root bool, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	if root {
+		return filepath.Join(e.androidDir, name)
+	}
+	return filepath.Join(e.androidDir, "app", name)
+}
+
+// property returns key from a properties file. local.properties is written
+// by the flutter tool from pubspec.yaml and the SDK in use, so when it is
+// missing, as in a fresh checkout, the flutter.* keys come from there.
+func (e *gradleEval) property(file, key string) gval {
+	props, ok := e.props[file]
+	if !ok {
+		props = readProperties(file, e.rel(file))
+		e.props[file] = props
+	}
+	if v, ok := props[key]; ok {
+		return v
+	}
+	if filepath.Base(file) != "local.properties" {
+		return gval{}
+	}
+	switch key {
+	case "flutter.versionName":
+		return e.pubspecVersion()["name"]
+	case "flutter.versionCode":
+		return e.pubspecVersion()["code"]
+	case "flutter.sdk":
+		if e.opts.FlutterSDK != "" {
+			return gval{kind: gString, s: e.opts.FlutterSDK, source: "Flutter SDK"}
+		}
+	}
+	return gval{}
+}
+
+func (e *gradleEval) flutterValue(name string) gval {
+	switch name {
+	case "versionCode", "versionName":
+		return e.property(filepath.Join(e.androidDir, "local.properties"), "flutter."+name)
+	}
+	if e.flutter == nil {
+		e.flutter = make(map[string]gval)
+		if sdk := e.property(filepath.Join(e.androidDir, "local.properties"), "flutter.sdk").value(); sdk != "" {
+			e.flutter = flutterDefaults(sdk)
+		}
+	}
+	return e.flutter[name]
+}
+
+// flutterExtensionFiles are where Flutter SDK releases, newest first, define
+// the values build scripts read as flutter.compileSdkVersion and so on.
+var flutterExtensionFiles = []string{
+	"packages/flutter_tools/gradle/src/main/kotlin/FlutterExtension.kt",
+	"packages/flutter_tools/gradle/src/main/groovy/flutter.groovy",
+	"packages/flutter_tools/gradle/flutter.gradle",
+}
+
+var flutterDefaultRe = regexp.MustCompile(`\b(compileSdkVersion|minSdkVersion|targetSdkVersion|ndkVersion)\s*(?::\s*\w+\s*)?=\s*(?:(\d+)|"([^"]+)")`)
+
+// flutterDefaults reads the flutter.* defaults of the Flutter SDK in sdk.
+func flutterDefaults(sdk string) map[string]gval {
+	values := make(map[string]gval)
+	for _, name := range flutterExtensionFiles {
+		file := filepath.Join(sdk, filepath.FromSlash(name))
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, m := range flutterDefaultRe.FindAllSubmatchIndex(data, -1) {
+			key := string(data[m[2]:m[3]])
+			if _, ok := values[key]; ok {
+				continue
+			}
+			value := m[4:6]
+			if value[0] < 0 {
+				value = m[6:8]
+			}
+			line := 1 + strings.Count(string(data[:m[0]]), "\n")
+			values[key] = gval{kind: gString, s: string(data[value[0]:value[1]]), source: file + ":" + strconv.Itoa(line)}
+		}
+		break
+	}
+	return values
+}
+
+var pubspecVersionRe = regexp.MustCompile(`(?m)^version:\s*["']?([^\s"'#+]+)(?:\+(\d+))?`)
+
+// pubspecVersion returns the build name and number of pubspec.yaml as
+// "name" and "code".
+func (e *gradleEval) pubspecVersion() map[string]gval {
+	if e.pubspec != nil {
+		return e.pubspec
+	}
+	e.pubspec = make(map[string]gval)
+	file := filepath.Join(e.root, "pubspec.yaml")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return e.pubspec
+	}
+	m := pubspecVersionRe.FindSubmatchIndex(data)
+	if m == nil {
+		return e.pubspec
+	}
+	source := e.rel(file) + ":" + strconv.Itoa(1+strings.Count(string(data[:m[0]]), "\n"))
+	e.pubspec["name"] = gval{kind: gString, s: string(data[m[2]:m[3]]), source: source}
+	if m[4] >= 0 {
+		e.pubspec["code"] = gval{kind: gString, s: string(data[m[4]:m[5]]), source: source}
+	}
+	return e.pubspec
+}
+
+var catalogVersionRe = regexp.MustCompile(`^([\w.-]+)\s*=\s*(?:"([^"]*)"|\{.*\b(?:strictly|require|prefer)\s*=\s*"([^"]*)".*\})`)
+
+// versionCatalog returns the [versions] of gradle/libs.versions.toml keyed
+// as the libs.versions accessors name them, with - and _ read as dots.
+func (e *gradleEval) versionCatalog() map[string]gval {
+	if e.catalog != nil {
+		return e.catalog
+	}
+	e.catalog = make(map[string]gval)
+	file := filepath.Join(e.androidDir, "gradle", "libs.versions.toml")
+	f, err := os.Open(file)
+	if err != nil {
+		return e.catalog
+	}
+	defer f.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "[") {
+			section = strings.Trim(text, "[] ")
+			continue
+		}
+		if section != "versions" {
+			continue
+		}
+		if m := catalogVersionRe.FindStringSubmatch(text); m != nil {
+			key := strings.NewReplacer("-", ".", "_", ".").Replace(m[1])
+			e.catalog[key] = gval{kind: gString, s: m[2] + m[3], source: e.rel(file) + ":" + strconv.Itoa(line)}
+		}
+	}
+	return e.catalog
+}
+
+// readProperties reads a Java properties file, with sources named after rel.
+func readProperties(file, rel string) map[string]gval {
+	props := make(map[string]gval)
+	f, err := os.Open(file)
+	if err != nil {
+		return props
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' || text[0] == '!' {
+			continue
+		}
+		i := strings.IndexAny(text, "=:")
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(text[:i])
+		value := strings.NewReplacer(`\\`, `\`, `\:`, `:`, `\=`, `=`).Replace(strings.TrimSpace(text[i+1:]))
+		props[key] = gval{kind: gString, s: value, source: rel + ":" + strconv.Itoa(line)}
+	}
+	return props
+}
+
+// rel returns file relative to the project root, or file itself if it is
+// outside the project.
+func (e *gradleEval) rel(file string) string {
+	rel, err := filepath.Rel(e.root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// stripGradleComments blanks out // and /* */ comments, keeping line breaks
+// and anything inside string literals.
+func stripGradleComments(s string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(s) {
+				b.WriteByte(c)
+				i++
+				c = s[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '/' && i+1 < len(s) && s[i+1] == '/':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+			if i < len(s) {
+				b.WriteByte('\n')
+			}
+			continue
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				end = len(s) - i - 2
+			}
+			b.WriteString(strings.Repeat("\n", strings.Count(s[i:i+2+end], "\n")))
+			i += end + 3
+			continue
+		}
+		if c == '\n' {
+			quote = 0
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// openParens returns how many parentheses and brackets are left open at the
+// end of line, outside strings.
+func openParens(line string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+type bracePiece struct {
+	text  string
+	open  bool
+	close bool
+}
+
+// splitBraces splits a line into statement text, block openings, whose
+// text is the block header, and block closings.
+func splitBraces(line string) []bracePiece {
+	var pieces []bracePiece
+	var quote byte
+	start := 0
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			pieces = append(pieces, bracePiece{text: line[start:i], open: true})
+			start = i + 1
+		case c == '}':
+			if text := strings.TrimSpace(line[start:i]); text != "" {
+				pieces = append(pieces, bracePiece{text: text})
+			}
+			pieces = append(pieces, bracePiece{close: true})
+			start = i + 1
+		}
+	}
+	if text := strings.TrimSpace(line[start:]); text != "" {
+		pieces = append(pieces, bracePiece{text: text})
+	}
+	return pieces
+}
+
+type gradleToken struct {
+	kind byte // 'i' identifier, 'n' number, 's' single-quoted string, 't' template string, 'p' punctuation
+	text string
+}
+
+// scanGradle splits a Groovy or Kotlin expression into tokens.
+func scanGradle(expr string) []gradleToken {
+	var toks []gradleToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '_' || c == '$' || isLetter(c):
+			j := i + 1
+			for j < len(expr) && (expr[j] == '_' || expr[j] == '$' || isLetter(expr[j]) || isDigit(expr[j])) {
+				j++
+			}
+			toks = append(toks, gradleToken{'i', expr[i:j]})
+			i = j
+		case isDigit(c):
+			j := i + 1
+			for j < len(expr) && (isDigit(expr[j]) || expr[j] == '_' || expr[j] == '.' && j+1 < len(expr) && isDigit(expr[j+1])) {
+				j++
+			}
+			toks = append(toks, gradleToken{'n', strings.ReplaceAll(expr[i:j], "_", "")})
+			for j < len(expr) && strings.IndexByte("LlFfDd", expr[j]) >= 0 {
+				j++
+			}
+			i = j
+		case c == '"' || c == '\'':
+			delim := string(c)
+			if strings.HasPrefix(expr[i:], strings.Repeat(delim, 3)) {
+				delim = strings.Repeat(delim, 3)
+			}
+			j := i + len(delim)
+			for j < len(expr) && !strings.HasPrefix(expr[j:], delim) {
+				if expr[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			kind := byte('s')
+			if c == '"' {
+				kind = 't'
+			}
+			toks = append(toks, gradleToken{kind, unescapeGradle(expr[i+len(delim) : min(j, len(expr))])})
+			i = min(j+len(delim), len(expr))
+		default:
+			n := 1
+			for _, op := range []string{"?:", "?.", "!!", "==", "!=", "->"} {
+				if strings.HasPrefix(expr[i:], op) {
+					n = 2
+					break
+				}
+			}
+			toks = append(toks, gradleToken{'p', expr[i : i+n]})
+			i += n
+		}
+	}
+	return toks
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func unescapeGradle(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\'`, `'`, `\"`, `"`, `\$`, "\x00", `\n`, "\n", `\t`, "\t").Replace(s)
+}
+
+// gradleExpr evaluates the tokens of one expression. It understands
+// literals, string templates, dotted references, method calls, indexing,
+// +, the elvis operator and the ternary operator, whose condition is not
+// evaluated: the first branch that resolves is used.
+type gradleExpr struct {
+	e    *gradleEval
+	toks []gradleToken
+	pos  int
+	line int
+}
+
+func (p *gradleExpr) peek(text string) bool {
+	return p.pos < len(p.toks) && p.toks[p.pos].kind == 'p' && p.toks[p.pos].text == text
+}
+
+func (p *gradleExpr) accept(text string) bool {
+	if p.peek(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *gradleExpr) ident() string {
+	if p.pos < len(p.toks) && p.toks[p.pos].kind == 'i' {
+		p.pos++
+		return p.toks[p.pos-1].text
+	}
+	return ""
+}
+
+func (p *gradleExpr) expr() gval {
+	v := p.elvis()
+	if p.accept("?") {
+		a := p.expr()
+		p.accept(":")
+		b := p.expr()
+		if a.kind != gNone {
+			return a
+		}
+		return b
+	}
+	return v
+}
+
+func (p *gradleExpr) elvis() gval {
+	v := p.sum()
+	for p.accept("?:") {
+		if w := p.sum(); v.kind == gNone {
+			v = w
+		}
+	}
+	return v
+}
+
+func (p *gradleExpr) sum() gval {
+	v := p.chain()
+	for p.accept("+") {
+		w := p.chain()
+		if v.kind != gString || w.kind != gString {
+			v = gval{}
+			continue
+		}
+		a, errA := strconv.Atoi(v.s)
+		b, errB := strconv.Atoi(w.s)
+		if errA == nil && errB == nil {
+			v.s = strconv.Itoa(a + b)
+		} else {
+			v.s += w.s
+		}
+	}
+	return v
+}
+
+// chain evaluates a primary expression followed by member accesses, calls
+// and indexing. Dotted names are collected in ref until something needs
+// their value, so that flutter.minSdkVersion or libs.versions.x.get() can
+// be resolved as a whole.
+func (p *gradleExpr) chain() gval {
+	var cur gval
+	var ref []string
+
+	if p.pos >= len(p.toks) {
+		return gval{}
+	}
+	tok := p.toks[p.pos]
+	p.pos++
+	switch {
+	case tok.kind == 's' || tok.kind == 'n':
+		cur = p.e.literal(strings.ReplaceAll(tok.text, "\x00", "$"), p.line)
+	case tok.kind == 't':
+		cur = p.template(tok.text)
+	case tok.kind == 'i' && tok.text == "new":
+		name := p.ident()
+		for p.accept(".") {
+			name = p.ident()
+		}
+		cur = p.e.call(gval{}, nil, name, p.args())
+	case tok.kind == 'i':
+		ref = []string{tok.text}
+	case tok.kind == 'p' && tok.text == "(":
+		cur = p.expr()
+		p.accept(")")
+	default:
+		return gval{}
+	}
+
+	for {
+		switch {
+		case p.accept(".") || p.accept("?."):
+			name := p.ident()
+			switch {
+			case name == "":
+				return gval{}
+			case p.peek("("):
+				cur = p.e.call(cur, ref, name, p.args())
+				ref = nil
+			case ref != nil:
+				ref = append(ref, name)
+			default:
+				cur = gval{}
+			}
+		case p.peek("(") && ref != nil:
+			name := ref[len(ref)-1]
+			recv := ref[:len(ref)-1]
+			if len(recv) == 0 {
+				recv = nil
+			}
+			cur = p.e.call(gval{}, recv, name, p.args())
+			ref = nil
+		case p.accept("["):
+			key := p.expr()
+			p.accept("]")
+			if n := len(ref); n > 0 && (ref[n-1] == "ext" || ref[n-1] == "extra") {
+				cur = p.e.extValue(key.value())
+			} else {
+				cur = p.e.propsValue(ref, cur, key.value(), gval{})
+			}
+			ref = nil
+		case p.accept("!!"):
+		case p.pos < len(p.toks) && p.toks[p.pos].kind == 'i' && p.toks[p.pos].text == "as":
+			p.pos++
+			p.ident()
+		default:
+			if ref != nil {
+				return p.e.resolve(ref)
+			}
+			return cur
+		}
+	}
+}
+
+// args evaluates a parenthesized argument list. Named Kotlin arguments are
+// read by position.
+func (p *gradleExpr) args() []gval {
+	var args []gval
+	if !p.accept("(") {
+		return nil
+	}
+	for !p.accept(")") && p.pos < len(p.toks) {
+		if p.pos+1 < len(p.toks) && p.toks[p.pos].kind == 'i' && p.toks[p.pos+1].kind == 'p' && p.toks[p.pos+1].text == "=" {
+			p.pos += 2
+		}
+		start := p.pos
+		args = append(args, p.expr())
+		if !p.accept(",") && p.pos == start {
+			p.pos++
+		}
+	}
+	return args
+}
+
+// template evaluates a double-quoted string with $name and ${expr}
+// placeholders. It does not resolve if any placeholder does not.
+func (p *gradleExpr) template(s string) gval {
+	if !strings.Contains(s, "$") {
+		return p.e.literal(strings.ReplaceAll(s, "\x00", "$"), p.line)
+	}
+
+	var b strings.Builder
+	source := ""
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		var v gval
+		if s[i+1] == '{' {
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return gval{}
+			}
+			v = p.e.eval(s[i+2:i+end], p.line)
+			i += end
+		} else {
+			// Groovy reads $a.b as a property of a, Kotlin as ${a}.b, so
+			// the longest reference that resolves is used.
+			names := strings.Split(gradleTemplateRe.FindString(s[i+1:]), ".")
+			if names[0] == "" {
+				b.WriteByte('$')
+				continue
+			}
+			for n := len(names); n > 0; n-- {
+				if v = p.e.resolve(names[:n]); v.kind != gNone {
+					names = names[:n]
+					break
+				}
+			}
+			i += len(strings.Join(names, "."))
+		}
+		if v.kind != gString {
+			return gval{}
+		}
+		b.WriteString(v.s)
+		if source == "" {
+			source = v.source
+		}
+	}
+	return gval{kind: gString, s: strings.ReplaceAll(b.String(), "\x00", "$"), source: source}
+}
@@ -0,0 +1,263 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files, keyed by slash-separated path, under a new
+// temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const flutterExtension = `package com.flutter.gradle
+
+open class FlutterExtension {
+    /** Sets the compileSdkVersion used by default in Flutter app projects. */
+    val compileSdkVersion: Int = 35
+
+    /** Sets the minSdkVersion used by default in Flutter app projects. */
+    val minSdkVersion: Int = 21
+
+    /** Sets the targetSdkVersion used by default in Flutter app projects. */
+    val targetSdkVersion: Int = 35
+
+    val ndkVersion: String = "26.3.11579264"
+}
+`
+
+func wantGradleValue(t *testing.T, cfg *GradleConfig, name, value string, line int, source string) {
+	t.Helper()
+	got := cfg.Values[name]
+	if got.Value != value || got.Line != line || got.Source != source {
+		t.Errorf("%s = %+v, want value %q on line %d from %q", name, got, value, line, source)
+	}
+}
+
+func TestEvaluateGradleKtsTemplate(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"sdk/packages/flutter_tools/gradle/src/main/kotlin/FlutterExtension.kt": flutterExtension,
+		"app/pubspec.yaml": "name: app\nversion: 2.1.0+17\n",
+		"app/android/app/build.gradle.kts": `plugins {
+    id("com.android.application")
+    id("dev.flutter.flutter-gradle-plugin")
+}
+
+android {
+    namespace = "com.example.app"
+    compileSdk = flutter.compileSdkVersion
+    ndkVersion = flutter.ndkVersion
+
+    defaultConfig {
+        // TODO: Specify your own unique Application ID.
+        applicationId = "com.example.app"
+        minSdk = flutter.minSdkVersion
+        targetSdk = flutter.targetSdkVersion
+        versionCode = flutter.versionCode
+        versionName = flutter.versionName
+    }
+}
+`,
+	})
+	sdk := filepath.Join(dir, "sdk")
+	extension := filepath.Join(sdk, "packages", "flutter_tools", "gradle", "src", "main", "kotlin", "FlutterExtension.kt")
+
+	cfg, err := EvaluateGradle(filepath.Join(dir, "app", "android", "app", "build.gradle.kts"), GradleOptions{FlutterSDK: sdk})
+	if err != nil {
+		t.Fatalf("EvaluateGradle failed: %v", err)
+	}
+
+	wantGradleValue(t, cfg, "namespace", "com.example.app", 7, "android/app/build.gradle.kts:7")
+	wantGradleValue(t, cfg, "compileSdkVersion", "35", 8, extension+":5")
+	wantGradleValue(t, cfg, "ndkVersion", "26.3.11579264", 9, extension+":13")
+	wantGradleValue(t, cfg, "minSdkVersion", "21", 14, extension+":8")
+	wantGradleValue(t, cfg, "targetSdkVersion", "35", 15, extension+":11")
+	wantGradleValue(t, cfg, "versionCode", "17", 16, "pubspec.yaml:2")
+	wantGradleValue(t, cfg, "versionName", "2.1.0", 17, "pubspec.yaml:2")
+
+	if cfg.TargetSDKVersion != "35" || cfg.MinSDKVersion != "21" || cfg.ApplicationID != "com.example.app" {
+		t.Errorf("unexpected fields %+v", cfg)
+	}
+	if cfg.Values["targetSdkVersion"].Expr != "flutter.targetSdkVersion" {
+		t.Errorf("expected the expression to be kept, got %q", cfg.Values["targetSdkVersion"].Expr)
+	}
+}
+
+func TestEvaluateGradleWithoutFlutterSDK(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"android/app/build.gradle.kts": `android {
+    defaultConfig {
+        targetSdk = flutter.targetSdkVersion
+    }
+}
+`,
+	})
+
+	cfg, err := EvaluateGradle(filepath.Join(dir, "android", "app", "build.gradle.kts"), GradleOptions{})
+	if err != nil {
+		t.Fatalf("EvaluateGradle failed: %v", err)
+	}
+
+	got := cfg.Values["targetSdkVersion"]
+	if got.Value != "" || got.Line != 3 || got.Expr != "flutter.targetSdkVersion" {
+		t.Errorf("expected an unresolved value on line 3, got %+v", got)
+	}
+}
+
+func TestEvaluateGradleGroovyTemplate(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"sdk/packages/flutter_tools/gradle/src/main/groovy/flutter.groovy": `class FlutterExtension {
+    /** Sets the compileSdkVersion used by default in Flutter app projects. */
+    public final int compileSdkVersion = 34
+    public final int minSdkVersion = 21
+    public final int targetSdkVersion = 34
+}
+`,
+		"android/local.properties": "sdk.dir=/opt/android\nflutter.sdk=SDK\nflutter.versionName=1.4.0\nflutter.versionCode=9\n",
+		"android/app/build.gradle": `def localProperties = new Properties()
+def localPropertiesFile = rootProject.file('local.properties')
+if (localPropertiesFile.exists()) {
+    localPropertiesFile.withReader('UTF-8') { reader ->
+        localProperties.load(reader)
+    }
+}
+
+def flutterVersionCode = localProperties.getProperty('flutter.versionCode')
+if (flutterVersionCode == null) {
+    flutterVersionCode = '1'
+}
+
+def flutterVersionName = localProperties.getProperty('flutter.versionName')
+if (flutterVersionName == null) {
+    flutterVersionName = '1.0'
+}
+
+android {
+    compileSdkVersion flutter.compileSdkVersion
+
+    defaultConfig {
+        applicationId "com.example.app"
+        minSdkVersion flutter.minSdkVersion
+        targetSdkVersion flutter.targetSdkVersion
+        versionCode flutterVersionCode.toInteger()
+        versionName flutterVersionName
+    }
+}
+`,
+	})
+	sdk := filepath.Join(dir, "sdk")
+	props := filepath.Join(dir, "android", "local.properties")
+	data, err := os.ReadFile(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(props, []byte(strings.Replace(string(data), "SDK", sdk, 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ParseGradleFile(filepath.Join(dir, "android", "app", "build.gradle"))
+	if err != nil {
+		t.Fatalf("ParseGradleFile failed: %v", err)
+	}
+
+	groovy := filepath.Join(sdk, "packages", "flutter_tools", "gradle", "src", "main", "groovy", "flutter.groovy")
+	wantGradleValue(t, cfg, "compileSdkVersion", "34", 20, groovy+":3")
+	wantGradleValue(t, cfg, "targetSdkVersion", "34", 25, groovy+":5")
+	wantGradleValue(t, cfg, "versionCode", "9", 26, "android/local.properties:4")
+	wantGradleValue(t, cfg, "versionName", "1.4.0", 27, "android/local.properties:3")
+}
+
+func TestEvaluateGradleFallbacks(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"android/app/build.gradle": `def flutterVersionCode = localProperties.getProperty('flutter.versionCode')
+if (flutterVersionCode == null) {
+    flutterVersionCode = '1'
+}
+def localProperties = new Properties()
+
+android {
+    defaultConfig {
+        versionCode flutterVersionCode.toInteger()
+    }
+}
+`,
+	})
+
+	cfg, err := ParseGradleFile(filepath.Join(dir, "android", "app", "build.gradle"))
+	if err != nil {
+		t.Fatalf("ParseGradleFile failed: %v", err)
+	}
+	wantGradleValue(t, cfg, "versionCode", "1", 9, "android/app/build.gradle:3")
+}
+
+func TestEvaluateGradleProperties(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"android/gradle.properties": "org.gradle.jvmargs=-Xmx4G\nappTargetSdk=34\n",
+		"android/gradle/libs.versions.toml": `[versions]
+agp = "8.3.0"
+android-minSdk = "23"
+android-compileSdk = { strictly = "35" }
+
+[libraries]
+junit = { module = "junit:junit", version = "4.13.2" }
+`,
+		"android/build.gradle": `buildscript {
+    ext.kotlin_version = '1.9.22'
+    ext {
+        appVersionName = "3.0.0"
+    }
+}
+`,
+		"android/app/build.gradle": `def suffix = "-beta"
+def code = 40 + 2
+
+android {
+    compileSdk libs.versions.android.compileSdk.get().toInteger()
+    defaultConfig {
+        minSdk libs.versions.android.minSdk.get().toInteger()
+        targetSdk project.property("appTargetSdk") as Integer
+        versionCode code
+        versionName "${rootProject.ext.appVersionName}$suffix"
+    }
+    productFlavors {
+        free {
+            versionCode 99
+        }
+    }
+}
+`,
+	})
+
+	cfg, err := ParseGradleFile(filepath.Join(dir, "android", "app", "build.gradle"))
+	if err != nil {
+		t.Fatalf("ParseGradleFile failed: %v", err)
+	}
+
+	wantGradleValue(t, cfg, "compileSdkVersion", "35", 5, "android/gradle/libs.versions.toml:4")
+	wantGradleValue(t, cfg, "minSdkVersion", "23", 7, "android/gradle/libs.versions.toml:3")
+	wantGradleValue(t, cfg, "targetSdkVersion", "34", 8, "android/gradle.properties:2")
+	wantGradleValue(t, cfg, "versionCode", "42", 9, "android/app/build.gradle:2")
+	wantGradleValue(t, cfg, "versionName", "3.0.0-beta", 10, "android/build.gradle:4")
+}
+
+func TestScanGradleComments(t *testing.T) {
+	got := stripGradleComments("a = 'http://x' // c\n/* one\ntwo */ b = 1\n")
+	want := "a = 'http://x' \n\n b = 1\n"
+	if got != want {
+		t.Errorf("stripGradleComments = %q, want %q", got, want)
+	}
+}
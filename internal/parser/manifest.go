@@ -2,42 +2,140 @@ package parser
 
 import (
 	"encoding/xml"
-	"os"
-	"regexp"
 	"strings"
 )
 
+// AndroidManifest is the content of an AndroidManifest.xml. Attribute
+// values are kept as written; an attribute that is not set is "". Line
+// fields give the line each element starts on in the file it came from.
 type AndroidManifest struct {
-	Package         string
-	VersionCode     string
-	VersionName     string
-	Debuggable      string
-	AllowBackup     string
+	Package     string
+	VersionCode string
+	VersionName string
+
+	// Application is the <application> element, with Line 0 if there is
+	// none.
+	Application Application
+	// Debuggable and AllowBackup repeat the attributes of Application.
+	Debuggable  string
+	AllowBackup string
+
+	// UsesPermissions includes <uses-permission-sdk-23> elements.
 	UsesPermissions []UsesPermission
+	UsesFeatures    []UsesFeature
 	Activities      []Activity
+	Services        []Component
+	Receivers       []Component
+	Providers       []Component
 	Queries         []Queries
+
+	// Plugins lists the plugin manifests merged into the app manifest.
+	Plugins []PluginManifest
+	// Conflicts lists attributes a plugin manifest sets differently from
+	// the manifest it is merged into, without a tools:replace marker.
+	Conflicts []ManifestConflict
 }
 
-type UsesPermission struct {
-	Name string
+type Application struct {
+	Name                  string
+	Debuggable            string
+	AllowBackup           string
+	UsesCleartextTraffic  string
+	NetworkSecurityConfig string
+	MetaData              []MetaData
+	Line                  int
 }
 
-type Activity struct {
+type UsesPermission struct {
 	Name          string
-	Exported      string
+	MaxSDKVersion string
+	// SDK23 is set for <uses-permission-sdk-23>, which only applies on
+	// Android 6.0 and later.
+	SDK23 bool
+	Line  int
+	// Origin is the Flutter plugin whose manifest declared the permission,
+	// or "" if the app manifest declares it.
+	Origin string
+}
+
+type UsesFeature struct {
+	Name        string
+	Required    string
+	GLESVersion string
+	Line        int
+	Origin      string
+}
+
+// IsRequired reports whether the feature is required, which is the
+// default when android:required is not set.
+func (f UsesFeature) IsRequired() bool {
+	return strings.ToLower(f.Required) != "false"
+}
+
+// Component is an <activity>, <activity-alias>, <service>, <receiver> or
+// <provider> element.
+type Component struct {
+	// Kind is the element name, such as receiver.
+	Kind     string
+	Name     string
+	Exported string
+	Enabled  string
+	// Permission is android:permission, which callers must hold.
+	Permission string
+	// ForegroundServiceType is set on services, such as "location|camera".
+	ForegroundServiceType string
+	// Authorities is set on providers.
+	Authorities   string
 	IntentFilters []IntentFilter
+	MetaData      []MetaData
+	Line          int
+	// Origin is the Flutter plugin whose manifest declared the component,
+	// or "" if the app manifest declares it.
+	Origin string
 }
 
+// Activity is an <activity> or <activity-alias> element.
+type Activity = Component
+
 type IntentFilter struct {
-	Actions []Action
+	Actions    []Action
+	Categories []Category
+	Data       []IntentData
+	AutoVerify string
+	Line       int
 }
 
 type Action struct {
 	Name string
 }
 
+type Category struct {
+	Name string
+}
+
+type IntentData struct {
+	Scheme      string
+	Host        string
+	Port        string
+	Path        string
+	PathPrefix  string
+	PathPattern string
+	MimeType    string
+}
+
+type MetaData struct {
+	Name     string
+	Value    string
+	Resource string
+	Line     int
+	Origin   string
+}
+
 type Queries struct {
 	Packages []Package
+	Intents  []IntentFilter
+	// Providers lists the authorities of <provider> elements.
+	Providers []string
 }
 
 type Package struct {
@@ -56,63 +154,198 @@ func getAttrValue(attrs []xml.Attr, name string) string {
 	return ""
 }
 
+// ParseAndroidManifest parses the AndroidManifest.xml at path. Elements
+// marked tools:node="remove" or "removeAll" are left out, as they are of the
+// merged manifest the build produces.
 func ParseAndroidManifest(path string) (*AndroidManifest, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
+	return MergeAndroidManifests(path, nil)
+}
 
-	content := string(data)
+// buildManifest reads the elements of the manifest tree rooted at root.
+func buildManifest(root *manifestNode) *AndroidManifest {
 	manifest := &AndroidManifest{
+		Package:         getAttrValue(root.attrs, "package"),
+		VersionCode:     getAttrValue(root.attrs, "versionCode"),
+		VersionName:     getAttrValue(root.attrs, "versionName"),
 		UsesPermissions: make([]UsesPermission, 0),
 		Activities:      make([]Activity, 0),
 		Queries:         make([]Queries, 0),
 	}
 
-	xmlDecoder := xml.NewDecoder(strings.NewReader(content))
+	for _, elem := range root.children {
+		switch elem.name {
+		case "uses-permission", "uses-permission-sdk-23":
+			manifest.UsesPermissions = append(manifest.UsesPermissions, UsesPermission{
+				Name:          getAttrValue(elem.attrs, "name"),
+				MaxSDKVersion: getAttrValue(elem.attrs, "maxSdkVersion"),
+				SDK23:         elem.name == "uses-permission-sdk-23",
+				Line:          elem.line,
+				Origin:        elem.origin,
+			})
+		case "uses-feature":
+			manifest.UsesFeatures = append(manifest.UsesFeatures, UsesFeature{
+				Name:        getAttrValue(elem.attrs, "name"),
+				Required:    getAttrValue(elem.attrs, "required"),
+				GLESVersion: getAttrValue(elem.attrs, "glEsVersion"),
+				Line:        elem.line,
+				Origin:      elem.origin,
+			})
+		case "queries":
+			manifest.Queries = append(manifest.Queries, buildQueries(elem))
+		case "application":
+			manifest.buildApplication(elem)
+		}
+	}
 
-	for {
-		token, err := xmlDecoder.Token()
-		if err != nil {
-			break
+	return manifest
+}
+
+func (m *AndroidManifest) buildApplication(elem *manifestNode) {
+	m.Application = Application{
+		Name:                  getAttrValue(elem.attrs, "name"),
+		Debuggable:            getAttrValue(elem.attrs, "debuggable"),
+		AllowBackup:           getAttrValue(elem.attrs, "allowBackup"),
+		UsesCleartextTraffic:  getAttrValue(elem.attrs, "usesCleartextTraffic"),
+		NetworkSecurityConfig: getAttrValue(elem.attrs, "networkSecurityConfig"),
+		Line:                  elem.line,
+	}
+	m.Debuggable = m.Application.Debuggable
+	m.AllowBackup = m.Application.AllowBackup
+
+	for _, child := range elem.children {
+		switch child.name {
+		case "activity", "activity-alias":
+			m.Activities = append(m.Activities, buildComponent(child))
+		case "service":
+			m.Services = append(m.Services, buildComponent(child))
+		case "receiver":
+			m.Receivers = append(m.Receivers, buildComponent(child))
+		case "provider":
+			m.Providers = append(m.Providers, buildComponent(child))
+		case "meta-data":
+			m.Application.MetaData = append(m.Application.MetaData, buildMetaData(child))
 		}
+	}
+}
 
-		switch elem := token.(type) {
-		case xml.StartElement:
-			name := getAttrValue(elem.Attr, "name")
-			exported := getAttrValue(elem.Attr, "exported")
-			debuggable := getAttrValue(elem.Attr, "debuggable")
-			allowBackup := getAttrValue(elem.Attr, "allowBackup")
-
-			switch elem.Name.Local {
-			case "manifest":
-				manifest.Package = getAttrValue(elem.Attr, "package")
-				manifest.VersionCode = getAttrValue(elem.Attr, "versionCode")
-				manifest.VersionName = getAttrValue(elem.Attr, "versionName")
-			case "uses-permission":
-				manifest.UsesPermissions = append(manifest.UsesPermissions, UsesPermission{
-					Name: name,
-				})
-			case "activity":
-				manifest.Activities = append(manifest.Activities, Activity{
-					Name:     name,
-					Exported: exported,
-				})
-			case "queries":
-				manifest.Queries = append(manifest.Queries, Queries{Packages: make([]Package, 0)})
-			case "package":
-				if len(manifest.Queries) > 0 {
-					lastQuery := &manifest.Queries[len(manifest.Queries)-1]
-					lastQuery.Packages = append(lastQuery.Packages, Package{Name: name})
-				}
-			case "application":
-				manifest.Debuggable = debuggable
-				manifest.AllowBackup = allowBackup
-			}
+func buildComponent(elem *manifestNode) Component {
+	c := Component{
+		Kind:                  elem.name,
+		Name:                  getAttrValue(elem.attrs, "name"),
+		Exported:              getAttrValue(elem.attrs, "exported"),
+		Enabled:               getAttrValue(elem.attrs, "enabled"),
+		Permission:            getAttrValue(elem.attrs, "permission"),
+		ForegroundServiceType: getAttrValue(elem.attrs, "foregroundServiceType"),
+		Authorities:           getAttrValue(elem.attrs, "authorities"),
+		Line:                  elem.line,
+		Origin:                elem.origin,
+	}
+	if c.Kind == "activity-alias" && c.Name == "" {
+		c.Name = getAttrValue(elem.attrs, "targetActivity")
+	}
+	for _, child := range elem.children {
+		switch child.name {
+		case "intent-filter":
+			c.IntentFilters = append(c.IntentFilters, buildIntentFilter(child))
+		case "meta-data":
+			c.MetaData = append(c.MetaData, buildMetaData(child))
+		}
+	}
+	return c
+}
+
+// buildIntentFilter reads an <intent-filter>, or the <intent> of a
+// <queries> element, which has the same children.
+func buildIntentFilter(elem *manifestNode) IntentFilter {
+	filter := IntentFilter{
+		AutoVerify: getAttrValue(elem.attrs, "autoVerify"),
+		Line:       elem.line,
+	}
+	for _, child := range elem.children {
+		name := getAttrValue(child.attrs, "name")
+		switch child.name {
+		case "action":
+			filter.Actions = append(filter.Actions, Action{Name: name})
+		case "category":
+			filter.Categories = append(filter.Categories, Category{Name: name})
+		case "data":
+			filter.Data = append(filter.Data, IntentData{
+				Scheme:      getAttrValue(child.attrs, "scheme"),
+				Host:        getAttrValue(child.attrs, "host"),
+				Port:        getAttrValue(child.attrs, "port"),
+				Path:        getAttrValue(child.attrs, "path"),
+				PathPrefix:  getAttrValue(child.attrs, "pathPrefix"),
+				PathPattern: getAttrValue(child.attrs, "pathPattern"),
+				MimeType:    getAttrValue(child.attrs, "mimeType"),
+			})
+		}
+	}
+	return filter
+}
+
+func buildMetaData(elem *manifestNode) MetaData {
+	return MetaData{
+		Name:     getAttrValue(elem.attrs, "name"),
+		Value:    getAttrValue(elem.attrs, "value"),
+		Resource: getAttrValue(elem.attrs, "resource"),
+		Line:     elem.line,
+		Origin:   elem.origin,
+	}
+}
+
+func buildQueries(elem *manifestNode) Queries {
+	queries := Queries{Packages: make([]Package, 0)}
+	for _, child := range elem.children {
+		switch child.name {
+		case "package":
+			queries.Packages = append(queries.Packages, Package{Name: getAttrValue(child.attrs, "name")})
+		case "intent":
+			queries.Intents = append(queries.Intents, buildIntentFilter(child))
+		case "provider":
+			queries.Providers = append(queries.Providers, getAttrValue(child.attrs, "authorities"))
+		}
+	}
+	return queries
+}
+
+// IsExported reports whether the component is exported. When
+// android:exported is not set, components with an intent filter are
+// exported, as on Android 11 and earlier.
+func (c Component) IsExported() bool {
+	if c.Exported != "" {
+		return strings.ToLower(c.Exported) == "true"
+	}
+	return len(c.IntentFilters) > 0
+}
+
+// DeepLinks returns the intent filters that open the component for VIEW
+// intents with an http, https or custom scheme from the browser.
+func (c Component) DeepLinks() []IntentFilter {
+	var links []IntentFilter
+	for _, filter := range c.IntentFilters {
+		if filter.HasAction("android.intent.action.VIEW") && filter.HasCategory("android.intent.category.BROWSABLE") && len(filter.Data) > 0 {
+			links = append(links, filter)
+		}
+	}
+	return links
+}
+
+func (f IntentFilter) HasAction(name string) bool {
+	for _, a := range f.Actions {
+		if a.Name == name {
+			return true
 		}
 	}
+	return false
+}
 
-	return manifest, nil
+func (f IntentFilter) HasCategory(name string) bool {
+	for _, c := range f.Categories {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *AndroidManifest) GetPackageName() string {
@@ -172,99 +405,3 @@ func (m *AndroidManifest) HasQueryPackage(pkg string) bool {
 	}
 	return false
 }
-
-type GradleConfig struct {
-	ApplicationID    string
-	MinSDKVersion    string
-	TargetSDKVersion string
-	VersionCode      string
-	VersionName      string
-	NdkVersion       string
-	KotlinVersion    string
-	FlutterVersion   string
-}
-
-func ParseGradleFile(path string) (*GradleConfig, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	content := string(data)
-	config := &GradleConfig{}
-
-	patterns := map[string]*regexp.Regexp{
-		"applicationId":    regexp.MustCompile(`applicationId\s+["']([^"']+)["']`),
-		"minSdkVersion":    regexp.MustCompile(`minSdkVersion\s+(\d+)`),
-		"targetSdkVersion": regexp.MustCompile(`targetSdkVersion\s+(\d+)`),
-		"versionCode":      regexp.MustCompile(`versionCode\s+(\d+)`),
-		"versionName":      regexp.MustCompile(`versionName\s+["']([^"']+)["']`),
-		"ndkVersion":       regexp.MustCompile(`ndkVersion\s+["']([^"']+)["']`),
-	}
-
-	for key, pattern := range patterns {
-		if matches := pattern.FindStringSubmatch(content); len(matches) > 1 {
-			switch key {
-			case "applicationId":
-				config.ApplicationID = matches[1]
-			case "minSdkVersion":
-				config.MinSDKVersion = matches[1]
-			case "targetSdkVersion":
-				config.TargetSDKVersion = matches[1]
-			case "versionCode":
-				config.VersionCode = matches[1]
-			case "versionName":
-				config.VersionName = matches[1]
-			case "ndkVersion":
-				config.NdkVersion = matches[1]
-			}
-		}
-	}
-
-	return config, nil
-}
-
-type BuildGradleKts struct {
-	ApplicationID    string
-	MinSDKVersion    string
-	TargetSDKVersion string
-	VersionCode      string
-	VersionName      string
-}
-
-func ParseGradleKtsFile(path string) (*BuildGradleKts, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	content := string(data)
-	config := &BuildGradleKts{}
-
-	patterns := map[string]*regexp.Regexp{
-		"applicationId":    regexp.MustCompile(`applicationId\s*=\s*["']([^"']+)["']`),
-		"minSdkVersion":    regexp.MustCompile(`minSdkVersion\s*=\s*["']?(\d+)["']?`),
-		"targetSdkVersion": regexp.MustCompile(`targetSdkVersion\s*=\s*["']?(\d+)["']?`),
-		"versionCode":      regexp.MustCompile(`versionCode\s*=\s*["']?(\d+)["']?`),
-		"versionName":      regexp.MustCompile(`versionName\s*=\s*["']([^"']+)["']`),
-	}
-
-	for key, pattern := range patterns {
-		if matches := pattern.FindStringSubmatch(content); len(matches) > 1 {
-			switch key {
-			case "applicationId":
-				config.ApplicationID = matches[1]
-			case "minSdkVersion":
-				config.MinSDKVersion = matches[1]
-			case "targetSdkVersion":
-				config.TargetSDKVersion = matches[1]
-			case "versionCode":
-				config.VersionCode = matches[1]
-			case "versionName":
-				config.VersionName = matches[1]
-			}
-		}
-	}
-
-	return config, nil
-}
@@ -0,0 +1,475 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	androidNamespace = "http://schemas.android.com/apk/res/android"
+	toolsNamespace   = "http://schemas.android.com/tools"
+)
+
+// FlutterPlugin is a plugin with native code, as listed for a platform in a
+// project's .flutter-plugins-dependencies.
+type FlutterPlugin struct {
+	Name string
+	// Path is the plugin's package directory, usually in the pub cache.
+	Path string
+	// DevDependency is set for plugins only pulled in by dev_dependencies,
+	// which are not built into release apps.
+	DevDependency bool
+}
+
+// PluginManifest is a plugin manifest merged into the app manifest.
+type PluginManifest struct {
+	Plugin string
+	File   string
+	// Err is set when the manifest could not be parsed; it is then left
+	// out of the merge.
+	Err error
+}
+
+// ManifestConflict is an attribute a plugin manifest sets differently from
+// the manifest it is merged into. The Android build fails on these unless
+// the app marks the attribute with tools:replace.
+type ManifestConflict struct {
+	// Element is the element name and its android:name, such as
+	// "activity com.example.PickerActivity".
+	Element     string
+	Attribute   string
+	Value       string
+	Plugin      string
+	PluginValue string
+}
+
+// ParseFlutterPlugins returns the plugins with code for platform, android
+// or ios, listed in the .flutter-plugins-dependencies file at path, which
+// flutter pub get writes to the project root.
+func ParseFlutterPlugins(path, platform string) ([]FlutterPlugin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps struct {
+		Plugins map[string][]struct {
+			Name          string `json:"name"`
+			Path          string `json:"path"`
+			DevDependency bool   `json:"dev_dependency"`
+		} `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &deps); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	listed := deps.Plugins[platform]
+	plugins := make([]FlutterPlugin, 0, len(listed))
+	for _, p := range listed {
+		plugins = append(plugins, FlutterPlugin{Name: p.Name, Path: p.Path, DevDependency: p.DevDependency})
+	}
+	return plugins, nil
+}
+
+// FindPluginManifest returns the android/src/main/AndroidManifest.xml of
+// plugin, or "" if the plugin has no manifest.
+func FindPluginManifest(plugin FlutterPlugin) string {
+	dir := PluginDir(plugin)
+	if dir == "" {
+		return ""
+	}
+	if file := filepath.Join(dir, "android", "src", "main", "AndroidManifest.xml"); isFile(file) {
+		return file
+	}
+	return ""
+}
+
+// PluginDir returns the package directory of plugin. The recorded path is
+// tried first, then the same package in the local pub cache, for
+// .flutter-plugins-dependencies files written on another machine. It
+// returns "" if neither exists.
+func PluginDir(plugin FlutterPlugin) string {
+	if plugin.Path == "" {
+		return ""
+	}
+	if isDir(plugin.Path) {
+		return plugin.Path
+	}
+
+	cache := PubCacheDir()
+	// The path may use the separators of another platform.
+	base := path.Base(strings.TrimRight(strings.ReplaceAll(plugin.Path, `\`, "/"), "/"))
+	if cache == "" || base == "." || base == "/" {
+		return ""
+	}
+	candidates, _ := filepath.Glob(filepath.Join(cache, "hosted", "*", base))
+	candidates = append(candidates, filepath.Join(cache, "git", base))
+	for _, dir := range candidates {
+		if isDir(dir) {
+			return dir
+		}
+	}
+	return ""
+}
+
+// PubCacheDir returns the local pub cache directory: $PUB_CACHE, or the
+// platform default. It returns "" if the home directory is unknown.
+func PubCacheDir() string {
+	if dir := os.Getenv("PUB_CACHE"); dir != "" {
+		return dir
+	}
+	if runtime.GOOS == "windows" {
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			return filepath.Join(local, "Pub", "Cache")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".pub-cache")
+}
+
+// MergeAndroidManifests parses the app manifest at path and merges the
+// manifests of plugins into it the way the Android manifest merger does:
+// the app manifest takes priority, elements with the same android:name are
+// merged, and its tools:node, tools:replace and tools:remove markers apply
+// to the plugin manifests. Each permission and activity records the plugin
+// it came from. Dev-only plugins and plugins without a manifest are
+// skipped; a plugin manifest that cannot be parsed is recorded in Plugins
+// and left out.
+func MergeAndroidManifests(path string, plugins []FlutterPlugin) (*AndroidManifest, error) {
+	app, err := readManifestTree(path, "")
+	if err != nil {
+		return nil, err
+	}
+	app.setPackage(manifestPackage(app, path))
+
+	m := &manifestMerger{}
+	var merged []PluginManifest
+	for _, plugin := range plugins {
+		if plugin.DevDependency {
+			continue
+		}
+		file := FindPluginManifest(plugin)
+		if file == "" {
+			continue
+		}
+		lib, err := readManifestTree(file, plugin.Name)
+		merged = append(merged, PluginManifest{Plugin: plugin.Name, File: file, Err: err})
+		if err != nil {
+			continue
+		}
+		m.plugin, m.pkg = plugin.Name, manifestPackage(lib, file)
+		lib.setPackage(m.pkg)
+		// The attributes of <manifest> itself, such as package, are not
+		// merged.
+		m.mergeChildren(app, lib)
+	}
+	app.prune()
+
+	manifest := buildManifest(app)
+	manifest.Plugins = merged
+	manifest.Conflicts = m.conflicts
+	return manifest, nil
+}
+
+// manifestNode is an element of a manifest.
+type manifestNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*manifestNode
+	line     int
+	// origin is the plugin whose manifest the element came from, or "".
+	origin string
+	// pkg is the package of that manifest, which relative class names
+	// such as .BgService are resolved against.
+	pkg string
+}
+
+// classElements are the elements whose android:name is a class name, which
+// may be given relative to the manifest's package.
+var classElements = map[string]bool{
+	"application":     true,
+	"activity":        true,
+	"activity-alias":  true,
+	"service":         true,
+	"receiver":        true,
+	"provider":        true,
+	"instrumentation": true,
+}
+
+// manifestPackage returns the package of the manifest tree read from file:
+// its package attribute or, for manifests that leave it to the build, the
+// namespace of the module build script three directories up, as in
+// android/src/main/AndroidManifest.xml. It returns "" if neither is set.
+func manifestPackage(root *manifestNode, file string) string {
+	if pkg := getAttrValue(root.attrs, "package"); pkg != "" {
+		return pkg
+	}
+	module := filepath.Dir(filepath.Dir(filepath.Dir(file)))
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		script := filepath.Join(module, name)
+		if !isFile(script) {
+			continue
+		}
+		if cfg, err := ParseGradleFile(script); err == nil {
+			return cfg.Namespace
+		}
+	}
+	return ""
+}
+
+// setPackage records pkg as the package of n and its descendants.
+func (n *manifestNode) setPackage(pkg string) {
+	n.pkg = pkg
+	for _, child := range n.children {
+		child.setPackage(pkg)
+	}
+}
+
+// readManifestTree parses the manifest at path into a tree whose elements
+// come from origin.
+func readManifestTree(path, origin string) (*manifestNode, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &manifestNode{name: "manifest", origin: origin}
+	var stack []*manifestNode
+	d := xml.NewDecoder(bytes.NewReader(data))
+	line, counted := 1, 0
+	for {
+		// The element starts where the previous token ended.
+		offset := int(d.InputOffset())
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			line += bytes.Count(data[counted:offset], []byte("\n"))
+			counted = offset
+			node := &manifestNode{name: elem.Name.Local, attrs: elem.Attr, line: line, origin: origin}
+			if len(stack) == 0 {
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return root, nil
+}
+
+// tool returns the value of the tools: attribute name.
+func (n *manifestNode) tool(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name && (attr.Name.Space == toolsNamespace || attr.Name.Space == "tools") {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// key identifies the element among its siblings for merging, or is "" for
+// elements such as <intent-filter> that are never merged with another.
+func (n *manifestNode) key() string {
+	switch n.name {
+	case "manifest", "application", "uses-sdk":
+		return n.name
+	}
+	if name := getAttrValue(n.attrs, "name"); name != "" {
+		if strings.HasPrefix(name, ".") && classElements[n.name] && n.pkg != "" {
+			name = n.pkg + name
+		}
+		return n.name + " " + name
+	}
+	if n.name == "uses-feature" {
+		if version := getAttrValue(n.attrs, "glEsVersion"); version != "" {
+			return n.name + " " + version
+		}
+	}
+	return ""
+}
+
+// find returns the child of n with key, or nil.
+func (n *manifestNode) find(key string) *manifestNode {
+	for _, child := range n.children {
+		if child.key() == key {
+			return child
+		}
+	}
+	return nil
+}
+
+// prune drops the elements marked tools:node="remove" or "removeAll",
+// which only instruct the merger.
+func (n *manifestNode) prune() {
+	kept := n.children[:0]
+	for _, child := range n.children {
+		switch child.tool("node") {
+		case "remove", "removeAll":
+			continue
+		}
+		child.prune()
+		kept = append(kept, child)
+	}
+	n.children = kept
+}
+
+// manifestMerger merges the manifest of one plugin at a time into the app
+// manifest.
+type manifestMerger struct {
+	plugin    string
+	pkg       string
+	conflicts []ManifestConflict
+}
+
+func (m *manifestMerger) merge(high, low *manifestNode) {
+	m.mergeAttrs(high, low)
+	m.mergeChildren(high, low)
+}
+
+func (m *manifestMerger) mergeChildren(high, low *manifestNode) {
+	for _, child := range low.children {
+		// minSdkVersion and targetSdkVersion come from the build script.
+		if child.name == "uses-sdk" || m.removed(high, child) {
+			continue
+		}
+		key := child.key()
+		var target *manifestNode
+		if key != "" {
+			target = high.find(key)
+		}
+		if target == nil {
+			high.children = append(high.children, child)
+			continue
+		}
+		switch target.tool("node") {
+		case "replace":
+		case "merge-only-attributes":
+			m.mergeAttrs(target, child)
+		default:
+			m.merge(target, child)
+		}
+	}
+}
+
+// removed reports whether a sibling in high removes child, through
+// tools:node="remove" on an element with the same key or "removeAll" on
+// any element of the same name, limited by tools:selector to a plugin.
+func (m *manifestMerger) removed(high, child *manifestNode) bool {
+	for _, sibling := range high.children {
+		if sibling.name != child.name {
+			continue
+		}
+		if selector := sibling.tool("selector"); selector != "" && selector != m.pkg && selector != m.plugin {
+			continue
+		}
+		switch sibling.tool("node") {
+		case "removeAll":
+			return true
+		case "remove":
+			if sibling.key() == child.key() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeAttrs adds the attributes of low that high lacks, except those
+// high lists in tools:remove, and records those both set differently
+// unless high lists them in tools:replace.
+func (m *manifestMerger) mergeAttrs(high, low *manifestNode) {
+	replace := toolsList(high.tool("replace"))
+	remove := toolsList(high.tool("remove"))
+	for _, attr := range low.attrs {
+		name := attrName(attr)
+		// Merged elements have the same android:name, perhaps written
+		// relative to their package.
+		if name == "" || name == "android:name" || remove[name] {
+			continue
+		}
+		value, ok := high.attr(name)
+		if !ok {
+			high.attrs = append(high.attrs, attr)
+			continue
+		}
+		if value != attr.Value && !replace[name] {
+			m.conflicts = append(m.conflicts, ManifestConflict{
+				Element:     strings.TrimSpace(high.name + " " + getAttrValue(high.attrs, "name")),
+				Attribute:   name,
+				Value:       value,
+				Plugin:      m.plugin,
+				PluginValue: attr.Value,
+			})
+		}
+	}
+}
+
+// attr returns the value of the attribute with the prefixed name.
+func (n *manifestNode) attr(name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attrName(attr) == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// attrName returns the prefixed name of attr, such as android:exported, or
+// "" for namespace declarations and tools: markers.
+func attrName(attr xml.Attr) string {
+	switch attr.Name.Space {
+	case "":
+		if attr.Name.Local == "xmlns" {
+			return ""
+		}
+		return attr.Name.Local
+	case "xmlns", toolsNamespace, "tools":
+		return ""
+	case androidNamespace:
+		return "android:" + attr.Name.Local
+	}
+	return attr.Name.Space + ":" + attr.Name.Local
+}
+
+// toolsList returns the attribute names in a comma-separated tools:
+// marker value.
+func toolsList(value string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,238 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeAndroidManifests(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"app/android/app/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    xmlns:tools="http://schemas.android.com/tools">
+    <uses-permission android:name="android.permission.INTERNET" />
+    <uses-permission android:name="android.permission.READ_EXTERNAL_STORAGE" tools:node="remove" />
+
+    <application android:label="app" android:allowBackup="false" tools:replace="android:allowBackup">
+        <activity android:name=".MainActivity" android:exported="true" />
+        <activity
+            android:name="com.example.picker.PickerActivity"
+            android:exported="false"
+            tools:replace="android:exported" />
+        <activity android:name="com.example.camera.CameraActivity" android:theme="@style/Camera" />
+    </application>
+</manifest>
+`,
+		"cache/geolocator_android-4.6.1/android/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    package="com.baseflow.geolocator">
+    <uses-permission android:name="android.permission.ACCESS_FINE_LOCATION" />
+    <uses-permission android:name="android.permission.ACCESS_BACKGROUND_LOCATION" />
+    <uses-permission android:name="android.permission.INTERNET" />
+</manifest>
+`,
+		"cache/image_picker_android-0.8.9/android/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <uses-permission android:name="android.permission.READ_EXTERNAL_STORAGE" android:maxSdkVersion="32" />
+    <uses-sdk android:minSdkVersion="21" />
+    <application android:allowBackup="true">
+        <activity android:name="com.example.picker.PickerActivity" android:exported="true" />
+        <activity android:name="com.example.camera.CameraActivity" android:exported="true" android:theme="@style/Other" />
+    </application>
+</manifest>
+`,
+		"cache/integration_test-0.0.1/android/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <uses-permission android:name="android.permission.WAKE_LOCK" />
+</manifest>
+`,
+		"cache/broken-1.0.0/android/src/main/AndroidManifest.xml": `<manifest><application></manifest>`,
+	})
+	cache := filepath.Join(dir, "cache")
+	plugins := []FlutterPlugin{
+		{Name: "geolocator_android", Path: filepath.Join(cache, "geolocator_android-4.6.1")},
+		{Name: "image_picker_android", Path: filepath.Join(cache, "image_picker_android-0.8.9")},
+		{Name: "integration_test", Path: filepath.Join(cache, "integration_test-0.0.1"), DevDependency: true},
+		{Name: "path_provider_android", Path: filepath.Join(cache, "path_provider_android-2.2.0")},
+		{Name: "broken", Path: filepath.Join(cache, "broken-1.0.0")},
+	}
+
+	manifest, err := MergeAndroidManifests(filepath.Join(dir, "app", "android", "app", "src", "main", "AndroidManifest.xml"), plugins)
+	if err != nil {
+		t.Fatalf("MergeAndroidManifests failed: %v", err)
+	}
+
+	origins := map[string]string{}
+	for _, p := range manifest.UsesPermissions {
+		origins[p.Name] = p.Origin
+	}
+	want := map[string]string{
+		"android.permission.INTERNET":                   "",
+		"android.permission.ACCESS_FINE_LOCATION":       "geolocator_android",
+		"android.permission.ACCESS_BACKGROUND_LOCATION": "geolocator_android",
+	}
+	if len(origins) != len(want) {
+		t.Errorf("expected permissions %v, got %v", want, origins)
+	}
+	for name, origin := range want {
+		if got, ok := origins[name]; !ok || got != origin {
+			t.Errorf("permission %s: expected origin %q, got %q (present %v)", name, origin, got, ok)
+		}
+	}
+
+	exported := map[string]string{}
+	for _, a := range manifest.Activities {
+		exported[a.Name] = a.Exported
+	}
+	if exported["com.example.picker.PickerActivity"] != "false" {
+		t.Errorf("tools:replace should keep the app's android:exported, got %q", exported["com.example.picker.PickerActivity"])
+	}
+	if exported["com.example.camera.CameraActivity"] != "true" {
+		t.Errorf("plugin attributes the app lacks should be merged, got %q", exported["com.example.camera.CameraActivity"])
+	}
+	if len(manifest.Activities) != 3 {
+		t.Errorf("expected 3 activities, got %+v", manifest.Activities)
+	}
+	if manifest.AllowBackup != "false" {
+		t.Errorf("expected the app's allowBackup, got %q", manifest.AllowBackup)
+	}
+
+	if len(manifest.Conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", manifest.Conflicts)
+	}
+	conflict := manifest.Conflicts[0]
+	if conflict.Element != "activity com.example.camera.CameraActivity" || conflict.Attribute != "android:theme" ||
+		conflict.Value != "@style/Camera" || conflict.Plugin != "image_picker_android" || conflict.PluginValue != "@style/Other" {
+		t.Errorf("unexpected conflict %+v", conflict)
+	}
+
+	if len(manifest.Plugins) != 3 {
+		t.Fatalf("expected 3 plugin manifests, got %+v", manifest.Plugins)
+	}
+	if manifest.Plugins[2].Plugin != "broken" || manifest.Plugins[2].Err == nil {
+		t.Errorf("expected the broken manifest to be reported, got %+v", manifest.Plugins[2])
+	}
+}
+
+func TestMergeAndroidManifestsRemoveAll(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"app/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    xmlns:tools="http://schemas.android.com/tools">
+    <uses-permission android:name="android.permission.CAMERA" />
+    <uses-permission tools:node="removeAll" tools:selector="com.example.tracking" />
+</manifest>
+`,
+		"tracking/android/src/main/AndroidManifest.xml": `<manifest package="com.example.tracking">
+    <uses-permission android:name="android.permission.ACCESS_FINE_LOCATION" />
+</manifest>
+`,
+		"other/android/src/main/AndroidManifest.xml": `<manifest package="com.example.other">
+    <uses-permission android:name="android.permission.RECORD_AUDIO" />
+</manifest>
+`,
+	})
+
+	manifest, err := MergeAndroidManifests(filepath.Join(dir, "app", "AndroidManifest.xml"), []FlutterPlugin{
+		{Name: "tracking", Path: filepath.Join(dir, "tracking")},
+		{Name: "other", Path: filepath.Join(dir, "other")},
+	})
+	if err != nil {
+		t.Fatalf("MergeAndroidManifests failed: %v", err)
+	}
+
+	if len(manifest.UsesPermissions) != 2 || manifest.HasPermission("ACCESS_FINE_LOCATION") || !manifest.HasPermission("RECORD_AUDIO") {
+		t.Errorf("expected only the selected plugin's permissions to be removed, got %+v", manifest.UsesPermissions)
+	}
+}
+
+func TestMergeAndroidManifestsRelativeNames(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"app/android/app/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    xmlns:tools="http://schemas.android.com/tools">
+    <application>
+        <service android:name="com.example.plug.BgService" tools:node="remove" />
+        <receiver android:name="com.example.plug.BootReceiver" android:exported="false" tools:replace="android:exported" />
+    </application>
+</manifest>
+`,
+		"plug/android/build.gradle": `android {
+    namespace 'com.example.plug'
+}
+`,
+		"plug/android/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <application>
+        <service android:name=".BgService" android:exported="true" />
+        <receiver android:name=".BootReceiver" android:exported="true" />
+    </application>
+</manifest>
+`,
+	})
+
+	manifest, err := MergeAndroidManifests(filepath.Join(dir, "app", "android", "app", "src", "main", "AndroidManifest.xml"), []FlutterPlugin{
+		{Name: "plug", Path: filepath.Join(dir, "plug")},
+	})
+	if err != nil {
+		t.Fatalf("MergeAndroidManifests failed: %v", err)
+	}
+
+	if len(manifest.Services) != 0 {
+		t.Errorf("expected the relative plugin service to be removed, got %+v", manifest.Services)
+	}
+	if len(manifest.Receivers) != 1 || manifest.Receivers[0].Exported != "false" {
+		t.Errorf("expected the relative plugin receiver to merge into the app's, got %+v", manifest.Receivers)
+	}
+	if len(manifest.Conflicts) != 0 {
+		t.Errorf("expected tools:replace to apply, got %+v", manifest.Conflicts)
+	}
+}
+
+func TestFindPluginManifestInPubCache(t *testing.T) {
+	cache := writeFiles(t, map[string]string{
+		"hosted/pub.dev/geolocator_android-4.6.1/android/src/main/AndroidManifest.xml": "<manifest/>",
+	})
+	t.Setenv("PUB_CACHE", cache)
+
+	plugin := FlutterPlugin{Name: "geolocator_android", Path: `C:\Users\dev\AppData\Local\Pub\Cache\hosted\pub.dev\geolocator_android-4.6.1\`}
+	want := filepath.Join(cache, "hosted", "pub.dev", "geolocator_android-4.6.1", "android", "src", "main", "AndroidManifest.xml")
+	if got := FindPluginManifest(plugin); got != want {
+		t.Errorf("FindPluginManifest = %q, want %q", got, want)
+	}
+
+	if got := FindPluginManifest(FlutterPlugin{Name: "missing", Path: "/nowhere/missing-1.0.0"}); got != "" {
+		t.Errorf("expected no manifest, got %q", got)
+	}
+}
+
+func TestParseFlutterPlugins(t *testing.T) {
+	data, err := json.Marshal(map[string]any{
+		"info": "This is a generated file; do not edit or check into version control.",
+		"plugins": map[string]any{
+			"ios": []any{map[string]any{"name": "geolocator_apple", "path": "/cache/geolocator_apple-2.3.7/"}},
+			"android": []any{
+				map[string]any{"name": "geolocator_android", "path": "/cache/geolocator_android-4.6.1/", "native_build": true, "dependencies": []string{}, "dev_dependency": false},
+				map[string]any{"name": "integration_test", "path": "/sdk/packages/integration_test/", "native_build": true, "dev_dependency": true},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), ".flutter-plugins-dependencies")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins, err := ParseFlutterPlugins(path, "android")
+	if err != nil {
+		t.Fatalf("ParseFlutterPlugins failed: %v", err)
+	}
+	if len(plugins) != 2 || plugins[0].Name != "geolocator_android" || plugins[0].Path != "/cache/geolocator_android-4.6.1/" || plugins[0].DevDependency || !plugins[1].DevDependency {
+		t.Errorf("unexpected plugins %+v", plugins)
+	}
+
+	plugins, err = ParseFlutterPlugins(path, "ios")
+	if err != nil {
+		t.Fatalf("ParseFlutterPlugins failed: %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Name != "geolocator_apple" {
+		t.Errorf("unexpected iOS plugins %+v", plugins)
+	}
+}
@@ -96,6 +96,98 @@ func TestParseAndroidManifest(t *testing.T) {
 	})
 }
 
+func TestParseAndroidManifestComponents(t *testing.T) {
+	path := filepath.Join(getTestdataDir(t), "android", "components_manifest.xml")
+	manifest, err := ParseAndroidManifest(path)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	t.Run("permissions", func(t *testing.T) {
+		if len(manifest.UsesPermissions) != 4 {
+			t.Fatalf("Expected 4 permissions, got %+v", manifest.UsesPermissions)
+		}
+		storage := manifest.UsesPermissions[1]
+		if storage.MaxSDKVersion != "32" || storage.Line != 6 {
+			t.Errorf("Expected maxSdkVersion 32 on line 6, got %+v", storage)
+		}
+		if location := manifest.UsesPermissions[2]; !location.SDK23 || location.Name != "android.permission.ACCESS_FINE_LOCATION" {
+			t.Errorf("Expected a uses-permission-sdk-23 element, got %+v", location)
+		}
+	})
+
+	t.Run("features", func(t *testing.T) {
+		if len(manifest.UsesFeatures) != 3 {
+			t.Fatalf("Expected 3 features, got %+v", manifest.UsesFeatures)
+		}
+		if manifest.UsesFeatures[0].IsRequired() {
+			t.Error("Expected the camera feature to be optional")
+		}
+		if !manifest.UsesFeatures[1].IsRequired() || manifest.UsesFeatures[1].Line != 13 {
+			t.Errorf("Expected a required gps feature on line 13, got %+v", manifest.UsesFeatures[1])
+		}
+		if manifest.UsesFeatures[2].GLESVersion != "0x00020000" {
+			t.Errorf("Expected glEsVersion, got %+v", manifest.UsesFeatures[2])
+		}
+	})
+
+	t.Run("application", func(t *testing.T) {
+		app := manifest.Application
+		if app.UsesCleartextTraffic != "true" || app.NetworkSecurityConfig != "@xml/network_security_config" || app.Line != 16 {
+			t.Errorf("Unexpected application %+v", app)
+		}
+		if len(app.MetaData) != 1 || app.MetaData[0].Name != "flutterEmbedding" || app.MetaData[0].Value != "2" {
+			t.Errorf("Expected the application meta-data only, got %+v", app.MetaData)
+		}
+	})
+
+	t.Run("activities", func(t *testing.T) {
+		if len(manifest.Activities) != 1 {
+			t.Fatalf("Expected 1 activity, got %d", len(manifest.Activities))
+		}
+		main := manifest.Activities[0]
+		if main.Line != 21 || len(main.IntentFilters) != 2 || len(main.MetaData) != 1 {
+			t.Errorf("Unexpected activity %+v", main)
+		}
+		if !main.IsExported() {
+			t.Error("Expected MainActivity to be exported")
+		}
+		links := main.DeepLinks()
+		if len(links) != 1 || links[0].AutoVerify != "true" || len(links[0].Data) != 2 || links[0].Line != 26 {
+			t.Errorf("Expected one verified deep link filter on line 26, got %+v", links)
+		}
+		if links[0].Data[1].Host != "example.com" || links[0].Data[1].PathPrefix != "/orders" {
+			t.Errorf("Unexpected deep link data %+v", links[0].Data)
+		}
+	})
+
+	t.Run("services, receivers and providers", func(t *testing.T) {
+		if len(manifest.Services) != 1 || manifest.Services[0].ForegroundServiceType != "location" || manifest.Services[0].IsExported() {
+			t.Errorf("Unexpected services %+v", manifest.Services)
+		}
+		if len(manifest.Receivers) != 1 {
+			t.Fatalf("Expected 1 receiver, got %+v", manifest.Receivers)
+		}
+		boot := manifest.Receivers[0]
+		if boot.Kind != "receiver" || boot.Exported != "" || !boot.IsExported() || !boot.IntentFilters[0].HasAction("android.intent.action.BOOT_COMPLETED") {
+			t.Errorf("Expected an implicitly exported boot receiver, got %+v", boot)
+		}
+		if len(manifest.Providers) != 1 || manifest.Providers[0].Authorities != "com.example.components.fileprovider" {
+			t.Errorf("Unexpected providers %+v", manifest.Providers)
+		}
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		if len(manifest.Queries) != 1 {
+			t.Fatalf("Expected 1 queries element, got %d", len(manifest.Queries))
+		}
+		q := manifest.Queries[0]
+		if len(q.Packages) != 1 || len(q.Intents) != 1 || q.Intents[0].Data[0].Scheme != "mailto" || len(q.Providers) != 1 {
+			t.Errorf("Unexpected queries %+v", q)
+		}
+	})
+}
+
 func TestParseGradleFile(t *testing.T) {
 	testdataDir := getTestdataDir(t)
 
@@ -1,12 +1,17 @@
 package parser
 
 import (
-	"os"
-	"regexp"
+	"fmt"
 	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/plist"
 )
 
+// Plist holds the Info.plist keys fsct checks. Root is the whole decoded
+// property list, for keys without a field of their own.
 type Plist struct {
+	Root *plist.Value
+
 	CFBundleIdentifier                           string
 	CFBundleDisplayName                          string
 	CFBundleVersion                              string
@@ -33,107 +38,95 @@ type Plist struct {
 	UIBackgroundModes                            []string
 	UILaunchStoryboardName                       string
 	UIApplicationSupportsIndirectInputEvents     bool
-	ITSAppTransportSecurity                      map[string]interface{}
+	NSAppTransportSecurity                       map[string]interface{}
 	UIStatusBarStyle                             string
 	UIUserInterfaceStyle                         string
 	NSUserTrackingUsageDescription               string
 }
 
+// ParseInfoPlist reads an Info.plist in the XML or binary format.
 func ParseInfoPlist(path string) (*Plist, error) {
-	data, err := os.ReadFile(path)
+	root, err := plist.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	content := string(data)
-	plist := &Plist{}
-
-	cfbundlePatterns := map[string]*regexp.Regexp{
-		"CFBundleIdentifier":                           regexp.MustCompile(`<key>CFBundleIdentifier</key>\s*<string>([^<]+)</string>`),
-		"CFBundleDisplayName":                          regexp.MustCompile(`<key>CFBundleDisplayName</key>\s*<string>([^<]+)</string>`),
-		"CFBundleVersion":                              regexp.MustCompile(`<key>CFBundleVersion</key>\s*<string>([^<]+)</string>`),
-		"CFBundleShortVersionString":                   regexp.MustCompile(`<key>CFBundleShortVersionString</key>\s*<string>([^<]+)</string>`),
-		"CFBundleExecutable":                           regexp.MustCompile(`<key>CFBundleExecutable</key>\s*<string>([^<]+)</string>`),
-		"CFBundleName":                                 regexp.MustCompile(`<key>CFBundleName</key>\s*<string>([^<]+)</string>`),
-		"NSPhotoLibraryUsageDescription":               regexp.MustCompile(`<key>NSPhotoLibraryUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSCameraUsageDescription":                     regexp.MustCompile(`<key>NSCameraUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSMicrophoneUsageDescription":                 regexp.MustCompile(`<key>NSMicrophoneUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSLocationWhenInUseUsageDescription":          regexp.MustCompile(`<key>NSLocationWhenInUseUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSLocationAlwaysAndWhenInUseUsageDescription": regexp.MustCompile(`<key>NSLocationAlwaysAndWhenInUseUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSContactsUsageDescription":                   regexp.MustCompile(`<key>NSContactsUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSCalendarsUsageDescription":                  regexp.MustCompile(`<key>NSCalendarsUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSRemindersUsageDescription":                  regexp.MustCompile(`<key>NSRemindersUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSBluetoothAlwaysUsageDescription":            regexp.MustCompile(`<key>NSBluetoothAlwaysUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSBluetoothPeripheralUsageDescription":        regexp.MustCompile(`<key>NSBluetoothPeripheralUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSAppleMusicUsageDescription":                 regexp.MustCompile(`<key>NSAppleMusicUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSHealthShareUsageDescription":                regexp.MustCompile(`<key>NSHealthShareUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSHealthUpdateUsageDescription":               regexp.MustCompile(`<key>NSHealthUpdateUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSSiriUsageDescription":                       regexp.MustCompile(`<key>NSSiriUsageDescription</key>\s*<string>([^<]*)</string>`),
-		"NSUserTrackingUsageDescription":               regexp.MustCompile(`<key>NSUserTrackingUsageDescription</key>\s*<string>([^<]*)</string>`),
+	if root.Kind() != plist.KindDict {
+		return nil, fmt.Errorf("%s: expected a dictionary, found %s", path, root.Kind())
 	}
 
-	for key, pattern := range cfbundlePatterns {
-		if matches := pattern.FindStringSubmatch(content); len(matches) > 1 {
-			switch key {
-			case "CFBundleIdentifier":
-				plist.CFBundleIdentifier = strings.TrimSpace(matches[1])
-			case "CFBundleDisplayName":
-				plist.CFBundleDisplayName = strings.TrimSpace(matches[1])
-			case "CFBundleVersion":
-				plist.CFBundleVersion = strings.TrimSpace(matches[1])
-			case "CFBundleShortVersionString":
-				plist.CFBundleShortVersionString = strings.TrimSpace(matches[1])
-			case "CFBundleExecutable":
-				plist.CFBundleExecutable = strings.TrimSpace(matches[1])
-			case "CFBundleName":
-				plist.CFBundleName = strings.TrimSpace(matches[1])
-			case "NSPhotoLibraryUsageDescription":
-				plist.NSPhotoLibraryUsageDescription = strings.TrimSpace(matches[1])
-			case "NSCameraUsageDescription":
-				plist.NSCameraUsageDescription = strings.TrimSpace(matches[1])
-			case "NSMicrophoneUsageDescription":
-				plist.NSMicrophoneUsageDescription = strings.TrimSpace(matches[1])
-			case "NSLocationWhenInUseUsageDescription":
-				plist.NSLocationWhenInUseUsageDescription = strings.TrimSpace(matches[1])
-			case "NSLocationAlwaysAndWhenInUseUsageDescription":
-				plist.NSLocationAlwaysAndWhenInUseUsageDescription = strings.TrimSpace(matches[1])
-			case "NSContactsUsageDescription":
-				plist.NSContactsUsageDescription = strings.TrimSpace(matches[1])
-			case "NSCalendarsUsageDescription":
-				plist.NSCalendarsUsageDescription = strings.TrimSpace(matches[1])
-			case "NSRemindersUsageDescription":
-				plist.NSRemindersUsageDescription = strings.TrimSpace(matches[1])
-			case "NSBluetoothAlwaysUsageDescription":
-				plist.NSBluetoothAlwaysUsageDescription = strings.TrimSpace(matches[1])
-			case "NSBluetoothPeripheralUsageDescription":
-				plist.NSBluetoothPeripheralUsageDescription = strings.TrimSpace(matches[1])
-			case "NSAppleMusicUsageDescription":
-				plist.NSAppleMusicUsageDescription = strings.TrimSpace(matches[1])
-			case "NSHealthShareUsageDescription":
-				plist.NSHealthShareUsageDescription = strings.TrimSpace(matches[1])
-			case "NSHealthUpdateUsageDescription":
-				plist.NSHealthUpdateUsageDescription = strings.TrimSpace(matches[1])
-			case "NSSiriUsageDescription":
-				plist.NSSiriUsageDescription = strings.TrimSpace(matches[1])
-			case "NSUserTrackingUsageDescription":
-				plist.NSUserTrackingUsageDescription = strings.TrimSpace(matches[1])
-			}
-		}
-	}
+	p := &Plist{Root: root}
+	p.read()
+	return p, nil
+}
+
+// Expand replaces the $(NAME) build setting references in the values of
+// the property list, such as $(PRODUCT_BUNDLE_IDENTIFIER), the way Xcode
+// does when it copies Info.plist into the app. References to settings
+// that are not defined are left as written.
+func (p *Plist) Expand(settings BuildSettings) {
+	p.Root.ReplaceStrings(settings.Expand)
+	p.read()
+}
 
-	itsAppUsesNonExemptPattern := regexp.MustCompile(`<key>ITSAppUsesNonExemptEncryption</key>\s*<([a-z]+)/?>`)
-	if matches := itsAppUsesNonExemptPattern.FindStringSubmatch(content); len(matches) > 1 {
-		value := matches[1] == "true"
-		plist.ITSAppUsesNonExemptEncryption = &value
+// read sets the fields from Root.
+func (p *Plist) read() {
+	root := p.Root
+	for key, field := range map[string]*string{
+		"CFBundleIdentifier":                           &p.CFBundleIdentifier,
+		"CFBundleDisplayName":                          &p.CFBundleDisplayName,
+		"CFBundleVersion":                              &p.CFBundleVersion,
+		"CFBundleShortVersionString":                   &p.CFBundleShortVersionString,
+		"CFBundleExecutable":                           &p.CFBundleExecutable,
+		"CFBundleName":                                 &p.CFBundleName,
+		"NSPhotoLibraryUsageDescription":               &p.NSPhotoLibraryUsageDescription,
+		"NSCameraUsageDescription":                     &p.NSCameraUsageDescription,
+		"NSMicrophoneUsageDescription":                 &p.NSMicrophoneUsageDescription,
+		"NSLocationWhenInUseUsageDescription":          &p.NSLocationWhenInUseUsageDescription,
+		"NSLocationAlwaysAndWhenInUseUsageDescription": &p.NSLocationAlwaysAndWhenInUseUsageDescription,
+		"NSContactsUsageDescription":                   &p.NSContactsUsageDescription,
+		"NSCalendarsUsageDescription":                  &p.NSCalendarsUsageDescription,
+		"NSRemindersUsageDescription":                  &p.NSRemindersUsageDescription,
+		"NSBluetoothAlwaysUsageDescription":            &p.NSBluetoothAlwaysUsageDescription,
+		"NSBluetoothPeripheralUsageDescription":        &p.NSBluetoothPeripheralUsageDescription,
+		"NSAppleMusicUsageDescription":                 &p.NSAppleMusicUsageDescription,
+		"NSHealthShareUsageDescription":                &p.NSHealthShareUsageDescription,
+		"NSHealthUpdateUsageDescription":               &p.NSHealthUpdateUsageDescription,
+		"NSSiriUsageDescription":                       &p.NSSiriUsageDescription,
+		"NSUserTrackingUsageDescription":               &p.NSUserTrackingUsageDescription,
+		"UILaunchStoryboardName":                       &p.UILaunchStoryboardName,
+		"UIStatusBarStyle":                             &p.UIStatusBarStyle,
+		"UIUserInterfaceStyle":                         &p.UIUserInterfaceStyle,
+	} {
+		*field = strings.TrimSpace(root.Get(key).String())
 	}
 
-	uiRequiresFullScreenPattern := regexp.MustCompile(`<key>UIRequiresFullScreen</key>\s*<([a-z]+)/?>`)
-	if matches := uiRequiresFullScreenPattern.FindStringSubmatch(content); len(matches) > 1 {
-		value := matches[1] == "true"
-		plist.UIRequiresFullScreen = &value
+	p.ITSAppUsesNonExemptEncryption = boolValue(root.Get("ITSAppUsesNonExemptEncryption"))
+	p.UIRequiresFullScreen = boolValue(root.Get("UIRequiresFullScreen"))
+	p.UIApplicationSupportsIndirectInputEvents, _ = root.Get("UIApplicationSupportsIndirectInputEvents").Bool()
+	p.UIApplicationSceneManifest = root.Get("UIApplicationSceneManifest").Map()
+	p.NSAppTransportSecurity = root.Get("NSAppTransportSecurity").Map()
+	p.UIBackgroundModes = root.Get("UIBackgroundModes").Strings()
+}
+
+func boolValue(v *plist.Value) *bool {
+	b, ok := v.Bool()
+	if !ok {
+		return nil
 	}
+	return &b
+}
+
+// Line returns the line of the key at path, such as
+// NSAppTransportSecurity.NSAllowsArbitraryLoads, or 0.
+func (p *Plist) Line(path string) int {
+	return p.Root.Lookup(path).Line()
+}
 
-	return plist, nil
+// AllowsArbitraryLoads reports whether App Transport Security is turned off
+// for all connections.
+func (p *Plist) AllowsArbitraryLoads() bool {
+	b, _ := p.Root.Lookup("NSAppTransportSecurity.NSAllowsArbitraryLoads").Bool()
+	return b
 }
 
 func (p *Plist) HasCameraUsageDescription() bool {
@@ -64,6 +64,48 @@ func TestParseInfoPlist(t *testing.T) {
 		}
 	})
 
+	t.Run("binary Info.plist", func(t *testing.T) {
+		plist, err := ParseInfoPlist(filepath.Join(testdataDir, "ios", "Binary.plist"))
+		if err != nil {
+			t.Fatalf("Failed to parse binary plist: %v", err)
+		}
+
+		if plist.CFBundleIdentifier != "com.example.binary" {
+			t.Errorf("Expected CFBundleIdentifier 'com.example.binary', got '%s'", plist.CFBundleIdentifier)
+		}
+
+		if len(plist.UIBackgroundModes) != 2 || plist.UIBackgroundModes[0] != "audio" {
+			t.Errorf("Expected background modes [audio location], got %v", plist.UIBackgroundModes)
+		}
+
+		if !plist.AllowsArbitraryLoads() {
+			t.Error("Expected arbitrary loads to be allowed")
+		}
+
+		if !plist.IsEncryptionExempt() {
+			t.Error("Expected encryption to be exempt")
+		}
+	})
+
+	t.Run("line numbers", func(t *testing.T) {
+		plist, err := ParseInfoPlist(filepath.Join(testdataDir, "ios", "Info.plist"))
+		if err != nil {
+			t.Fatalf("Failed to parse Info.plist: %v", err)
+		}
+
+		if line := plist.Line("CFBundleIdentifier"); line != 11 {
+			t.Errorf("Expected CFBundleIdentifier on line 11, got %d", line)
+		}
+
+		if line := plist.Line("UIApplicationSceneManifest.UIApplicationSupportsMultipleScenes"); line != 29 {
+			t.Errorf("Expected UIApplicationSupportsMultipleScenes on line 29, got %d", line)
+		}
+
+		if supports, ok := plist.UIApplicationSceneManifest["UIApplicationSupportsMultipleScenes"].(bool); !ok || supports {
+			t.Errorf("Expected UIApplicationSupportsMultipleScenes false, got %v", plist.UIApplicationSceneManifest)
+		}
+	})
+
 	t.Run("nonexistent file", func(t *testing.T) {
 		_, err := ParseInfoPlist("/nonexistent/path/Info.plist")
 		if err == nil {
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Podfile is what fsct reads from an ios/Podfile. The Podfile is Ruby, so
+// only the lines that follow the usual patterns are understood.
+type Podfile struct {
+	// Platform is the version of the platform :ios line, or "" if there
+	// is none. Flutter's template leaves the line commented out, and
+	// CocoaPods then uses the deployment target of the app.
+	Platform     string
+	PlatformLine int
+	// DeploymentTargets are the IPHONEOS_DEPLOYMENT_TARGET values hooks
+	// such as post_install assign to the pod targets, which override
+	// Platform.
+	DeploymentTargets []PodfileSetting
+}
+
+// PodfileSetting is a value the Podfile assigns, and its line.
+type PodfileSetting struct {
+	Value string
+	Line  int
+}
+
+var (
+	podfilePlatform         = regexp.MustCompile(`^platform\s*\(?\s*:ios\b\s*(?:,\s*['"]([^'"]*)['"])?`)
+	podfileDeploymentTarget = regexp.MustCompile(`build_settings\s*\[\s*['"]IPHONEOS_DEPLOYMENT_TARGET['"]\s*\]\s*=\s*['"]?([0-9][0-9.]*)`)
+)
+
+// ParsePodfile parses the Podfile at path.
+func ParsePodfile(path string) (*Podfile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p := &Podfile{}
+	scanner := bufio.NewScanner(f)
+	inComment := false
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		// =begin and =end at the start of a line enclose a block comment.
+		switch {
+		case strings.HasPrefix(line, "=begin"):
+			inComment = true
+			continue
+		case strings.HasPrefix(line, "=end"):
+			inComment = false
+			continue
+		case inComment:
+			continue
+		}
+
+		line = strings.TrimSpace(stripRubyComment(line))
+		if m := podfilePlatform.FindStringSubmatch(line); m != nil {
+			p.Platform, p.PlatformLine = m[1], n
+		}
+		if m := podfileDeploymentTarget.FindStringSubmatch(line); m != nil {
+			p.DeploymentTargets = append(p.DeploymentTargets, PodfileSetting{Value: m[1], Line: n})
+		}
+	}
+	return p, scanner.Err()
+}
+
+// stripRubyComment removes a # comment from line, leaving # in strings.
+func stripRubyComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,171 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PodfileLock is the content of an ios/Podfile.lock.
+type PodfileLock struct {
+	// Pods lists the installed pods by name. The subspecs of a pod, such
+	// as GoogleUtilities/Environment, are folded into it.
+	Pods []LockedPod
+	// CocoaPodsVersion is the version of CocoaPods that wrote the file.
+	CocoaPodsVersion string
+	CocoaPodsLine    int
+}
+
+// LockedPod is a pod pinned in Podfile.lock.
+type LockedPod struct {
+	Name    string
+	Version string
+	// Subspecs are the installed subspecs, such as Environment for
+	// GoogleUtilities/Environment.
+	Subspecs []string
+	// Dependencies are the other pods it requires.
+	Dependencies []string
+	// Repo is the spec repo the pod came from, such as trunk. It is "" for
+	// a pod from an external source and in lock files written before
+	// CocoaPods 1.7.
+	Repo string
+	// External is the :path, :git or :podspec a pod from an external
+	// source was installed from, such as
+	// .symlinks/plugins/camera_avfoundation/ios for a Flutter plugin.
+	External string
+	Checksum string
+	Line     int
+}
+
+// podEntry matches a PODS entry such as "GoogleUtilities/Logger (7.13.3)"
+// or a requirement such as "PromisesObjC (< 3.0, >= 1.2)".
+var podEntry = regexp.MustCompile(`^(\S+)(?:\s+\((.*)\))?$`)
+
+// ParsePodfileLock parses the Podfile.lock at path.
+func ParsePodfileLock(path string) (*PodfileLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	lock := &PodfileLock{}
+	if len(doc.Content) == 0 {
+		return lock, nil
+	}
+	root := yamlValue(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		if root.Tag == "!!null" {
+			return lock, nil
+		}
+		return nil, fmt.Errorf("parse %s: line %d: expected a mapping", path, root.Line)
+	}
+
+	pods := make(map[string]*LockedPod)
+	pod := func(name string, line int) *LockedPod {
+		p := pods[name]
+		if p == nil {
+			p = &LockedPod{Name: name, Line: line}
+			pods[name] = p
+		}
+		return p
+	}
+	eachYAMLEntry(root, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "PODS":
+			for _, item := range value.Content {
+				item = yamlValue(item)
+				entry, requires := item, (*yaml.Node)(nil)
+				if item.Kind == yaml.MappingNode && len(item.Content) == 2 {
+					entry, requires = item.Content[0], yamlValue(item.Content[1])
+				}
+				m := podEntry.FindStringSubmatch(yamlScalar(entry))
+				if m == nil {
+					continue
+				}
+				name, subspec, _ := strings.Cut(m[1], "/")
+				p := pod(name, entry.Line)
+				p.Version = m[2]
+				if subspec != "" {
+					p.Subspecs = appendUnique(p.Subspecs, subspec)
+				}
+				if requires == nil {
+					continue
+				}
+				for _, req := range requires.Content {
+					if m := podEntry.FindStringSubmatch(yamlScalar(yamlValue(req))); m != nil {
+						if dep, _, _ := strings.Cut(m[1], "/"); dep != name {
+							p.Dependencies = appendUnique(p.Dependencies, dep)
+						}
+					}
+				}
+			}
+		case "SPEC REPOS":
+			eachYAMLEntry(value, func(repo, names *yaml.Node) {
+				for _, name := range names.Content {
+					if p := pods[yamlScalar(yamlValue(name))]; p != nil {
+						p.Repo = repo.Value
+					}
+				}
+			})
+		case "EXTERNAL SOURCES":
+			eachYAMLEntry(value, func(name, source *yaml.Node) {
+				p := pods[name.Value]
+				if p == nil {
+					return
+				}
+				eachYAMLEntry(source, func(key, value *yaml.Node) {
+					switch key.Value {
+					case ":path", ":git", ":podspec":
+						p.External = yamlScalar(value)
+					}
+				})
+			})
+		case "SPEC CHECKSUMS":
+			eachYAMLEntry(value, func(name, checksum *yaml.Node) {
+				if p := pods[name.Value]; p != nil {
+					p.Checksum = yamlScalar(checksum)
+				}
+			})
+		case "COCOAPODS":
+			lock.CocoaPodsVersion, lock.CocoaPodsLine = yamlScalar(value), key.Line
+		}
+	})
+
+	lock.Pods = make([]LockedPod, 0, len(pods))
+	for _, p := range pods {
+		sort.Strings(p.Subspecs)
+		sort.Strings(p.Dependencies)
+		lock.Pods = append(lock.Pods, *p)
+	}
+	sort.Slice(lock.Pods, func(i, j int) bool {
+		return lock.Pods[i].Name < lock.Pods[j].Name
+	})
+	return lock, nil
+}
+
+// Pod returns the pod named name, or nil.
+func (l *PodfileLock) Pod(name string) *LockedPod {
+	i := sort.Search(len(l.Pods), func(i int) bool { return l.Pods[i].Name >= name })
+	if i < len(l.Pods) && l.Pods[i].Name == name {
+		return &l.Pods[i]
+	}
+	return nil
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsePodfile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"commented/Podfile": `# Uncomment this line to define a global platform for your project
+# platform :ios, '12.0'
+
+target 'Runner' do
+  use_frameworks!
+end
+`,
+		"Podfile": `platform :ios, "13.0" # raised for camera_avfoundation
+
+=begin
+platform :ios, '11.0'
+=end
+
+target 'Runner' do
+  flutter_install_all_ios_pods File.dirname(File.realpath(__FILE__))
+end
+
+post_install do |installer|
+  installer.pods_project.targets.each do |target|
+    flutter_additional_ios_build_settings(target)
+    target.build_configurations.each do |config|
+      config.build_settings['IPHONEOS_DEPLOYMENT_TARGET'] = '12.0'
+      # config.build_settings['IPHONEOS_DEPLOYMENT_TARGET'] = '9.0'
+      config.build_settings["GCC_PREPROCESSOR_DEFINITIONS"] = "PERMISSION_CAMERA=1 # not a comment"
+    end
+  end
+end
+`,
+	})
+
+	podfile, err := ParsePodfile(filepath.Join(dir, "Podfile"))
+	if err != nil {
+		t.Fatalf("ParsePodfile failed: %v", err)
+	}
+	if podfile.Platform != "13.0" || podfile.PlatformLine != 1 {
+		t.Errorf("expected platform 13.0 on line 1, got %q on %d", podfile.Platform, podfile.PlatformLine)
+	}
+	want := []PodfileSetting{{Value: "12.0", Line: 15}}
+	if !reflect.DeepEqual(podfile.DeploymentTargets, want) {
+		t.Errorf("DeploymentTargets = %+v, want %+v", podfile.DeploymentTargets, want)
+	}
+
+	commented, err := ParsePodfile(filepath.Join(dir, "commented", "Podfile"))
+	if err != nil {
+		t.Fatalf("ParsePodfile failed: %v", err)
+	}
+	if commented.Platform != "" || commented.PlatformLine != 0 {
+		t.Errorf("expected no platform in the Flutter template, got %q", commented.Platform)
+	}
+}
+
+func TestParsePodfileLock(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"Podfile.lock": `PODS:
+  - Flutter (1.0.0)
+  - GoogleUtilities/Environment (7.12.0):
+    - PromisesObjC (< 3.0, >= 1.2)
+  - GoogleUtilities/Logger (7.12.0):
+    - GoogleUtilities/Environment
+  - image_picker_ios (0.0.1):
+    - Flutter
+  - PromisesObjC (2.3.1)
+
+DEPENDENCIES:
+  - Flutter (from ` + "`Flutter`" + `)
+  - image_picker_ios (from ` + "`.symlinks/plugins/image_picker_ios/ios`" + `)
+
+SPEC REPOS:
+  trunk:
+    - GoogleUtilities
+    - PromisesObjC
+
+EXTERNAL SOURCES:
+  Flutter:
+    :path: Flutter
+  image_picker_ios:
+    :path: ".symlinks/plugins/image_picker_ios/ios"
+
+SPEC CHECKSUMS:
+  Flutter: e0871f40cf51350855a761d2e70bf5af5b9b5de7
+  GoogleUtilities: 0759d1a57ebb953965c2dfe0ba4c82e95ccc2e34
+  image_picker_ios: c560581cceedb403a6ff17f2f816d7fea1421fc1
+  PromisesObjC: c50d2056b5253dadbd6c2bea79b0674bd5a52fa4
+
+PODFILE CHECKSUM: 819463c6a0c5b1d5b7d4e3c2a3b0e2c7a0f2c4c7
+
+COCOAPODS: 1.15.2
+`})
+
+	lock, err := ParsePodfileLock(filepath.Join(dir, "Podfile.lock"))
+	if err != nil {
+		t.Fatalf("ParsePodfileLock failed: %v", err)
+	}
+
+	var names []string
+	for _, pod := range lock.Pods {
+		names = append(names, pod.Name)
+	}
+	if want := []string{"Flutter", "GoogleUtilities", "PromisesObjC", "image_picker_ios"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected pods %v, got %v", want, names)
+	}
+
+	utilities := lock.Pod("GoogleUtilities")
+	want := &LockedPod{
+		Name:         "GoogleUtilities",
+		Version:      "7.12.0",
+		Subspecs:     []string{"Environment", "Logger"},
+		Dependencies: []string{"PromisesObjC"},
+		Repo:         "trunk",
+		Checksum:     "0759d1a57ebb953965c2dfe0ba4c82e95ccc2e34",
+		Line:         3,
+	}
+	if !reflect.DeepEqual(utilities, want) {
+		t.Errorf("GoogleUtilities = %+v, want %+v", utilities, want)
+	}
+	if p := lock.Pod("image_picker_ios"); p == nil || p.External != ".symlinks/plugins/image_picker_ios/ios" || p.Repo != "" ||
+		!reflect.DeepEqual(p.Dependencies, []string{"Flutter"}) {
+		t.Errorf("unexpected plugin pod %+v", p)
+	}
+	if p := lock.Pod("Flutter"); p == nil || p.External != "Flutter" || p.Version != "1.0.0" || p.Line != 2 {
+		t.Errorf("unexpected Flutter pod %+v", p)
+	}
+	if lock.Pod("FirebaseCore") != nil {
+		t.Error("expected no pod for an unknown name")
+	}
+	if lock.CocoaPodsVersion != "1.15.2" || lock.CocoaPodsLine != 34 {
+		t.Errorf("expected CocoaPods 1.15.2 on line 34, got %q on %d", lock.CocoaPodsVersion, lock.CocoaPodsLine)
+	}
+
+	bad := writeFiles(t, map[string]string{"Podfile.lock": "- a\n- b\n"})
+	if _, err := ParsePodfileLock(filepath.Join(bad, "Podfile.lock")); err == nil {
+		t.Error("expected an error for a lock file that is not a mapping")
+	}
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/ricky-irfandi/fsct/internal/plist"
+)
+
+// PrivacyManifestName is the file name Xcode requires for privacy
+// manifests.
+const PrivacyManifestName = "PrivacyInfo.xcprivacy"
+
+// PrivacyManifest is the content of a PrivacyInfo.xcprivacy file, in which
+// an app or SDK declares the data it collects and why it uses required
+// reason APIs. Root is the whole decoded property list.
+type PrivacyManifest struct {
+	Root *plist.Value
+
+	Tracking        bool
+	TrackingDomains []string
+	// AccessedAPIs are the entries of NSPrivacyAccessedAPITypes.
+	AccessedAPIs []AccessedAPI
+}
+
+// AccessedAPI declares the use of a category of required reason APIs.
+type AccessedAPI struct {
+	// Type is the category, such as
+	// NSPrivacyAccessedAPICategoryUserDefaults.
+	Type string
+	// Reasons are the approved reason codes, such as CA92.1.
+	Reasons []string
+	// Line is the line of the entry's dictionary.
+	Line int
+}
+
+// ParsePrivacyManifest reads a PrivacyInfo.xcprivacy property list.
+func ParsePrivacyManifest(path string) (*PrivacyManifest, error) {
+	root, err := plist.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if root.Kind() != plist.KindDict {
+		return nil, fmt.Errorf("%s: expected a dictionary, found %s", path, root.Kind())
+	}
+
+	m := &PrivacyManifest{
+		Root:            root,
+		TrackingDomains: root.Get("NSPrivacyTrackingDomains").Strings(),
+	}
+	m.Tracking, _ = root.Get("NSPrivacyTracking").Bool()
+	for _, item := range root.Get("NSPrivacyAccessedAPITypes").Items() {
+		m.AccessedAPIs = append(m.AccessedAPIs, AccessedAPI{
+			Type:    item.Get("NSPrivacyAccessedAPIType").String(),
+			Reasons: item.Get("NSPrivacyAccessedAPITypeReasons").Strings(),
+			Line:    item.Line(),
+		})
+	}
+	return m, nil
+}
+
+// FindPluginPrivacyManifest returns the privacy manifest the iOS code of
+// plugin ships, anywhere under its ios or darwin directory, or "" if it
+// has none. The second result is false if the plugin's package could not
+// be found, in which case nothing is known about its manifest.
+func FindPluginPrivacyManifest(plugin FlutterPlugin) (string, bool) {
+	dir := PluginDir(plugin)
+	if dir == "" {
+		return "", false
+	}
+
+	var found string
+	for _, platform := range []string{"ios", "darwin"} {
+		_ = filepath.WalkDir(filepath.Join(dir, platform), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if !d.IsDir() && d.Name() == PrivacyManifestName {
+				found = path
+				return fs.SkipAll
+			}
+			return nil
+		})
+		if found != "" {
+			break
+		}
+	}
+	return found, true
+}
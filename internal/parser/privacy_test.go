@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsePrivacyManifest(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"PrivacyInfo.xcprivacy": `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>NSPrivacyTracking</key>
+	<false/>
+	<key>NSPrivacyTrackingDomains</key>
+	<array/>
+	<key>NSPrivacyAccessedAPITypes</key>
+	<array>
+		<dict>
+			<key>NSPrivacyAccessedAPIType</key>
+			<string>NSPrivacyAccessedAPICategoryUserDefaults</string>
+			<key>NSPrivacyAccessedAPITypeReasons</key>
+			<array>
+				<string>CA92.1</string>
+			</array>
+		</dict>
+		<dict>
+			<key>NSPrivacyAccessedAPIType</key>
+			<string>NSPrivacyAccessedAPICategoryFileTimestamp</string>
+			<key>NSPrivacyAccessedAPITypeReasons</key>
+			<array>
+				<string>C617.1</string>
+				<string>3B52.1</string>
+			</array>
+		</dict>
+	</array>
+</dict>
+</plist>`,
+		"list.xcprivacy": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><array/></plist>`,
+	})
+
+	m, err := ParsePrivacyManifest(filepath.Join(dir, "PrivacyInfo.xcprivacy"))
+	if err != nil {
+		t.Fatalf("ParsePrivacyManifest failed: %v", err)
+	}
+	if m.Tracking || len(m.TrackingDomains) != 0 {
+		t.Errorf("unexpected tracking %v %v", m.Tracking, m.TrackingDomains)
+	}
+	want := []AccessedAPI{
+		{Type: "NSPrivacyAccessedAPICategoryUserDefaults", Reasons: []string{"CA92.1"}, Line: 11},
+		{Type: "NSPrivacyAccessedAPICategoryFileTimestamp", Reasons: []string{"C617.1", "3B52.1"}, Line: 19},
+	}
+	if !reflect.DeepEqual(m.AccessedAPIs, want) {
+		t.Errorf("AccessedAPIs = %+v, want %+v", m.AccessedAPIs, want)
+	}
+
+	if _, err := ParsePrivacyManifest(filepath.Join(dir, "list.xcprivacy")); err == nil {
+		t.Error("expected an error for a manifest that is not a dictionary")
+	}
+}
+
+func TestFindPluginPrivacyManifest(t *testing.T) {
+	t.Setenv("PUB_CACHE", t.TempDir())
+	dir := writeFiles(t, map[string]string{
+		"shared_preferences_foundation-2.5.3/darwin/shared_preferences_foundation/Sources/shared_preferences_foundation/Resources/PrivacyInfo.xcprivacy": "",
+		"fluttertoast-8.2.4/ios/Classes/FluttertoastPlugin.m": "",
+	})
+
+	file, ok := FindPluginPrivacyManifest(FlutterPlugin{Name: "shared_preferences_foundation", Path: filepath.Join(dir, "shared_preferences_foundation-2.5.3")})
+	if want := filepath.Join(dir, "shared_preferences_foundation-2.5.3", "darwin", "shared_preferences_foundation", "Sources", "shared_preferences_foundation", "Resources", "PrivacyInfo.xcprivacy"); !ok || file != want {
+		t.Errorf("FindPluginPrivacyManifest = %q, %v, want %q", file, ok, want)
+	}
+	if file, ok := FindPluginPrivacyManifest(FlutterPlugin{Name: "fluttertoast", Path: filepath.Join(dir, "fluttertoast-8.2.4")}); !ok || file != "" {
+		t.Errorf("expected fluttertoast to have no manifest, got %q, %v", file, ok)
+	}
+	if _, ok := FindPluginPrivacyManifest(FlutterPlugin{Name: "missing", Path: filepath.Join(dir, "missing-1.0.0")}); ok {
+		t.Error("expected a plugin that is not on disk to be unknown")
+	}
+}
@@ -1,25 +1,115 @@
 package parser
 
 import (
+	"fmt"
 	"os"
-	"regexp"
+	"path/filepath"
+	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ricky-irfandi/fsct/internal/semver"
 )
 
+// Pubspec is the content of a pubspec.yaml.
 type Pubspec struct {
-	Name            string
-	Version         string
-	Description     string
-	Homepage        string
-	Repository      string
+	Name        string
+	Version     string
+	Description string
+	Homepage    string
+	Repository  string
+	PublishTo   string
+
+	// Dependencies and DevDependencies map each package to its version
+	// constraint, or for packages that are not hosted to a summary of
+	// their source such as "sdk: flutter". A package without a constraint
+	// maps to "".
 	Dependencies    map[string]string
 	DevDependencies map[string]string
-	Flutter         *FlutterConfig
-	Environment     map[string]string
+
+	// Specs lists the dependencies, dev_dependencies and
+	// dependency_overrides entries in file order.
+	Specs []DependencySpec
+
+	// Environment maps the environment entries, such as sdk, to their
+	// constraints.
+	Environment map[string]string
+	Flutter     *FlutterConfig
+
+	// LauncherIcons and NativeSplash are the flutter_launcher_icons and
+	// flutter_native_splash sections, or nil if there are none.
+	LauncherIcons map[string]interface{}
+	NativeSplash  map[string]interface{}
+
+	// Lines maps each top-level key to its line.
+	Lines map[string]int
+
+	dir string
+}
+
+// DependencySource is where a dependency is fetched from.
+type DependencySource string
+
+const (
+	SourceHosted DependencySource = "hosted"
+	SourceGit    DependencySource = "git"
+	SourcePath   DependencySource = "path"
+	SourceSDK    DependencySource = "sdk"
+)
+
+// DependencyKind is the section a dependency is declared in.
+type DependencyKind string
+
+const (
+	KindMain     DependencyKind = "dependencies"
+	KindDev      DependencyKind = "dev_dependencies"
+	KindOverride DependencyKind = "dependency_overrides"
+)
+
+// DependencySpec is an entry of a dependencies section.
+type DependencySpec struct {
+	Name   string
+	Kind   DependencyKind
+	Source DependencySource
+	// Constraint is the version constraint as written, or "" if none.
+	Constraint string
+	// Range is the parsed Constraint. It allows any version when the
+	// constraint is missing or invalid.
+	Range semver.Range
+	// URL is the git repository, or the server of a package not hosted on
+	// pub.dev.
+	URL string
+	// Ref is the git branch, tag or commit.
+	Ref string
+	// Path is the directory of a path dependency, or the package directory
+	// inside a git repository.
+	Path string
+	// SDK is the SDK providing an sdk dependency, such as flutter.
+	SDK  string
+	Line int
+}
+
+// Summary returns the constraint of a hosted dependency and a description
+// of the source of other dependencies, such as "git: https://...#main".
+func (d DependencySpec) Summary() string {
+	switch d.Source {
+	case SourceSDK:
+		return "sdk: " + d.SDK
+	case SourcePath:
+		return "path: " + d.Path
+	case SourceGit:
+		if d.Ref != "" {
+			return "git: " + d.URL + "#" + d.Ref
+		}
+		return "git: " + d.URL
+	}
+	return d.Constraint
 }
 
 type FlutterConfig struct {
 	UsesMaterialDesign bool
+	Generate           bool
 	Plugin             *PluginConfig
 	Assets             []string
 	Fonts              []FontConfig
@@ -39,129 +129,237 @@ type FontConfig struct {
 }
 
 type FontEntry struct {
+	Asset  string
 	Weight int
 	Style  string
 }
 
-func isTopLevel(line string) bool {
-	return len(line) > 0 && (line[0] == ' ' || line[0] == '\t') == false
-}
-
 func ParsePubspec(path string) (*Pubspec, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	content := string(data)
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
 	pubspec := &Pubspec{
 		Dependencies:    make(map[string]string),
 		DevDependencies: make(map[string]string),
 		Environment:     make(map[string]string),
+		Lines:           make(map[string]int),
+		dir:             filepath.Dir(path),
 	}
-
-	namePattern := regexp.MustCompile(`^name:\s*(.+)$`)
-	versionPattern := regexp.MustCompile(`^version:\s*(.+)$`)
-	descriptionPattern := regexp.MustCompile(`^description:\s*(.+)$`)
-	homepagePattern := regexp.MustCompile(`^homepage:\s*(.+)$`)
-	repositoryPattern := regexp.MustCompile(`^repository:\s*(.+)$`)
-	usesMaterialDesignPattern := regexp.MustCompile(`uses-material-design:\s*(true|false)`)
-
-	for _, line := range strings.Split(content, "\n") {
-		line = strings.TrimSpace(line)
-
-		if matches := namePattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Name = strings.TrimSpace(matches[1])
-		}
-		if matches := versionPattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Version = strings.TrimSpace(matches[1])
-		}
-		if matches := descriptionPattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Description = strings.TrimSpace(matches[1])
-		}
-		if matches := homepagePattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Homepage = strings.TrimSpace(matches[1])
-		}
-		if matches := repositoryPattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Repository = strings.TrimSpace(matches[1])
+	if len(doc.Content) == 0 {
+		return pubspec, nil
+	}
+	root := yamlValue(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		if root.Tag == "!!null" {
+			return pubspec, nil
 		}
-		if matches := usesMaterialDesignPattern.FindStringSubmatch(line); len(matches) > 1 {
-			pubspec.Flutter = &FlutterConfig{}
-			pubspec.Flutter.UsesMaterialDesign = matches[1] == "true"
+		return nil, fmt.Errorf("parse %s: line %d: expected a mapping", path, root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], yamlValue(root.Content[i+1])
+		pubspec.Lines[key.Value] = key.Line
+
+		switch key.Value {
+		case "name":
+			pubspec.Name = yamlScalar(value)
+		case "version":
+			pubspec.Version = yamlScalar(value)
+		case "description":
+			pubspec.Description = strings.TrimSpace(yamlScalar(value))
+		case "homepage":
+			pubspec.Homepage = yamlScalar(value)
+		case "repository":
+			pubspec.Repository = yamlScalar(value)
+		case "publish_to":
+			pubspec.PublishTo = yamlScalar(value)
+		case "environment":
+			eachYAMLEntry(value, func(key, value *yaml.Node) {
+				pubspec.Environment[key.Value] = yamlScalar(value)
+			})
+		case "dependencies", "dev_dependencies", "dependency_overrides":
+			kind := DependencyKind(key.Value)
+			eachYAMLEntry(value, func(key, value *yaml.Node) {
+				spec := dependencySpec(kind, key, value)
+				pubspec.Specs = append(pubspec.Specs, spec)
+				switch kind {
+				case KindMain:
+					pubspec.Dependencies[spec.Name] = spec.Summary()
+				case KindDev:
+					pubspec.DevDependencies[spec.Name] = spec.Summary()
+				}
+			})
+		case "flutter":
+			pubspec.Flutter = flutterConfig(value)
+		case "flutter_launcher_icons", "flutter_icons":
+			pubspec.LauncherIcons = yamlMap(value)
+		case "flutter_native_splash":
+			pubspec.NativeSplash = yamlMap(value)
 		}
 	}
 
-	depPattern := regexp.MustCompile(`^([a-zA-Z0-9_-]+):\s*(.+)$`)
-	simpleDepPattern := regexp.MustCompile(`^-\s*([a-zA-Z0-9_-]+):\s*(.+)$`)
+	return pubspec, nil
+}
 
-	currentSection := ""
-	for _, line := range strings.Split(content, "\n") {
-		trimmed := strings.TrimSpace(line)
+// dependencySpec reads the dependency entry key: value of a section.
+func dependencySpec(kind DependencyKind, key, value *yaml.Node) DependencySpec {
+	spec := DependencySpec{Name: key.Value, Kind: kind, Source: SourceHosted, Line: key.Line}
+
+	switch value.Kind {
+	case yaml.ScalarNode:
+		spec.Constraint = yamlScalar(value)
+	case yaml.MappingNode:
+		eachYAMLEntry(value, func(key, value *yaml.Node) {
+			switch key.Value {
+			case "version":
+				spec.Constraint = yamlScalar(value)
+			case "sdk":
+				spec.Source, spec.SDK = SourceSDK, yamlScalar(value)
+			case "path":
+				spec.Source, spec.Path = SourcePath, yamlScalar(value)
+			case "hosted":
+				// Either the server URL or a name and url mapping.
+				spec.URL = yamlScalar(value)
+				eachYAMLEntry(value, func(key, value *yaml.Node) {
+					if key.Value == "url" {
+						spec.URL = yamlScalar(value)
+					}
+				})
+			case "git":
+				// Either the repository URL or a url, ref and path mapping.
+				spec.Source, spec.URL = SourceGit, yamlScalar(value)
+				eachYAMLEntry(value, func(key, value *yaml.Node) {
+					switch key.Value {
+					case "url":
+						spec.URL = yamlScalar(value)
+					case "ref":
+						spec.Ref = yamlScalar(value)
+					case "path":
+						spec.Path = yamlScalar(value)
+					}
+				})
+			}
+		})
+	}
 
-		sectionPatterns := map[string]string{
-			"dependencies:":     "dependencies",
-			"dev_dependencies:": "dev_dependencies",
-			"flutter:":          "flutter",
-			"environment:":      "environment",
-		}
+	if r, err := semver.ParseRange(spec.Constraint); err == nil {
+		spec.Range = r
+	}
+	return spec
+}
 
-		if isTopLevel(line) {
-			for pattern, section := range sectionPatterns {
-				if trimmed == pattern {
-					currentSection = section
-					break
+func flutterConfig(value *yaml.Node) *FlutterConfig {
+	config := &FlutterConfig{}
+	eachYAMLEntry(value, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "uses-material-design":
+			config.UsesMaterialDesign = yamlScalar(value) == "true"
+		case "generate":
+			config.Generate = yamlScalar(value) == "true"
+		case "assets":
+			for _, item := range value.Content {
+				// An asset is a path, or a mapping with a path and flavors.
+				asset := yamlScalar(yamlValue(item))
+				eachYAMLEntry(yamlValue(item), func(key, value *yaml.Node) {
+					if key.Value == "path" {
+						asset = yamlScalar(value)
+					}
+				})
+				if asset != "" {
+					config.Assets = append(config.Assets, asset)
 				}
 			}
-		}
-
-		if currentSection == "dependencies" || currentSection == "dev_dependencies" {
-			if matches := depPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
-				depName := matches[1]
-				depVersion := strings.TrimSpace(matches[2])
-				if currentSection == "dependencies" {
-					pubspec.Dependencies[depName] = depVersion
-				} else {
-					pubspec.DevDependencies[depName] = depVersion
-				}
-			} else if matches := simpleDepPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
-				depName := matches[1]
-				depVersion := strings.TrimSpace(matches[2])
-				if currentSection == "dependencies" {
-					pubspec.Dependencies[depName] = depVersion
-				} else {
-					pubspec.DevDependencies[depName] = depVersion
-				}
+		case "fonts":
+			for _, item := range value.Content {
+				var font FontConfig
+				eachYAMLEntry(yamlValue(item), func(key, value *yaml.Node) {
+					switch key.Value {
+					case "family":
+						font.Family = yamlScalar(value)
+					case "fonts":
+						for _, item := range value.Content {
+							var entry FontEntry
+							eachYAMLEntry(yamlValue(item), func(key, value *yaml.Node) {
+								switch key.Value {
+								case "asset":
+									entry.Asset = yamlScalar(value)
+								case "weight":
+									entry.Weight, _ = strconv.Atoi(yamlScalar(value))
+								case "style":
+									entry.Style = yamlScalar(value)
+								}
+							})
+							font.Fonts = append(font.Fonts, entry)
+						}
+					}
+				})
+				config.Fonts = append(config.Fonts, font)
 			}
+		case "plugin":
+			config.Plugin = &PluginConfig{Platforms: make(map[string]PluginPlatformConfig)}
+			eachYAMLEntry(value, func(key, value *yaml.Node) {
+				if key.Value != "platforms" {
+					return
+				}
+				eachYAMLEntry(value, func(platform, value *yaml.Node) {
+					var pc PluginPlatformConfig
+					eachYAMLEntry(value, func(key, value *yaml.Node) {
+						if key.Value == "pluginClass" {
+							pc.PluginClass = yamlScalar(value)
+						}
+					})
+					config.Plugin.Platforms[platform.Value] = pc
+				})
+			})
 		}
+	})
+	return config
+}
 
-		if currentSection == "environment" {
-			envPattern := regexp.MustCompile(`^([a-zA-Z0-9_]+):\s*(.+)$`)
-			if matches := envPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
-				pubspec.Environment[matches[1]] = strings.TrimSpace(matches[2])
-			}
-		}
+// yamlValue follows aliases to the node they refer to.
+func yamlValue(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
 	}
+	return n
+}
 
-	if pubspec.Flutter == nil {
-		usesMaterialDesignPattern := regexp.MustCompile(`uses-material-design:\s*(true|false)`)
-		for _, line := range strings.Split(content, "\n") {
-			if strings.Contains(line, "flutter:") {
-				pubspec.Flutter = &FlutterConfig{UsesMaterialDesign: false}
-				break
-			}
-		}
-		for _, line := range strings.Split(content, "\n") {
-			if matches := usesMaterialDesignPattern.FindStringSubmatch(line); len(matches) > 1 {
-				if pubspec.Flutter == nil {
-					pubspec.Flutter = &FlutterConfig{}
-				}
-				pubspec.Flutter.UsesMaterialDesign = matches[1] == "true"
-				break
-			}
-		}
+// yamlScalar returns the text of a scalar node, or "" for null and other
+// kinds of node.
+func yamlScalar(n *yaml.Node) string {
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return ""
 	}
+	return n.Value
+}
 
-	return pubspec, nil
+// eachYAMLEntry calls fn for each key and value of a mapping node.
+func eachYAMLEntry(n *yaml.Node, fn func(key, value *yaml.Node)) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		fn(n.Content[i], yamlValue(n.Content[i+1]))
+	}
+}
+
+// yamlMap decodes a mapping node, returning nil for other nodes.
+func yamlMap(n *yaml.Node) map[string]interface{} {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	var m map[string]interface{}
+	if n.Decode(&m) != nil {
+		return nil
+	}
+	return m
 }
 
 func (p *Pubspec) HasDependency(dep string) bool {
@@ -184,32 +382,67 @@ func (p *Pubspec) HasLinter() bool {
 	return false
 }
 
+// HasIconConfig reports whether flutter_launcher_icons is configured, in
+// pubspec.yaml or in its own flutter_launcher_icons.yaml or per-flavor
+// flutter_launcher_icons-<flavor>.yaml.
 func (p *Pubspec) HasIconConfig() bool {
-	if p.HasDependency("flutter_launcher_icons") {
-		return true
+	return len(p.LauncherIcons) > 0 || p.hasConfigFile("flutter_launcher_icons")
+}
+
+// HasSplashConfig reports whether flutter_native_splash is configured, in
+// pubspec.yaml or in its own flutter_native_splash.yaml or per-flavor
+// flutter_native_splash-<flavor>.yaml.
+func (p *Pubspec) HasSplashConfig() bool {
+	return len(p.NativeSplash) > 0 || p.hasConfigFile("flutter_native_splash")
+}
+
+func (p *Pubspec) hasConfigFile(tool string) bool {
+	if p.dir == "" {
+		return false
 	}
-	if _, ok := p.DevDependencies["flutter_launcher_icons"]; ok {
+	if isFile(filepath.Join(p.dir, tool+".yaml")) {
 		return true
 	}
-	return false
+	matches, _ := filepath.Glob(filepath.Join(p.dir, tool+"-*.yaml"))
+	return len(matches) > 0
 }
 
-func (p *Pubspec) HasSplashConfig() bool {
-	return p.HasDependency("flutter_native_splash")
+// deprecatedPackages are discontinued packages with a maintained
+// replacement, mostly from the Flutter plugins moved to plus_plugins.
+var deprecatedPackages = []string{
+	"package_info", "device_info", "android_alarm_manager", "connectivity",
+	"share", "sensors", "battery", "android_intent", "wifi_info_flutter",
+	"firebase_admob", "flutter_webview_plugin",
 }
 
-func (p *Pubspec) HasDeprecatedPackage() bool {
-	deprecatedPackages := []string{"package_info", "android_alarm_manager", "device_info"}
-	for _, pkg := range deprecatedPackages {
-		if p.HasDependency(pkg) || p.HasDevDependency(pkg) {
-			return true
+// DeprecatedPackages returns the discontinued packages among the
+// dependencies and dev_dependencies, in file order.
+func (p *Pubspec) DeprecatedPackages() []string {
+	var found []string
+	for _, spec := range p.Specs {
+		if spec.Kind == KindOverride {
+			continue
+		}
+		for _, pkg := range deprecatedPackages {
+			if spec.Name == pkg {
+				found = append(found, pkg)
+			}
 		}
 	}
-	return false
+	return found
 }
 
+func (p *Pubspec) HasDeprecatedPackage() bool {
+	return len(p.DeprecatedPackages()) > 0
+}
+
+// debugPackages are test and tooling packages that belong in
+// dev_dependencies.
+var debugPackages = []string{"flutter_test", "flutter_driver", "integration_test", "mockito", "mocktail", "build_runner"}
+
+// HasDebugDepInMain reports whether a test or tooling package is declared
+// under dependencies, which builds it into the release app.
 func (p *Pubspec) HasDebugDepInMain() bool {
-	debugPackages := []string{"flutter_driver", "integration_test", "mockito"}
 	for _, pkg := range debugPackages {
 		if p.HasDependency(pkg) {
 			return true
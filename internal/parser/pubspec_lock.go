@@ -0,0 +1,275 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DependencyType is how pubspec.lock says a package was pulled in.
+type DependencyType string
+
+const (
+	DirectMain       DependencyType = "direct main"
+	DirectDev        DependencyType = "direct dev"
+	DirectOverridden DependencyType = "direct overridden"
+	Transitive       DependencyType = "transitive"
+)
+
+// PubspecLock is the content of a pubspec.lock.
+type PubspecLock struct {
+	// Packages lists the resolved packages by name.
+	Packages []LockedPackage
+	// SDKs maps the SDKs, dart and flutter, to the constraint every
+	// resolved package allows.
+	SDKs map[string]string
+
+	dir string
+}
+
+// LockedPackage is a package pinned in pubspec.lock.
+type LockedPackage struct {
+	Name       string
+	Version    string
+	Source     DependencySource
+	Dependency DependencyType
+	// SHA256 is the content hash of a hosted package archive.
+	SHA256 string
+	// URL is the package server of a hosted package or the repository of
+	// a git package.
+	URL string
+	// Ref is the git ref asked for and ResolvedRef the commit it resolved
+	// to.
+	Ref         string
+	ResolvedRef string
+	// Path is the directory of a path package, or the package directory
+	// inside a git repository.
+	Path string
+	// SDK is the SDK providing an sdk package, such as flutter.
+	SDK  string
+	Line int
+}
+
+// IsDirect reports whether the app's pubspec.yaml names the package.
+func (p LockedPackage) IsDirect() bool {
+	return p.Dependency != Transitive
+}
+
+// ParsePubspecLock parses the pubspec.lock at path.
+func ParsePubspecLock(path string) (*PubspecLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	lock := &PubspecLock{SDKs: make(map[string]string), dir: filepath.Dir(path)}
+	if len(doc.Content) == 0 {
+		return lock, nil
+	}
+	root := yamlValue(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		if root.Tag == "!!null" {
+			return lock, nil
+		}
+		return nil, fmt.Errorf("parse %s: line %d: expected a mapping", path, root.Line)
+	}
+
+	eachYAMLEntry(root, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "packages":
+			eachYAMLEntry(value, func(key, value *yaml.Node) {
+				lock.Packages = append(lock.Packages, lockedPackage(key, value))
+			})
+		case "sdks":
+			eachYAMLEntry(value, func(key, value *yaml.Node) {
+				lock.SDKs[key.Value] = yamlScalar(value)
+			})
+		}
+	})
+	sort.Slice(lock.Packages, func(i, j int) bool {
+		return lock.Packages[i].Name < lock.Packages[j].Name
+	})
+	return lock, nil
+}
+
+// lockedPackage reads the packages entry key: value.
+func lockedPackage(key, value *yaml.Node) LockedPackage {
+	pkg := LockedPackage{Name: key.Value, Line: key.Line}
+	var description *yaml.Node
+	eachYAMLEntry(value, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "version":
+			pkg.Version = yamlScalar(value)
+		case "source":
+			pkg.Source = DependencySource(yamlScalar(value))
+		case "dependency":
+			pkg.Dependency = DependencyType(yamlScalar(value))
+		case "description":
+			description = value
+		}
+	})
+	if description == nil {
+		return pkg
+	}
+
+	// The description of an sdk package is the SDK name; the others are
+	// mappings whose keys depend on the source.
+	if pkg.Source == SourceSDK {
+		pkg.SDK = yamlScalar(description)
+	}
+	eachYAMLEntry(description, func(key, value *yaml.Node) {
+		switch key.Value {
+		case "sha256":
+			pkg.SHA256 = yamlScalar(value)
+		case "url":
+			pkg.URL = yamlScalar(value)
+		case "ref":
+			pkg.Ref = yamlScalar(value)
+		case "resolved-ref":
+			pkg.ResolvedRef = yamlScalar(value)
+		case "path":
+			pkg.Path = yamlScalar(value)
+		}
+	})
+	return pkg
+}
+
+// Package returns the package named name, or nil.
+func (l *PubspecLock) Package(name string) *LockedPackage {
+	i := sort.Search(len(l.Packages), func(i int) bool { return l.Packages[i].Name >= name })
+	if i < len(l.Packages) && l.Packages[i].Name == name {
+		return &l.Packages[i]
+	}
+	return nil
+}
+
+// Requirements maps each resolved package to the resolved packages its
+// pubspec.yaml lists under dependencies. Package directories are taken
+// from .dart_tool/package_config.json next to the lock file, or else
+// looked up in the pub cache, relative to the project for path packages,
+// and in flutterSDK for Flutter SDK packages. A package whose directory
+// cannot be found has no entry.
+func (l *PubspecLock) Requirements(flutterSDK string) map[string][]string {
+	dirs := readPackageConfig(filepath.Join(l.dir, ".dart_tool", "package_config.json"))
+	requirements := make(map[string][]string)
+	for _, pkg := range l.Packages {
+		dir, ok := dirs[pkg.Name]
+		if !ok {
+			dir = l.packageDir(pkg, flutterSDK)
+		}
+		if dir == "" {
+			continue
+		}
+		pubspec, err := ParsePubspec(filepath.Join(dir, "pubspec.yaml"))
+		if err != nil {
+			continue
+		}
+		deps := []string{}
+		for _, spec := range pubspec.Specs {
+			if spec.Kind == KindMain && l.Package(spec.Name) != nil {
+				deps = append(deps, spec.Name)
+			}
+		}
+		sort.Strings(deps)
+		requirements[pkg.Name] = deps
+	}
+	return requirements
+}
+
+// packageDir guesses where pub put pkg when there is no package config.
+func (l *PubspecLock) packageDir(pkg LockedPackage, flutterSDK string) string {
+	var dir string
+	switch pkg.Source {
+	case SourceHosted:
+		dir = filepath.Join(PubCacheDir(), "hosted", hostedCacheDir(pkg.URL), pkg.Name+"-"+pkg.Version)
+	case SourceGit:
+		if pkg.ResolvedRef == "" {
+			return ""
+		}
+		repo := strings.TrimSuffix(pkg.URL[strings.LastIndex(pkg.URL, "/")+1:], ".git")
+		dir = filepath.Join(PubCacheDir(), "git", repo+"-"+pkg.ResolvedRef, filepath.FromSlash(pkg.Path))
+	case SourcePath:
+		dir = filepath.FromSlash(pkg.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(l.dir, dir)
+		}
+	case SourceSDK:
+		if pkg.SDK != "flutter" || flutterSDK == "" {
+			return ""
+		}
+		dir = filepath.Join(flutterSDK, "packages", pkg.Name)
+	}
+	if !isFile(filepath.Join(dir, "pubspec.yaml")) {
+		return ""
+	}
+	return dir
+}
+
+// hostedCacheDir returns the pub cache directory of the package server at
+// serverURL, which is the server URL without its scheme and with reserved
+// characters replaced by their decimal code, such as localhost%588080.
+func hostedCacheDir(serverURL string) string {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" || u.Host == "pub.dev" || u.Host == "pub.dartlang.org" {
+		return "pub.dev"
+	}
+	var b strings.Builder
+	for _, r := range strings.TrimSuffix(u.Host+u.Path, "/") {
+		if strings.ContainsRune(`<>:"\/|?*%`, r) {
+			fmt.Fprintf(&b, "%%%d", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// readPackageConfig returns the package directories listed in the
+// package_config.json at path, which pub get writes to .dart_tool.
+func readPackageConfig(path string) map[string]string {
+	dirs := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dirs
+	}
+	var config struct {
+		Packages []struct {
+			Name    string `json:"name"`
+			RootURI string `json:"rootUri"`
+		} `json:"packages"`
+	}
+	if json.Unmarshal(data, &config) != nil {
+		return dirs
+	}
+
+	for _, p := range config.Packages {
+		u, err := url.Parse(p.RootURI)
+		if err != nil {
+			continue
+		}
+		// Relative URIs resolve against the directory of the file.
+		dir := filepath.Join(filepath.Dir(path), filepath.FromSlash(u.Path))
+		if u.Scheme == "file" {
+			dir = filepath.FromSlash(u.Path)
+			// file:///C:/... on Windows.
+			if len(dir) > 2 && dir[0] == filepath.Separator && dir[2] == ':' {
+				dir = dir[1:]
+			}
+		} else if u.Scheme != "" {
+			continue
+		}
+		dirs[p.Name] = dir
+	}
+	return dirs
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testPubspecLock = `# Generated by pub
+# See https://dart.dev/tools/pub/glossary#lockfile
+packages:
+  image_picker:
+    dependency: "direct main"
+    description:
+      name: image_picker
+      sha256: "021834d9c0c3de46bf0fe40341fa07168407f694d9b2bb18d532dc1261867f7a"
+      url: "https://pub.dev"
+    source: hosted
+    version: "1.1.2"
+  camera_kit:
+    dependency: "direct main"
+    description:
+      path: "."
+      ref: main
+      resolved-ref: "9f1c2e4"
+      url: "https://github.com/example/camera_kit.git"
+    source: git
+    version: "0.3.0"
+  flutter:
+    dependency: "direct main"
+    description: flutter
+    source: sdk
+    version: "0.0.0"
+  flutter_lints:
+    dependency: "direct dev"
+    description:
+      name: flutter_lints
+      sha256: "3f41d009ba7172d5ff9be5f6e6e6abb4300e263aab8866d2a0842ed2a70f8f0c"
+      url: "https://pub.dev"
+    source: hosted
+    version: "4.0.0"
+  image_picker_android:
+    dependency: transitive
+    description:
+      name: image_picker_android
+      sha256: "8c5abf0dcc24fe6e8e0b4a5c0b51a5cf30cefdf6407a3213dae61edc75a70f56"
+      url: "https://pub.dev"
+    source: hosted
+    version: "0.8.12+12"
+  shared:
+    dependency: "direct overridden"
+    description:
+      path: "../shared"
+      relative: true
+    source: path
+    version: "1.0.0"
+sdks:
+  dart: ">=3.4.0 <4.0.0"
+  flutter: ">=3.22.0"
+`
+
+func TestParsePubspecLock(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"pubspec.lock": testPubspecLock})
+
+	lock, err := ParsePubspecLock(filepath.Join(dir, "pubspec.lock"))
+	if err != nil {
+		t.Fatalf("ParsePubspecLock failed: %v", err)
+	}
+
+	var names []string
+	for _, pkg := range lock.Packages {
+		names = append(names, pkg.Name)
+	}
+	want := []string{"camera_kit", "flutter", "flutter_lints", "image_picker", "image_picker_android", "shared"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected packages %v, got %v", want, names)
+	}
+
+	picker := lock.Package("image_picker")
+	if picker == nil || picker.Version != "1.1.2" || picker.Source != SourceHosted || picker.Dependency != DirectMain ||
+		picker.SHA256 != "021834d9c0c3de46bf0fe40341fa07168407f694d9b2bb18d532dc1261867f7a" || picker.URL != "https://pub.dev" || picker.Line != 4 {
+		t.Errorf("unexpected hosted package %+v", picker)
+	}
+	if p := lock.Package("image_picker_android"); p == nil || p.IsDirect() || p.Version != "0.8.12+12" {
+		t.Errorf("unexpected transitive package %+v", p)
+	}
+	if p := lock.Package("camera_kit"); p == nil || p.Source != SourceGit || p.Ref != "main" || p.ResolvedRef != "9f1c2e4" || p.Path != "." {
+		t.Errorf("unexpected git package %+v", p)
+	}
+	if p := lock.Package("flutter"); p == nil || p.Source != SourceSDK || p.SDK != "flutter" {
+		t.Errorf("unexpected sdk package %+v", p)
+	}
+	if p := lock.Package("shared"); p == nil || p.Source != SourcePath || p.Path != "../shared" || p.Dependency != DirectOverridden || !p.IsDirect() {
+		t.Errorf("unexpected path package %+v", p)
+	}
+	if p := lock.Package("flutter_lints"); p == nil || p.Dependency != DirectDev {
+		t.Errorf("unexpected dev package %+v", p)
+	}
+	if lock.Package("missing") != nil {
+		t.Error("expected no package for an unknown name")
+	}
+
+	if lock.SDKs["dart"] != ">=3.4.0 <4.0.0" || lock.SDKs["flutter"] != ">=3.22.0" {
+		t.Errorf("unexpected sdks %v", lock.SDKs)
+	}
+
+	bad := writeFiles(t, map[string]string{"pubspec.lock": "- a\n- b\n"})
+	if _, err := ParsePubspecLock(filepath.Join(bad, "pubspec.lock")); err == nil {
+		t.Error("expected an error for a lock file that is not a mapping")
+	}
+}
+
+func TestPubspecLockRequirements(t *testing.T) {
+	cache := writeFiles(t, map[string]string{
+		"hosted/pub.dev/image_picker_android-0.8.12+12/pubspec.yaml": "name: image_picker_android\ndependencies:\n  flutter:\n    sdk: flutter\n",
+		"git/camera_kit-9f1c2e4/pubspec.yaml":                        "name: camera_kit\ndependencies:\n  image_picker_android: ^0.8.0\n  unrelated: ^1.0.0\n",
+	})
+	t.Setenv("PUB_CACHE", cache)
+
+	dir := writeFiles(t, map[string]string{
+		"app/pubspec.lock": testPubspecLock,
+		// image_picker is listed in the package config; the others are
+		// looked up by source.
+		"app/.dart_tool/package_config.json": `{"configVersion": 2, "packages": [
+  {"name": "image_picker", "rootUri": "../../image_picker-1.1.2", "packageUri": "lib/"},
+  {"name": "app", "rootUri": "../", "packageUri": "lib/"}
+]}`,
+		"image_picker-1.1.2/pubspec.yaml":   "name: image_picker\ndependencies:\n  flutter:\n    sdk: flutter\n  image_picker_android: ^0.8.12\ndev_dependencies:\n  flutter_lints: ^4.0.0\n",
+		"shared/pubspec.yaml":               "name: shared\ndependencies:\n  flutter:\n    sdk: flutter\n",
+		"sdk/packages/flutter/pubspec.yaml": "name: flutter\n",
+	})
+
+	lock, err := ParsePubspecLock(filepath.Join(dir, "app", "pubspec.lock"))
+	if err != nil {
+		t.Fatalf("ParsePubspecLock failed: %v", err)
+	}
+	got := lock.Requirements(filepath.Join(dir, "sdk"))
+	want := map[string][]string{
+		"image_picker":         {"flutter", "image_picker_android"},
+		"image_picker_android": {"flutter"},
+		"camera_kit":           {"image_picker_android"},
+		"shared":               {"flutter"},
+		"flutter":              {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Requirements() = %v, want %v", got, want)
+	}
+}
+
+func TestHostedCacheDir(t *testing.T) {
+	tests := map[string]string{
+		"https://pub.dev":                     "pub.dev",
+		"https://pub.dartlang.org":            "pub.dev",
+		"":                                    "pub.dev",
+		"https://pub.example.com/":            "pub.example.com",
+		"http://localhost:8080":               "localhost%588080",
+		"https://example.com/artifactory/pub": "example.com%47artifactory%47pub",
+	}
+	for url, want := range tests {
+		if got := hostedCacheDir(url); got != want {
+			t.Errorf("hostedCacheDir(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
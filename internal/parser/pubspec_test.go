@@ -75,3 +75,170 @@ func TestParsePubspec(t *testing.T) {
 		}
 	})
 }
+
+func TestParsePubspecDependencySources(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"pubspec.yaml": `name: shop
+version: 2.3.0+41
+publish_to: none
+
+environment:
+  sdk: ">=3.2.0 <4.0.0"
+  flutter: ">=3.16.0"
+
+dependencies:
+  flutter:
+    sdk: flutter
+  http: ^1.1.0
+  intl:
+  device_info: any
+  shared:
+    path: ../shared
+  charts:
+    git:
+      url: https://github.com/example/charts.git
+      ref: v2
+      path: packages/charts
+  internal_ui:
+    hosted: https://pub.example.com
+    version: ">=1.0.0 <1.5.0"
+  integration_test:
+    sdk: flutter
+
+dev_dependencies:
+  flutter_test:
+    sdk: flutter
+  flutter_lints: ^3.0.0
+
+dependency_overrides:
+  http: 1.1.2
+
+flutter:
+  uses-material-design: true
+  generate: true
+  assets:
+    - assets/images/
+    - path: assets/dev/
+      flavors: [staging]
+  fonts:
+    - family: Inter
+      fonts:
+        - asset: fonts/Inter-Bold.ttf
+          weight: 700
+`,
+		"flutter_launcher_icons-prod.yaml": "flutter_launcher_icons:\n  android: true\n",
+	})
+
+	pubspec, err := ParsePubspec(filepath.Join(dir, "pubspec.yaml"))
+	if err != nil {
+		t.Fatalf("ParsePubspec failed: %v", err)
+	}
+
+	if pubspec.Environment["sdk"] != ">=3.2.0 <4.0.0" || pubspec.Environment["flutter"] != ">=3.16.0" || pubspec.PublishTo != "none" {
+		t.Errorf("unexpected environment %v, publish_to %q", pubspec.Environment, pubspec.PublishTo)
+	}
+	if pubspec.Lines["version"] != 2 || pubspec.Lines["dependencies"] != 9 {
+		t.Errorf("unexpected lines %v", pubspec.Lines)
+	}
+
+	specs := map[string]DependencySpec{}
+	for _, spec := range pubspec.Specs {
+		specs[string(spec.Kind)+"/"+spec.Name] = spec
+	}
+	if len(specs) != 11 {
+		t.Errorf("expected 11 specs, got %d: %+v", len(specs), pubspec.Specs)
+	}
+	tests := []struct {
+		key        string
+		source     DependencySource
+		constraint string
+		summary    string
+		line       int
+	}{
+		{"dependencies/flutter", SourceSDK, "", "sdk: flutter", 10},
+		{"dependencies/http", SourceHosted, "^1.1.0", "^1.1.0", 12},
+		{"dependencies/intl", SourceHosted, "", "", 13},
+		{"dependencies/shared", SourcePath, "", "path: ../shared", 15},
+		{"dependencies/charts", SourceGit, "", "git: https://github.com/example/charts.git#v2", 17},
+		{"dependencies/internal_ui", SourceHosted, ">=1.0.0 <1.5.0", ">=1.0.0 <1.5.0", 22},
+		{"dev_dependencies/flutter_lints", SourceHosted, "^3.0.0", "^3.0.0", 31},
+		{"dependency_overrides/http", SourceHosted, "1.1.2", "1.1.2", 34},
+	}
+	for _, tt := range tests {
+		spec, ok := specs[tt.key]
+		if !ok {
+			t.Errorf("%s: missing", tt.key)
+			continue
+		}
+		if spec.Source != tt.source || spec.Constraint != tt.constraint || spec.Summary() != tt.summary || spec.Line != tt.line {
+			t.Errorf("%s: unexpected spec %+v (summary %q)", tt.key, spec, spec.Summary())
+		}
+	}
+	if charts := specs["dependencies/charts"]; charts.Path != "packages/charts" {
+		t.Errorf("expected the git package path, got %q", charts.Path)
+	}
+	if ui := specs["dependencies/internal_ui"]; ui.URL != "https://pub.example.com" || ui.Range.String() != ">=1.0.0 <1.5.0" {
+		t.Errorf("unexpected hosted spec %+v", ui)
+	}
+
+	if _, ok := pubspec.Dependencies["sdk"]; ok {
+		t.Error("nested keys should not be read as dependencies")
+	}
+	if pubspec.Dependencies["http"] != "^1.1.0" || !pubspec.HasDevDependency("flutter_test") {
+		t.Errorf("unexpected dependency maps %v %v", pubspec.Dependencies, pubspec.DevDependencies)
+	}
+
+	if !pubspec.Flutter.UsesMaterialDesign || !pubspec.Flutter.Generate {
+		t.Errorf("unexpected flutter section %+v", pubspec.Flutter)
+	}
+	if len(pubspec.Flutter.Assets) != 2 || pubspec.Flutter.Assets[1] != "assets/dev/" {
+		t.Errorf("unexpected assets %v", pubspec.Flutter.Assets)
+	}
+	if len(pubspec.Flutter.Fonts) != 1 || pubspec.Flutter.Fonts[0].Fonts[0].Weight != 700 {
+		t.Errorf("unexpected fonts %+v", pubspec.Flutter.Fonts)
+	}
+
+	if !pubspec.HasIconConfig() {
+		t.Error("expected the flavor icon config file to count")
+	}
+	if pubspec.HasSplashConfig() {
+		t.Error("expected no splash config")
+	}
+	if got := pubspec.DeprecatedPackages(); len(got) != 1 || got[0] != "device_info" {
+		t.Errorf("DeprecatedPackages = %v", got)
+	}
+	if !pubspec.HasDebugDepInMain() {
+		t.Error("expected integration_test under dependencies to be reported")
+	}
+}
+
+func TestParsePubspecSections(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"pubspec.yaml": `name: app
+dev_dependencies:
+  flutter_launcher_icons: ^0.13.1
+  mockito: ^5.4.0
+flutter_native_splash:
+  color: "#ffffff"
+`,
+	})
+
+	pubspec, err := ParsePubspec(filepath.Join(dir, "pubspec.yaml"))
+	if err != nil {
+		t.Fatalf("ParsePubspec failed: %v", err)
+	}
+	if pubspec.HasIconConfig() {
+		t.Error("a dependency without configuration is not an icon config")
+	}
+	if !pubspec.HasSplashConfig() || pubspec.NativeSplash["color"] != "#ffffff" {
+		t.Errorf("expected the splash section, got %v", pubspec.NativeSplash)
+	}
+	if pubspec.HasDebugDepInMain() {
+		t.Error("dev_dependencies should not count as debug dependencies in main")
+	}
+
+	list := writeFiles(t, map[string]string{"pubspec.yaml": "- a\n- b\n"})
+	if _, err := ParsePubspec(filepath.Join(list, "pubspec.yaml")); err == nil {
+		t.Error("expected an error for a pubspec that is not a mapping")
+	}
+}
@@ -0,0 +1,385 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ricky-irfandi/fsct/internal/plist"
+)
+
+// XcodeProject is the content of an Xcode project.pbxproj.
+type XcodeProject struct {
+	// Dir is the directory containing the .xcodeproj bundle, which build
+	// settings call SRCROOT.
+	Dir  string
+	Name string
+	// Configurations are the project-level build configurations, which
+	// every target inherits.
+	Configurations []BuildConfiguration
+	Targets        []XcodeTarget
+}
+
+// XcodeTarget is a native target, such as the Runner app.
+type XcodeTarget struct {
+	Name string
+	// ProductType is the kind of product, such as
+	// com.apple.product-type.application.
+	ProductType    string
+	Configurations []BuildConfiguration
+	// Resources are the paths of the files the target's Copy Bundle
+	// Resources phase copies into the product.
+	Resources []string
+}
+
+// BuildConfiguration is a named set of build settings, such as Release.
+type BuildConfiguration struct {
+	Name string
+	// BaseConfiguration is the path of the xcconfig file the configuration
+	// is based on, or "".
+	BaseConfiguration string
+	// Settings are the build settings as written. Settings with a
+	// condition, such as CODE_SIGN_IDENTITY[sdk=iphoneos*], are left out.
+	Settings map[string]string
+	// Lines maps each setting to its line in project.pbxproj.
+	Lines map[string]int
+}
+
+// IsApplication reports whether t builds an app.
+func (t *XcodeTarget) IsApplication() bool {
+	return t.ProductType == "com.apple.product-type.application"
+}
+
+// Configuration returns the build configuration named name, or nil.
+func (t *XcodeTarget) Configuration(name string) *BuildConfiguration {
+	return findConfiguration(t.Configurations, name)
+}
+
+func findConfiguration(configs []BuildConfiguration, name string) *BuildConfiguration {
+	for i := range configs {
+		if configs[i].Name == name {
+			return &configs[i]
+		}
+	}
+	return nil
+}
+
+// ParseXcodeProject parses the project.pbxproj at path, inside a
+// .xcodeproj bundle.
+func ParseXcodeProject(path string) (*XcodeProject, error) {
+	root, err := plist.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	objects := root.Get("objects")
+	main := objects.Get(root.Get("rootObject").String())
+	if main.Get("isa").String() != "PBXProject" {
+		return nil, fmt.Errorf("%s: no PBXProject root object", path)
+	}
+
+	bundle := filepath.Dir(path)
+	p := &XcodeProject{
+		Dir:  filepath.Dir(bundle),
+		Name: strings.TrimSuffix(filepath.Base(bundle), ".xcodeproj"),
+	}
+	files := make(map[string]string)
+	collectFilePaths(objects, main.Get("mainGroup").String(), p.Dir, p.Dir, files)
+
+	p.Configurations = buildConfigurations(objects, main.Get("buildConfigurationList").String(), files)
+	for _, item := range main.Get("targets").Items() {
+		target := objects.Get(item.String())
+		if target.Get("isa").String() != "PBXNativeTarget" {
+			continue
+		}
+		p.Targets = append(p.Targets, XcodeTarget{
+			Name:           target.Get("name").String(),
+			ProductType:    target.Get("productType").String(),
+			Configurations: buildConfigurations(objects, target.Get("buildConfigurationList").String(), files),
+			Resources:      resourceFiles(objects, target, files),
+		})
+	}
+	return p, nil
+}
+
+// collectFilePaths records the path of each file reference under the group
+// with the given id, whose directory is dir. Paths relative to the project
+// are relative to srcroot.
+func collectFilePaths(objects *plist.Value, id, dir, srcroot string, files map[string]string) {
+	for _, child := range objects.Get(id).Get("children").Items() {
+		ref := child.String()
+		obj := objects.Get(ref)
+		path := dir
+		if p := obj.Get("path").String(); p != "" {
+			switch obj.Get("sourceTree").String() {
+			case "<absolute>":
+				path = p
+			case "SOURCE_ROOT":
+				path = filepath.Join(srcroot, filepath.FromSlash(p))
+			default:
+				path = filepath.Join(dir, filepath.FromSlash(p))
+			}
+		}
+		switch obj.Get("isa").String() {
+		case "PBXGroup", "PBXVariantGroup":
+			collectFilePaths(objects, ref, path, srcroot, files)
+		case "PBXFileReference":
+			files[ref] = path
+		}
+	}
+}
+
+// resourceFiles returns the paths of the files in the resources build
+// phases of target. Localized files, whose build file refers to a variant
+// group, are left out.
+func resourceFiles(objects, target *plist.Value, files map[string]string) []string {
+	var paths []string
+	for _, item := range target.Get("buildPhases").Items() {
+		phase := objects.Get(item.String())
+		if phase.Get("isa").String() != "PBXResourcesBuildPhase" {
+			continue
+		}
+		for _, file := range phase.Get("files").Items() {
+			if path, ok := files[objects.Get(file.String()).Get("fileRef").String()]; ok {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+func buildConfigurations(objects *plist.Value, listID string, files map[string]string) []BuildConfiguration {
+	var configs []BuildConfiguration
+	for _, item := range objects.Get(listID).Get("buildConfigurations").Items() {
+		obj := objects.Get(item.String())
+		config := BuildConfiguration{
+			Name:              obj.Get("name").String(),
+			BaseConfiguration: files[obj.Get("baseConfigurationReference").String()],
+			Settings:          make(map[string]string),
+			Lines:             make(map[string]int),
+		}
+		settings := obj.Get("buildSettings")
+		for _, key := range settings.Keys() {
+			if strings.Contains(key, "[") {
+				continue
+			}
+			value := settings.Get(key)
+			config.Settings[key] = value.String()
+			if value.Kind() == plist.KindArray {
+				config.Settings[key] = strings.Join(value.Strings(), " ")
+			}
+			config.Lines[key] = value.Line()
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// AppTarget returns the target that builds the app: Runner, or else the
+// first application target. It returns nil if there is none.
+func (p *XcodeProject) AppTarget() *XcodeTarget {
+	var app *XcodeTarget
+	for i := range p.Targets {
+		t := &p.Targets[i]
+		if t.Name == "Runner" {
+			return t
+		}
+		if app == nil && t.IsApplication() {
+			app = t
+		}
+	}
+	return app
+}
+
+// BuildSettings resolves the settings target builds with in the named
+// configuration, layered as Xcode does: defaults, then the project
+// configuration's xcconfig file and settings, then the target
+// configuration's. $(inherited) takes the value of the layer below.
+// Included xcconfig files that do not exist, such as
+// Flutter/Generated.xcconfig before flutter pub get, are skipped.
+func (p *XcodeProject) BuildSettings(target *XcodeTarget, configuration string) BuildSettings {
+	s := BuildSettings{
+		"SRCROOT":              p.Dir,
+		"PROJECT_DIR":          p.Dir,
+		"PROJECT_NAME":         p.Name,
+		"TARGET_NAME":          target.Name,
+		"CONFIGURATION":        configuration,
+		"PRODUCT_NAME":         "$(TARGET_NAME)",
+		"EXECUTABLE_NAME":      "$(PRODUCT_NAME)",
+		"PRODUCT_MODULE_NAME":  "$(PRODUCT_NAME:c99extidentifier)",
+		"DEVELOPMENT_LANGUAGE": "en",
+		"SDKROOT":              "iphoneos",
+	}
+	if target.IsApplication() {
+		s["PRODUCT_BUNDLE_PACKAGE_TYPE"] = "APPL"
+	}
+	for _, config := range []*BuildConfiguration{findConfiguration(p.Configurations, configuration), target.Configuration(configuration)} {
+		if config == nil {
+			continue
+		}
+		if config.BaseConfiguration != "" {
+			s.include(config.BaseConfiguration, 0)
+		}
+		for name, value := range config.Settings {
+			s.set(name, value)
+		}
+	}
+	return s
+}
+
+// BuildSettings maps build setting names to their values as written, with
+// $(inherited) applied.
+type BuildSettings map[string]string
+
+// set assigns value to name, replacing $(inherited) with the current
+// value.
+func (s BuildSettings) set(name, value string) {
+	for _, inherited := range []string{"$(inherited)", "${inherited}"} {
+		value = strings.ReplaceAll(value, inherited, s[name])
+	}
+	s[name] = strings.TrimSpace(value)
+}
+
+var (
+	xcconfigInclude = regexp.MustCompile(`^#include(\?)?\s+"([^"]+)"`)
+	xcconfigSetting = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=(.*)$`)
+)
+
+// include applies the xcconfig file at path.
+func (s BuildSettings) include(path string, depth int) {
+	f, err := os.Open(path)
+	if err != nil || depth > 10 {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := xcconfigInclude.FindStringSubmatch(line); m != nil {
+			included := filepath.FromSlash(m[2])
+			if !filepath.IsAbs(included) {
+				included = filepath.Join(filepath.Dir(path), included)
+			}
+			s.include(included, depth+1)
+			continue
+		}
+		// Everything after // is a comment, even in a URL.
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		if m := xcconfigSetting.FindStringSubmatch(line); m != nil {
+			s.set(m[1], strings.TrimSuffix(strings.TrimSpace(m[2]), ";"))
+		}
+	}
+}
+
+// Get returns the value of the setting name with references expanded.
+func (s BuildSettings) Get(name string) string {
+	return s.Expand(s[name])
+}
+
+// Expand replaces the $(NAME) and ${NAME} references in value with the
+// settings they name, applying modifiers such as
+// $(PRODUCT_NAME:rfc1034identifier). References to settings that are not
+// defined are left as written.
+func (s BuildSettings) Expand(value string) string {
+	return s.expand(value, 0)
+}
+
+func (s BuildSettings) expand(value string, depth int) string {
+	if depth > 10 || !strings.Contains(value, "$") {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 == len(value) || (value[i+1] != '(' && value[i+1] != '{') {
+			b.WriteByte(value[i])
+			continue
+		}
+		end := closingBracket(value, i+1)
+		if end < 0 {
+			b.WriteString(value[i:])
+			break
+		}
+		ref := s.expand(value[i+2:end], depth+1)
+		name, modifiers, _ := strings.Cut(ref, ":")
+		v, ok := s[name]
+		switch {
+		case ok:
+			v = s.expand(v, depth+1)
+		case strings.Contains(modifiers, "default="):
+		default:
+			b.WriteString(value[i : end+1])
+			i = end
+			continue
+		}
+		if modifiers != "" {
+			for _, m := range strings.Split(modifiers, ":") {
+				v = applyModifier(v, m)
+			}
+		}
+		b.WriteString(v)
+		i = end
+	}
+	return b.String()
+}
+
+// closingBracket returns the index of the bracket closing the one at
+// open, allowing nested references, or -1.
+func closingBracket(s string, open int) int {
+	closing := byte(')')
+	if s[open] == '{' {
+		closing = '}'
+	}
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case s[open]:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// applyModifier applies a build setting modifier, such as lower or
+// rfc1034identifier, to v. Unknown modifiers leave v unchanged.
+func applyModifier(v, modifier string) string {
+	switch {
+	case modifier == "lower":
+		return strings.ToLower(v)
+	case modifier == "upper":
+		return strings.ToUpper(v)
+	case modifier == "rfc1034identifier":
+		return strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '.') {
+				return r
+			}
+			return '-'
+		}, v)
+	case modifier == "c99extidentifier" || modifier == "identifier":
+		v = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+				return r
+			}
+			return '_'
+		}, v)
+		if v != "" && unicode.IsDigit(rune(v[0])) {
+			v = "_" + v
+		}
+		return v
+	case strings.HasPrefix(modifier, "default="):
+		if v == "" {
+			return strings.TrimPrefix(modifier, "default=")
+		}
+	}
+	return v
+}
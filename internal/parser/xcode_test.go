@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseXcodeProject(t *testing.T) {
+	ios := filepath.Join(getTestdataDir(t), "ios")
+	xcode, err := ParseXcodeProject(filepath.Join(ios, "Runner.xcodeproj", "project.pbxproj"))
+	if err != nil {
+		t.Fatalf("ParseXcodeProject failed: %v", err)
+	}
+
+	if xcode.Name != "Runner" || xcode.Dir != ios {
+		t.Errorf("unexpected project %q in %q", xcode.Name, xcode.Dir)
+	}
+	if len(xcode.Targets) != 2 || len(xcode.Configurations) != 3 {
+		t.Fatalf("expected 2 targets and 3 project configurations, got %+v", xcode)
+	}
+
+	target := xcode.AppTarget()
+	if target == nil || target.Name != "Runner" || !target.IsApplication() {
+		t.Fatalf("expected the Runner app target, got %+v", target)
+	}
+	release := target.Configuration("Release")
+	if release == nil {
+		t.Fatal("expected a Release configuration")
+	}
+	if want := filepath.Join(ios, "Flutter", "Release.xcconfig"); release.BaseConfiguration != want {
+		t.Errorf("BaseConfiguration = %q, want %q", release.BaseConfiguration, want)
+	}
+	if release.Lines["IPHONEOS_DEPLOYMENT_TARGET"] != 145 {
+		t.Errorf("expected IPHONEOS_DEPLOYMENT_TARGET on line 145, got %d", release.Lines["IPHONEOS_DEPLOYMENT_TARGET"])
+	}
+	if _, ok := release.Settings["CODE_SIGN_IDENTITY[sdk=iphoneos*]"]; ok {
+		t.Error("conditional settings should be left out")
+	}
+
+	tests := []struct {
+		configuration, name, want string
+	}{
+		{"Release", "IPHONEOS_DEPLOYMENT_TARGET", "12.0"},
+		{"Profile", "IPHONEOS_DEPLOYMENT_TARGET", "11.0"},
+		{"Release", "PRODUCT_BUNDLE_IDENTIFIER", "com.example.sampleApp"},
+		{"Debug", "PRODUCT_BUNDLE_IDENTIFIER", "com.example.sampleApp.debug"},
+		{"Release", "TARGETED_DEVICE_FAMILY", "1,2"},
+		{"Release", "CODE_SIGN_ENTITLEMENTS", "Runner/Runner.entitlements"},
+		{"Release", "MARKETING_VERSION", "2.1.0"},
+		{"Release", "CURRENT_PROJECT_VERSION", "42"},
+		{"Release", "PRODUCT_NAME", "Runner"},
+		{"Release", "PRODUCT_MODULE_NAME", "Runner"},
+		{"Release", "GCC_PREPROCESSOR_DEFINITIONS", "FLUTTER=1 RELEASE=1"},
+		{"Debug", "DART_OBFUSCATION", "false"},
+	}
+	for _, tt := range tests {
+		settings := xcode.BuildSettings(target, tt.configuration)
+		if got := settings.Get(tt.name); got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.configuration, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildSettingsExpand(t *testing.T) {
+	settings := BuildSettings{
+		"PRODUCT_NAME":  "My App_2",
+		"BUNDLE_PREFIX": "com.example",
+		"SUFFIX_NAME":   "SUFFIX",
+		"SUFFIX":        ".beta",
+		"EMPTY":         "",
+	}
+	tests := map[string]string{
+		"$(BUNDLE_PREFIX).$(PRODUCT_NAME:rfc1034identifier)": "com.example.My-App-2",
+		"${PRODUCT_NAME:lower}":                              "my app_2",
+		"$(PRODUCT_NAME:c99extidentifier)":                   "My_App_2",
+		"app$($(SUFFIX_NAME))":                               "app.beta",
+		"$(EMPTY:default=fallback)":                          "fallback",
+		"$(UNDEFINED:default=fallback)":                      "fallback",
+		"$(UNDEFINED).$(PRODUCT_NAME:upper)":                 "$(UNDEFINED).MY APP_2",
+		"$(PRODUCT_NAME":                                     "$(PRODUCT_NAME",
+		"cost: $5":                                           "cost: $5",
+	}
+	for value, want := range tests {
+		if got := settings.Expand(value); got != want {
+			t.Errorf("Expand(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestInfoPlistExpand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Info.plist")
+	err := os.WriteFile(path, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>$(PRODUCT_BUNDLE_IDENTIFIER)</string>
+	<key>CFBundleShortVersionString</key>
+	<string>$(FLUTTER_BUILD_NAME)</string>
+	<key>CFBundleVersion</key>
+	<string>$(FLUTTER_BUILD_NUMBER)</string>
+	<key>NSCameraUsageDescription</key>
+	<string>$(PRODUCT_NAME) scans receipts</string>
+</dict>
+</plist>`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plist, err := ParseInfoPlist(path)
+	if err != nil {
+		t.Fatalf("ParseInfoPlist failed: %v", err)
+	}
+
+	plist.Expand(BuildSettings{
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.example.app",
+		"FLUTTER_BUILD_NAME":        "1.4.0",
+		"PRODUCT_NAME":              "$(TARGET_NAME)",
+		"TARGET_NAME":               "Runner",
+	})
+	if plist.CFBundleIdentifier != "com.example.app" || plist.CFBundleShortVersionString != "1.4.0" {
+		t.Errorf("expected references to be expanded, got %q and %q", plist.CFBundleIdentifier, plist.CFBundleShortVersionString)
+	}
+	if plist.CFBundleVersion != "$(FLUTTER_BUILD_NUMBER)" {
+		t.Errorf("expected an undefined reference to be kept, got %q", plist.CFBundleVersion)
+	}
+	if got := plist.Root.Get("NSCameraUsageDescription").String(); got != "Runner scans receipts" {
+		t.Errorf("expected nested references to be expanded, got %q", got)
+	}
+}
+
+func TestXcodeTargetResources(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"Runner.xcodeproj/project.pbxproj": `// !$*UTF8*$!
+{
+	objects = {
+		A1 /* PrivacyInfo.xcprivacy in Resources */ = {isa = PBXBuildFile; fileRef = B1 /* PrivacyInfo.xcprivacy */; };
+		A2 /* Main.storyboard in Resources */ = {isa = PBXBuildFile; fileRef = B2 /* Main.storyboard */; };
+		A3 /* AppDelegate.swift in Sources */ = {isa = PBXBuildFile; fileRef = B3 /* AppDelegate.swift */; };
+		B1 = {isa = PBXFileReference; path = PrivacyInfo.xcprivacy; sourceTree = "<group>"; };
+		B2 = {isa = PBXVariantGroup; children = (B4); name = Main.storyboard; sourceTree = "<group>"; };
+		B3 = {isa = PBXFileReference; path = AppDelegate.swift; sourceTree = "<group>"; };
+		B4 = {isa = PBXFileReference; name = Base; path = Base.lproj/Main.storyboard; sourceTree = "<group>"; };
+		C1 = {isa = PBXGroup; children = (C2); sourceTree = "<group>"; };
+		C2 = {isa = PBXGroup; children = (B1, B2, B3); path = Runner; sourceTree = "<group>"; };
+		D1 = {isa = PBXResourcesBuildPhase; files = (A1, A2); };
+		D2 = {isa = PBXSourcesBuildPhase; files = (A3); };
+		E1 = {isa = PBXNativeTarget; buildPhases = (D2, D1); name = Runner; productType = "com.apple.product-type.application"; };
+		F1 = {isa = PBXProject; mainGroup = C1; targets = (E1); };
+	};
+	rootObject = F1;
+}`,
+	})
+
+	xcode, err := ParseXcodeProject(filepath.Join(dir, "Runner.xcodeproj", "project.pbxproj"))
+	if err != nil {
+		t.Fatalf("ParseXcodeProject failed: %v", err)
+	}
+	target := xcode.AppTarget()
+	if target == nil {
+		t.Fatal("expected the Runner target")
+	}
+	want := []string{filepath.Join(dir, "Runner", "PrivacyInfo.xcprivacy")}
+	if !reflect.DeepEqual(target.Resources, want) {
+		t.Errorf("Resources = %v, want %v", target.Resources, want)
+	}
+}
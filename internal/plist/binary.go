@@ -0,0 +1,255 @@
+package plist
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+const binaryMagic = "bplist00"
+
+// binaryEpoch is the reference date binary property lists count from.
+var binaryEpoch = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var errTruncated = errors.New("binary property list is truncated")
+
+// binaryDecoder reads the bplist00 format: a header, an object table, an
+// offset table giving the start of each object and a 32-byte trailer.
+type binaryDecoder struct {
+	data    []byte
+	offsets []uint64
+	refSize int
+	active  []bool
+	// decoded holds the objects decoded so far. Objects referenced more
+	// than once are shared rather than decoded again, which would take
+	// exponential time for a crafted file.
+	decoded []*Value
+}
+
+func decodeBinary(data []byte) (*Value, error) {
+	if len(data) < len(binaryMagic)+32 {
+		return nil, errTruncated
+	}
+	trailer := data[len(data)-32:]
+	offsetSize := int(trailer[6])
+	d := &binaryDecoder{data: data, refSize: int(trailer[7])}
+	count := binary.BigEndian.Uint64(trailer[8:16])
+	top := binary.BigEndian.Uint64(trailer[16:24])
+	table := binary.BigEndian.Uint64(trailer[24:32])
+
+	if offsetSize < 1 || offsetSize > 8 || d.refSize < 1 || d.refSize > 8 {
+		return nil, fmt.Errorf("binary property list has invalid integer sizes %d and %d", offsetSize, d.refSize)
+	}
+	end := uint64(len(data) - 32)
+	if table > end || count > (end-table)/uint64(offsetSize) || top >= count {
+		return nil, errTruncated
+	}
+
+	d.offsets = make([]uint64, count)
+	for i := range d.offsets {
+		start := table + uint64(i*offsetSize)
+		d.offsets[i] = readUint(data[start : start+uint64(offsetSize)])
+	}
+	d.active = make([]bool, count)
+	d.decoded = make([]*Value, count)
+	return d.object(top)
+}
+
+// object returns the object with index ref, decoding it on first use.
+// Objects already being decoded are rejected so that a crafted file cannot
+// recurse forever.
+func (d *binaryDecoder) object(ref uint64) (*Value, error) {
+	if ref >= uint64(len(d.offsets)) {
+		return nil, fmt.Errorf("binary property list object %d out of range", ref)
+	}
+	if v := d.decoded[ref]; v != nil {
+		return v, nil
+	}
+	if d.active[ref] {
+		return nil, fmt.Errorf("binary property list object %d contains itself", ref)
+	}
+	d.active[ref] = true
+	defer func() { d.active[ref] = false }()
+
+	v, err := d.decode(ref)
+	if err != nil {
+		return nil, err
+	}
+	d.decoded[ref] = v
+	return v, nil
+}
+
+// decode decodes the object with index ref.
+func (d *binaryDecoder) decode(ref uint64) (*Value, error) {
+	off := d.offsets[ref]
+	if off >= uint64(len(d.data)) {
+		return nil, errTruncated
+	}
+	marker := d.data[off]
+	off++
+	kind, info := marker>>4, int(marker&0x0f)
+
+	switch kind {
+	case 0x0:
+		switch marker {
+		case 0x08, 0x09:
+			return &Value{kind: KindBool, flag: marker == 0x09}, nil
+		}
+		return nil, fmt.Errorf("binary property list object %d has unsupported marker %#02x", ref, marker)
+	case 0x1, 0x8:
+		b, err := d.bytes(off, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{kind: KindInteger, num: readInt(b)}, nil
+	case 0x2:
+		b, err := d.bytes(off, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+		switch len(b) {
+		case 4:
+			return &Value{kind: KindReal, real: float64(math.Float32frombits(binary.BigEndian.Uint32(b)))}, nil
+		case 8:
+			return &Value{kind: KindReal, real: math.Float64frombits(binary.BigEndian.Uint64(b))}, nil
+		}
+		return nil, fmt.Errorf("binary property list real %d has %d bytes", ref, len(b))
+	case 0x3:
+		b, err := d.bytes(off, 8)
+		if err != nil {
+			return nil, err
+		}
+		seconds := math.Float64frombits(binary.BigEndian.Uint64(b))
+		return &Value{kind: KindDate, date: binaryEpoch.Add(time.Duration(seconds * float64(time.Second)))}, nil
+	}
+
+	n, off, err := d.count(info, off)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case 0x4:
+		b, err := d.bytes(off, n)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{kind: KindData, data: append([]byte(nil), b...)}, nil
+	case 0x5, 0x7:
+		b, err := d.bytes(off, n)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{kind: KindString, str: string(b)}, nil
+	case 0x6:
+		b, err := d.bytes(off, n*2)
+		if err != nil {
+			return nil, err
+		}
+		units := make([]uint16, n)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+		return &Value{kind: KindString, str: string(utf16.Decode(units))}, nil
+	case 0xa, 0xc:
+		refs, err := d.refs(off, n)
+		if err != nil {
+			return nil, err
+		}
+		v := &Value{kind: KindArray}
+		for _, r := range refs {
+			item, err := d.object(r)
+			if err != nil {
+				return nil, err
+			}
+			v.items = append(v.items, item)
+		}
+		return v, nil
+	case 0xd:
+		refs, err := d.refs(off, n*2)
+		if err != nil {
+			return nil, err
+		}
+		v := &Value{kind: KindDict}
+		for i := 0; i < n; i++ {
+			key, err := d.object(refs[i])
+			if err != nil {
+				return nil, err
+			}
+			if key.kind != KindString {
+				return nil, fmt.Errorf("binary property list dictionary %d has a %s key", ref, key.kind)
+			}
+			value, err := d.object(refs[n+i])
+			if err != nil {
+				return nil, err
+			}
+			v.setEntry(key.str, value)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("binary property list object %d has unsupported marker %#02x", ref, marker)
+}
+
+// count returns the length in a marker's low nibble, or that of the integer
+// object following it when the nibble is 0xf, and the offset after it.
+func (d *binaryDecoder) count(info int, off uint64) (int, uint64, error) {
+	if info != 0x0f {
+		return info, off, nil
+	}
+	b, err := d.bytes(off, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if b[0]>>4 != 0x1 {
+		return 0, 0, fmt.Errorf("binary property list has an invalid length marker %#02x", b[0])
+	}
+	size := 1 << (b[0] & 0x0f)
+	nb, err := d.bytes(off+1, size)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := readInt(nb)
+	if n < 0 || n > int64(len(d.data)) {
+		return 0, 0, errTruncated
+	}
+	return int(n), off + 1 + uint64(size), nil
+}
+
+// refs reads n object references starting at off.
+func (d *binaryDecoder) refs(off uint64, n int) ([]uint64, error) {
+	b, err := d.bytes(off, n*d.refSize)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]uint64, n)
+	for i := range refs {
+		refs[i] = readUint(b[i*d.refSize : (i+1)*d.refSize])
+	}
+	return refs, nil
+}
+
+func (d *binaryDecoder) bytes(off uint64, n int) ([]byte, error) {
+	if n < 0 || off > uint64(len(d.data)) || uint64(n) > uint64(len(d.data))-off {
+		return nil, errTruncated
+	}
+	return d.data[off : off+uint64(n)], nil
+}
+
+func readUint(b []byte) uint64 {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n
+}
+
+// readInt reads a big-endian integer. Eight-byte integers are signed; the
+// 16-byte form keeps its low eight bytes.
+func readInt(b []byte) int64 {
+	if len(b) > 8 {
+		b = b[len(b)-8:]
+	}
+	return int64(readUint(b))
+}
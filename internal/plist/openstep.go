@@ -0,0 +1,242 @@
+package plist
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// openStepDecoder reads the old-style ASCII property list format that
+// Xcode still uses for project.pbxproj. Every scalar decodes as a string.
+type openStepDecoder struct {
+	data string
+	pos  int
+	line int
+}
+
+// isOpenStep reports whether data looks like an old-style property list
+// rather than XML.
+func isOpenStep(data []byte) bool {
+	s := strings.TrimLeft(string(data), " \t\r\n\ufeff")
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "(") ||
+		strings.HasPrefix(s, "//") || strings.HasPrefix(s, "/*")
+}
+
+func decodeOpenStep(data []byte) (*Value, error) {
+	d := &openStepDecoder{data: string(data), line: 1}
+	d.skip()
+	if d.pos >= len(d.data) {
+		return nil, errors.New("no property list value found")
+	}
+	v, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	d.skip()
+	if d.pos < len(d.data) {
+		return nil, d.errorf("unexpected %q after the property list", d.data[d.pos])
+	}
+	return v, nil
+}
+
+func (d *openStepDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", d.line, fmt.Sprintf(format, args...))
+}
+
+// skip moves past whitespace and comments.
+func (d *openStepDecoder) skip() {
+	for d.pos < len(d.data) {
+		switch c := d.data[d.pos]; {
+		case c == '\n':
+			d.line++
+			d.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			d.pos++
+		case strings.HasPrefix(d.data[d.pos:], "\ufeff"):
+			d.pos += len("\ufeff")
+		case strings.HasPrefix(d.data[d.pos:], "//"):
+			end := strings.IndexByte(d.data[d.pos:], '\n')
+			if end < 0 {
+				d.pos = len(d.data)
+				return
+			}
+			d.pos += end
+		case strings.HasPrefix(d.data[d.pos:], "/*"):
+			end := strings.Index(d.data[d.pos+2:], "*/")
+			if end < 0 {
+				d.pos = len(d.data)
+				return
+			}
+			d.line += strings.Count(d.data[d.pos:d.pos+2+end], "\n")
+			d.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+// value decodes the value at the current position.
+func (d *openStepDecoder) value() (*Value, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of input")
+	}
+	v := &Value{line: d.line}
+
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		d.pos++
+		v.kind = KindDict
+		for {
+			d.skip()
+			if d.pos >= len(d.data) {
+				return nil, d.errorf("unterminated dictionary")
+			}
+			if d.data[d.pos] == '}' {
+				d.pos++
+				return v, nil
+			}
+			line := d.line
+			key, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			if key.kind != KindString {
+				return nil, d.errorf("dictionary key must be a string")
+			}
+			if err := d.expect('='); err != nil {
+				return nil, err
+			}
+			d.skip()
+			value, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			if err := d.expect(';'); err != nil {
+				return nil, err
+			}
+			value.line = line
+			v.setEntry(key.str, value)
+		}
+	case c == '(':
+		d.pos++
+		v.kind = KindArray
+		for {
+			d.skip()
+			if d.pos >= len(d.data) {
+				return nil, d.errorf("unterminated array")
+			}
+			if d.data[d.pos] == ')' {
+				d.pos++
+				return v, nil
+			}
+			item, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			v.items = append(v.items, item)
+			d.skip()
+			if d.pos < len(d.data) && d.data[d.pos] == ',' {
+				d.pos++
+			} else if d.pos < len(d.data) && d.data[d.pos] != ')' {
+				return nil, d.errorf("expected ',' or ')' in array")
+			}
+		}
+	case c == '"' || c == '\'':
+		s, err := d.quoted(c)
+		if err != nil {
+			return nil, err
+		}
+		v.kind, v.str = KindString, s
+		return v, nil
+	case c == '<':
+		end := strings.IndexByte(d.data[d.pos:], '>')
+		if end < 0 {
+			return nil, d.errorf("unterminated data")
+		}
+		digits := strings.Join(strings.Fields(d.data[d.pos+1:d.pos+end]), "")
+		data, err := hex.DecodeString(digits)
+		if err != nil {
+			return nil, d.errorf("invalid data: %v", err)
+		}
+		d.line += strings.Count(d.data[d.pos:d.pos+end], "\n")
+		d.pos += end + 1
+		v.kind, v.data = KindData, data
+		return v, nil
+	default:
+		start := d.pos
+		for d.pos < len(d.data) && isUnquoted(d.data[d.pos]) {
+			d.pos++
+		}
+		if d.pos == start {
+			return nil, d.errorf("unexpected %q", c)
+		}
+		v.kind, v.str = KindString, d.data[start:d.pos]
+		return v, nil
+	}
+}
+
+// expect skips to and past c.
+func (d *openStepDecoder) expect(c byte) error {
+	d.skip()
+	if d.pos >= len(d.data) || d.data[d.pos] != c {
+		return d.errorf("expected %q", c)
+	}
+	d.pos++
+	return nil
+}
+
+// quoted decodes a string in quote characters, with C-style escapes.
+func (d *openStepDecoder) quoted(quote byte) (string, error) {
+	var b strings.Builder
+	d.pos++
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch {
+		case c == quote:
+			d.pos++
+			return b.String(), nil
+		case c == '\\' && d.pos+1 < len(d.data):
+			d.pos++
+			switch e := d.data[d.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'U':
+				if d.pos+4 < len(d.data) {
+					if r, err := strconv.ParseUint(d.data[d.pos+1:d.pos+5], 16, 32); err == nil {
+						b.WriteRune(rune(r))
+						d.pos += 4
+						break
+					}
+				}
+				b.WriteByte(e)
+			default:
+				if e == '\n' {
+					d.line++
+				}
+				b.WriteByte(e)
+			}
+			d.pos++
+		default:
+			if c == '\n' {
+				d.line++
+			}
+			_, size := utf8.DecodeRuneInString(d.data[d.pos:])
+			b.WriteString(d.data[d.pos : d.pos+size])
+			d.pos += size
+		}
+	}
+	return "", d.errorf("unterminated string")
+}
+
+// isUnquoted reports whether c may appear in an unquoted string.
+func isUnquoted(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("_$+/:.-", c) >= 0
+}
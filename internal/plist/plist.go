@@ -0,0 +1,325 @@
+// Package plist decodes Apple property lists in the XML, binary
+// (bplist00) and old-style ASCII formats into a tree of values that can be
+// queried by path.
+package plist
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the type of a property list value.
+type Kind int
+
+const (
+	KindInvalid Kind = iota
+	KindString
+	KindInteger
+	KindReal
+	KindBool
+	KindDate
+	KindData
+	KindArray
+	KindDict
+)
+
+var kindNames = [...]string{"invalid", "string", "integer", "real", "bool", "date", "data", "array", "dict"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Value is a node of a decoded property list. All methods are safe to call
+// on a nil *Value, which is what Get and Lookup return for missing keys, so
+// queries can be chained without checks.
+type Value struct {
+	kind  Kind
+	line  int
+	str   string
+	num   int64
+	real  float64
+	flag  bool
+	date  time.Time
+	data  []byte
+	items []*Value
+	keys  []string
+	dict  map[string]*Value
+}
+
+// Decode decodes an XML, binary or old-style ASCII property list.
+func Decode(data []byte) (*Value, error) {
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return decodeBinary(data)
+	}
+	if isOpenStep(data) {
+		return decodeOpenStep(data)
+	}
+	return decodeXML(data)
+}
+
+// ReadFile reads and decodes the property list in file.
+func ReadFile(file string) (*Value, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	v, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return v, nil
+}
+
+// Kind returns the type of v, or KindInvalid if v is nil.
+func (v *Value) Kind() Kind {
+	if v == nil {
+		return KindInvalid
+	}
+	return v.kind
+}
+
+// Line returns the line v was read from: for a dictionary entry, the line
+// of its <key>. It is 0 for binary property lists and for nil.
+func (v *Value) Line() int {
+	if v == nil {
+		return 0
+	}
+	return v.line
+}
+
+// Get returns the dictionary entry named key, or nil.
+func (v *Value) Get(key string) *Value {
+	if v.Kind() != KindDict {
+		return nil
+	}
+	return v.dict[key]
+}
+
+// Index returns the i-th array item, or nil.
+func (v *Value) Index(i int) *Value {
+	if v.Kind() != KindArray || i < 0 || i >= len(v.items) {
+		return nil
+	}
+	return v.items[i]
+}
+
+// Lookup returns the value at a dot-separated path such as
+// NSAppTransportSecurity.NSAllowsArbitraryLoads, or nil. A numeric segment
+// indexes an array, as in UIBackgroundModes.0. Keys that contain dots
+// themselves, such as com.apple.developer.associated-domains, are matched
+// whole; where several keys fit, the longest wins.
+func (v *Value) Lookup(path string) *Value {
+	if path == "" {
+		return v
+	}
+	switch v.Kind() {
+	case KindDict:
+		if child, ok := v.dict[path]; ok {
+			return child
+		}
+		var best string
+		for _, key := range v.keys {
+			if strings.HasPrefix(path, key+".") && len(key) > len(best) {
+				best = key
+			}
+		}
+		if best == "" {
+			return nil
+		}
+		return v.dict[best].Lookup(path[len(best)+1:])
+	case KindArray:
+		segment, rest, _ := strings.Cut(path, ".")
+		i, err := strconv.Atoi(segment)
+		if err != nil {
+			return nil
+		}
+		return v.Index(i).Lookup(rest)
+	default:
+		return nil
+	}
+}
+
+// Has reports whether a value exists at path.
+func (v *Value) Has(path string) bool {
+	return v.Lookup(path) != nil
+}
+
+// Keys returns the keys of a dictionary in document order.
+func (v *Value) Keys() []string {
+	if v.Kind() != KindDict {
+		return nil
+	}
+	return v.keys
+}
+
+// Items returns the items of an array.
+func (v *Value) Items() []*Value {
+	if v.Kind() != KindArray {
+		return nil
+	}
+	return v.items
+}
+
+// String returns a string value, or the text of an integer, real, bool or
+// date. It returns "" for other kinds.
+func (v *Value) String() string {
+	switch v.Kind() {
+	case KindString:
+		return v.str
+	case KindInteger:
+		return strconv.FormatInt(v.num, 10)
+	case KindReal:
+		return strconv.FormatFloat(v.real, 'g', -1, 64)
+	case KindBool:
+		return strconv.FormatBool(v.flag)
+	case KindDate:
+		return v.date.Format(time.RFC3339)
+	default:
+		return ""
+	}
+}
+
+// Strings returns the string items of an array, skipping other kinds.
+func (v *Value) Strings() []string {
+	var out []string
+	for _, item := range v.Items() {
+		if item.kind == KindString {
+			out = append(out, item.str)
+		}
+	}
+	return out
+}
+
+// Bool returns a boolean value and whether v holds one. As in Xcode, the
+// strings YES, NO, true and false and the integers 0 and 1 are accepted.
+func (v *Value) Bool() (bool, bool) {
+	switch v.Kind() {
+	case KindBool:
+		return v.flag, true
+	case KindInteger:
+		return v.num != 0, v.num == 0 || v.num == 1
+	case KindString:
+		switch strings.ToLower(strings.TrimSpace(v.str)) {
+		case "yes", "true", "1":
+			return true, true
+		case "no", "false", "0":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// Int returns an integer value, converting reals and numeric strings.
+func (v *Value) Int() (int64, bool) {
+	switch v.Kind() {
+	case KindInteger:
+		return v.num, true
+	case KindReal:
+		return int64(v.real), true
+	case KindString:
+		n, err := strconv.ParseInt(strings.TrimSpace(v.str), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// Real returns a real value, converting integers.
+func (v *Value) Real() (float64, bool) {
+	switch v.Kind() {
+	case KindReal:
+		return v.real, true
+	case KindInteger:
+		return float64(v.num), true
+	}
+	return 0, false
+}
+
+// Date returns a date value.
+func (v *Value) Date() (time.Time, bool) {
+	if v.Kind() != KindDate {
+		return time.Time{}, false
+	}
+	return v.date, true
+}
+
+// Data returns the bytes of a data value.
+func (v *Value) Data() []byte {
+	if v.Kind() != KindData {
+		return nil
+	}
+	return v.data
+}
+
+// Interface returns v as plain Go values: string, int64, float64, bool,
+// time.Time, []byte, []any and map[string]any.
+func (v *Value) Interface() any {
+	switch v.Kind() {
+	case KindString:
+		return v.str
+	case KindInteger:
+		return v.num
+	case KindReal:
+		return v.real
+	case KindBool:
+		return v.flag
+	case KindDate:
+		return v.date
+	case KindData:
+		return v.data
+	case KindArray:
+		out := make([]any, len(v.items))
+		for i, item := range v.items {
+			out[i] = item.Interface()
+		}
+		return out
+	case KindDict:
+		out := make(map[string]any, len(v.keys))
+		for _, key := range v.keys {
+			out[key] = v.dict[key].Interface()
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Map returns a dictionary as map[string]any, or nil.
+func (v *Value) Map() map[string]any {
+	m, _ := v.Interface().(map[string]any)
+	return m
+}
+
+// ReplaceStrings replaces every string in v, including those nested in
+// arrays and dictionaries, with fn of it. Dictionary keys are kept.
+func (v *Value) ReplaceStrings(fn func(string) string) {
+	switch v.Kind() {
+	case KindString:
+		v.str = fn(v.str)
+	case KindArray:
+		for _, item := range v.items {
+			item.ReplaceStrings(fn)
+		}
+	case KindDict:
+		for _, key := range v.keys {
+			v.dict[key].ReplaceStrings(fn)
+		}
+	}
+}
+
+// setEntry adds or replaces a dictionary entry, keeping document order.
+func (v *Value) setEntry(key string, value *Value) {
+	if v.dict == nil {
+		v.dict = make(map[string]*Value)
+	}
+	if _, ok := v.dict[key]; !ok {
+		v.keys = append(v.keys, key)
+	}
+	v.dict[key] = value
+}
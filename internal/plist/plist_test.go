@@ -0,0 +1,363 @@
+package plist
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.example.app</string>
+	<key>UIBackgroundModes</key>
+	<array>
+		<string>fetch</string>
+		<string>remote-notification</string>
+	</array>
+	<key>NSAppTransportSecurity</key>
+	<dict>
+		<key>NSAllowsArbitraryLoads</key>
+		<true/>
+		<key>NSExceptionDomains</key>
+		<dict>
+			<key>example.com</key>
+			<dict>
+				<key>NSIncludesSubdomains</key>
+				<string>YES</string>
+			</dict>
+		</dict>
+	</dict>
+	<key>com.apple.developer.associated-domains</key>
+	<array>
+		<string>applinks:example.com</string>
+	</array>
+	<key>Count</key>
+	<integer>-7</integer>
+	<key>Ratio</key>
+	<real>1.5</real>
+	<key>Released</key>
+	<date>2024-01-15T10:30:00Z</date>
+	<key>Token</key>
+	<data>
+	AAFmc2N0
+	</data>
+	<key>Escaped</key>
+	<string>Fish &amp; Chips</string>
+</dict>
+</plist>
+`
+
+func TestDecodeXML(t *testing.T) {
+	root, err := Decode([]byte(sample))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if root.Kind() != KindDict {
+		t.Fatalf("expected a dict, got %s", root.Kind())
+	}
+	if got := root.Get("CFBundleIdentifier").String(); got != "com.example.app" {
+		t.Errorf("CFBundleIdentifier = %q", got)
+	}
+	if got := root.Lookup("UIBackgroundModes").Strings(); !reflect.DeepEqual(got, []string{"fetch", "remote-notification"}) {
+		t.Errorf("UIBackgroundModes = %v", got)
+	}
+	if b, ok := root.Lookup("NSAppTransportSecurity.NSAllowsArbitraryLoads").Bool(); !ok || !b {
+		t.Errorf("expected NSAllowsArbitraryLoads true, got %v, %v", b, ok)
+	}
+	if b, ok := root.Lookup("NSAppTransportSecurity.NSExceptionDomains.example.com.NSIncludesSubdomains").Bool(); !ok || !b {
+		t.Errorf("expected YES to read as true, got %v, %v", b, ok)
+	}
+	if got := root.Lookup("com.apple.developer.associated-domains.0").String(); got != "applinks:example.com" {
+		t.Errorf("dotted key lookup = %q", got)
+	}
+	if n, ok := root.Get("Count").Int(); !ok || n != -7 {
+		t.Errorf("Count = %d, %v", n, ok)
+	}
+	if r, ok := root.Get("Ratio").Real(); !ok || r != 1.5 {
+		t.Errorf("Ratio = %v, %v", r, ok)
+	}
+	if d, ok := root.Get("Released").Date(); !ok || !d.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("Released = %v, %v", d, ok)
+	}
+	if got := root.Get("Token").Data(); !bytes.Equal(got, []byte("\x00\x01fsct")) {
+		t.Errorf("Token = %v", got)
+	}
+	if got := root.Get("Escaped").String(); got != "Fish & Chips" {
+		t.Errorf("Escaped = %q", got)
+	}
+	if keys := root.Keys(); len(keys) != 9 || keys[0] != "CFBundleIdentifier" || keys[8] != "Escaped" {
+		t.Errorf("expected keys in document order, got %v", keys)
+	}
+}
+
+func TestDecodeXMLLines(t *testing.T) {
+	root, err := Decode([]byte(sample))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		line int
+	}{
+		{"CFBundleIdentifier", 5},
+		{"UIBackgroundModes", 7},
+		{"UIBackgroundModes.1", 10},
+		{"NSAppTransportSecurity.NSAllowsArbitraryLoads", 14},
+		{"NSAppTransportSecurity.NSExceptionDomains.example.com", 18},
+		{"Missing", 0},
+	}
+	for _, tt := range tests {
+		if got := root.Lookup(tt.path).Line(); got != tt.line {
+			t.Errorf("Line of %s = %d, want %d", tt.path, got, tt.line)
+		}
+	}
+}
+
+func TestLookupMissing(t *testing.T) {
+	root, err := Decode([]byte(sample))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	for _, path := range []string{
+		"Missing",
+		"Missing.Deeper",
+		"CFBundleIdentifier.Deeper",
+		"UIBackgroundModes.2",
+		"UIBackgroundModes.first",
+	} {
+		if v := root.Lookup(path); v != nil {
+			t.Errorf("Lookup(%q) = %v, want nil", path, v)
+		}
+	}
+	if _, ok := root.Lookup("Missing").Bool(); ok {
+		t.Error("a missing value should not be a bool")
+	}
+	if root.Lookup("Missing").Strings() != nil || root.Lookup("Missing").String() != "" {
+		t.Error("a missing value should read as empty")
+	}
+}
+
+func TestDecodeXMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":          ``,
+		"unterminated":   `<plist><dict><key>A</key><string>x</string>`,
+		"value sans key": `<plist><dict><string>x</string></dict></plist>`,
+		"key sans value": `<plist><dict><key>A</key></dict></plist>`,
+		"bad integer":    `<plist><integer>ten</integer></plist>`,
+		"unknown":        `<plist><set/></plist>`,
+	}
+	for name, input := range tests {
+		if _, err := Decode([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	root, err := ReadFile(filepath.Join("..", "..", "testdata", "ios", "Binary.plist"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if got := root.Get("CFBundleIdentifier").String(); got != "com.example.binary" {
+		t.Errorf("CFBundleIdentifier = %q", got)
+	}
+	if got := root.Get("CFBundleDisplayName").String(); got != "Café ☕" {
+		t.Errorf("expected UTF-16 string to decode, got %q", got)
+	}
+	if got := root.Get("UIBackgroundModes").Strings(); !reflect.DeepEqual(got, []string{"audio", "location"}) {
+		t.Errorf("UIBackgroundModes = %v", got)
+	}
+	if b, ok := root.Lookup("NSAppTransportSecurity.NSAllowsArbitraryLoads").Bool(); !ok || !b {
+		t.Errorf("expected NSAllowsArbitraryLoads true, got %v, %v", b, ok)
+	}
+	if b, ok := root.Lookup("NSAppTransportSecurity.NSExceptionDomains.example.com.NSIncludesSubdomains").Bool(); !ok || !b {
+		t.Errorf("expected nested exception domain, got %v, %v", b, ok)
+	}
+	if b, ok := root.Get("ITSAppUsesNonExemptEncryption").Bool(); !ok || b {
+		t.Errorf("expected ITSAppUsesNonExemptEncryption false, got %v, %v", b, ok)
+	}
+	if n, ok := root.Get("BuildNumber").Int(); !ok || n != 1234567890123 {
+		t.Errorf("BuildNumber = %d, %v", n, ok)
+	}
+	if r, ok := root.Get("Ratio").Real(); !ok || r != 0.5 {
+		t.Errorf("Ratio = %v, %v", r, ok)
+	}
+	if d, ok := root.Get("Released").Date(); !ok || !d.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("Released = %v, %v", d, ok)
+	}
+	if got := root.Get("Token").Data(); !bytes.Equal(got, []byte("\x00\x01fsct")) {
+		t.Errorf("Token = %v", got)
+	}
+	if root.Get("CFBundleIdentifier").Line() != 0 {
+		t.Error("binary values have no line")
+	}
+}
+
+func TestDecodeBinaryCorrupt(t *testing.T) {
+	tests := map[string][]byte{
+		"short": []byte("bplist00"),
+		// A dict (0xd1) whose key and value both refer back to itself.
+		"cycle": append([]byte("bplist00\xd1\x00\x00\x08"), trailer(1, 1, 1, 0, 11)...),
+		// The offset table points past the end of the file.
+		"offset": append([]byte("bplist00\xff"), trailer(1, 1, 1, 0, 200)...),
+	}
+	for name, data := range tests {
+		if _, err := Decode(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if !strings.Contains(err.Error(), "binary property list") {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+// TestDecodeBinaryShared decodes a chain of arrays that each refer to the
+// next twice. Decoding every reference separately would take 2^40 steps.
+func TestDecodeBinaryShared(t *testing.T) {
+	const depth = 40
+	data := []byte("bplist00")
+	var offsets []byte
+	for i := 0; i < depth; i++ {
+		offsets = append(offsets, byte(len(data)))
+		data = append(data, 0xa2, byte(i+1), byte(i+1))
+	}
+	offsets = append(offsets, byte(len(data)))
+	data = append(data, 0x09)
+	table := uint64(len(data))
+	data = append(data, offsets...)
+	data = append(data, trailer(1, 1, depth+1, 0, table)...)
+
+	root, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	v := root
+	for i := 0; i < depth; i++ {
+		if len(v.Items()) != 2 {
+			t.Fatalf("expected array %d to have 2 items, got %d", i, len(v.Items()))
+		}
+		v = v.Index(1)
+	}
+	if b, ok := v.Bool(); !ok || !b {
+		t.Errorf("expected the innermost object to be true, got %v, %v", b, ok)
+	}
+}
+
+// trailer returns a bplist00 trailer.
+func trailer(offsetSize, refSize byte, count, top, table uint64) []byte {
+	b := make([]byte, 32)
+	b[6], b[7] = offsetSize, refSize
+	put := func(i int, n uint64) {
+		for j := 0; j < 8; j++ {
+			b[i+7-j] = byte(n >> (8 * j))
+		}
+	}
+	put(8, count)
+	put(16, top)
+	put(24, table)
+	return b
+}
+
+func TestInterface(t *testing.T) {
+	root, err := Decode([]byte(`<plist><dict>
+		<key>A</key><array><integer>1</integer><true/></array>
+		<key>B</key><dict><key>C</key><string>d</string></dict>
+	</dict></plist>`))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	want := map[string]any{
+		"A": []any{int64(1), true},
+		"B": map[string]any{"C": "d"},
+	}
+	if got := root.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDecodeOpenStep(t *testing.T) {
+	input := `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	/* a block
+	   comment */
+	objects = {
+		ABC123 /* Runner */ = {isa = PBXNativeTarget; name = "Runner App"; };
+	};
+	list = (one, "two words", three, );
+	escaped = "say \"hi\"\n\U00e9";
+	path = Flutter/Release.xcconfig;
+	token = <00 01 66 73 63 74>;
+	'single' = 'quoted';
+}
+`
+	root, err := Decode([]byte(input))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if got := root.Lookup("objects.ABC123.name").String(); got != "Runner App" {
+		t.Errorf("objects.ABC123.name = %q", got)
+	}
+	if got := root.Get("list").Strings(); !reflect.DeepEqual(got, []string{"one", "two words", "three"}) {
+		t.Errorf("list = %v", got)
+	}
+	if got := root.Get("escaped").String(); got != "say \"hi\"\né" {
+		t.Errorf("escaped = %q", got)
+	}
+	if got := root.Get("path").String(); got != "Flutter/Release.xcconfig" {
+		t.Errorf("path = %q", got)
+	}
+	if got := root.Get("token").Data(); !bytes.Equal(got, []byte("\x00\x01fsct")) {
+		t.Errorf("token = %v", got)
+	}
+	if got := root.Get("single").String(); got != "quoted" {
+		t.Errorf("single = %q", got)
+	}
+	if n, ok := root.Get("archiveVersion").Int(); !ok || n != 1 {
+		t.Errorf("archiveVersion = %d, %v", n, ok)
+	}
+	if got := root.Keys(); !reflect.DeepEqual(got, []string{"archiveVersion", "objects", "list", "escaped", "path", "token", "single"}) {
+		t.Errorf("expected keys in document order, got %v", got)
+	}
+	if line := root.Get("objects").Get("ABC123").Line(); line != 7 {
+		t.Errorf("expected ABC123 on line 7, got %d", line)
+	}
+	if line := root.Get("list").Line(); line != 9 {
+		t.Errorf("expected list on line 9, got %d", line)
+	}
+
+	replaced := root.Lookup("objects.ABC123")
+	replaced.ReplaceStrings(strings.ToUpper)
+	if got := replaced.Get("name").String(); got != "RUNNER APP" {
+		t.Errorf("ReplaceStrings: name = %q", got)
+	}
+}
+
+func TestDecodeOpenStepErrors(t *testing.T) {
+	tests := map[string]string{
+		"unterminated dict":   `{ a = b;`,
+		"missing semicolon":   `{ a = b }`,
+		"missing value":       `{ a = ; }`,
+		"unterminated string": `{ a = "b; }`,
+		"unterminated array":  `( a, b`,
+		"bad data":            `{ a = <zz>; }`,
+		"trailing":            `{ } }`,
+		"comment only":        `// nothing here`,
+	}
+	for name, input := range tests {
+		if _, err := Decode([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
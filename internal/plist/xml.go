@@ -0,0 +1,173 @@
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xmlDecoder reads the XML property list format, as written by Xcode.
+type xmlDecoder struct {
+	d *xml.Decoder
+}
+
+func decodeXML(data []byte) (*Value, error) {
+	x := &xmlDecoder{d: xml.NewDecoder(bytes.NewReader(data))}
+	for {
+		tok, err := x.d.Token()
+		if err == io.EOF {
+			return nil, errors.New("no property list value found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local == "plist" {
+			continue
+		}
+		return x.value(start)
+	}
+}
+
+// value decodes the element opened by start.
+func (x *xmlDecoder) value(start xml.StartElement) (*Value, error) {
+	line, _ := x.d.InputPos()
+	v := &Value{line: line}
+
+	switch start.Name.Local {
+	case "dict":
+		v.kind = KindDict
+		return v, x.dict(v)
+	case "array":
+		v.kind = KindArray
+		for {
+			child, err := x.next()
+			if err != nil || child == nil {
+				return v, err
+			}
+			v.items = append(v.items, child)
+		}
+	case "true", "false":
+		v.kind, v.flag = KindBool, start.Name.Local == "true"
+		_, err := x.text(start)
+		return v, err
+	}
+
+	text, err := x.text(start)
+	if err != nil {
+		return nil, err
+	}
+	switch start.Name.Local {
+	case "string":
+		v.kind, v.str = KindString, text
+	case "integer":
+		v.kind = KindInteger
+		if v.num, err = strconv.ParseInt(strings.TrimSpace(text), 0, 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid integer %q", line, text)
+		}
+	case "real":
+		v.kind = KindReal
+		if v.real, err = strconv.ParseFloat(strings.TrimSpace(text), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid real %q", line, text)
+		}
+	case "date":
+		v.kind = KindDate
+		if v.date, err = time.Parse(time.RFC3339, strings.TrimSpace(text)); err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, text)
+		}
+	case "data":
+		v.kind = KindData
+		if v.data, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), "")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid data: %v", line, err)
+		}
+	default:
+		return nil, fmt.Errorf("line %d: unknown element <%s>", line, start.Name.Local)
+	}
+	return v, nil
+}
+
+// dict decodes the <key> and value pairs of a <dict>. Each entry takes the
+// line of its key.
+func (x *xmlDecoder) dict(v *Value) error {
+	for {
+		tok, err := x.token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			line, _ := x.d.InputPos()
+			if tok.Name.Local != "key" {
+				return fmt.Errorf("line %d: <%s> without a <key>", line, tok.Name.Local)
+			}
+			key, err := x.text(tok)
+			if err != nil {
+				return err
+			}
+			child, err := x.next()
+			if err != nil {
+				return err
+			}
+			if child == nil {
+				return fmt.Errorf("line %d: key %q has no value", line, key)
+			}
+			child.line = line
+			v.setEntry(key, child)
+		}
+	}
+}
+
+// next decodes the next value inside the current element, or returns nil
+// at its end.
+func (x *xmlDecoder) next() (*Value, error) {
+	for {
+		tok, err := x.token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil, nil
+		case xml.StartElement:
+			return x.value(tok)
+		}
+	}
+}
+
+// text returns the character data of the element opened by start.
+func (x *xmlDecoder) text(start xml.StartElement) (string, error) {
+	var b strings.Builder
+	for {
+		tok, err := x.token()
+		if err != nil {
+			return "", err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b.Write(tok)
+		case xml.StartElement:
+			line, _ := x.d.InputPos()
+			return "", fmt.Errorf("line %d: unexpected <%s> in <%s>", line, tok.Name.Local, start.Name.Local)
+		case xml.EndElement:
+			return b.String(), nil
+		}
+	}
+}
+
+// token returns the next token, treating the end of input as an error since
+// it only ends a document between values.
+func (x *xmlDecoder) token() (xml.Token, error) {
+	tok, err := x.d.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return tok, err
+}
@@ -0,0 +1,225 @@
+// Package profile defines named selections of checks, such as the store
+// submission checks or the code-quality checks.
+package profile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/config"
+)
+
+// Built-in profile names.
+const (
+	Store   = "store"
+	Quality = "quality"
+	Full    = "full"
+	Custom  = "custom"
+)
+
+// Default is the profile used when none is selected.
+const Default = Store
+
+// Profile selects checks by category, with individual checks added to or
+// removed from that selection.
+type Profile struct {
+	Name        string
+	Description string
+
+	all        bool
+	categories map[checker.Category]bool
+	include    map[string]bool
+	exclude    map[string]bool
+}
+
+// flutterQuality are Flutter checks about project hygiene rather than store
+// requirements; they belong to the quality profile.
+var flutterQuality = []string{"FLT-002", "FLT-006", "FLT-007", "FLT-008"}
+
+var builtin = map[string]*Profile{
+	Store: {
+		Name:        Store,
+		Description: "Checks that affect store submission and review",
+		categories: categorySet(
+			checker.CategoryAndroid, checker.CategoryIOS, checker.CategoryFlutter,
+			checker.CategorySecurity, checker.CategoryPolicy,
+			checker.CategoryReviewer, checker.CategoryAI,
+		),
+		exclude: idSet(flutterQuality),
+	},
+	Quality: {
+		Name:        Quality,
+		Description: "Code quality, testing, linting, documentation and performance checks",
+		categories: categorySet(
+			checker.CategoryCodeQuality, checker.CategoryTesting, checker.CategoryLinting,
+			checker.CategoryDocumentation, checker.CategoryPerformance,
+		),
+		include: idSet(flutterQuality),
+	},
+	Full: {
+		Name:        Full,
+		Description: "Every available check",
+		all:         true,
+	},
+}
+
+// Builtin returns the built-in profiles sorted by name.
+func Builtin() []*Profile {
+	profiles := make([]*Profile, 0, len(builtin))
+	for _, p := range builtin {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// Names returns the names of the built-in profiles and of the profiles
+// defined in custom, sorted.
+func Names(custom map[string]*config.ProfileConfig) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for name := range builtin {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range custom {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup resolves name to a profile. Profiles defined in custom (the
+// profiles section of .fsct.yaml) take precedence over built-in ones of the
+// same name. An empty name selects Default.
+func Lookup(name string, custom map[string]*config.ProfileConfig) (*Profile, error) {
+	if name == "" {
+		name = Default
+	}
+
+	normalized := make(map[string]*config.ProfileConfig, len(custom))
+	for n, cfg := range custom {
+		normalized[strings.ToLower(n)] = cfg
+	}
+	return lookup(strings.ToLower(name), normalized, nil)
+}
+
+func lookup(name string, custom map[string]*config.ProfileConfig, visiting []string) (*Profile, error) {
+	cfg, ok := custom[name]
+	if !ok {
+		if p, ok := builtin[name]; ok {
+			return p, nil
+		}
+		if name == Custom {
+			return nil, fmt.Errorf("profile %q is not defined (add it under profiles: in .fsct.yaml)", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(Names(custom), ", "))
+	}
+
+	for _, v := range visiting {
+		if v == name {
+			return nil, fmt.Errorf("profile %q: base cycle %s -> %s", name, strings.Join(visiting, " -> "), name)
+		}
+	}
+
+	p := &Profile{
+		Name:        name,
+		Description: "Custom profile from .fsct.yaml",
+		categories:  make(map[checker.Category]bool),
+		include:     make(map[string]bool),
+		exclude:     make(map[string]bool),
+	}
+	if cfg == nil {
+		return p, nil
+	}
+
+	if cfg.Base != "" {
+		var base *Profile
+		var err error
+		if strings.EqualFold(cfg.Base, name) {
+			// A custom profile may refine the built-in profile it shadows.
+			base, ok = builtin[strings.ToLower(cfg.Base)]
+			if !ok {
+				return nil, fmt.Errorf("profile %q: unknown base %q", name, cfg.Base)
+			}
+		} else {
+			base, err = lookup(strings.ToLower(cfg.Base), custom, append(visiting, name))
+			if err != nil {
+				return nil, err
+			}
+		}
+		p.all = base.all
+		for category := range base.categories {
+			p.categories[category] = true
+		}
+		for id := range base.include {
+			p.include[id] = true
+		}
+		for id := range base.exclude {
+			p.exclude[id] = true
+		}
+	}
+
+	for _, c := range cfg.Categories {
+		category, ok := checker.ParseCategory(c)
+		if !ok {
+			return nil, fmt.Errorf("profile %q: unknown category %q", name, c)
+		}
+		p.categories[category] = true
+	}
+	for _, id := range cfg.Checks {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		p.include[id] = true
+		delete(p.exclude, id)
+	}
+	for _, id := range cfg.Exclude {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		p.exclude[id] = true
+		delete(p.include, id)
+	}
+
+	return p, nil
+}
+
+// Includes reports whether the check described by meta is part of the
+// profile.
+func (p *Profile) Includes(meta checker.Metadata) bool {
+	if p.exclude[meta.ID] {
+		return false
+	}
+	return p.all || p.include[meta.ID] || p.categories[meta.Category]
+}
+
+// Categories returns the categories the profile selects in presentation
+// order. It is empty for a profile that selects every check.
+func (p *Profile) Categories() []checker.Category {
+	var categories []checker.Category
+	for _, category := range checker.Categories() {
+		if p.categories[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func categorySet(categories ...checker.Category) map[checker.Category]bool {
+	set := make(map[checker.Category]bool, len(categories))
+	for _, c := range categories {
+		set[c] = true
+	}
+	return set
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,136 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/config"
+)
+
+func meta(id string) checker.Metadata {
+	return checker.Metadata{ID: id, Category: checker.CategoryForID(id)}
+}
+
+func TestBuiltinProfiles(t *testing.T) {
+	tests := []struct {
+		profile  string
+		included []string
+		excluded []string
+	}{
+		{Store, []string{"AND-001", "IOS-001", "FLT-001", "SEC-001", "POL-001", "REV-001", "AI-001"}, []string{"FLT-002", "FLT-008", "COD-001", "TST-001", "LINT-001", "DOC-001", "PERF-001"}},
+		{Quality, []string{"COD-001", "TST-001", "LINT-001", "DOC-001", "PERF-001", "FLT-002", "FLT-006"}, []string{"AND-001", "FLT-001", "SEC-001", "AI-001"}},
+		{Full, []string{"AND-001", "COD-001", "FLT-002", "AI-001", "ACME-001"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			p, err := Lookup(tt.profile, nil)
+			if err != nil {
+				t.Fatalf("Lookup failed: %v", err)
+			}
+			for _, id := range tt.included {
+				if !p.Includes(meta(id)) {
+					t.Errorf("expected %s in %s", id, tt.profile)
+				}
+			}
+			for _, id := range tt.excluded {
+				if p.Includes(meta(id)) {
+					t.Errorf("expected %s not in %s", id, tt.profile)
+				}
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		p, err := Lookup("", nil)
+		if err != nil || p.Name != Store {
+			t.Errorf("expected store profile, got %v, %v", p, err)
+		}
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		p, err := Lookup("Quality", nil)
+		if err != nil || p.Name != Quality {
+			t.Errorf("expected quality profile, got %v, %v", p, err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := Lookup("nope", nil); err == nil || !strings.Contains(err.Error(), "store") {
+			t.Errorf("expected error listing available profiles, got %v", err)
+		}
+	})
+
+	t.Run("custom not defined", func(t *testing.T) {
+		if _, err := Lookup(Custom, nil); err == nil || !strings.Contains(err.Error(), ".fsct.yaml") {
+			t.Errorf("expected error pointing at .fsct.yaml, got %v", err)
+		}
+	})
+}
+
+func TestCustomProfile(t *testing.T) {
+	custom := map[string]*config.ProfileConfig{
+		"Custom": {
+			Base:       "store",
+			Categories: []string{"Testing", "LINT"},
+			Checks:     []string{"cod-001", "FLT-002"},
+			Exclude:    []string{"AND-007", "TST-006"},
+		},
+	}
+
+	p, err := Lookup(Custom, custom)
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+
+	for _, id := range []string{"AND-001", "TST-001", "LINT-001", "COD-001", "FLT-002"} {
+		if !p.Includes(meta(id)) {
+			t.Errorf("expected %s in custom profile", id)
+		}
+	}
+	for _, id := range []string{"AND-007", "TST-006", "COD-002", "FLT-006", "DOC-001"} {
+		if p.Includes(meta(id)) {
+			t.Errorf("expected %s not in custom profile", id)
+		}
+	}
+
+	categories := p.Categories()
+	if len(categories) != 9 || categories[0] != checker.CategoryAndroid {
+		t.Errorf("expected store categories plus Testing and Linting, got %v", categories)
+	}
+}
+
+func TestCustomProfileErrors(t *testing.T) {
+	t.Run("unknown category", func(t *testing.T) {
+		custom := map[string]*config.ProfileConfig{"mine": {Categories: []string{"Nope"}}}
+		if _, err := Lookup("mine", custom); err == nil {
+			t.Error("expected error for unknown category")
+		}
+	})
+
+	t.Run("base cycle", func(t *testing.T) {
+		custom := map[string]*config.ProfileConfig{
+			"a": {Base: "b"},
+			"b": {Base: "a"},
+		}
+		if _, err := Lookup("a", custom); err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Errorf("expected cycle error, got %v", err)
+		}
+	})
+
+	t.Run("shadowing builtin", func(t *testing.T) {
+		custom := map[string]*config.ProfileConfig{
+			"store": {Base: "store", Exclude: []string{"AND-001"}},
+		}
+		p, err := Lookup(Store, custom)
+		if err != nil {
+			t.Fatalf("Lookup failed: %v", err)
+		}
+		if p.Includes(meta("AND-001")) || !p.Includes(meta("AND-002")) {
+			t.Error("expected store refined without AND-001")
+		}
+	})
+}
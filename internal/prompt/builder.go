@@ -60,7 +60,9 @@ type templateData struct {
 	IsReadyForPlayStore bool
 	TotalChecks       int
 	PassedChecks      int
+	CriticalCount     int
 	HighCount         int
+	ErrorCount        int
 	WarningCount      int
 	InfoCount         int
 	Blockers          []BlockerInfo
@@ -83,9 +85,11 @@ type templateData struct {
 
 // findingsBySeverity wraps findings for template access
 type findingsBySeverity struct {
-	HIGH    []FindingSummary
-	WARNING []FindingSummary
-	INFO    []FindingSummary
+	CRITICAL []FindingSummary
+	HIGH     []FindingSummary
+	ERROR    []FindingSummary
+	WARNING  []FindingSummary
+	INFO     []FindingSummary
 }
 
 // prepareTemplateData converts PromptData to template-friendly format
@@ -103,14 +107,18 @@ func (pb *PromptBuilder) prepareTemplateData(data *PromptData) *templateData {
 		IsReadyForPlayStore: data.IsReadyForPlayStore(),
 		TotalChecks:         data.TotalChecks,
 		PassedChecks:        data.PassedChecks,
+		CriticalCount:       data.GetCriticalCount(),
 		HighCount:           data.GetHighCount(),
+		ErrorCount:          data.GetErrorCount(),
 		WarningCount:        data.GetWarningCount(),
 		InfoCount:           data.GetInfoCount(),
 		Blockers:            data.Blockers,
 		FindingsBySeverity: findingsBySeverity{
-			HIGH:    data.FindingsBySeverity[report.SeverityHigh],
-			WARNING: data.FindingsBySeverity[report.SeverityWarning],
-			INFO:    data.FindingsBySeverity[report.SeverityInfo],
+			CRITICAL: data.FindingsBySeverity[report.SeverityCritical],
+			HIGH:     data.FindingsBySeverity[report.SeverityHigh],
+			ERROR:    data.FindingsBySeverity[report.SeverityError],
+			WARNING:  data.FindingsBySeverity[report.SeverityWarning],
+			INFO:     data.FindingsBySeverity[report.SeverityInfo],
 		},
 		FindingsByCategory: data.FindingsByCategory,
 		AndroidConfig:      data.AndroidConfig,
@@ -180,6 +180,16 @@ func (p *PromptData) AddFinding(f FindingSummary) {
 	p.FindingsByCategory[f.Category] = append(p.FindingsByCategory[f.Category], f)
 }
 
+// GetCriticalCount returns number of CRITICAL severity findings
+func (p *PromptData) GetCriticalCount() int {
+	return len(p.FindingsBySeverity[report.SeverityCritical])
+}
+
+// GetErrorCount returns number of ERROR severity findings
+func (p *PromptData) GetErrorCount() int {
+	return len(p.FindingsBySeverity[report.SeverityError])
+}
+
 // GetHighCount returns number of HIGH severity findings
 func (p *PromptData) GetHighCount() int {
 	return len(p.FindingsBySeverity[report.SeverityHigh])
@@ -203,8 +213,8 @@ func (p *PromptData) IsReadyForAppStore() bool {
 			return false
 		}
 	}
-	// Check for any HIGH severity iOS findings
-	for _, f := range p.FindingsBySeverity[report.SeverityHigh] {
+	// Check for any HIGH or CRITICAL severity iOS findings
+	for _, f := range p.blocking() {
 		if f.Category == "iOS" || f.Category == "Security" {
 			return false
 		}
@@ -220,8 +230,8 @@ func (p *PromptData) IsReadyForPlayStore() bool {
 			return false
 		}
 	}
-	// Check for any HIGH severity Android findings
-	for _, f := range p.FindingsBySeverity[report.SeverityHigh] {
+	// Check for any HIGH or CRITICAL severity Android findings
+	for _, f := range p.blocking() {
 		if f.Category == "Android" || f.Category == "Security" {
 			return false
 		}
@@ -229,6 +239,12 @@ func (p *PromptData) IsReadyForPlayStore() bool {
 	return true
 }
 
+// blocking returns the CRITICAL and HIGH severity findings
+func (p *PromptData) blocking() []FindingSummary {
+	blocking := append([]FindingSummary(nil), p.FindingsBySeverity[report.SeverityCritical]...)
+	return append(blocking, p.FindingsBySeverity[report.SeverityHigh]...)
+}
+
 // CalculateComplianceScore computes overall compliance score
 func (p *PromptData) CalculateComplianceScore() int {
 	if p.TotalChecks == 0 {
@@ -237,11 +253,13 @@ func (p *PromptData) CalculateComplianceScore() int {
 	// Score based on passed checks
 	passed := p.PassedChecks
 	// Deduct for high severity issues
+	criticalPenalty := p.GetCriticalCount() * 20
 	highPenalty := p.GetHighCount() * 10
+	errorPenalty := p.GetErrorCount() * 5
 	warningPenalty := p.GetWarningCount() * 3
 	infoPenalty := p.GetInfoCount()
 
-	score := (passed * 100 / p.TotalChecks) - highPenalty - warningPenalty - infoPenalty
+	score := (passed * 100 / p.TotalChecks) - criticalPenalty - highPenalty - errorPenalty - warningPenalty - infoPenalty
 	if score < 0 {
 		score = 0
 	}
@@ -254,7 +272,7 @@ func (p *PromptData) CalculateComplianceScore() int {
 // GetStatus returns overall compliance status
 func (p *PromptData) GetStatus() string {
 	score := p.CalculateComplianceScore()
-	highCount := p.GetHighCount()
+	highCount := p.GetCriticalCount() + p.GetHighCount()
 
 	if highCount > 0 {
 		return "❌ NEEDS CRITICAL ATTENTION"
@@ -72,7 +72,7 @@ func (b *Builder) buildMetadata() {
 // buildFindings processes findings into summary format
 func (b *Builder) buildFindings(findings []report.Finding) {
 	for _, f := range findings {
-		category := extractCategory(f.ID)
+		category := string(checker.CategoryForID(f.ID))
 		summary := FindingSummary{
 			ID:         f.ID,
 			Severity:   f.Severity,
@@ -396,42 +396,3 @@ func (b *Builder) buildBlockers() {
 
 // Helper functions
 
-func extractCategory(id string) string {
-	if strings.HasPrefix(id, "AND-") {
-		return "Android"
-	}
-	if strings.HasPrefix(id, "IOS-") {
-		return "iOS"
-	}
-	if strings.HasPrefix(id, "FLT-") {
-		return "Flutter"
-	}
-	if strings.HasPrefix(id, "SEC-") {
-		return "Security"
-	}
-	if strings.HasPrefix(id, "POL-") {
-		return "Policy"
-	}
-	if strings.HasPrefix(id, "COD-") {
-		return "Code Quality"
-	}
-	if strings.HasPrefix(id, "TST-") {
-		return "Testing"
-	}
-	if strings.HasPrefix(id, "LINT-") {
-		return "Linting"
-	}
-	if strings.HasPrefix(id, "DOC-") {
-		return "Documentation"
-	}
-	if strings.HasPrefix(id, "PERF-") {
-		return "Performance"
-	}
-	if strings.HasPrefix(id, "REV-") {
-		return "Reviewer"
-	}
-	if strings.HasPrefix(id, "AI-") {
-		return "AI Analysis"
-	}
-	return "Other"
-}
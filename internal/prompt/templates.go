@@ -30,7 +30,9 @@ STATIC ANALYSIS RESULTS
 
 Total Checks: {{.TotalChecks}}
 Passed: {{.PassedChecks}}
+Critical: {{.CriticalCount}}
 High Severity: {{.HighCount}}
+Errors: {{.ErrorCount}}
 Warnings: {{.WarningCount}}
 Info: {{.InfoCount}}
 
@@ -46,6 +48,18 @@ Info: {{.InfoCount}}
 {{end}}
 {{end}}
 
+{{if .FindingsBySeverity.CRITICAL}}
+───────────────────────────────────────────────────────────────────────────────
+CRITICAL SEVERITY ISSUES (Certain Rejection)
+───────────────────────────────────────────────────────────────────────────────
+{{range .FindingsBySeverity.CRITICAL}}
+• [{{.ID}}] {{.Title}}
+  {{.Message}}
+  File: {{.File}}
+  Suggestion: {{.Suggestion}}
+{{end}}
+{{end}}
+
 {{if .FindingsBySeverity.HIGH}}
 ───────────────────────────────────────────────────────────────────────────────
 HIGH SEVERITY ISSUES
@@ -58,6 +72,18 @@ HIGH SEVERITY ISSUES
 {{end}}
 {{end}}
 
+{{if .FindingsBySeverity.ERROR}}
+───────────────────────────────────────────────────────────────────────────────
+ERROR SEVERITY ISSUES
+───────────────────────────────────────────────────────────────────────────────
+{{range .FindingsBySeverity.ERROR}}
+• [{{.ID}}] {{.Title}}
+  {{.Message}}
+  File: {{.File}}
+  Suggestion: {{.Suggestion}}
+{{end}}
+{{end}}
+
 {{if .FindingsBySeverity.WARNING}}
 ───────────────────────────────────────────────────────────────────────────────
 WARNING SEVERITY ISSUES
@@ -279,7 +305,9 @@ SCORE: {{.ComplianceScore}}/100
 STATUS: {{.Status}}
 
 FINDINGS:
+- Critical: {{.CriticalCount}}
 - High: {{.HighCount}}
+- Errors: {{.ErrorCount}}
 - Warnings: {{.WarningCount}}
 - Info: {{.InfoCount}}
 - Passed: {{.PassedChecks}}/{{.TotalChecks}}
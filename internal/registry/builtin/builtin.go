@@ -0,0 +1,20 @@
+// Package builtin links every built-in check into the registry catalog.
+// Import it for its side effects:
+//
+//	import _ "github.com/ricky-irfandi/fsct/internal/registry/builtin"
+package builtin
+
+import (
+	_ "github.com/ricky-irfandi/fsct/internal/checker/ai"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/android"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/code"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/docs"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/flutter"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/ios"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/linting"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/perf"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/policy"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/reviewer"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/security"
+	_ "github.com/ricky-irfandi/fsct/internal/checker/testing"
+)
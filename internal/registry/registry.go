@@ -1,18 +1,95 @@
+// Package registry holds the catalog of checks fsct knows about.
+//
+// Check packages add their checks to the catalog from init with Register or
+// RegisterFactory, so linking a package in is enough to make its checks
+// available. The built-in checks are linked by importing
+// internal/registry/builtin; in-house checks can be added the same way from
+// any package, without editing this one.
 package registry
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	aipkg "github.com/ricky-irfandi/fsct/internal/ai"
 	"github.com/ricky-irfandi/fsct/internal/checker"
-	aichecks "github.com/ricky-irfandi/fsct/internal/checker/ai"
-	"github.com/ricky-irfandi/fsct/internal/checker/android"
-	"github.com/ricky-irfandi/fsct/internal/checker/flutter"
-	"github.com/ricky-irfandi/fsct/internal/checker/ios"
-	"github.com/ricky-irfandi/fsct/internal/checker/policy"
-	"github.com/ricky-irfandi/fsct/internal/checker/reviewer"
-	"github.com/ricky-irfandi/fsct/internal/checker/security"
 	"github.com/ricky-irfandi/fsct/internal/config"
 )
 
+// Environment carries what a factory may need to build its check.
+type Environment struct {
+	AI       *aipkg.Client
+	Reviewer *config.ReviewerConfig
+}
+
+// Factory builds a check for one run. It returns nil when the check cannot
+// run in env, for example an AI check without a client.
+type Factory func(env Environment) checker.Check
+
+type definition struct {
+	prototype checker.Check
+	factory   Factory
+}
+
+var (
+	mu          sync.RWMutex
+	definitions = make(map[string]definition)
+)
+
+// Register adds stateless checks to the catalog. Every registry loads them
+// as they are. It panics if a check ID is already registered.
+func Register(checks ...checker.Check) {
+	for _, c := range checks {
+		c := c
+		RegisterFactory(c, func(Environment) checker.Check { return c })
+	}
+}
+
+// RegisterFactory adds a check that is built per registry by factory.
+// prototype identifies and describes the check in the catalog and is never
+// run. It panics if the check ID is already registered.
+func RegisterFactory(prototype checker.Check, factory Factory) {
+	if prototype == nil || factory == nil {
+		panic("registry: RegisterFactory called with a nil check or factory")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := prototype.ID()
+	if _, exists := definitions[id]; exists {
+		panic(fmt.Sprintf("registry: check %s registered twice", id))
+	}
+	definitions[id] = definition{prototype: prototype, factory: factory}
+}
+
+// Catalog returns metadata for every registered check, including AI and
+// reviewer checks that only load when configured, sorted by ID.
+func Catalog() []checker.Metadata {
+	defs := sortedDefinitions()
+	catalog := make([]checker.Metadata, 0, len(defs))
+	for _, def := range defs {
+		catalog = append(catalog, checker.Describe(def.prototype))
+	}
+	return catalog
+}
+
+func sortedDefinitions() []definition {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	defs := make([]definition, 0, len(definitions))
+	for _, def := range definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].prototype.ID() < defs[j].prototype.ID()
+	})
+	return defs
+}
+
+// CheckerRegistry is the set of checks selected for one run.
 type CheckerRegistry struct {
 	checks map[string]checker.Check
 }
@@ -23,65 +100,32 @@ func NewRegistry() *CheckerRegistry {
 	}
 }
 
+// Register adds a single check to this registry only. It returns an error
+// if a check with the same ID is already present.
+func (r *CheckerRegistry) Register(c checker.Check) error {
+	if _, exists := r.checks[c.ID()]; exists {
+		return fmt.Errorf("check %s is already registered", c.ID())
+	}
+	r.checks[c.ID()] = c
+	return nil
+}
+
+// Load builds every catalog check whose category is in categories, or every
+// check when none are given, and adds those that can run in env.
+func (r *CheckerRegistry) Load(env Environment, categories ...checker.Category) {
+	for _, def := range sortedDefinitions() {
+		if len(categories) > 0 && !containsCategory(categories, checker.Describe(def.prototype).Category) {
+			continue
+		}
+		if c := def.factory(env); c != nil {
+			r.checks[c.ID()] = c
+		}
+	}
+}
+
+// RegisterAll loads every check that needs no configuration.
 func (r *CheckerRegistry) RegisterAll() {
-	r.registerAndroidChecks()
-	r.registerIOSChecks()
-	r.registerFlutterChecks()
-	r.registerSecurityChecks()
-	r.registerPolicyChecks()
-}
-
-func (r *CheckerRegistry) registerAndroidChecks() {
-	r.checks["AND-001"] = &android.TargetSDKCheck{}
-	r.checks["AND-002"] = &android.MinSDKCheck{}
-	r.checks["AND-003"] = &android.InternetPermissionCheck{}
-	r.checks["AND-004"] = &android.DangerousPermissionsCheck{}
-	r.checks["AND-005"] = &android.DebuggableCheck{}
-	r.checks["AND-006"] = &android.ExportedAttributeCheck{}
-	r.checks["AND-007"] = &android.MissingAppIconCheck{}
-	r.checks["AND-008"] = &android.PlaceholderIconCheck{}
-	r.checks["AND-009"] = &android.ApplicationIDCheck{}
-	r.checks["AND-010"] = &android.VersionCodeCheck{}
-	r.checks["AND-011"] = &android.PackageVisibilityCheck{}
-	r.checks["AND-012"] = &android.AllowBackupCheck{}
-}
-
-func (r *CheckerRegistry) registerIOSChecks() {
-	r.checks["IOS-001"] = &ios.CameraUsageDescriptionCheck{}
-	r.checks["IOS-002"] = &ios.PhotoLibraryUsageDescriptionCheck{}
-	r.checks["IOS-003"] = &ios.LocationUsageDescriptionCheck{}
-	r.checks["IOS-004"] = &ios.MicrophoneUsageDescriptionCheck{}
-	r.checks["IOS-005"] = &ios.ContactsUsageDescriptionCheck{}
-	r.checks["IOS-006"] = &ios.CalendarsUsageDescriptionCheck{}
-	r.checks["IOS-007"] = &ios.EmptyUsageDescriptionCheck{}
-	r.checks["IOS-008"] = &ios.MissingAppIconCheck{}
-	r.checks["IOS-009"] = &ios.Missing1024IconCheck{}
-	r.checks["IOS-010"] = &ios.FullScreenConflictCheck{}
-	r.checks["IOS-011"] = &ios.EncryptionDeclarationCheck{}
-	r.checks["IOS-012"] = &ios.DeploymentTargetCheck{}
-}
-
-func (r *CheckerRegistry) registerFlutterChecks() {
-	r.checks["FLT-001"] = &flutter.FlutterSDKVersionCheck{}
-	r.checks["FLT-003"] = &flutter.MinSDKVersionCheck{}
-	r.checks["FLT-004"] = &flutter.PackageNameCheck{}
-	r.checks["FLT-005"] = &flutter.VersionCheck{}
-}
-
-func (r *CheckerRegistry) registerSecurityChecks() {
-	r.checks["SEC-001"] = &security.HardcodedCredentialsCheck{}
-	r.checks["SEC-002"] = &security.DebugModeCheck{}
-	r.checks["SEC-003"] = &security.InsecureHTTPCheck{}
-	r.checks["SEC-004"] = &security.ExportedActivityCheck{}
-	r.checks["SEC-005"] = &security.SQLInjectionCheck{}
-}
-
-func (r *CheckerRegistry) registerPolicyChecks() {
-	r.checks["POL-001"] = &policy.PrivacyPolicyCheck{}
-	r.checks["POL-002"] = &policy.TermsOfServiceCheck{}
-	r.checks["POL-003"] = &policy.DataDeletionCheck{}
-	r.checks["POL-004"] = &policy.LogoutCheck{}
-	r.checks["POL-005"] = &policy.AccountRecoveryCheck{}
+	r.Load(Environment{})
 }
 
 // RegisterAIChecks registers AI-powered checks if AI client is available
@@ -89,12 +133,16 @@ func (r *CheckerRegistry) RegisterAIChecks(client *aipkg.Client) {
 	if client == nil || !client.IsAvailable() {
 		return
 	}
+	r.Load(Environment{AI: client}, checker.CategoryAI)
+}
 
-	r.checks["AI-001"] = aichecks.AI001PermissionJustificationCheck(client)
-	r.checks["AI-002"] = aichecks.AI002PolicyComplianceCheck(client)
-	r.checks["AI-003"] = aichecks.AI003DependencyRiskCheck(client)
-	r.checks["AI-004"] = aichecks.AI004StoreGuidanceCheck(client)
-	r.checks["AI-005"] = aichecks.AI005ReviewerNotesCheck(client)
+// RegisterReviewerChecks registers reviewer verification checks. A nil
+// config is read from the environment.
+func (r *CheckerRegistry) RegisterReviewerChecks(cfg *config.ReviewerConfig) {
+	if cfg == nil {
+		cfg = config.ReviewerConfigFromEnv()
+	}
+	r.Load(Environment{Reviewer: cfg}, checker.CategoryReviewer)
 }
 
 func (r *CheckerRegistry) Get(id string) (checker.Check, bool) {
@@ -102,48 +150,80 @@ func (r *CheckerRegistry) Get(id string) (checker.Check, bool) {
 	return check, ok
 }
 
+// GetAll returns the registered checks sorted by ID.
 func (r *CheckerRegistry) GetAll() []checker.Check {
 	checks := make([]checker.Check, 0, len(r.checks))
 	for _, check := range r.checks {
 		checks = append(checks, check)
 	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].ID() < checks[j].ID()
+	})
 	return checks
 }
 
 func (r *CheckerRegistry) GetAllByID() map[string]checker.Check {
-	return r.checks
+	checks := make(map[string]checker.Check, len(r.checks))
+	for id, check := range r.checks {
+		checks[id] = check
+	}
+	return checks
+}
+
+// GetByCategory returns the registered checks in category, sorted by ID.
+func (r *CheckerRegistry) GetByCategory(category checker.Category) []checker.Check {
+	var checks []checker.Check
+	for _, c := range r.GetAll() {
+		if checker.Describe(c).Category == category {
+			checks = append(checks, c)
+		}
+	}
+	return checks
+}
+
+// GetByPlatform returns the registered checks that apply to platform,
+// including platform-independent ones, sorted by ID.
+func (r *CheckerRegistry) GetByPlatform(platform string) []checker.Check {
+	var checks []checker.Check
+	for _, c := range r.GetAll() {
+		if checker.Describe(c).AppliesTo(platform) {
+			checks = append(checks, c)
+		}
+	}
+	return checks
 }
 
 func (r *CheckerRegistry) Count() int {
 	return len(r.checks)
 }
 
-func (r *CheckerRegistry) GetCategories() []string {
-	return []string{
-		"Android", "iOS", "Flutter", "Security", "Policy",
-		"AI Analysis", "Reviewer",
+// GetCategories returns the categories of the registered checks in
+// presentation order.
+func (r *CheckerRegistry) GetCategories() []checker.Category {
+	present := make(map[checker.Category]bool)
+	for _, c := range r.checks {
+		present[checker.Describe(c).Category] = true
+	}
+
+	var categories []checker.Category
+	for _, category := range checker.Categories() {
+		if present[category] {
+			categories = append(categories, category)
+		}
 	}
+	return categories
 }
 
 // HasAIChecks returns true if AI checks are registered
 func (r *CheckerRegistry) HasAIChecks() bool {
-	_, hasAI001 := r.checks["AI-001"]
-	return hasAI001
+	return len(r.GetByCategory(checker.CategoryAI)) > 0
 }
 
-// RegisterReviewerChecks registers reviewer verification checks
-func (r *CheckerRegistry) RegisterReviewerChecks(cfg *config.ReviewerConfig) {
-	if cfg == nil {
-		cfg = reviewer.GetConfigFromEnv()
-	}
-
-	// Always register basic credential checks
-	r.checks["REV-001"] = reviewer.NewNoCredentialsCheck(cfg)
-	r.checks["REV-002"] = reviewer.NewPlaceholderEmailCheck(cfg)
-	r.checks["REV-003"] = reviewer.NewWeakPasswordCheck(cfg)
-
-	// Register login verification only if enabled
-	if cfg != nil && cfg.Verification != nil && cfg.Verification.Enabled {
-		r.checks["REV-004"] = reviewer.NewLoginVerificationCheck(cfg)
+func containsCategory(categories []checker.Category, category checker.Category) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
 	}
+	return false
 }
@@ -1,34 +1,97 @@
-package registry
+package registry_test
 
 import (
+	"slices"
+	"sort"
 	"testing"
 
 	aipkg "github.com/ricky-irfandi/fsct/internal/ai"
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/checker/android"
+	"github.com/ricky-irfandi/fsct/internal/config"
+	"github.com/ricky-irfandi/fsct/internal/registry"
+	_ "github.com/ricky-irfandi/fsct/internal/registry/builtin"
+	"github.com/ricky-irfandi/fsct/internal/report"
 )
 
 func TestNewRegistry(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	if reg == nil {
 		t.Fatal("expected registry to be created")
 	}
 
-	if reg.checks == nil {
-		t.Error("expected checks map to be initialized")
+	if reg.Count() != 0 {
+		t.Errorf("expected an empty registry, got %d checks", reg.Count())
 	}
 }
 
+// checkIDs lists the built-in checks by category. Adding, moving or removing
+// a check means updating it.
+var checkIDs = map[checker.Category][]string{
+	checker.CategoryAndroid: {
+		"AND-001", "AND-002", "AND-003", "AND-004", "AND-005", "AND-006", "AND-007",
+		"AND-008", "AND-009", "AND-010", "AND-011", "AND-012", "AND-013", "AND-014",
+	},
+	checker.CategoryIOS: {
+		"IOS-001", "IOS-002", "IOS-003", "IOS-004", "IOS-005", "IOS-006", "IOS-007", "IOS-008",
+		"IOS-009", "IOS-010", "IOS-011", "IOS-012", "IOS-013", "IOS-014", "IOS-015", "IOS-016",
+		"IOS-017", "IOS-018", "IOS-019", "IOS-020", "IOS-021", "IOS-022", "IOS-023", "IOS-024",
+	},
+	checker.CategoryFlutter: {
+		"FLT-001", "FLT-002", "FLT-003", "FLT-004", "FLT-005", "FLT-006", "FLT-007", "FLT-008",
+	},
+	checker.CategorySecurity:      {"SEC-001", "SEC-002", "SEC-003", "SEC-004", "SEC-005"},
+	checker.CategoryPolicy:        {"POL-001", "POL-002", "POL-003", "POL-004", "POL-005"},
+	checker.CategoryCodeQuality:   {"COD-001", "COD-002", "COD-003", "COD-004", "COD-005", "COD-006", "COD-007"},
+	checker.CategoryTesting:       {"TST-001", "TST-002", "TST-003", "TST-004", "TST-005", "TST-006"},
+	checker.CategoryLinting:       {"LINT-001", "LINT-002", "LINT-003", "LINT-004", "LINT-005", "LINT-006", "LINT-007"},
+	checker.CategoryDocumentation: {"DOC-001", "DOC-002", "DOC-003", "DOC-004", "DOC-005", "DOC-006"},
+	checker.CategoryPerformance:   {"PERF-001", "PERF-002", "PERF-003", "PERF-004", "PERF-005", "PERF-006"},
+	checker.CategoryReviewer:      {"REV-001", "REV-002", "REV-003", "REV-004"},
+	checker.CategoryAI:            {"AI-001", "AI-002", "AI-003", "AI-004", "AI-005"},
+}
+
+// idsIn returns the sorted IDs of checkIDs that pass keep.
+func idsIn(keep func(checker.Category) bool) []string {
+	var ids []string
+	for category, categoryIDs := range checkIDs {
+		if keep(category) {
+			ids = append(ids, categoryIDs...)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// coreIDs are the checks that load without configuration: all but the AI
+// and reviewer checks.
+func coreIDs() []string {
+	return idsIn(func(category checker.Category) bool {
+		return category != checker.CategoryAI && category != checker.CategoryReviewer
+	})
+}
+
+// loaded returns the sorted IDs of checks.
+func loaded(checks []checker.Check) []string {
+	ids := make([]string, 0, len(checks))
+	for _, c := range checks {
+		ids = append(ids, c.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func TestRegisterAll(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
-	// Should have 38 checks (no AI checks yet)
-	if reg.Count() != 38 {
-		t.Errorf("expected 38 checks, got %d", reg.Count())
+	if got, want := loaded(reg.GetAll()), coreIDs(); !slices.Equal(got, want) {
+		t.Errorf("expected the checks without AI or reviewer checks\ngot  %v\nwant %v", got, want)
 	}
 }
 
 func TestRegisterAIChecks(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	// Create a mock AI client
@@ -37,53 +100,44 @@ func TestRegisterAIChecks(t *testing.T) {
 	// Register AI checks
 	reg.RegisterAIChecks(client)
 
-	// Should now have 43 checks (38 + 5 AI)
-	if reg.Count() != 43 {
-		t.Errorf("expected 43 checks after AI registration, got %d", reg.Count())
+	want := append(coreIDs(), checkIDs[checker.CategoryAI]...)
+	sort.Strings(want)
+	if got := loaded(reg.GetAll()); !slices.Equal(got, want) {
+		t.Errorf("expected the AI checks to be added\ngot  %v\nwant %v", got, want)
 	}
-
-	// Check specific AI checks
-	aiChecks := []string{"AI-001", "AI-002", "AI-003", "AI-004", "AI-005"}
-	for _, id := range aiChecks {
-		check, ok := reg.Get(id)
-		if !ok {
-			t.Errorf("expected AI check %s to be registered", id)
-		}
-		if check.ID() != id {
-			t.Errorf("expected check ID %s, got %s", id, check.ID())
-		}
+	if got := loaded(reg.GetByCategory(checker.CategoryAI)); !slices.Equal(got, checkIDs[checker.CategoryAI]) {
+		t.Errorf("expected AI checks %v, got %v", checkIDs[checker.CategoryAI], got)
 	}
 }
 
 func TestRegisterAIChecksWithNilClient(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	// Register with nil client
 	reg.RegisterAIChecks(nil)
 
-	// Count should remain 38
-	if reg.Count() != 38 {
-		t.Errorf("expected 38 checks with nil AI client, got %d", reg.Count())
+	if got := loaded(reg.GetAll()); !slices.Equal(got, coreIDs()) {
+		t.Errorf("expected no AI checks with a nil client, got %v", got)
 	}
 }
 
 func TestRegisterAIChecksWithUnavailableClient(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	// Register AI checks with an unconfigured client
 	unconfiguredClient := &aipkg.Client{}
 	reg.RegisterAIChecks(unconfiguredClient)
 
-	// Count should remain 38 since client is not available
-	if reg.Count() != 38 {
-		t.Errorf("expected 38 checks with unavailable AI client, got %d", reg.Count())
+	// Count should not change since client is not available
+	if got := loaded(reg.GetAll()); !slices.Equal(got, coreIDs()) {
+		t.Errorf("expected no AI checks with an unavailable client, got %v", got)
 	}
 }
 
 func TestGet(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	// Test getting an existing check
@@ -103,22 +157,120 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetAll(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	checks := reg.GetAll()
-	if len(checks) != 38 {
-		t.Errorf("expected 38 checks, got %d", len(checks))
+	if len(checks) != reg.Count() {
+		t.Errorf("expected %d checks, got %d", reg.Count(), len(checks))
+	}
+
+	for i := 1; i < len(checks); i++ {
+		if checks[i-1].ID() >= checks[i].ID() {
+			t.Errorf("expected checks sorted by ID, got %s before %s", checks[i-1].ID(), checks[i].ID())
+		}
+	}
+}
+
+func TestGetByCategory(t *testing.T) {
+	reg := registry.NewRegistry()
+	reg.RegisterAll()
+
+	for category, want := range checkIDs {
+		if category == checker.CategoryAI || category == checker.CategoryReviewer {
+			continue
+		}
+		if got := loaded(reg.GetByCategory(category)); !slices.Equal(got, want) {
+			t.Errorf("%s: expected %v, got %v", category, want, got)
+		}
+	}
+
+	if checks := reg.GetByCategory(checker.CategoryAI); len(checks) != 0 {
+		t.Errorf("expected no AI checks without a client, got %d", len(checks))
 	}
 }
 
+func TestGetByPlatform(t *testing.T) {
+	reg := registry.NewRegistry()
+	reg.RegisterAll()
+
+	ids := make(map[string]bool)
+	for _, c := range reg.GetByPlatform(checker.PlatformIOS) {
+		ids[c.ID()] = true
+	}
+
+	if !ids["IOS-001"] {
+		t.Error("expected IOS-001 for ios")
+	}
+	if !ids["SEC-001"] {
+		t.Error("expected platform-independent SEC-001 for ios")
+	}
+	if ids["AND-006"] {
+		t.Error("expected android-only AND-006 to be excluded for ios")
+	}
+
+	if len(reg.GetByPlatform("both")) != reg.Count() {
+		t.Error("expected every check for both platforms")
+	}
+}
+
+func TestRegisterReviewerChecks(t *testing.T) {
+	reg := registry.NewRegistry()
+	reg.RegisterReviewerChecks(&config.ReviewerConfig{})
+
+	// Every reviewer check but REV-004 runs without verification.
+	if got, want := loaded(reg.GetAll()), []string{"REV-001", "REV-002", "REV-003"}; !slices.Equal(got, want) {
+		t.Errorf("expected reviewer checks %v without verification, got %v", want, got)
+	}
+
+	reg.RegisterReviewerChecks(&config.ReviewerConfig{
+		Verification: &config.VerificationConfig{Enabled: true},
+	})
+	if _, ok := reg.Get("REV-004"); !ok {
+		t.Error("expected REV-004 with verification enabled")
+	}
+}
+
+type inHouseCheck struct{}
+
+func (c *inHouseCheck) ID() string                                    { return "ACME-001" }
+func (c *inHouseCheck) Name() string                                  { return "In-house Check" }
+func (c *inHouseCheck) Run(project *checker.Project) []report.Finding { return nil }
+
+func TestRegister(t *testing.T) {
+	reg := registry.NewRegistry()
+	reg.RegisterAll()
+
+	if err := reg.Register(&inHouseCheck{}); err != nil {
+		t.Fatalf("expected in-house check to register, got %v", err)
+	}
+	if _, ok := reg.Get("ACME-001"); !ok {
+		t.Error("expected ACME-001 to be registered")
+	}
+	if err := reg.Register(&inHouseCheck{}); err == nil {
+		t.Error("expected an error for a duplicate ID")
+	}
+	if err := reg.Register(&android.TargetSDKCheck{}); err == nil {
+		t.Error("expected an error for a duplicate built-in ID")
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering AND-001 twice to panic")
+		}
+	}()
+	registry.Register(&android.TargetSDKCheck{})
+}
+
 func TestGetAllByID(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	checks := reg.GetAllByID()
-	if len(checks) != 38 {
-		t.Errorf("expected 38 checks, got %d", len(checks))
+	if len(checks) != reg.Count() {
+		t.Errorf("expected %d checks, got %d", reg.Count(), len(checks))
 	}
 
 	// Verify specific check exists
@@ -128,7 +280,7 @@ func TestGetAllByID(t *testing.T) {
 }
 
 func TestCount(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 
 	if reg.Count() != 0 {
 		t.Errorf("expected 0 checks initially, got %d", reg.Count())
@@ -136,39 +288,40 @@ func TestCount(t *testing.T) {
 
 	reg.RegisterAll()
 
-	if reg.Count() != 38 {
-		t.Errorf("expected 38 checks after registration, got %d", reg.Count())
+	if want := len(coreIDs()); reg.Count() != want {
+		t.Errorf("expected %d checks after registration, got %d", want, reg.Count())
 	}
 }
 
 func TestGetCategories(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
+	if categories := reg.GetCategories(); len(categories) != 0 {
+		t.Errorf("expected no categories for an empty registry, got %v", categories)
+	}
+
+	reg.RegisterAll()
+	reg.RegisterReviewerChecks(&config.ReviewerConfig{})
+	reg.RegisterAIChecks(createMockAIClient())
 	categories := reg.GetCategories()
 
-	expectedCategories := []string{
+	expectedCategories := []checker.Category{
 		"Android", "iOS", "Flutter", "Security", "Policy",
-		"AI Analysis", "Reviewer",
+		"Code Quality", "Testing", "Linting", "Documentation", "Performance",
+		"Reviewer", "AI Analysis",
 	}
 
 	if len(categories) != len(expectedCategories) {
-		t.Errorf("expected %d categories, got %d", len(expectedCategories), len(categories))
+		t.Fatalf("expected %d categories, got %v", len(expectedCategories), categories)
 	}
-
-	// Check for AI Analysis category
-	hasAI := false
-	for _, cat := range categories {
-		if cat == "AI Analysis" {
-			hasAI = true
-			break
+	for i, category := range expectedCategories {
+		if categories[i] != category {
+			t.Errorf("expected %s at %d, got %s", category, i, categories[i])
 		}
 	}
-	if !hasAI {
-		t.Error("expected 'AI Analysis' in categories")
-	}
 }
 
 func TestHasAIChecks(t *testing.T) {
-	reg := NewRegistry()
+	reg := registry.NewRegistry()
 	reg.RegisterAll()
 
 	// Initially should not have AI checks
@@ -193,3 +346,58 @@ func createMockAIClient() *aipkg.Client {
 	provider, _ := factory.Create("minimax")
 	return aipkg.NewClient(provider, nil)
 }
+
+func TestCatalog(t *testing.T) {
+	catalog := registry.Catalog()
+
+	reg := registry.NewRegistry()
+	reg.RegisterAll()
+	reg.RegisterAIChecks(createMockAIClient())
+	reg.RegisterReviewerChecks(&config.ReviewerConfig{
+		Verification: &config.VerificationConfig{Enabled: true},
+	})
+	all := idsIn(func(checker.Category) bool { return true })
+	if got := loaded(reg.GetAll()); !slices.Equal(got, all) {
+		t.Errorf("expected every check to load when configured\ngot  %v\nwant %v", got, all)
+	}
+	for _, meta := range catalog {
+		if !slices.Contains(checkIDs[meta.Category], meta.ID) {
+			t.Errorf("%s: expected in the %s checks", meta.ID, meta.Category)
+		}
+	}
+	if len(catalog) != len(all) {
+		t.Errorf("expected %d catalog checks, got %d", len(all), len(catalog))
+	}
+
+	for i, meta := range catalog {
+		if i > 0 && catalog[i-1].ID >= meta.ID {
+			t.Errorf("expected catalog sorted by ID, got %s before %s", catalog[i-1].ID, meta.ID)
+		}
+		if meta.Category == checker.CategoryOther {
+			t.Errorf("%s: expected a category", meta.ID)
+		}
+		if meta.Description == "" || meta.Description == meta.Name {
+			t.Errorf("%s: expected a description", meta.ID)
+		}
+		if meta.Remediation == "" {
+			t.Errorf("%s: expected remediation text", meta.ID)
+		}
+	}
+}
+
+func TestCatalogPlatforms(t *testing.T) {
+	platforms := make(map[string][]string)
+	for _, meta := range registry.Catalog() {
+		platforms[meta.ID] = meta.Platforms
+	}
+
+	if p := platforms["AND-006"]; len(p) != 1 || p[0] != checker.PlatformAndroid {
+		t.Errorf("expected AND-006 to be android only, got %v", p)
+	}
+	if p := platforms["IOS-001"]; len(p) != 1 || p[0] != checker.PlatformIOS {
+		t.Errorf("expected IOS-001 to be ios only, got %v", p)
+	}
+	if p := platforms["SEC-001"]; len(p) != 0 {
+		t.Errorf("expected SEC-001 to apply to all platforms, got %v", p)
+	}
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Fingerprint identifies a finding across runs. It hashes the check ID, the
+// normalised file path and subject, the thing the finding is about: a
+// permission, activity or plist key, or the text of the flagged line. The
+// message and line number are deliberately left out so that rewording a
+// message or moving code does not change it.
+func Fingerprint(id, file, subject string) string {
+	h := sha256.New()
+	h.Write([]byte(strings.ToUpper(id)))
+	h.Write([]byte{0})
+	h.Write([]byte(NormalizePath(file)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(strings.Fields(subject), " ")))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// NormalizePath returns file as a clean, slash-separated relative path.
+// Directory paths keep their trailing slash.
+func NormalizePath(file string) string {
+	file = filepath.ToSlash(file)
+	if file == "" || strings.HasSuffix(file, "/") {
+		return file
+	}
+	return path.Clean(strings.TrimPrefix(file, "./"))
+}
+
+// WithSubject returns a copy of f about subject, for findings a check can
+// report several times for the same file, such as one per permission.
+func (f Finding) WithSubject(subject string) Finding {
+	f.Subject = subject
+	return f
+}
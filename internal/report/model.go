@@ -1,28 +1,60 @@
 package report
 
-type Severity string
-
-const (
-	SeverityInfo    Severity = "INFO"
-	SeverityWarning Severity = "WARNING"
-	SeverityHigh    Severity = "HIGH"
-)
-
 type Finding struct {
-	ID         string   `json:"id"`
-	Severity   Severity `json:"severity"`
-	Title      string   `json:"title"`
-	Message    string   `json:"message"`
-	File       string   `json:"file,omitempty"`
-	Line       int      `json:"line,omitempty"`
-	Suggestion string   `json:"suggestion,omitempty"`
+	ID          string       `json:"id"`
+	Severity    Severity     `json:"severity"`
+	Title       string       `json:"title"`
+	Message     string       `json:"message"`
+	File        string       `json:"file,omitempty"`
+	Line        int          `json:"line,omitempty"`
+	Suggestion  string       `json:"suggestion,omitempty"`
+	Subject     string       `json:"subject,omitempty"`
+	Fingerprint string       `json:"fingerprint,omitempty"`
+	Suppression *Suppression `json:"suppression,omitempty"`
+}
+
+// Suppression records the inline fsct-ignore directive that suppressed a
+// finding. Suppressed findings are reported but not counted.
+type Suppression struct {
+	Reason string `json:"reason"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
 }
 
 type Summary struct {
-	High    int `json:"high"`
-	Warning int `json:"warning"`
-	Info    int `json:"info"`
-	Passed  int `json:"passed"`
+	Critical int    `json:"critical"`
+	High     int    `json:"high"`
+	Error    int    `json:"error"`
+	Warning  int    `json:"warning"`
+	Info     int    `json:"info"`
+	Passed   int    `json:"passed"`
+	Checks   int    `json:"checks,omitempty"`
+	Profile  string `json:"profile,omitempty"`
+
+	Suppressed int `json:"suppressed,omitempty"`
+	Baselined  int `json:"baselined,omitempty"`
+}
+
+// Count adds a finding of the given severity to the summary. Unknown
+// severities count as info.
+func (s *Summary) Count(severity Severity) {
+	switch severity {
+	case SeverityCritical:
+		s.Critical++
+	case SeverityHigh:
+		s.High++
+	case SeverityError:
+		s.Error++
+	case SeverityWarning:
+		s.Warning++
+	default:
+		s.Info++
+	}
+}
+
+// Total returns the number of counted findings.
+func (s Summary) Total() int {
+	return s.Critical + s.High + s.Error + s.Warning + s.Info
 }
 
 type Report struct {
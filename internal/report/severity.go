@@ -0,0 +1,75 @@
+package report
+
+import (
+	"cmp"
+	"strings"
+)
+
+// Severity is how serious a finding is. Severities are ordered from
+// SeverityInfo to SeverityCritical; compare them with Compare or AtLeast
+// rather than ==.
+type Severity string
+
+const (
+	// SeverityInfo is advice that does not affect review.
+	SeverityInfo Severity = "INFO"
+	// SeverityWarning is a problem worth fixing that rarely blocks review.
+	SeverityWarning Severity = "WARNING"
+	// SeverityError is a defect likely to break the app for some users.
+	SeverityError Severity = "ERROR"
+	// SeverityHigh is a likely store rejection.
+	SeverityHigh Severity = "HIGH"
+	// SeverityCritical is a certain store rejection, such as a target SDK
+	// below the Google Play deadline.
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// Severities lists every severity from least to most severe.
+var Severities = []Severity{SeverityInfo, SeverityWarning, SeverityError, SeverityHigh, SeverityCritical}
+
+// ParseSeverity resolves a severity name such as "warning", ignoring case.
+func ParseSeverity(s string) (Severity, bool) {
+	severity := Severity(strings.ToUpper(strings.TrimSpace(s)))
+	return severity, severity.Valid()
+}
+
+// SeverityNames returns the severities as a lower-case list for messages,
+// e.g. "info, warning, error, high, or critical".
+func SeverityNames() string {
+	names := make([]string, len(Severities))
+	for i, s := range Severities {
+		names[i] = strings.ToLower(string(s))
+	}
+	return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+}
+
+// Valid reports whether s is one of Severities.
+func (s Severity) Valid() bool {
+	for _, v := range Severities {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank returns the position of s in Severities, from 1 for SeverityInfo.
+// Unknown severities rank as SeverityInfo.
+func (s Severity) Rank() int {
+	for i, v := range Severities {
+		if s == v {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// Compare returns -1, 0 or +1 as s is less, as or more severe than other.
+func (s Severity) Compare(other Severity) int {
+	return cmp.Compare(s.Rank(), other.Rank())
+}
+
+// AtLeast reports whether s is as severe as min or more.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.Compare(min) >= 0
+}
@@ -0,0 +1,49 @@
+package report
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Severity
+		ok   bool
+	}{
+		{"info", SeverityInfo, true},
+		{"Warning", SeverityWarning, true},
+		{"error", SeverityError, true},
+		{" HIGH ", SeverityHigh, true},
+		{"critical", SeverityCritical, true},
+		{"loud", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseSeverity(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("ParseSeverity(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSeverityOrder(t *testing.T) {
+	for i := 1; i < len(Severities); i++ {
+		lower, higher := Severities[i-1], Severities[i]
+		if lower.Compare(higher) != -1 || higher.Compare(lower) != 1 {
+			t.Errorf("expected %s < %s", lower, higher)
+		}
+		if !higher.AtLeast(lower) || lower.AtLeast(higher) {
+			t.Errorf("AtLeast is wrong for %s and %s", lower, higher)
+		}
+	}
+	if !SeverityWarning.AtLeast(SeverityWarning) {
+		t.Error("a severity should be at least itself")
+	}
+	if Severity("bogus").Rank() != SeverityInfo.Rank() {
+		t.Error("unknown severities should rank as info")
+	}
+}
+
+func TestSeverityNames(t *testing.T) {
+	if got, want := SeverityNames(), "info, warning, error, high, or critical"; got != want {
+		t.Errorf("SeverityNames() = %q, want %q", got, want)
+	}
+}
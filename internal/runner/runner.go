@@ -0,0 +1,213 @@
+// Package runner executes checks concurrently against a loaded project.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	// StatusPassed means the check completed without findings.
+	StatusPassed Status = "passed"
+	// StatusFailed means the check completed and reported findings.
+	StatusFailed Status = "failed"
+	// StatusError means the check returned an error or panicked.
+	StatusError Status = "error"
+	// StatusTimeout means the check exceeded its deadline.
+	StatusTimeout Status = "timeout"
+	// StatusCanceled means the run was canceled before the check finished.
+	StatusCanceled Status = "canceled"
+)
+
+// Options controls how checks are executed.
+type Options struct {
+	// Workers is the maximum number of checks run at once. Zero or less
+	// uses runtime.NumCPU.
+	Workers int
+	// Timeout bounds each check individually. Zero means no limit.
+	Timeout time.Duration
+}
+
+// Result records the outcome of one check.
+type Result struct {
+	ID       string
+	Name     string
+	Status   Status
+	Duration time.Duration
+	Findings []report.Finding
+	Err      error
+}
+
+// Run executes checks on a bounded worker pool and returns one result per
+// check, in the order the checks were given. Checks implementing
+// checker.ContextCheck receive a context carrying the per-check deadline;
+// other checks are abandoned when their deadline passes. A panicking check
+// is reported as an internal error finding instead of crashing the run.
+func Run(ctx context.Context, checks []checker.Check, project *checker.Project, opts Options) []Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+
+	results := make([]Result, len(checks))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runOne(ctx, checks[i], project, opts.Timeout)
+			}
+		}()
+	}
+
+	for i := range checks {
+		if ctx.Err() != nil {
+			results[i] = Result{
+				ID:     checks[i].ID(),
+				Name:   checks[i].Name(),
+				Status: StatusCanceled,
+				Err:    ctx.Err(),
+			}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// Findings returns the findings of all results in order.
+func Findings(results []Result) []report.Finding {
+	var findings []report.Finding
+	for _, r := range results {
+		findings = append(findings, r.Findings...)
+	}
+	return findings
+}
+
+// Passed returns the number of results with StatusPassed.
+func Passed(results []Result) int {
+	passed := 0
+	for _, r := range results {
+		if r.Status == StatusPassed {
+			passed++
+		}
+	}
+	return passed
+}
+
+type outcome struct {
+	findings []report.Finding
+	err      error
+}
+
+func runOne(ctx context.Context, c checker.Check, project *checker.Project, timeout time.Duration) Result {
+	result := Result{ID: c.ID(), Name: c.Name()}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		done <- invoke(ctx, c, project)
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out = outcome{err: ctx.Err()}
+	}
+	result.Duration = time.Since(start)
+	result.Findings = out.findings
+	result.Err = out.err
+
+	var panicErr *panicError
+	switch {
+	case out.err == nil && len(out.findings) == 0:
+		result.Status = StatusPassed
+	case out.err == nil:
+		result.Status = StatusFailed
+	case errors.Is(out.err, context.DeadlineExceeded):
+		result.Status = StatusTimeout
+		message := "Check did not finish before the deadline"
+		if timeout > 0 {
+			message = fmt.Sprintf("Check did not finish within %s", timeout)
+		}
+		result.Findings = append(result.Findings, internalError(c, message,
+			"Raise the per-check limit with --timeout, or skip the check with --skip."))
+	case errors.Is(out.err, context.Canceled):
+		result.Status = StatusCanceled
+	case errors.As(out.err, &panicErr):
+		result.Status = StatusError
+		result.Findings = append(result.Findings, internalError(c,
+			fmt.Sprintf("Check panicked: %v", panicErr.value), bugSuggestion))
+	default:
+		result.Status = StatusError
+		result.Findings = append(result.Findings, internalError(c,
+			fmt.Sprintf("Check failed: %v", out.err), bugSuggestion))
+	}
+
+	return result
+}
+
+func invoke(ctx context.Context, c checker.Check, project *checker.Project) (out outcome) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = outcome{err: &panicError{value: r, stack: debug.Stack()}}
+		}
+	}()
+
+	if cc, ok := c.(checker.ContextCheck); ok {
+		findings, err := cc.RunContext(ctx, project)
+		return outcome{findings: findings, err: err}
+	}
+	return outcome{findings: c.Run(project)}
+}
+
+// panicError wraps a value recovered from a panicking check.
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v\n%s", e.value, e.stack)
+}
+
+const bugSuggestion = "This is a problem in fsct or its environment, not in your project. Re-run with --verbose and report it if it persists."
+
+// internalError reports a check that could not complete, attributed to the
+// check's own ID so it can be skipped like any other finding.
+func internalError(c checker.Check, message, suggestion string) report.Finding {
+	return report.Finding{
+		ID:         c.ID(),
+		Severity:   report.SeverityWarning,
+		Title:      c.Name() + " (internal error)",
+		Message:    message,
+		Suggestion: suggestion,
+	}
+}
@@ -0,0 +1,191 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ricky-irfandi/fsct/internal/checker"
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+type fakeCheck struct {
+	id  string
+	run func(project *checker.Project) []report.Finding
+}
+
+func (c *fakeCheck) ID() string   { return c.id }
+func (c *fakeCheck) Name() string { return "Fake " + c.id }
+func (c *fakeCheck) Run(project *checker.Project) []report.Finding {
+	return c.run(project)
+}
+
+type fakeContextCheck struct {
+	fakeCheck
+	runContext func(ctx context.Context) ([]report.Finding, error)
+}
+
+func (c *fakeContextCheck) RunContext(ctx context.Context, project *checker.Project) ([]report.Finding, error) {
+	return c.runContext(ctx)
+}
+
+func finding(id string) []report.Finding {
+	return []report.Finding{{ID: id, Severity: report.SeverityWarning}}
+}
+
+func TestRun(t *testing.T) {
+	project := checker.NewProject(".")
+
+	t.Run("preserves order and status", func(t *testing.T) {
+		checks := []checker.Check{
+			&fakeCheck{id: "A-001", run: func(*checker.Project) []report.Finding {
+				time.Sleep(20 * time.Millisecond)
+				return finding("A-001")
+			}},
+			&fakeCheck{id: "A-002", run: func(*checker.Project) []report.Finding { return nil }},
+			&fakeCheck{id: "A-003", run: func(*checker.Project) []report.Finding { return finding("A-003") }},
+		}
+
+		results := Run(context.Background(), checks, project, Options{Workers: 3})
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(results))
+		}
+		expected := []Status{StatusFailed, StatusPassed, StatusFailed}
+		for i, r := range results {
+			if r.ID != checks[i].ID() {
+				t.Errorf("expected %s at %d, got %s", checks[i].ID(), i, r.ID)
+			}
+			if r.Status != expected[i] {
+				t.Errorf("expected %s for %s, got %s", expected[i], r.ID, r.Status)
+			}
+		}
+		if results[0].Duration < 20*time.Millisecond {
+			t.Errorf("expected duration to be recorded, got %s", results[0].Duration)
+		}
+		if len(Findings(results)) != 2 {
+			t.Errorf("expected 2 findings, got %d", len(Findings(results)))
+		}
+		if Passed(results) != 1 {
+			t.Errorf("expected 1 passed, got %d", Passed(results))
+		}
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		var running, peak int32
+		var checks []checker.Check
+		for i := 0; i < 8; i++ {
+			checks = append(checks, &fakeCheck{id: "B", run: func(*checker.Project) []report.Finding {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			}})
+		}
+
+		Run(context.Background(), checks, project, Options{Workers: 2})
+		if peak > 2 {
+			t.Errorf("expected at most 2 concurrent checks, got %d", peak)
+		}
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		checks := []checker.Check{
+			&fakeCheck{id: "C-001", run: func(*checker.Project) []report.Finding { panic("boom") }},
+			&fakeCheck{id: "C-002", run: func(*checker.Project) []report.Finding { return nil }},
+		}
+
+		results := Run(context.Background(), checks, project, Options{})
+		if results[0].Status != StatusError {
+			t.Errorf("expected error status, got %s", results[0].Status)
+		}
+		if len(results[0].Findings) != 1 || !strings.Contains(results[0].Findings[0].Message, "boom") {
+			t.Errorf("expected internal error finding, got %+v", results[0].Findings)
+		}
+		if results[0].Findings[0].ID != "C-001" {
+			t.Errorf("expected finding attributed to C-001, got %s", results[0].Findings[0].ID)
+		}
+		if results[1].Status != StatusPassed {
+			t.Errorf("expected other checks to run, got %s", results[1].Status)
+		}
+	})
+
+	t.Run("per-check timeout", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		checks := []checker.Check{
+			&fakeContextCheck{
+				fakeCheck: fakeCheck{id: "D-001"},
+				runContext: func(ctx context.Context) ([]report.Finding, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+			&fakeCheck{id: "D-002", run: func(*checker.Project) []report.Finding {
+				<-block
+				return nil
+			}},
+		}
+
+		results := Run(context.Background(), checks, project, Options{Timeout: 20 * time.Millisecond})
+		for _, r := range results {
+			if r.Status != StatusTimeout {
+				t.Errorf("expected timeout for %s, got %s", r.ID, r.Status)
+			}
+			if len(r.Findings) != 1 {
+				t.Errorf("expected timeout finding for %s, got %d", r.ID, len(r.Findings))
+			}
+		}
+	})
+
+	t.Run("context check error", func(t *testing.T) {
+		checks := []checker.Check{
+			&fakeContextCheck{
+				fakeCheck: fakeCheck{id: "E-001"},
+				runContext: func(ctx context.Context) ([]report.Finding, error) {
+					return nil, errors.New("unreachable")
+				},
+			},
+		}
+
+		results := Run(context.Background(), checks, project, Options{})
+		if results[0].Status != StatusError || results[0].Err == nil {
+			t.Errorf("expected error result, got %+v", results[0])
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		checks := []checker.Check{
+			&fakeCheck{id: "F-001", run: func(*checker.Project) []report.Finding { return finding("F-001") }},
+			&fakeCheck{id: "F-002", run: func(*checker.Project) []report.Finding { return finding("F-002") }},
+		}
+
+		results := Run(ctx, checks, project, Options{Workers: 1})
+		for _, r := range results {
+			if r.Status != StatusCanceled {
+				t.Errorf("expected canceled for %s, got %s", r.ID, r.Status)
+			}
+			if len(r.Findings) != 0 {
+				t.Errorf("expected no findings for canceled %s", r.ID)
+			}
+		}
+	})
+
+	t.Run("no checks", func(t *testing.T) {
+		if results := Run(context.Background(), nil, project, Options{}); len(results) != 0 {
+			t.Errorf("expected no results, got %d", len(results))
+		}
+	})
+}
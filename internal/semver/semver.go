@@ -0,0 +1,230 @@
+// Package semver parses the semantic versions and version constraints used
+// in pubspec.yaml and pubspec.lock.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version such as 1.2.3-dev.1+45.
+type Version struct {
+	Major, Minor, Patch int
+	// Pre is the pre-release suffix after "-", such as dev.1.
+	Pre string
+	// Build is the build suffix after "+", such as 45. It does not take
+	// part in comparisons.
+	Build string
+}
+
+// Parse parses a version with major, minor and patch numbers.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimSpace(s)
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest, v.Build = rest[:i], rest[i+1:]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		rest, v.Pre = rest[:i], rest[i+1:]
+	}
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	for i, dst := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		*dst = n
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v is lower than, equal to or higher than
+// o. A pre-release is lower than its release.
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if d[0] != d[1] {
+			return compareInts(d[0], d[1])
+		}
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+	a, b := strings.Split(v.Pre, "."), strings.Split(o.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePre(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+// comparePre compares pre-release identifiers: numbers numerically and
+// below words, which compare as text.
+func comparePre(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// nextBreaking returns the lowest version a caret constraint on v excludes.
+// Before 1.0.0 the minor version is the breaking one.
+func (v Version) nextBreaking() Version {
+	if v.Major == 0 {
+		return Version{Minor: v.Minor + 1}
+	}
+	return Version{Major: v.Major + 1}
+}
+
+// Range is a version constraint such as ^1.2.0 or ">=2.0.0 <3.0.0". A nil
+// bound is open; the zero Range allows every version.
+type Range struct {
+	Min, Max               *Version
+	IncludeMin, IncludeMax bool
+}
+
+// ParseRange parses a pub version constraint: any, an exact version, a
+// caret constraint, or comparisons that all have to hold.
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	var r Range
+	if s == "" || s == "any" {
+		return r, nil
+	}
+
+	for _, field := range strings.Fields(s) {
+		op := ""
+		for _, prefix := range []string{">=", "<=", ">", "<", "^"} {
+			if strings.HasPrefix(field, prefix) {
+				op, field = prefix, field[len(prefix):]
+				break
+			}
+		}
+		if field == "" {
+			return Range{}, fmt.Errorf("invalid version constraint %q", s)
+		}
+		v, err := Parse(field)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		switch op {
+		case "":
+			r.raise(v, true)
+			r.lower(v, true)
+		case "^":
+			r.raise(v, true)
+			r.lower(v.nextBreaking(), false)
+		case ">=", ">":
+			r.raise(v, op == ">=")
+		case "<=", "<":
+			r.lower(v, op == "<=")
+		}
+	}
+	return r, nil
+}
+
+// raise narrows the lower bound of r to v.
+func (r *Range) raise(v Version, inclusive bool) {
+	if r.Min != nil {
+		c := v.Compare(*r.Min)
+		if c < 0 || c == 0 && inclusive {
+			return
+		}
+	}
+	r.Min, r.IncludeMin = &v, inclusive
+}
+
+// lower narrows the upper bound of r to v.
+func (r *Range) lower(v Version, inclusive bool) {
+	if r.Max != nil {
+		c := v.Compare(*r.Max)
+		if c > 0 || c == 0 && inclusive {
+			return
+		}
+	}
+	r.Max, r.IncludeMax = &v, inclusive
+}
+
+// IsAny reports whether r allows every version.
+func (r Range) IsAny() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+// Allows reports whether v satisfies r.
+func (r Range) Allows(v Version) bool {
+	if r.Min != nil {
+		c := v.Compare(*r.Min)
+		if c < 0 || c == 0 && !r.IncludeMin {
+			return false
+		}
+	}
+	if r.Max != nil {
+		c := v.Compare(*r.Max)
+		if c > 0 || c == 0 && !r.IncludeMax {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Range) String() string {
+	if r.IsAny() {
+		return "any"
+	}
+	if r.Min != nil && r.Max != nil && r.IncludeMin && r.IncludeMax && r.Min.Compare(*r.Max) == 0 {
+		return r.Min.String()
+	}
+	var parts []string
+	if r.Min != nil {
+		op := ">"
+		if r.IncludeMin {
+			op = ">="
+		}
+		parts = append(parts, op+r.Min.String())
+	}
+	if r.Max != nil {
+		op := "<"
+		if r.IncludeMax {
+			op = "<="
+		}
+		parts = append(parts, op+r.Max.String())
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,83 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	v, err := Parse("1.2.3-dev.1+45")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 || v.Pre != "dev.1" || v.Build != "45" {
+		t.Errorf("unexpected version %+v", v)
+	}
+	if v.String() != "1.2.3-dev.1+45" {
+		t.Errorf("String() = %q", v.String())
+	}
+
+	for _, bad := range []string{"", "1.2", "1.2.x", "1.2.3.4", "-1.0.0"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"0.9.9", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0", "1.0.1", "1.10.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := Parse(ordered[i-1])
+		b, _ := Parse(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	a, _ := Parse("1.0.0+1")
+	b, _ := Parse("1.0.0+2")
+	if a.Compare(b) != 0 {
+		t.Error("build metadata should not affect comparison")
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+		allows     []string
+		excludes   []string
+	}{
+		{"any", "any", []string{"0.0.1", "99.0.0"}, nil},
+		{"", "any", []string{"1.0.0"}, nil},
+		{"^1.2.3", ">=1.2.3 <2.0.0", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"}},
+		{"^0.10.5", ">=0.10.5 <0.11.0", []string{"0.10.9"}, []string{"0.11.0"}},
+		{"^0.0.3", ">=0.0.3 <0.1.0", []string{"0.0.9"}, []string{"0.1.0"}},
+		{">=3.0.0 <4.0.0", ">=3.0.0 <4.0.0", []string{"3.5.1"}, []string{"4.0.0", "2.19.6"}},
+		{">1.0.0 <=1.5.0", ">1.0.0 <=1.5.0", []string{"1.5.0"}, []string{"1.0.0", "1.5.1"}},
+		{"2.1.0", "2.1.0", []string{"2.1.0"}, []string{"2.1.1"}},
+		{">=1.0.0 >=1.5.0 <3.0.0 <2.0.0", ">=1.5.0 <2.0.0", nil, []string{"1.4.0", "2.5.0"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseRange(%q) failed: %v", tt.constraint, err)
+			continue
+		}
+		if r.String() != tt.want {
+			t.Errorf("ParseRange(%q) = %s, want %s", tt.constraint, r, tt.want)
+		}
+		for _, s := range tt.allows {
+			if v, _ := Parse(s); !r.Allows(v) {
+				t.Errorf("%s should allow %s", tt.constraint, s)
+			}
+		}
+		for _, s := range tt.excludes {
+			if v, _ := Parse(s); r.Allows(v) {
+				t.Errorf("%s should exclude %s", tt.constraint, s)
+			}
+		}
+	}
+
+	for _, bad := range []string{"^", ">=1.0", "~1.0.0", "latest"} {
+		if _, err := ParseRange(bad); err == nil {
+			t.Errorf("ParseRange(%q): expected an error", bad)
+		}
+	}
+}
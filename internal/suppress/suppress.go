@@ -0,0 +1,349 @@
+// Package suppress parses inline fsct-ignore directives and applies them to
+// findings.
+//
+// A directive is a comment in any of the common comment styles:
+//
+//	// fsct-ignore SEC-003: staging endpoint
+//	<!-- fsct-ignore AND-006: launched only by our own deep link -->
+//	# fsct-ignore-file LINT-004: generated file
+//
+// fsct-ignore suppresses the named checks on its own line and on the line
+// after it; fsct-ignore-file suppresses them for the whole file. Several IDs
+// can be separated by commas. The reason after the colon is required;
+// directives without one are reported and have no effect. Comment tokens
+// inside quoted strings, such as a URL in a Dart string, do not start a
+// directive.
+package suppress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+// Finding IDs reported for suppression directives.
+const (
+	InvalidID = "SUP-001"
+	UnusedID  = "SUP-002"
+)
+
+var (
+	directivePattern = regexp.MustCompile(`^\s*fsct-ignore(-file)?\b(.*)`)
+	idPattern        = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[0-9]+$`)
+)
+
+// Directive is a single fsct-ignore or fsct-ignore-file comment.
+type Directive struct {
+	File      string
+	Line      int
+	IDs       []string
+	Reason    string
+	WholeFile bool
+
+	used map[string]bool
+}
+
+// Problem is a directive that was found but cannot be applied.
+type Problem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+// Parse returns the directives in content, which was read from file, and
+// any malformed directives.
+func Parse(file, content string) ([]*Directive, []Problem) {
+	var directives []*Directive
+	var problems []Problem
+
+	for i, text := range strings.Split(content, "\n") {
+		m := findDirective(text)
+		if m == nil {
+			continue
+		}
+		line := i + 1
+
+		rest := m[2]
+		for _, closer := range []string{"-->", "*/"} {
+			if idx := strings.Index(rest, closer); idx >= 0 {
+				rest = rest[:idx]
+			}
+		}
+		idList, reason, _ := strings.Cut(rest, ":")
+		reason = strings.TrimSpace(reason)
+
+		var ids []string
+		for _, field := range strings.FieldsFunc(idList, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			ids = append(ids, strings.ToUpper(field))
+		}
+
+		switch {
+		case len(ids) == 0:
+			problems = append(problems, Problem{File: file, Line: line, Message: "fsct-ignore names no check"})
+			continue
+		case reason == "":
+			problems = append(problems, Problem{File: file, Line: line, Message: fmt.Sprintf("fsct-ignore %s has no reason", strings.Join(ids, ","))})
+			continue
+		}
+		if bad := invalidIDs(ids); len(bad) > 0 {
+			problems = append(problems, Problem{File: file, Line: line, Message: fmt.Sprintf("fsct-ignore has malformed check ID %s", strings.Join(bad, ", "))})
+			continue
+		}
+
+		directives = append(directives, &Directive{
+			File:      file,
+			Line:      line,
+			IDs:       ids,
+			Reason:    reason,
+			WholeFile: m[1] != "",
+			used:      make(map[string]bool),
+		})
+	}
+
+	return directives, problems
+}
+
+// commentTokens start a comment in the file types fsct reads. A line that
+// starts with * continues a block comment.
+var commentTokens = []string{"//", "#", "<!--", "/*"}
+
+// findDirective returns the submatches of directivePattern in the comment
+// text that directly follows a comment token in line, or nil. Tokens in
+// quoted strings, as in 'http://example.com', do not start comments; a
+// quote that is never closed, as in <string>Don't</string>, is taken as
+// text.
+func findDirective(line string) []string {
+	if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "*/") {
+		if m := directivePattern.FindStringSubmatch(trimmed[1:]); m != nil {
+			return m
+		}
+	}
+
+	for {
+		m, unclosed := scanComments(line)
+		if m != nil || unclosed < 0 {
+			return m
+		}
+		line = line[unclosed+1:]
+	}
+}
+
+// scanComments looks for a directive after each comment token in line. It
+// returns the position of the opening quote of a string that is not closed
+// by the end of line, or -1.
+func scanComments(line string) (m []string, unclosed int) {
+	var quote byte
+	start, inComment := -1, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case !inComment && (c == '\'' || c == '"' || c == '`'):
+			quote, start = c, i
+			continue
+		}
+		for _, token := range commentTokens {
+			if strings.HasPrefix(line[i:], token) {
+				if m := directivePattern.FindStringSubmatch(line[i+len(token):]); m != nil {
+					return m, -1
+				}
+				// Quotes in the rest of a comment, as in "// don't", are
+				// text.
+				inComment = true
+			}
+		}
+	}
+	if quote != 0 {
+		return nil, start
+	}
+	return nil, -1
+}
+
+func invalidIDs(ids []string) []string {
+	var bad []string
+	for _, id := range ids {
+		if !idPattern.MatchString(id) {
+			bad = append(bad, id)
+		}
+	}
+	return bad
+}
+
+// Set holds the directives of a project. Files are added explicitly, and
+// files named by findings are read from the project root on demand, so a
+// directive is honoured wherever a check reports.
+type Set struct {
+	root     string
+	scanned  map[string]bool
+	byFile   map[string][]*Directive
+	problems []Problem
+}
+
+// New returns an empty set for the project at root.
+func New(root string) *Set {
+	return &Set{
+		root:    root,
+		scanned: make(map[string]bool),
+		byFile:  make(map[string][]*Directive),
+	}
+}
+
+// Add parses content as the project file at the slash-separated relative
+// path file. Adding a file twice has no effect.
+func (s *Set) Add(file, content string) {
+	file = report.NormalizePath(file)
+	if s.scanned[file] {
+		return
+	}
+	s.scanned[file] = true
+
+	directives, problems := Parse(file, content)
+	s.byFile[file] = append(s.byFile[file], directives...)
+	s.problems = append(s.problems, problems...)
+}
+
+// Load reads and adds the given project-relative files. Unreadable files
+// are skipped; the loader reports them separately.
+func (s *Set) Load(files ...string) {
+	for _, file := range files {
+		s.load(report.NormalizePath(file))
+	}
+}
+
+func (s *Set) load(file string) {
+	if s.scanned[file] || file == "" || strings.HasSuffix(file, "/") {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(file)))
+	if err != nil {
+		s.scanned[file] = true
+		return
+	}
+	s.Add(file, string(data))
+}
+
+// Apply marks the findings covered by a directive as suppressed and returns
+// how many were suppressed. Findings that are already suppressed are left
+// alone.
+func (s *Set) Apply(findings []report.Finding) int {
+	suppressed := 0
+	for i := range findings {
+		f := &findings[i]
+		if f.Suppression != nil || f.File == "" {
+			continue
+		}
+		file := report.NormalizePath(f.File)
+		s.load(file)
+
+		if d := s.match(file, f.ID, f.Line); d != nil {
+			d.used[f.ID] = true
+			f.Suppression = &report.Suppression{Reason: d.Reason, File: d.File, Line: d.Line}
+			suppressed++
+		}
+	}
+	return suppressed
+}
+
+func (s *Set) match(file, id string, line int) *Directive {
+	var fileLevel *Directive
+	for _, d := range s.byFile[file] {
+		if !d.names(id) {
+			continue
+		}
+		if !d.WholeFile && line > 0 && (d.Line == line || d.Line == line-1) {
+			return d
+		}
+		if d.WholeFile && fileLevel == nil {
+			fileLevel = d
+		}
+	}
+	return fileLevel
+}
+
+func (d *Directive) names(id string) bool {
+	for _, named := range d.IDs {
+		if named == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Directives returns every valid directive, ordered by file and line.
+func (s *Set) Directives() []*Directive {
+	var all []*Directive
+	for _, directives := range s.byFile {
+		all = append(all, directives...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].File != all[j].File {
+			return all[i].File < all[j].File
+		}
+		return all[i].Line < all[j].Line
+	})
+	return all
+}
+
+// Problems returns a finding for every malformed directive.
+func (s *Set) Problems() []report.Finding {
+	problems := append([]Problem(nil), s.problems...)
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+
+	findings := make([]report.Finding, 0, len(problems))
+	for _, p := range problems {
+		findings = append(findings, report.Finding{
+			ID:         InvalidID,
+			Severity:   report.SeverityWarning,
+			Title:      "Invalid Suppression",
+			Message:    p.Message + "; it has no effect",
+			File:       p.File,
+			Line:       p.Line,
+			Suggestion: "Write directives as fsct-ignore CHECK-ID: reason, e.g. // fsct-ignore SEC-003: staging endpoint",
+		})
+	}
+	return findings
+}
+
+// Unused returns a finding for every check ID named by a directive that
+// suppressed nothing. ran reports whether a check was run; directives for
+// checks that did not run are not considered stale.
+func (s *Set) Unused(ran func(id string) bool) []report.Finding {
+	var findings []report.Finding
+	for _, d := range s.Directives() {
+		for _, id := range d.IDs {
+			if d.used[id] || !ran(id) {
+				continue
+			}
+			directive := "fsct-ignore"
+			if d.WholeFile {
+				directive = "fsct-ignore-file"
+			}
+			findings = append(findings, report.Finding{
+				ID:         UnusedID,
+				Severity:   report.SeverityWarning,
+				Title:      "Unused Suppression",
+				Message:    fmt.Sprintf("%s %s no longer matches any finding", directive, id),
+				File:       d.File,
+				Line:       d.Line,
+				Suggestion: "Remove the stale directive so it cannot hide a future finding",
+			})
+		}
+	}
+	return findings
+}
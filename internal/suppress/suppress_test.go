@@ -0,0 +1,220 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ricky-irfandi/fsct/internal/report"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		ids       []string
+		reason    string
+		wholeFile bool
+		problem   string
+	}{
+		{
+			name:    "dart line comment",
+			content: "final url = 'http://staging'; // fsct-ignore SEC-003: staging endpoint",
+			ids:     []string{"SEC-003"},
+			reason:  "staging endpoint",
+		},
+		{
+			name:    "xml comment",
+			content: "<!-- fsct-ignore AND-006: launched only by our own deep link -->",
+			ids:     []string{"AND-006"},
+			reason:  "launched only by our own deep link",
+		},
+		{
+			name:      "hash file directive",
+			content:   "# fsct-ignore-file LINT-004: generated file",
+			ids:       []string{"LINT-004"},
+			reason:    "generated file",
+			wholeFile: true,
+		},
+		{
+			name:    "block comment with several ids",
+			content: "/* fsct-ignore and-001, AND-002: debug flavor only */",
+			ids:     []string{"AND-001", "AND-002"},
+			reason:  "debug flavor only",
+		},
+		{
+			name:    "yaml comment after an unquoted url",
+			content: "url: http://staging.example.com # fsct-ignore SEC-003: staging endpoint",
+			ids:     []string{"SEC-003"},
+			reason:  "staging endpoint",
+		},
+		{
+			name:    "comment after an apostrophe in text",
+			content: "<string>Don't ask again</string> <!-- fsct-ignore AND-006: shown once -->",
+			ids:     []string{"AND-006"},
+			reason:  "shown once",
+		},
+		{
+			name:    "block comment continuation",
+			content: " * fsct-ignore SEC-001: test fixture",
+			ids:     []string{"SEC-001"},
+			reason:  "test fixture",
+		},
+		{
+			name:    "missing reason",
+			content: "// fsct-ignore SEC-003",
+			problem: "has no reason",
+		},
+		{
+			name:    "empty reason",
+			content: "<!-- fsct-ignore AND-006: -->",
+			problem: "has no reason",
+		},
+		{
+			name:    "no ids",
+			content: "// fsct-ignore: because",
+			problem: "names no check",
+		},
+		{
+			name:    "malformed id",
+			content: "// fsct-ignore SEC3: because",
+			problem: "malformed check ID SEC3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directives, problems := Parse("lib/main.dart", tt.content)
+			if tt.problem != "" {
+				if len(directives) != 0 || len(problems) != 1 || !strings.Contains(problems[0].Message, tt.problem) {
+					t.Fatalf("expected problem %q, got %+v %+v", tt.problem, directives, problems)
+				}
+				return
+			}
+			if len(problems) != 0 || len(directives) != 1 {
+				t.Fatalf("expected one directive, got %+v %+v", directives, problems)
+			}
+			d := directives[0]
+			if strings.Join(d.IDs, ",") != strings.Join(tt.ids, ",") || d.Reason != tt.reason || d.WholeFile != tt.wholeFile || d.Line != 1 {
+				t.Errorf("unexpected directive %+v", d)
+			}
+		})
+	}
+}
+
+func TestParse_IgnoresOrdinaryText(t *testing.T) {
+	directives, problems := Parse("README.md", "Use fsct-ignore SEC-003: reason to silence a finding.")
+	if len(directives) != 0 || len(problems) != 0 {
+		t.Errorf("expected prose to be ignored, got %+v %+v", directives, problems)
+	}
+}
+
+func TestParse_IgnoresStrings(t *testing.T) {
+	for _, content := range []string{
+		`final hint = "// fsct-ignore SEC-003: reason";`,
+		`final tag = '#fsct-ignore-file SEC-001: reason';`,
+		"final doc = `see /* fsct-ignore AND-001: reason */`;",
+		`final url = 'http://example.com/#fsct-ignore SEC-003: x'; // staging`,
+	} {
+		if directives, problems := Parse("lib/main.dart", content); len(directives) != 0 || len(problems) != 0 {
+			t.Errorf("Parse(%q) = %+v %+v, expected the string to be ignored", content, directives, problems)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	s := New("")
+	s.Add("lib/api.dart", strings.Join([]string{
+		"// fsct-ignore SEC-003: staging endpoint",
+		"const staging = 'http://staging.example.com';",
+		"const prod = 'http://example.com';",
+		"const other = 'http://other.example.com'; // fsct-ignore SEC-003: partner sandbox",
+	}, "\n"))
+	s.Add("android/app/build.gradle", "# fsct-ignore-file AND-002: minSdk is set by the flavor plugin")
+
+	findings := []report.Finding{
+		{ID: "SEC-003", File: "lib/api.dart", Line: 2},
+		{ID: "SEC-003", File: "lib/api.dart", Line: 3},
+		{ID: "SEC-003", File: "./lib/api.dart", Line: 4},
+		{ID: "SEC-004", File: "lib/api.dart", Line: 2},
+		{ID: "AND-002", File: "android/app/build.gradle"},
+		{ID: "AND-002", File: "android/app/src/main/AndroidManifest.xml"},
+	}
+
+	if n := s.Apply(findings); n != 3 {
+		t.Errorf("expected 3 suppressed findings, got %d", n)
+	}
+
+	want := []string{"staging endpoint", "", "partner sandbox", "", "minSdk is set by the flavor plugin", ""}
+	for i, f := range findings {
+		got := ""
+		if f.Suppression != nil {
+			got = f.Suppression.Reason
+		}
+		if got != want[i] {
+			t.Errorf("finding %d (%s line %d): expected reason %q, got %q", i, f.File, f.Line, want[i], got)
+		}
+	}
+	if s := findings[0].Suppression; s.File != "lib/api.dart" || s.Line != 1 {
+		t.Errorf("expected suppression to point at the directive, got %+v", s)
+	}
+}
+
+func TestApply_LoadsFilesOnDemand(t *testing.T) {
+	root := t.TempDir()
+	manifest := filepath.Join(root, "android", "app", "src", "main", "AndroidManifest.xml")
+	if err := os.MkdirAll(filepath.Dir(manifest), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "<manifest>\n    <!-- fsct-ignore AND-006: launched only by our own deep link -->\n    <activity android:exported=\"true\" />\n</manifest>\n"
+	if err := os.WriteFile(manifest, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(root)
+	findings := []report.Finding{{ID: "AND-006", File: "android/app/src/main/AndroidManifest.xml", Line: 3}}
+	if n := s.Apply(findings); n != 1 || findings[0].Suppression == nil {
+		t.Errorf("expected manifest directive to apply, got %+v", findings[0])
+	}
+}
+
+func TestProblems(t *testing.T) {
+	s := New("")
+	s.Add("ios/Runner/Info.plist", "<!-- fsct-ignore IOS-001 -->")
+
+	problems := s.Problems()
+	if len(problems) != 1 {
+		t.Fatalf("expected one problem, got %d", len(problems))
+	}
+	p := problems[0]
+	if p.ID != InvalidID || p.File != "ios/Runner/Info.plist" || p.Line != 1 || p.Severity != report.SeverityWarning {
+		t.Errorf("unexpected problem finding %+v", p)
+	}
+
+	findings := []report.Finding{{ID: "IOS-001", File: "ios/Runner/Info.plist", Line: 1}}
+	if s.Apply(findings) != 0 {
+		t.Error("expected directive without a reason to have no effect")
+	}
+}
+
+func TestUnused(t *testing.T) {
+	s := New("")
+	s.Add("lib/main.dart", strings.Join([]string{
+		"// fsct-ignore SEC-001, SEC-003: fixtures",
+		"const key = 'fixture';",
+		"// fsct-ignore AI-001: reviewed manually",
+	}, "\n"))
+
+	findings := []report.Finding{{ID: "SEC-001", File: "lib/main.dart", Line: 2}}
+	s.Apply(findings)
+
+	ran := func(id string) bool { return id != "AI-001" }
+	unused := s.Unused(ran)
+	if len(unused) != 1 {
+		t.Fatalf("expected one unused suppression, got %+v", unused)
+	}
+	if u := unused[0]; u.ID != UnusedID || u.Line != 1 || !strings.Contains(u.Message, "SEC-003") {
+		t.Errorf("unexpected unused finding %+v", u)
+	}
+}
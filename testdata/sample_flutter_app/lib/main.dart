@@ -0,0 +1,37 @@
+import 'package:flutter/material.dart';
+
+import 'src/api.dart';
+
+void main() {
+  runApp(const SampleApp());
+}
+
+class SampleApp extends StatelessWidget {
+  const SampleApp({super.key});
+
+  @override
+  Widget build(BuildContext context) {
+    return MaterialApp(
+      title: 'Sample App',
+      home: LoginPage(api: ApiClient()),
+    );
+  }
+}
+
+class LoginPage extends StatelessWidget {
+  const LoginPage({super.key, required this.api});
+
+  final ApiClient api;
+
+  @override
+  Widget build(BuildContext context) {
+    return Scaffold(
+      body: Center(
+        child: ElevatedButton(
+          onPressed: () => api.login('demo', 'demo'),
+          child: const Text('Sign in'),
+        ),
+      ),
+    );
+  }
+}
@@ -0,0 +1,14 @@
+import 'package:http/http.dart' as http;
+
+const String apiKey = 'sk_live_51HqLyjWDarjtT1zdp7dc';
+
+class ApiClient {
+  final String baseUrl = 'http://api.example.com/v1';
+
+  Future<http.Response> login(String user, String password) {
+    return http.post(
+      Uri.parse('$baseUrl/login'),
+      body: {'user': user, 'password': password},
+    );
+  }
+}
@@ -0,0 +1,10 @@
+import 'package:flutter_test/flutter_test.dart';
+
+import 'package:sample_app/main.dart';
+
+void main() {
+  testWidgets('renders sign in button', (tester) async {
+    await tester.pumpWidget(const SampleApp());
+    expect(find.text('Sign in'), findsOneWidget);
+  });
+}